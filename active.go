@@ -0,0 +1,107 @@
+package duckdb
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// ActiveQuery is a query in flight on a connection of a Connector.
+type ActiveQuery struct {
+	// ID identifies the query among all queries of the Connector.
+	ID uint64
+	// ConnectionID identifies the connection executing the query among all connections of the Connector.
+	ConnectionID uint64
+	// Query is the query text. For a multi-statement query, it contains all statements.
+	Query string
+	// Started is the time at which the driver started executing the query.
+	Started time.Time
+}
+
+// Elapsed returns the time since the driver started executing the query.
+func (q ActiveQuery) Elapsed() time.Duration {
+	return time.Since(q.Started)
+}
+
+// ActiveQueries returns the queries in flight on the connections of the Connector, ordered by their ID.
+// DuckDB does not expose the running queries of a database, so the driver tracks them itself.
+// Thus, ActiveQueries only returns the queries of this Connector, and not those of other Connectors
+// or processes opening the same database file. A query is in flight from the start of its execution,
+// including the retries of WithConflictRetry, until its result is materialized. The query of a
+// streaming result is in flight until its rows are exhausted or closed.
+// The Connector of sql.Open is not accessible, so create it with NewConnector and pass it to sql.OpenDB.
+func ActiveQueries(connector *Connector) []ActiveQuery {
+	return connector.queries.list()
+}
+
+// queryRegistry tracks the queries in flight on the connections of a Connector.
+type queryRegistry struct {
+	mu         sync.Mutex
+	lastID     uint64
+	lastConnID uint64
+	queries    map[uint64]ActiveQuery
+}
+
+func (r *queryRegistry) newConnID() uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastConnID++
+	return r.lastConnID
+}
+
+// start registers a query, and returns its ID.
+func (r *queryRegistry) start(connID uint64, query string) uint64 {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.queries == nil {
+		r.queries = make(map[uint64]ActiveQuery)
+	}
+	r.lastID++
+	r.queries[r.lastID] = ActiveQuery{ID: r.lastID, ConnectionID: connID, Query: query, Started: time.Now()}
+	return r.lastID
+}
+
+// finish removes a query. It is a no-op for an unknown ID.
+func (r *queryRegistry) finish(id uint64) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.queries, id)
+}
+
+func (r *queryRegistry) list() []ActiveQuery {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	queries := make([]ActiveQuery, 0, len(r.queries))
+	for _, q := range r.queries {
+		queries = append(queries, q)
+	}
+	sort.Slice(queries, func(i, j int) bool {
+		return queries[i].ID < queries[j].ID
+	})
+	return queries
+}
+
+// startQuery registers a query in flight on the connection, and returns its ID.
+func (c *conn) startQuery(query string) uint64 {
+	if c.stats != nil {
+		c.stats.queries.Add(1)
+	}
+	if c.queries == nil {
+		return 0
+	}
+	return c.queries.start(c.id, query)
+}
+
+func (c *conn) finishQuery(id uint64) {
+	if c.queries != nil {
+		c.queries.finish(id)
+	}
+}
+
+// finishQuery removes the query of a streaming result from the queries in flight.
+func (r *rows) finishQuery() {
+	if r.queryID != 0 {
+		r.stmt.c.finishQuery(r.queryID)
+		r.queryID = 0
+	}
+}
@@ -0,0 +1,67 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestActiveQueries(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	db := sql.OpenDB(c)
+	defer db.Close()
+
+	require.Empty(t, ActiveQueries(c))
+
+	t.Run("running query", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		const query = `SELECT count(*) FROM range(10000000) t1, range(1000000) t2`
+		done := make(chan error)
+		go func() {
+			_, err := db.ExecContext(ctx, query)
+			done <- err
+		}()
+
+		var active []ActiveQuery
+		require.Eventually(t, func() bool {
+			active = ActiveQueries(c)
+			return len(active) == 1
+		}, 10*time.Second, time.Millisecond)
+		require.Equal(t, query, active[0].Query)
+		require.NotZero(t, active[0].ID)
+		require.NotZero(t, active[0].ConnectionID)
+		require.Greater(t, active[0].Elapsed(), time.Duration(0))
+
+		cancel()
+		require.ErrorIs(t, <-done, context.Canceled)
+		require.Empty(t, ActiveQueries(c))
+	})
+
+	t.Run("streaming result", func(t *testing.T) {
+		ctx := ContextWithFetchMode(context.Background(), FetchStreaming)
+		rows, err := db.QueryContext(ctx, `SELECT * FROM range(100000)`)
+		require.NoError(t, err)
+
+		active := ActiveQueries(c)
+		require.Len(t, active, 1)
+		require.Equal(t, `SELECT * FROM range(100000)`, active[0].Query)
+
+		require.NoError(t, rows.Close())
+		require.Empty(t, ActiveQueries(c))
+	})
+
+	t.Run("materialized result", func(t *testing.T) {
+		rows, err := db.Query(`SELECT * FROM range(10)`)
+		require.NoError(t, err)
+		defer rows.Close()
+		require.Empty(t, ActiveQueries(c))
+	})
+}
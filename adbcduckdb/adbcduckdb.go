@@ -0,0 +1,184 @@
+// Package adbcduckdb implements the ADBC (Arrow Database Connectivity) interfaces on top of go-duckdb,
+// so that ADBC-based tooling can use DuckDB and transfer results as Apache Arrow records.
+// It is a separate module, so that the driver does not depend on ADBC.
+package adbcduckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/marcboeker/go-duckdb"
+)
+
+// Driver is the ADBC driver of DuckDB. Its databases share a duckdb.Connector between their connections,
+// so that the connections of an in-memory database see the same data.
+type Driver struct {
+	// ConnectorOptions configure the connectors of all databases of the driver, e.g., duckdb.WithStatementCache.
+	ConnectorOptions []duckdb.ConnectorOption
+}
+
+// NewDriver returns a new ADBC driver of DuckDB.
+func NewDriver(opts ...duckdb.ConnectorOption) *Driver {
+	return &Driver{ConnectorOptions: opts}
+}
+
+// NewDatabase returns a new database with the options. The adbc.OptionKeyURI option is the DSN of the
+// DuckDB database, e.g., "/path/to/foo.db?access_mode=read_only", and defaults to an in-memory database.
+// All other options, except those of ADBC itself, are DuckDB configuration options, e.g., "threads".
+func (d *Driver) NewDatabase(opts map[string]string) (adbc.Database, error) {
+	db := &database{opts: d.ConnectorOptions, config: url.Values{}}
+	if err := db.SetOptions(opts); err != nil {
+		return nil, err
+	}
+	return db, nil
+}
+
+type database struct {
+	opts []duckdb.ConnectorOption
+
+	mu        sync.Mutex
+	dsn       string
+	config    url.Values
+	connector *duckdb.Connector
+}
+
+func (db *database) SetOptions(opts map[string]string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.connector != nil {
+		return adbc.Error{Msg: "cannot set the options of an open database", Code: adbc.StatusInvalidState}
+	}
+	for key, value := range opts {
+		switch {
+		case key == adbc.OptionKeyURI:
+			db.dsn = value
+		case strings.HasPrefix(key, "adbc."), key == adbc.OptionKeyUsername, key == adbc.OptionKeyPassword:
+			return notImplementedError("database option %s", key)
+		default:
+			db.config.Set(key, value)
+		}
+	}
+	return nil
+}
+
+// Open opens a new connection. The first connection opens the database.
+func (db *database) Open(ctx context.Context) (adbc.Connection, error) {
+	connector, err := db.open()
+	if err != nil {
+		return nil, err
+	}
+
+	driverConn, err := connector.Connect(ctx)
+	if err != nil {
+		return nil, adbcError(err)
+	}
+	arrow, err := duckdb.NewArrowFromConn(driverConn)
+	if err != nil {
+		_ = driverConn.Close()
+		return nil, adbcError(err)
+	}
+	return &connection{conn: driverConn, arrow: arrow, autoCommit: true}, nil
+}
+
+func (db *database) open() (*duckdb.Connector, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.connector != nil {
+		return db.connector, nil
+	}
+
+	dsn := db.dsn
+	if len(db.config) > 0 {
+		sep := "?"
+		if strings.Contains(dsn, "?") {
+			sep = "&"
+		}
+		dsn += sep + db.config.Encode()
+	}
+	connector, err := duckdb.NewConnector(dsn, nil, db.opts...)
+	if err != nil {
+		return nil, adbcError(err)
+	}
+	db.connector = connector
+	return connector, nil
+}
+
+// Close closes the database. Its connections must be closed first.
+func (db *database) Close() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.connector == nil {
+		return nil
+	}
+	err := db.connector.Close()
+	db.connector = nil
+	return adbcError(err)
+}
+
+// errorStatuses are the ADBC statuses of the types of DuckDB errors. All other types have the status adbc.StatusUnknown.
+var errorStatuses = map[duckdb.ErrorType]adbc.Status{
+	duckdb.ErrorTypeConstraint:           adbc.StatusIntegrity,
+	duckdb.ErrorTypeParser:               adbc.StatusInvalidArgument,
+	duckdb.ErrorTypeSyntax:               adbc.StatusInvalidArgument,
+	duckdb.ErrorTypeBinder:               adbc.StatusInvalidArgument,
+	duckdb.ErrorTypeMismatchType:         adbc.StatusInvalidArgument,
+	duckdb.ErrorTypeInvalidInput:         adbc.StatusInvalidArgument,
+	duckdb.ErrorTypeParameterNotResolved: adbc.StatusInvalidArgument,
+	duckdb.ErrorTypeParameterNotAllowed:  adbc.StatusInvalidArgument,
+	duckdb.ErrorTypeConversion:           adbc.StatusInvalidData,
+	duckdb.ErrorTypeOutOfRange:           adbc.StatusInvalidData,
+	duckdb.ErrorTypeDivideByZero:         adbc.StatusInvalidData,
+	duckdb.ErrorTypeDecimal:              adbc.StatusInvalidData,
+	duckdb.ErrorTypeNotImplemented:       adbc.StatusNotImplemented,
+	duckdb.ErrorTypeTransaction:          adbc.StatusInvalidState,
+	duckdb.ErrorTypeIO:                   adbc.StatusIO,
+	duckdb.ErrorTypeHTTP:                 adbc.StatusIO,
+	duckdb.ErrorTypeInterrupt:            adbc.StatusCancelled,
+	duckdb.ErrorTypePermission:           adbc.StatusUnauthorized,
+	duckdb.ErrorTypeInternal:             adbc.StatusInternal,
+	duckdb.ErrorTypeFatal:                adbc.StatusInternal,
+	duckdb.ErrorTypeCatalog:              adbc.StatusNotFound,
+}
+
+// adbcError converts an error of the driver into an adbc.Error, whose status depends on the type of the DuckDB error.
+func adbcError(err error) error {
+	if err == nil {
+		return nil
+	}
+	var adbcErr adbc.Error
+	if errors.As(err, &adbcErr) {
+		return adbcErr
+	}
+
+	status := adbc.StatusUnknown
+	var duckdbErr *duckdb.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		status = adbc.StatusCancelled
+	case errors.Is(err, context.DeadlineExceeded):
+		status = adbc.StatusTimeout
+	case errors.Is(err, driver.ErrBadConn):
+		status = adbc.StatusInvalidState
+	case errors.As(err, &duckdbErr):
+		if s, ok := errorStatuses[duckdbErr.Type]; ok {
+			status = s
+		}
+		if duckdbErr.Type == duckdb.ErrorTypeCatalog && strings.Contains(duckdbErr.Msg, "already exists") {
+			status = adbc.StatusAlreadyExists
+		}
+	}
+	return adbc.Error{Msg: err.Error(), Code: status}
+}
+
+func notImplementedError(format string, args ...any) error {
+	return adbc.Error{Msg: fmt.Sprintf(format, args...) + " is not implemented", Code: adbc.StatusNotImplemented}
+}
@@ -0,0 +1,224 @@
+package adbcduckdb
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	_ adbc.Driver          = (*Driver)(nil)
+	_ adbc.Connection      = (*connection)(nil)
+	_ adbc.PostInitOptions = (*connection)(nil)
+	_ adbc.Statement       = (*statement)(nil)
+)
+
+func openConnection(t *testing.T, opts map[string]string) adbc.Connection {
+	db, err := NewDriver().NewDatabase(opts)
+	require.NoError(t, err)
+	cnxn, err := db.Open(context.Background())
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		require.NoError(t, cnxn.Close())
+		require.NoError(t, db.(io.Closer).Close())
+	})
+	return cnxn
+}
+
+func execUpdate(t *testing.T, cnxn adbc.Connection, query string) int64 {
+	stmt, err := cnxn.NewStatement()
+	require.NoError(t, err)
+	defer stmt.Close()
+	require.NoError(t, stmt.SetSqlQuery(query))
+	n, err := stmt.ExecuteUpdate(context.Background())
+	require.NoError(t, err)
+	return n
+}
+
+func queryInt64s(t *testing.T, cnxn adbc.Connection, query string) []int64 {
+	stmt, err := cnxn.NewStatement()
+	require.NoError(t, err)
+	defer stmt.Close()
+	require.NoError(t, stmt.SetSqlQuery(query))
+	reader, n, err := stmt.ExecuteQuery(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, int64(-1), n)
+	defer reader.Release()
+
+	var values []int64
+	for reader.Next() {
+		values = append(values, reader.Record().Column(0).(*array.Int64).Int64Values()...)
+	}
+	return values
+}
+
+func TestStatement(t *testing.T) {
+	cnxn := openConnection(t, map[string]string{"threads": "1"})
+	ctx := context.Background()
+
+	require.Equal(t, int64(0), execUpdate(t, cnxn, `CREATE TABLE tbl (id BIGINT PRIMARY KEY, name VARCHAR)`))
+	require.Equal(t, int64(2), execUpdate(t, cnxn, `INSERT INTO tbl VALUES (1, 'a'), (2, 'b')`))
+	require.Equal(t, []int64{1, 2}, queryInt64s(t, cnxn, `SELECT id FROM tbl ORDER BY id`))
+	require.Equal(t, []int64{1}, queryInt64s(t, cnxn, `SELECT current_setting('threads')`))
+
+	// Bind the rows of parameters.
+	schema := arrow.NewSchema([]arrow.Field{
+		{Name: "id", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "name", Type: arrow.BinaryTypes.String, Nullable: true},
+	}, nil)
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+	defer builder.Release()
+	builder.Field(0).(*array.Int64Builder).AppendValues([]int64{3, 4}, nil)
+	builder.Field(1).(*array.StringBuilder).AppendValues([]string{"c", ""}, []bool{true, false})
+	rec := builder.NewRecord()
+	defer rec.Release()
+
+	stmt, err := cnxn.NewStatement()
+	require.NoError(t, err)
+	defer stmt.Close()
+	require.NoError(t, stmt.SetSqlQuery(`INSERT INTO tbl VALUES (?, ?)`))
+	require.NoError(t, stmt.Prepare(ctx))
+	require.NoError(t, stmt.Bind(ctx, rec))
+	n, err := stmt.ExecuteUpdate(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+	require.Equal(t, []int64{4}, queryInt64s(t, cnxn, `SELECT id FROM tbl WHERE name IS NULL`))
+
+	// Query with a single row of parameters.
+	require.NoError(t, stmt.SetSqlQuery(`SELECT id FROM tbl WHERE id > ? ORDER BY id`))
+	idBuilder := array.NewInt64Builder(memory.DefaultAllocator)
+	defer idBuilder.Release()
+	idBuilder.Append(2)
+	ids := idBuilder.NewArray()
+	defer ids.Release()
+	params := array.NewRecord(arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil),
+		[]arrow.Array{ids}, 1)
+	defer params.Release()
+	require.NoError(t, stmt.Bind(ctx, params))
+	reader, _, err := stmt.ExecuteQuery(ctx)
+	require.NoError(t, err)
+	require.True(t, reader.Next())
+	require.Equal(t, []int64{3, 4}, reader.Record().Column(0).(*array.Int64).Int64Values())
+	reader.Release()
+
+	// Errors have the status of their DuckDB error type.
+	require.NoError(t, stmt.SetSqlQuery(`INSERT INTO tbl VALUES (1, 'x')`))
+	_, err = stmt.ExecuteUpdate(ctx)
+	var adbcErr adbc.Error
+	require.True(t, errors.As(err, &adbcErr))
+	require.Equal(t, adbc.StatusIntegrity, adbcErr.Code)
+
+	require.NoError(t, stmt.SetSqlQuery(`SELECT * FROM missing`))
+	_, _, err = stmt.ExecuteQuery(ctx)
+	require.True(t, errors.As(err, &adbcErr))
+	require.Equal(t, adbc.StatusNotFound, adbcErr.Code)
+
+	require.NoError(t, stmt.SetSqlQuery(`SELEC 1`))
+	require.True(t, errors.As(stmt.Prepare(ctx), &adbcErr))
+	require.Equal(t, adbc.StatusInvalidArgument, adbcErr.Code)
+}
+
+func TestIngest(t *testing.T) {
+	cnxn := openConnection(t, nil)
+	ctx := context.Background()
+
+	schema := arrow.NewSchema([]arrow.Field{{Name: "id", Type: arrow.PrimitiveTypes.Int64}}, nil)
+	record := func(ids ...int64) arrow.Record {
+		builder := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+		defer builder.Release()
+		builder.Field(0).(*array.Int64Builder).AppendValues(ids, nil)
+		return builder.NewRecord()
+	}
+
+	ingest := func(mode string, ids ...int64) (int64, error) {
+		stmt, err := cnxn.NewStatement()
+		require.NoError(t, err)
+		defer stmt.Close()
+		require.NoError(t, stmt.SetOption(adbc.OptionKeyIngestTargetTable, "ingested"))
+		require.NoError(t, stmt.SetOption(adbc.OptionKeyIngestMode, mode))
+
+		rec := record(ids...)
+		defer rec.Release()
+		require.NoError(t, stmt.Bind(ctx, rec))
+		return stmt.ExecuteUpdate(ctx)
+	}
+
+	n, err := ingest(adbc.OptionValueIngestModeCreate, 1, 2)
+	require.NoError(t, err)
+	require.Equal(t, int64(2), n)
+
+	_, err = ingest(adbc.OptionValueIngestModeCreate, 3)
+	var adbcErr adbc.Error
+	require.True(t, errors.As(err, &adbcErr))
+	require.Equal(t, adbc.StatusAlreadyExists, adbcErr.Code)
+
+	_, err = ingest(adbc.OptionValueIngestModeAppend, 3)
+	require.NoError(t, err)
+	_, err = ingest(adbc.OptionValueIngestModeCreateAppend, 4)
+	require.NoError(t, err)
+	require.Equal(t, []int64{1, 2, 3, 4}, queryInt64s(t, cnxn, `SELECT id FROM ingested ORDER BY id`))
+
+	_, err = ingest(adbc.OptionValueIngestModeReplace, 5)
+	require.NoError(t, err)
+	require.Equal(t, []int64{5}, queryInt64s(t, cnxn, `SELECT id FROM ingested`))
+
+	tableSchema, err := cnxn.GetTableSchema(ctx, nil, nil, "ingested")
+	require.NoError(t, err)
+	require.Equal(t, "id", tableSchema.Field(0).Name)
+	require.Equal(t, arrow.PrimitiveTypes.Int64, tableSchema.Field(0).Type)
+}
+
+func TestTransaction(t *testing.T) {
+	cnxn := openConnection(t, nil)
+	ctx := context.Background()
+	options := cnxn.(adbc.PostInitOptions)
+
+	require.Error(t, cnxn.Commit(ctx))
+	execUpdate(t, cnxn, `CREATE TABLE tbl (id BIGINT)`)
+
+	require.NoError(t, options.SetOption(adbc.OptionKeyAutoCommit, adbc.OptionValueDisabled))
+	execUpdate(t, cnxn, `INSERT INTO tbl VALUES (1)`)
+	require.NoError(t, cnxn.Rollback(ctx))
+	require.Empty(t, queryInt64s(t, cnxn, `SELECT id FROM tbl`))
+
+	execUpdate(t, cnxn, `INSERT INTO tbl VALUES (2)`)
+	require.NoError(t, cnxn.Commit(ctx))
+	execUpdate(t, cnxn, `INSERT INTO tbl VALUES (3)`)
+	require.NoError(t, options.SetOption(adbc.OptionKeyAutoCommit, adbc.OptionValueEnabled))
+	require.Equal(t, []int64{2, 3}, queryInt64s(t, cnxn, `SELECT id FROM tbl ORDER BY id`))
+}
+
+func TestGetInfo(t *testing.T) {
+	cnxn := openConnection(t, nil)
+	ctx := context.Background()
+
+	reader, err := cnxn.GetInfo(ctx, []adbc.InfoCode{adbc.InfoVendorName, adbc.InfoVendorVersion})
+	require.NoError(t, err)
+	defer reader.Release()
+	require.True(t, reader.Next())
+
+	rec := reader.Record()
+	require.Equal(t, int64(2), rec.NumRows())
+	values := rec.Column(1).(*array.DenseUnion)
+	strs := values.Field(0).(*array.String)
+	require.Equal(t, infoVendorName, strs.Value(int(values.ValueOffset(0))))
+	require.Regexp(t, `^v\d+\.\d+\.\d+`, strs.Value(int(values.ValueOffset(1))))
+
+	types, err := cnxn.GetTableTypes(ctx)
+	require.NoError(t, err)
+	defer types.Release()
+	require.True(t, types.Next())
+	require.Equal(t, int64(len(tableTypes)), types.Record().NumRows())
+
+	_, err = cnxn.GetObjects(ctx, adbc.ObjectDepthAll, nil, nil, nil, nil, nil)
+	var adbcErr adbc.Error
+	require.True(t, errors.As(err, &adbcErr))
+	require.Equal(t, adbc.StatusNotImplemented, adbcErr.Code)
+}
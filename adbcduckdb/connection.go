@@ -0,0 +1,228 @@
+package adbcduckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/marcboeker/go-duckdb"
+)
+
+// The info values of GetInfo, which are not queried from DuckDB.
+const (
+	infoVendorName = "DuckDB"
+	infoDriverName = "ADBC DuckDB Go Driver"
+)
+
+// The type codes of the info values of GetInfo, see adbc.GetInfoSchema.
+const (
+	infoStringTypeCode arrow.UnionTypeCode = 0
+	infoInt64TypeCode  arrow.UnionTypeCode = 2
+)
+
+// tableTypes are the types of tables of GetTableTypes, like the table_type column of information_schema.tables.
+var tableTypes = []string{"BASE TABLE", "LOCAL TEMPORARY", "VIEW"}
+
+// connection is an ADBC connection on top of a DuckDB driver connection.
+// Like a DuckDB driver connection, it is not safe for concurrent use.
+type connection struct {
+	conn  driver.Conn
+	arrow *duckdb.Arrow
+
+	autoCommit bool
+	// tx is the transaction of the connection, if autocommit is disabled.
+	tx driver.Tx
+}
+
+// SetOption sets the adbc.OptionKeyAutoCommit option of the connection. Disabling autocommit starts a transaction,
+// which Commit and Rollback end, before starting the next one. Enabling autocommit commits the current transaction.
+func (c *connection) SetOption(key, value string) error {
+	if key != adbc.OptionKeyAutoCommit {
+		return notImplementedError("connection option %s", key)
+	}
+
+	switch value {
+	case adbc.OptionValueEnabled:
+		if c.autoCommit {
+			return nil
+		}
+		if err := c.tx.Commit(); err != nil {
+			return adbcError(err)
+		}
+		c.tx = nil
+		c.autoCommit = true
+	case adbc.OptionValueDisabled:
+		if !c.autoCommit {
+			return nil
+		}
+		if err := c.begin(); err != nil {
+			return err
+		}
+		c.autoCommit = false
+	default:
+		return adbc.Error{Msg: "invalid value of the autocommit option: " + value, Code: adbc.StatusInvalidArgument}
+	}
+	return nil
+}
+
+func (c *connection) begin() error {
+	tx, err := c.conn.(driver.ConnBeginTx).BeginTx(context.Background(), driver.TxOptions{})
+	if err != nil {
+		return adbcError(err)
+	}
+	c.tx = tx
+	return nil
+}
+
+// GetInfo returns the vendor name and version, and the driver name and ADBC version.
+func (c *connection) GetInfo(ctx context.Context, infoCodes []adbc.InfoCode) (array.RecordReader, error) {
+	if len(infoCodes) == 0 {
+		infoCodes = []adbc.InfoCode{adbc.InfoVendorName, adbc.InfoVendorVersion, adbc.InfoDriverName,
+			adbc.InfoDriverADBCVersion}
+	}
+
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, adbc.GetInfoSchema)
+	defer builder.Release()
+	nameBuilder := builder.Field(0).(*array.Uint32Builder)
+	valueBuilder := builder.Field(1).(*array.DenseUnionBuilder)
+	stringBuilder := valueBuilder.Child(int(infoStringTypeCode)).(*array.StringBuilder)
+	int64Builder := valueBuilder.Child(int(infoInt64TypeCode)).(*array.Int64Builder)
+
+	for _, code := range infoCodes {
+		switch code {
+		case adbc.InfoVendorName:
+			nameBuilder.Append(uint32(code))
+			valueBuilder.Append(infoStringTypeCode)
+			stringBuilder.Append(infoVendorName)
+		case adbc.InfoVendorVersion:
+			version, err := c.queryString(ctx, `SELECT library_version FROM pragma_version()`)
+			if err != nil {
+				return nil, err
+			}
+			nameBuilder.Append(uint32(code))
+			valueBuilder.Append(infoStringTypeCode)
+			stringBuilder.Append(version)
+		case adbc.InfoDriverName:
+			nameBuilder.Append(uint32(code))
+			valueBuilder.Append(infoStringTypeCode)
+			stringBuilder.Append(infoDriverName)
+		case adbc.InfoDriverADBCVersion:
+			nameBuilder.Append(uint32(code))
+			valueBuilder.Append(infoInt64TypeCode)
+			int64Builder.Append(adbc.AdbcVersion1_0_0)
+		}
+	}
+
+	rec := builder.NewRecord()
+	defer rec.Release()
+	return array.NewRecordReader(adbc.GetInfoSchema, []arrow.Record{rec})
+}
+
+// queryString returns the string of the first column of the first row of the query.
+func (c *connection) queryString(ctx context.Context, query string) (string, error) {
+	reader, err := c.arrow.QueryContext(ctx, query)
+	if err != nil {
+		return "", adbcError(err)
+	}
+	defer reader.Release()
+
+	if !reader.Next() || reader.Record().NumRows() == 0 {
+		return "", adbc.Error{Msg: "no rows: " + query, Code: adbc.StatusInternal}
+	}
+	return reader.Record().Column(0).(*array.String).Value(0), nil
+}
+
+// GetObjects is not implemented.
+func (c *connection) GetObjects(context.Context, adbc.ObjectDepth, *string, *string, *string, *string,
+	[]string) (array.RecordReader, error) {
+	return nil, notImplementedError("GetObjects")
+}
+
+// GetTableSchema returns the Arrow schema of the table. The catalog and schema are optional.
+func (c *connection) GetTableSchema(ctx context.Context, catalog, dbSchema *string, tableName string) (*arrow.Schema, error) {
+	name := quoteIdentifier(tableName)
+	if dbSchema != nil {
+		name = quoteIdentifier(*dbSchema) + "." + name
+	}
+	if catalog != nil {
+		if dbSchema == nil {
+			name = "main." + name
+		}
+		name = quoteIdentifier(*catalog) + "." + name
+	}
+
+	reader, err := c.arrow.QueryContext(ctx, `SELECT * FROM `+name+` LIMIT 0`)
+	if err != nil {
+		return nil, adbcError(err)
+	}
+	defer reader.Release()
+	return reader.Schema(), nil
+}
+
+// GetTableTypes returns the types of tables, like the table_type column of information_schema.tables.
+func (c *connection) GetTableTypes(context.Context) (array.RecordReader, error) {
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, adbc.TableTypesSchema)
+	defer builder.Release()
+	builder.Field(0).(*array.StringBuilder).AppendValues(tableTypes, nil)
+
+	rec := builder.NewRecord()
+	defer rec.Release()
+	return array.NewRecordReader(adbc.TableTypesSchema, []arrow.Record{rec})
+}
+
+// Commit commits the transaction and starts a new one. It fails, if autocommit is enabled.
+func (c *connection) Commit(context.Context) error {
+	if c.autoCommit {
+		return adbc.Error{Msg: "cannot commit with autocommit enabled", Code: adbc.StatusInvalidState}
+	}
+	err := c.tx.Commit()
+	c.tx = nil
+	if err != nil {
+		return adbcError(err)
+	}
+	return c.begin()
+}
+
+// Rollback rolls back the transaction and starts a new one. It fails, if autocommit is enabled.
+func (c *connection) Rollback(context.Context) error {
+	if c.autoCommit {
+		return adbc.Error{Msg: "cannot roll back with autocommit enabled", Code: adbc.StatusInvalidState}
+	}
+	err := c.tx.Rollback()
+	c.tx = nil
+	if err != nil {
+		return adbcError(err)
+	}
+	return c.begin()
+}
+
+func (c *connection) NewStatement() (adbc.Statement, error) {
+	return &statement{c: c}, nil
+}
+
+// Close closes the connection. It rolls back the transaction, if autocommit is disabled.
+func (c *connection) Close() error {
+	if c.conn == nil {
+		return adbc.Error{Msg: "connection already closed", Code: adbc.StatusInvalidState}
+	}
+	if c.tx != nil {
+		_ = c.tx.Rollback()
+		c.tx = nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return adbcError(err)
+}
+
+// ReadPartition is not implemented, as DuckDB does not partition results.
+func (c *connection) ReadPartition(context.Context, []byte) (array.RecordReader, error) {
+	return nil, notImplementedError("ReadPartition")
+}
+
+func quoteIdentifier(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
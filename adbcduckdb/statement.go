@@ -0,0 +1,304 @@
+package adbcduckdb
+
+import (
+	"context"
+	"database/sql/driver"
+
+	"github.com/apache/arrow-adbc/go/adbc"
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/marcboeker/go-duckdb"
+)
+
+// ingestView is the name of the temporary view scanning the bound records of a bulk ingestion.
+const ingestView = "__adbc_ingest"
+
+// statement is an ADBC statement of a connection. It either executes a query, whose parameters
+// are the rows of the bound records, or ingests the bound records into a table.
+type statement struct {
+	c     *connection
+	query string
+
+	// The options of a bulk ingestion.
+	targetTable string
+	ingestMode  string
+
+	// bound are the bound records.
+	bound array.RecordReader
+}
+
+func (s *statement) Close() error {
+	if s.c == nil {
+		return adbc.Error{Msg: "statement already closed", Code: adbc.StatusInvalidState}
+	}
+	s.release()
+	s.c = nil
+	return nil
+}
+
+func (s *statement) release() {
+	if s.bound != nil {
+		s.bound.Release()
+		s.bound = nil
+	}
+}
+
+// SetOption sets the adbc.OptionKeyIngestTargetTable and adbc.OptionKeyIngestMode options of a bulk ingestion.
+func (s *statement) SetOption(key, val string) error {
+	switch key {
+	case adbc.OptionKeyIngestTargetTable:
+		s.query = ""
+		s.targetTable = val
+	case adbc.OptionKeyIngestMode:
+		switch val {
+		case adbc.OptionValueIngestModeCreate, adbc.OptionValueIngestModeAppend, adbc.OptionValueIngestModeReplace,
+			adbc.OptionValueIngestModeCreateAppend:
+			s.ingestMode = val
+		default:
+			return adbc.Error{Msg: "invalid ingestion mode: " + val, Code: adbc.StatusInvalidArgument}
+		}
+	default:
+		return notImplementedError("statement option %s", key)
+	}
+	return nil
+}
+
+func (s *statement) SetSqlQuery(query string) error {
+	s.targetTable = ""
+	s.query = query
+	return nil
+}
+
+// ExecuteQuery executes the query and returns its result. Its row count is unknown, i.e., -1.
+// Only a single row of parameters can be bound.
+func (s *statement) ExecuteQuery(ctx context.Context) (array.RecordReader, int64, error) {
+	if s.query == "" {
+		return nil, -1, adbc.Error{Msg: "no query set", Code: adbc.StatusInvalidState}
+	}
+
+	args, err := s.boundArgs()
+	if err != nil {
+		return nil, -1, err
+	}
+	if len(args) > 1 {
+		return nil, -1, adbc.Error{Msg: "cannot query with more than one row of parameters", Code: adbc.StatusInvalidArgument}
+	}
+
+	var params []any
+	if len(args) == 1 {
+		params = args[0]
+	}
+	reader, err := s.c.arrow.QueryContext(ctx, s.query, params...)
+	if err != nil {
+		return nil, -1, adbcError(err)
+	}
+	return reader, -1, nil
+}
+
+// ExecuteUpdate executes the query, or the bulk ingestion, and returns the number of affected rows.
+// The query executes once for each row of parameters, see duckdb.ExecBatch.
+func (s *statement) ExecuteUpdate(ctx context.Context) (int64, error) {
+	if s.targetTable != "" {
+		return s.ingest(ctx)
+	}
+	if s.query == "" {
+		return -1, adbc.Error{Msg: "no query set", Code: adbc.StatusInvalidState}
+	}
+
+	args, err := s.boundArgs()
+	if err != nil {
+		return -1, err
+	}
+	if args != nil {
+		n, err := duckdb.ExecBatch(ctx, s.c.conn, s.query, args)
+		return n, adbcError(err)
+	}
+	return s.exec(ctx, s.query)
+}
+
+func (s *statement) exec(ctx context.Context, query string) (int64, error) {
+	res, err := s.c.conn.(driver.ExecerContext).ExecContext(ctx, query, nil)
+	if err != nil {
+		return -1, adbcError(err)
+	}
+	n, err := res.RowsAffected()
+	return n, adbcError(err)
+}
+
+// ingest inserts the bound records into the target table, through a temporary view scanning them.
+func (s *statement) ingest(ctx context.Context) (int64, error) {
+	if s.bound == nil {
+		return -1, adbc.Error{Msg: "no records bound for the ingestion", Code: adbc.StatusInvalidState}
+	}
+
+	mode := s.ingestMode
+	if mode == adbc.OptionValueIngestModeCreateAppend {
+		mode = adbc.OptionValueIngestModeCreate
+		exists, err := s.tableExists(ctx)
+		if err != nil {
+			return -1, err
+		}
+		if exists {
+			mode = adbc.OptionValueIngestModeAppend
+		}
+	}
+
+	table := quoteIdentifier(s.targetTable)
+	var query string
+	switch mode {
+	case adbc.OptionValueIngestModeAppend:
+		query = `INSERT INTO ` + table + ` SELECT * FROM ` + ingestView
+	case adbc.OptionValueIngestModeReplace:
+		query = `CREATE OR REPLACE TABLE ` + table + ` AS SELECT * FROM ` + ingestView
+	default:
+		query = `CREATE TABLE ` + table + ` AS SELECT * FROM ` + ingestView
+	}
+
+	// DuckDB reads the records once, so they are no longer bound.
+	bound := s.bound
+	s.bound = nil
+	release, err := s.c.arrow.RegisterView(bound, ingestView)
+	if err != nil {
+		bound.Release()
+		return -1, adbcError(err)
+	}
+	defer release()
+
+	n, err := s.exec(ctx, query)
+	if _, dropErr := s.exec(ctx, `DROP VIEW IF EXISTS `+ingestView); err == nil && dropErr != nil {
+		return -1, dropErr
+	}
+	return n, err
+}
+
+func (s *statement) tableExists(ctx context.Context) (bool, error) {
+	reader, err := s.c.arrow.QueryContext(ctx, `SELECT 1 FROM duckdb_tables() WHERE table_name = ?`, s.targetTable)
+	if err != nil {
+		return false, adbcError(err)
+	}
+	defer reader.Release()
+
+	exists := false
+	for reader.Next() {
+		exists = exists || reader.Record().NumRows() > 0
+	}
+	return exists, nil
+}
+
+// Prepare checks that the query is valid. DuckDB prepares the query again for each execution.
+func (s *statement) Prepare(context.Context) error {
+	if s.query == "" {
+		return adbc.Error{Msg: "no query set", Code: adbc.StatusInvalidState}
+	}
+	stmt, err := s.c.conn.Prepare(s.query)
+	if err != nil {
+		return adbcError(err)
+	}
+	return adbcError(stmt.Close())
+}
+
+// SetSubstraitPlan is not implemented.
+func (s *statement) SetSubstraitPlan([]byte) error {
+	return notImplementedError("SetSubstraitPlan")
+}
+
+// Bind binds the record, whose rows are the parameters of the query, or the rows of the bulk ingestion.
+func (s *statement) Bind(_ context.Context, values arrow.Record) error {
+	reader, err := array.NewRecordReader(values.Schema(), []arrow.Record{values})
+	if err != nil {
+		return adbc.Error{Msg: err.Error(), Code: adbc.StatusInvalidArgument}
+	}
+	s.release()
+	s.bound = reader
+	return nil
+}
+
+// BindStream binds the records of the stream, like Bind.
+func (s *statement) BindStream(_ context.Context, stream array.RecordReader) error {
+	s.release()
+	stream.Retain()
+	s.bound = stream
+	return nil
+}
+
+// GetParameterSchema is not implemented.
+func (s *statement) GetParameterSchema() (*arrow.Schema, error) {
+	return nil, notImplementedError("GetParameterSchema")
+}
+
+// ExecutePartitions is not implemented, as DuckDB does not partition results.
+func (s *statement) ExecutePartitions(context.Context) (*arrow.Schema, adbc.Partitions, int64, error) {
+	return nil, adbc.Partitions{}, -1, notImplementedError("ExecutePartitions")
+}
+
+// boundArgs returns the rows of the bound records as the arguments of executions, or nil, if no records are bound.
+func (s *statement) boundArgs() ([][]any, error) {
+	if s.bound == nil {
+		return nil, nil
+	}
+	bound := s.bound
+	s.bound = nil
+	defer bound.Release()
+
+	args := [][]any{}
+	for bound.Next() {
+		rec := bound.Record()
+		for row := 0; row < int(rec.NumRows()); row++ {
+			values := make([]any, rec.NumCols())
+			for col := range values {
+				values[col] = arrowValue(rec.Column(col), row)
+			}
+			args = append(args, values)
+		}
+	}
+	if err := bound.Err(); err != nil {
+		return nil, adbc.Error{Msg: err.Error(), Code: adbc.StatusIO}
+	}
+	return args, nil
+}
+
+// arrowValue returns the Go value of a parameter in an Arrow array.
+func arrowValue(arr arrow.Array, i int) any {
+	if arr.IsNull(i) {
+		return nil
+	}
+	switch a := arr.(type) {
+	case *array.Boolean:
+		return a.Value(i)
+	case *array.Int8:
+		return a.Value(i)
+	case *array.Int16:
+		return a.Value(i)
+	case *array.Int32:
+		return a.Value(i)
+	case *array.Int64:
+		return a.Value(i)
+	case *array.Uint8:
+		return a.Value(i)
+	case *array.Uint16:
+		return a.Value(i)
+	case *array.Uint32:
+		return a.Value(i)
+	case *array.Uint64:
+		return a.Value(i)
+	case *array.Float32:
+		return a.Value(i)
+	case *array.Float64:
+		return a.Value(i)
+	case *array.String:
+		return a.Value(i)
+	case *array.LargeString:
+		return a.Value(i)
+	case *array.Binary:
+		return a.Value(i)
+	case *array.LargeBinary:
+		return a.Value(i)
+	case *array.Date32:
+		return a.Value(i).ToTime()
+	case *array.Date64:
+		return a.Value(i).ToTime()
+	case *array.Timestamp:
+		return a.Value(i).ToTime(a.DataType().(*arrow.TimestampType).Unit)
+	}
+	return arr.GetOneForMarshal(i)
+}
@@ -7,7 +7,11 @@ package duckdb
 import "C"
 
 import (
+	"context"
 	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
 	"unsafe"
 )
 
@@ -26,10 +30,57 @@ type Appender struct {
 
 	// The vector storage of each column in the data chunk.
 	vectors []vector
+
+	// True, if the appender runs in its own transaction, see WithTransaction.
+	tx bool
+	// True, if appending or flushing failed in the session of a transactional appender, which makes Close roll back.
+	txFailed bool
+
+	// The number of rows appended in the session, and the number of rows flushed successfully.
+	rows        int64
+	flushedRows int64
+	// The number of rows, after which appending a row flushes the appender, or 0, see WithAutoFlush.
+	autoFlushRows int64
+
+	// The names of the columns of the table, once a struct is appended, see AppendStruct.
+	// The names of the columns of an appender created WithColumns.
+	columnNames []string
+	// The staging table of an appender created WithColumns, or nil.
+	stage *appenderStage
+	// The index of the matching field for each column of each appended struct type, see AppendStruct.
+	structCache map[reflect.Type][][]int
+}
+
+// AppenderOption configures an Appender.
+type AppenderOption func(c *appenderConfig)
+
+type appenderConfig struct {
+	transaction   bool
+	autoFlushRows int64
+	columns       []string
+}
+
+// WithTransaction runs the appender session in an explicit transaction, so that a bulk load is all-or-nothing.
+// NewAppenderFromConn begins the transaction, and Close commits it. If appending or flushing a row fails,
+// then Close rolls back the transaction and returns the error. Rollback discards all rows of the session.
+// Until the transaction ends, the connection must not be used for other transactions.
+func WithTransaction() AppenderOption {
+	return func(c *appenderConfig) {
+		c.transaction = true
+	}
+}
+
+// WithAutoFlush flushes the appender after every rows appended rows, so that the rows of a long-running session
+// reach the table in deterministic batches. An error of the automatic flush is returned by the appending call,
+// and the rows remain unflushed, see AppenderError.
+func WithAutoFlush(rows int) AppenderOption {
+	return func(c *appenderConfig) {
+		c.autoFlushRows = int64(rows)
+	}
 }
 
 // NewAppenderFromConn returns a new Appender from a DuckDB driver connection.
-func NewAppenderFromConn(driverConn driver.Conn, schema, table string) (*Appender, error) {
+func NewAppenderFromConn(driverConn driver.Conn, schema, table string, opts ...AppenderOption) (*Appender, error) {
 	con, ok := driverConn.(*conn)
 	if !ok {
 		return nil, getError(errAppenderInvalidCon, nil)
@@ -38,6 +89,50 @@ func NewAppenderFromConn(driverConn driver.Conn, schema, table string) (*Appende
 		return nil, getError(errAppenderClosedCon, nil)
 	}
 
+	var config appenderConfig
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if config.autoFlushRows < 0 {
+		return nil, getError(errAppenderCreation, errors.New("negative auto-flush row count"))
+	}
+	if !config.transaction {
+		a, err := config.newAppender(con, schema, table)
+		if err != nil {
+			return nil, err
+		}
+		a.autoFlushRows = config.autoFlushRows
+		return a, nil
+	}
+
+	if con.tx {
+		return nil, getError(errAppenderCreation, errors.New("connection already in a transaction"))
+	}
+	if _, err := con.ExecContext(context.Background(), "BEGIN TRANSACTION", nil); err != nil {
+		return nil, getError(errAppenderCreation, err)
+	}
+	con.tx = true
+
+	a, err := config.newAppender(con, schema, table)
+	if err != nil {
+		con.tx = false
+		_, _ = con.ExecContext(context.Background(), "ROLLBACK", nil)
+		return nil, err
+	}
+	a.tx = true
+	a.autoFlushRows = config.autoFlushRows
+	return a, nil
+}
+
+// newAppender returns the appender of the table, or of the columns of the table, see WithColumns.
+func (c *appenderConfig) newAppender(con *conn, schema, table string) (*Appender, error) {
+	if len(c.columns) != 0 {
+		return newStagedAppender(con, schema, table, c.columns)
+	}
+	return newAppender(con, schema, table)
+}
+
+func newAppender(con *conn, schema, table string) (*Appender, error) {
 	var cSchema *C.char
 	if schema != "" {
 		cSchema = C.CString(schema)
@@ -48,7 +143,9 @@ func NewAppenderFromConn(driverConn driver.Conn, schema, table string) (*Appende
 	defer C.free(unsafe.Pointer(cTable))
 
 	var duckdbAppender C.duckdb_appender
+	con.mu.Lock()
 	state := C.duckdb_appender_create(con.duckdbCon, cSchema, cTable, &duckdbAppender)
+	con.mu.Unlock()
 
 	if state == C.DuckDBError {
 		// We destroy the error message when destroying the appender.
@@ -93,47 +190,152 @@ func NewAppenderFromConn(driverConn driver.Conn, schema, table string) (*Appende
 // Flush the data chunks to the underlying table and clear the internal cache.
 // Does not close the appender, even if it returns an error. Unless you have a good reason to call this,
 // call Close when you are done with the appender.
+// The error of a failed flush is an *AppenderError with the range of the unflushed rows, which wraps the *Error
+// of DuckDB, e.g., matching ErrConstraintUnique with errors.Is.
 func (a *Appender) Flush() error {
+	if a.closed {
+		return getError(errAppenderFlush, errors.New("appender already closed"))
+	}
 	// Nothing to flush.
-	if len(a.chunks) == 0 && a.currSize == 0 {
+	if a.rows == a.flushedRows {
 		return nil
 	}
 
-	if err := a.appendDataChunks(); err != nil {
-		return getError(errAppenderFlush, invalidatedAppenderError(err))
+	a.con.mu.Lock()
+	defer a.con.mu.Unlock()
+
+	if err := a.flushRows(); err != nil {
+		return a.sessionError(getError(errAppenderFlush, err))
 	}
+	return nil
+}
 
+// flushRows appends all remaining chunks, and flushes the rows of the appender to the table.
+// The connection must be locked.
+func (a *Appender) flushRows() error {
+	if len(a.chunks) != 0 {
+		if err := a.appendDataChunks(); err != nil {
+			return a.flushError(invalidatedAppenderError(err))
+		}
+	}
 	if state := C.duckdb_appender_flush(a.duckdbAppender); state == C.DuckDBError {
-		return getError(errAppenderFlush, invalidatedAppenderError(nil))
+		return a.flushError(invalidatedAppenderError(appenderFlushError(C.duckdb_appender_error(a.duckdbAppender))))
 	}
-
+	if a.stage != nil {
+		if err := a.flushStage(); err != nil {
+			return a.flushError(fmt.Errorf("%s: %w", duckdbErrMsg, err))
+		}
+	}
+	a.flushedRows = a.rows
 	return nil
 }
 
+// flushError returns the *AppenderError of the unflushed rows. DuckDB does not report the failing row.
+func (a *Appender) flushError(err error) error {
+	return &AppenderError{Row: a.flushedRows + 1, Rows: a.rows - a.flushedRows, Err: err}
+}
+
+// autoFlush flushes the appender, if the number of unflushed rows reached the threshold of WithAutoFlush.
+func (a *Appender) autoFlush() error {
+	if a.autoFlushRows == 0 || a.rows-a.flushedRows < a.autoFlushRows {
+		return nil
+	}
+	return a.Flush()
+}
+
 // Close the appender. This will flush the appender to the underlying table.
 // It is vital to call this when you are done with the appender to avoid leaking memory.
+// The error of the final flush is an *AppenderError, see Flush. The appender is closed, even if Close fails.
+// An appender created WithTransaction commits its transaction, or rolls it back after an error.
 func (a *Appender) Close() error {
 	if a.closed {
 		return getError(errAppenderDoubleClose, nil)
 	}
 	a.closed = true
 
-	// Append all remaining chunks.
+	err := a.destroy(true)
+	if !a.tx {
+		return err
+	}
+	if err == nil && a.txFailed {
+		// The caller already received the error of the session.
+		err = getError(errAppenderClose, errors.New("rolled back after an error of the session"))
+	}
+	if err != nil {
+		if rollbackErr := a.endTransaction("ROLLBACK"); rollbackErr != nil {
+			return getError(errAppenderRollback, rollbackErr)
+		}
+		return err
+	}
+	if err = a.endTransaction("COMMIT TRANSACTION"); err != nil {
+		return getError(errAppenderClose, err)
+	}
+	return nil
+}
+
+// Rollback closes an appender created WithTransaction, and rolls back its transaction.
+// None of the rows of the session are visible afterward.
+func (a *Appender) Rollback() error {
+	if !a.tx {
+		return getError(errAppenderRollback, errors.New("appender not created WithTransaction"))
+	}
+	if a.closed {
+		return getError(errAppenderDoubleClose, nil)
+	}
+	a.closed = true
+
+	// Discard the remaining chunks, as the transaction rolls back anyway.
+	destroyErr := a.destroy(false)
+	if err := a.endTransaction("ROLLBACK"); err != nil {
+		return getError(errAppenderRollback, err)
+	}
+	return destroyErr
+}
+
+// destroy destroys the appender, after appending all remaining chunks, if flush is set.
+func (a *Appender) destroy(flush bool) error {
+	a.con.mu.Lock()
+	defer a.con.mu.Unlock()
+
+	// Flush all remaining rows, so that the error of the flush is available before destroying the appender.
 	var err error
-	if len(a.chunks) != 0 || a.currSize != 0 {
-		err = a.appendDataChunks()
+	if flush && a.rows != a.flushedRows {
+		err = a.flushRows()
 	}
+	a.destroyDataChunks()
 
 	a.destroyColumnTypes()
 	state := C.duckdb_appender_destroy(&a.duckdbAppender)
+	if a.stage != nil {
+		a.dropStage()
+	}
 
-	if err != nil || state == C.DuckDBError {
-		return getError(errAppenderClose, invalidatedAppenderError(err))
+	if err != nil {
+		return getError(errAppenderClose, err)
+	}
+	if state == C.DuckDBError {
+		return getError(errAppenderClose, invalidatedAppenderError(nil))
 	}
 	return nil
 }
 
+// endTransaction ends the transaction of the appender with a COMMIT or ROLLBACK statement.
+func (a *Appender) endTransaction(query string) error {
+	a.con.tx = false
+	_, err := a.con.ExecContext(context.Background(), query, nil)
+	return err
+}
+
+// sessionError marks the session of a transactional appender as failed, and returns err.
+func (a *Appender) sessionError(err error) error {
+	if a.tx {
+		a.txFailed = true
+	}
+	return err
+}
+
 // AppendRow loads a row of values into the appender. The values are provided as separate arguments.
+// The error of a failing value is an *AppenderError with the position of its row in the session, and of its column.
 func (a *Appender) AppendRow(args ...driver.Value) error {
 	if a.closed {
 		return getError(errAppenderAppendAfterClose, nil)
@@ -141,9 +343,9 @@ func (a *Appender) AppendRow(args ...driver.Value) error {
 
 	err := a.appendRowSlice(args)
 	if err != nil {
-		return getError(errAppenderAppendRow, err)
+		return a.sessionError(getError(errAppenderAppendRow, err))
 	}
-	return nil
+	return a.autoFlush()
 }
 
 func (a *Appender) destroyColumnTypes() {
@@ -182,8 +384,11 @@ func (a *Appender) newDataChunk(colCount int) {
 
 func (a *Appender) appendRowSlice(args []driver.Value) error {
 	// early-out, if the number of args does not match the column count
-	if len(args) != len(a.vectors) {
-		return columnCountError(len(args), len(a.vectors))
+	if len(args) != a.columnCount() {
+		return &AppenderError{Row: a.rows + 1, Rows: 1, Err: columnCountError(len(args), a.columnCount())}
+	}
+	if a.stage != nil {
+		args = a.stagedRow(args)
 	}
 
 	// Create a new data chunk if the current chunk is full, or if this is the first row.
@@ -191,21 +396,34 @@ func (a *Appender) appendRowSlice(args []driver.Value) error {
 		a.newDataChunk(len(args))
 	}
 
+	if err := setRowValues(a.vectors, a.currSize, a.rows+1, args); err != nil {
+		return err
+	}
+
+	a.currSize++
+	a.rows++
+	return nil
+}
+
+// setRowValues sets the values of the row at rowIdx of the vectors of a data chunk. The error of a failing value is
+// an *AppenderError with the row, and the column of the value.
+func setRowValues(vectors []vector, rowIdx C.idx_t, row int64, args []driver.Value) error {
 	for i, val := range args {
-		vec := a.vectors[i]
+		vec := vectors[i]
+		if _, ok := val.(defaultValue); ok {
+			return &AppenderError{Row: row, Rows: 1, Column: i + 1, Err: columnError(errAppenderDefault, i+1)}
+		}
 
 		// Ensure that the types match before attempting to append anything.
 		v, err := vec.tryCast(val)
 		if err != nil {
 			// Use 1-based indexing for readability, as we're talking about columns.
-			return columnError(err, i+1)
+			return &AppenderError{Row: row, Rows: 1, Column: i + 1, Err: columnError(err, i+1)}
 		}
 
 		// Append the row to the data chunk.
-		vec.fn(&vec, a.currSize, v)
+		vec.fn(&vec, rowIdx, v)
 	}
-
-	a.currSize++
 	return nil
 }
 
@@ -220,9 +438,12 @@ func (a *Appender) appendDataChunks() error {
 	for _, chunk := range a.chunks {
 		state = C.duckdb_append_data_chunk(a.duckdbAppender, chunk)
 		if state == C.DuckDBError {
-			err = duckdbError(C.duckdb_appender_error(a.duckdbAppender))
+			err = appenderFlushError(C.duckdb_appender_error(a.duckdbAppender))
 			break
 		}
+		if a.con.stats != nil {
+			a.con.stats.appendedRows.Add(uint64(C.duckdb_data_chunk_get_size(chunk)))
+		}
 	}
 	a.destroyDataChunks()
 	return err
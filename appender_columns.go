@@ -0,0 +1,157 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// Default appends the DEFAULT value of its column, e.g., the next value of the sequence of an ID column,
+// or NULL, if the column has no default. Appending Default requires an appender created WithColumns.
+var Default driver.Value = defaultValue{}
+
+type defaultValue struct{}
+
+// WithColumns appends to the columns of the table, in the order of the columns, instead of to all columns.
+// The other columns receive their DEFAULT values, e.g., the next value of a sequence, or NULL.
+// Appending Default to one of the columns appends its DEFAULT value, too.
+// DuckDB v0.10 cannot append DEFAULT values, so the appender stages the rows in a temporary table,
+// and each flush inserts them into the table with an INSERT statement. Names match the columns
+// case-insensitively, preferring an exact match.
+func WithColumns(columns ...string) AppenderOption {
+	return func(c *appenderConfig) {
+		c.columns = columns
+	}
+}
+
+// appenderStageSeq numbers the staging tables of the appenders created WithColumns.
+var appenderStageSeq atomic.Uint64
+
+// appenderStage is the staging table of an appender created WithColumns. The staging table has the columns
+// of the appender, followed by a BOOLEAN column for each of them, which is true, if the row appended Default.
+type appenderStage struct {
+	table  string
+	insert string
+}
+
+// newStagedAppender returns an appender, which stages the rows appended to the columns of the table, see WithColumns.
+func newStagedAppender(con *conn, schema, table string, columns []string) (*Appender, error) {
+	ctx := context.Background()
+	tableColumns, err := DescribeTable(ctx, con, "", schema, table)
+	if err != nil {
+		return nil, getError(errAppenderCreation, err)
+	}
+
+	resolved := make([]TableColumnDescriptor, len(columns))
+	seen := make(map[string]bool, len(columns))
+	for i, name := range columns {
+		column, ok := matchTableColumn(tableColumns, name)
+		if !ok {
+			return nil, getError(errAppenderCreation, fmt.Errorf("column not found: %s", name))
+		}
+		if seen[column.Name] {
+			return nil, getError(errAppenderCreation, fmt.Errorf("duplicate column: %s", name))
+		}
+		seen[column.Name] = true
+		resolved[i] = column
+	}
+
+	target := quoteIdentifier(table)
+	if schema != "" {
+		target = quoteIdentifier(schema) + "." + target
+	}
+	stage := &appenderStage{table: "__appender_stage_" + strconv.FormatUint(appenderStageSeq.Add(1), 10)}
+
+	var names, selects, values []string
+	for i, column := range resolved {
+		name := quoteIdentifier(column.Name)
+		flag := quoteIdentifier("__default_" + strconv.Itoa(i))
+		names = append(names, name)
+		selects = append(selects, name)
+		def := "NULL"
+		if column.Default != nil {
+			def = *column.Default
+		}
+		values = append(values, "CASE WHEN "+flag+" THEN "+def+" ELSE "+name+" END")
+	}
+	for i := range resolved {
+		selects = append(selects, "false AS "+quoteIdentifier("__default_"+strconv.Itoa(i)))
+	}
+	stage.insert = "INSERT INTO " + target + " (" + strings.Join(names, ", ") + ") SELECT " +
+		strings.Join(values, ", ") + " FROM temp." + quoteIdentifier(stage.table) + " ORDER BY rowid"
+
+	// The staging table copies the types of the columns, but not their constraints, so that it holds NULL
+	// for the columns of rows appending Default.
+	if _, err = con.ExecContext(ctx, "CREATE TEMP TABLE "+quoteIdentifier(stage.table)+" AS SELECT "+
+		strings.Join(selects, ", ")+" FROM "+target+" LIMIT 0", nil); err != nil {
+		return nil, getError(errAppenderCreation, err)
+	}
+
+	a, err := newAppender(con, "", stage.table)
+	if err != nil {
+		_, _ = con.ExecContext(ctx, "DROP TABLE IF EXISTS temp."+quoteIdentifier(stage.table), nil)
+		return nil, err
+	}
+	a.stage = stage
+	a.columnNames = make([]string, len(resolved))
+	for i, column := range resolved {
+		a.columnNames[i] = column.Name
+	}
+	return a, nil
+}
+
+// matchTableColumn returns the column of the name, preferring an exact match over a case-insensitive match.
+func matchTableColumn(columns []TableColumnDescriptor, name string) (TableColumnDescriptor, bool) {
+	match, found := TableColumnDescriptor{}, false
+	for _, column := range columns {
+		if column.Name == name {
+			return column, true
+		}
+		if !found && strings.EqualFold(column.Name, name) {
+			match, found = column, true
+		}
+	}
+	return match, found
+}
+
+// columnCount returns the number of values of each appended row.
+func (a *Appender) columnCount() int {
+	if a.stage != nil {
+		return len(a.vectors) / 2
+	}
+	return len(a.vectors)
+}
+
+// stagedRow returns the values of the row and of its Default flags, i.e., the row of the staging table.
+func (a *Appender) stagedRow(args []driver.Value) []driver.Value {
+	row := make([]driver.Value, 2*len(args))
+	for i, arg := range args {
+		_, isDefault := arg.(defaultValue)
+		row[len(args)+i] = isDefault
+		if !isDefault {
+			row[i] = arg
+		}
+	}
+	return row
+}
+
+// flushStage inserts the staged rows into the table, and empties the staging table. It must be called while holding
+// the lock of the connection, after flushing the rows into the staging table.
+func (a *Appender) flushStage() error {
+	if err := a.con.rawExec(a.stage.insert); err != nil {
+		return err
+	}
+	return a.con.rawExec("DELETE FROM temp." + quoteIdentifier(a.stage.table))
+}
+
+// dropStage drops the staging table. It must be called while holding the lock of the connection.
+func (a *Appender) dropStage() {
+	// After a failed flush, the transaction of an appender created WithTransaction is aborted,
+	// and its rollback drops the staging table instead.
+	if err := a.con.rawExec("DROP TABLE IF EXISTS temp." + quoteIdentifier(a.stage.table)); err != nil && !a.tx {
+		a.con.logError("duckdb could not drop the staging table of the appender", err)
+	}
+}
@@ -0,0 +1,196 @@
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"sync"
+)
+
+// ConcurrentAppender is an Appender, which multiple goroutines feed concurrently through their own producers,
+// see NewProducer. Each producer converts its rows into its own chunk, and only takes the lock of the appender to hand
+// over a full chunk, so that parallel stages of a pipeline load into one table through one connection.
+type ConcurrentAppender struct {
+	mu sync.Mutex
+	a  *Appender
+	// The number of open producers, whose buffered rows Close would discard.
+	producers int
+}
+
+// NewConcurrentAppender returns a new ConcurrentAppender from a DuckDB driver connection, with the options of
+// NewAppenderFromConn. Until it is closed, the connection must not be used otherwise.
+func NewConcurrentAppender(driverConn driver.Conn, schema, table string, opts ...AppenderOption) (
+	*ConcurrentAppender, error,
+) {
+	a, err := NewAppenderFromConn(driverConn, schema, table, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ConcurrentAppender{a: a}, nil
+}
+
+// NewProducer returns a new producer of the appender, which converts the rows of one goroutine.
+// Each producer must be closed before closing the appender.
+func (c *ConcurrentAppender) NewProducer() *AppenderProducer {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.a.closed {
+		return &AppenderProducer{c: c, closed: true}
+	}
+
+	c.producers++
+	p := &AppenderProducer{c: c, vectors: make([]vector, len(c.a.vectors))}
+	for i := range p.vectors {
+		// Creating the appender succeeded, so its column types are supported.
+		_ = p.vectors[i].init(c.a.colTypes[i], i)
+	}
+	p.newChunk()
+	return p
+}
+
+// Flush flushes the rows, which the producers handed over to the appender, to the table, see Appender.Flush.
+// The rows of a partial chunk of a producer remain in the producer until it hands them over, see
+// AppenderProducer.Flush.
+func (c *ConcurrentAppender) Flush() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.a.Flush()
+}
+
+// Close closes the appender, after flushing the appended rows, see Appender.Close. The appender is closed,
+// even if Close fails. It fails, if a producer is still open, as the rows it buffers are discarded.
+func (c *ConcurrentAppender) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.a.closed {
+		return getError(errAppenderDoubleClose, nil)
+	}
+	err := c.a.Close()
+	if err == nil && c.producers != 0 {
+		err = getError(errAppenderClose, fmt.Errorf("%d producers not closed", c.producers))
+	}
+	return err
+}
+
+// appendChunk appends the rows of a chunk of a producer. It must be called while holding the lock of the
+// ConcurrentAppender.
+func (a *Appender) appendChunk(chunk C.duckdb_data_chunk, rows C.idx_t) error {
+	if a.closed {
+		return getError(errAppenderAppendAfterClose, nil)
+	}
+
+	C.duckdb_data_chunk_set_size(chunk, rows)
+	a.con.mu.Lock()
+	state := C.duckdb_append_data_chunk(a.duckdbAppender, chunk)
+	var err error
+	if state == C.DuckDBError {
+		err = appenderFlushError(C.duckdb_appender_error(a.duckdbAppender))
+	}
+	a.con.mu.Unlock()
+	if err != nil {
+		err = &AppenderError{Row: a.rows + 1, Rows: int64(rows), Err: invalidatedAppenderError(err)}
+		return a.sessionError(getError(errAppenderAppendRow, err))
+	}
+
+	if a.con.stats != nil {
+		a.con.stats.appendedRows.Add(uint64(rows))
+	}
+	a.rows += int64(rows)
+	return a.autoFlush()
+}
+
+// AppenderProducer converts the rows of a goroutine feeding a ConcurrentAppender. It is not safe for concurrent use.
+type AppenderProducer struct {
+	c      *ConcurrentAppender
+	closed bool
+
+	// The chunk of the producer, and the vector storage of each of its columns.
+	chunk   C.duckdb_data_chunk
+	vectors []vector
+	// The number of rows in the chunk, and the number of rows appended by the producer.
+	size C.idx_t
+	rows int64
+}
+
+// newChunk creates the next chunk of the producer. It must be called while holding the lock of the
+// ConcurrentAppender, as it reads the column types of the appender.
+func (p *AppenderProducer) newChunk() {
+	a := p.c.a
+	p.chunk = C.duckdb_create_data_chunk((*C.duckdb_logical_type)(a.colTypesPtr), C.idx_t(len(a.colTypes)))
+	C.duckdb_data_chunk_set_size(p.chunk, C.duckdb_vector_size())
+	for i := range p.vectors {
+		duckdbVector := C.duckdb_data_chunk_get_vector(p.chunk, C.idx_t(i))
+		p.vectors[i].duckdbVector = duckdbVector
+		p.vectors[i].getChildVectors(duckdbVector)
+	}
+	p.size = 0
+}
+
+// AppendRow converts a row of values into the chunk of the producer, like Appender.AppendRow, without taking the
+// lock of the appender. Once the chunk is full, the producer hands it over to the appender, see Flush.
+// The error of a failing value is an *AppenderError with the position of its row among the rows of the producer.
+func (p *AppenderProducer) AppendRow(args ...driver.Value) error {
+	if p.closed {
+		return getError(errAppenderAppendAfterClose, nil)
+	}
+
+	a := p.c.a
+	if len(args) != a.columnCount() {
+		err := &AppenderError{Row: p.rows + 1, Rows: 1, Err: columnCountError(len(args), a.columnCount())}
+		return getError(errAppenderAppendRow, err)
+	}
+	if a.stage != nil {
+		args = a.stagedRow(args)
+	}
+	if err := setRowValues(p.vectors, p.size, p.rows+1, args); err != nil {
+		return getError(errAppenderAppendRow, err)
+	}
+	p.size++
+	p.rows++
+
+	if p.size < C.duckdb_vector_size() {
+		return nil
+	}
+	return p.Flush()
+}
+
+// Flush hands over the rows of the chunk of the producer to the appender, without flushing the appender to the
+// table, see ConcurrentAppender.Flush.
+func (p *AppenderProducer) Flush() error {
+	if p.closed {
+		return getError(errAppenderAppendAfterClose, nil)
+	}
+	if p.size == 0 {
+		return nil
+	}
+
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+	err := p.c.a.appendChunk(p.chunk, p.size)
+	if p.c.a.closed {
+		return err
+	}
+	C.duckdb_destroy_data_chunk(&p.chunk)
+	p.newChunk()
+	return err
+}
+
+// Close hands over the rows of the chunk of the producer to the appender, see Flush, and closes the producer.
+func (p *AppenderProducer) Close() error {
+	if p.closed {
+		return getError(errAppenderDoubleClose, nil)
+	}
+	err := p.Flush()
+	p.closed = true
+	C.duckdb_destroy_data_chunk(&p.chunk)
+	p.vectors = nil
+
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+	p.c.producers--
+	return err
+}
@@ -0,0 +1,94 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConcurrentAppender(t *testing.T) {
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	defer c.Close()
+	db := sql.OpenDB(c)
+	_, err = db.Exec(`CREATE TABLE test (producer INTEGER, i INTEGER, l INTEGER[])`)
+	require.NoError(t, err)
+
+	con, err := c.Connect(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+	a, err := NewConcurrentAppender(con, "", "test")
+	require.NoError(t, err)
+
+	// Each producer appends more than a chunk of rows, so that producers append full batches concurrently.
+	const producers, rows = 8, 5000
+	errs := make(chan error, producers)
+	var wg sync.WaitGroup
+	for p := 0; p < producers; p++ {
+		wg.Add(1)
+		go func(p int32) {
+			defer wg.Done()
+			producer := a.NewProducer()
+			for i := int32(0); i < rows; i++ {
+				if err := producer.AppendRow(p, i, []int32{i, i}); err != nil {
+					errs <- err
+					return
+				}
+			}
+			errs <- producer.Close()
+		}(int32(p))
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.NoError(t, err)
+	}
+	require.NoError(t, a.Close())
+	testError(t, a.Close(), errAppenderDoubleClose.Error())
+
+	var count, distinct, sum, listSum int64
+	require.NoError(t, db.QueryRow(`SELECT count(*), count(DISTINCT (producer, i)), sum(i), sum(list_sum(l)) FROM test`).
+		Scan(&count, &distinct, &sum, &listSum))
+	require.Equal(t, int64(producers*rows), count)
+	require.Equal(t, count, distinct)
+	require.Equal(t, int64(producers*rows*(rows-1)/2), sum)
+	require.Equal(t, 2*sum, listSum)
+}
+
+func TestConcurrentAppenderErrors(t *testing.T) {
+	c, con, a := prepareAppender(t, `CREATE TABLE test (i INTEGER)`)
+	require.NoError(t, a.Close())
+	ca, err := NewConcurrentAppender(con, "", "test")
+	require.NoError(t, err)
+
+	// The producer converts each row, when appending it, so a failing row is not appended.
+	producer := ca.NewProducer()
+	require.NoError(t, producer.AppendRow(int32(1)))
+	err = producer.AppendRow("x")
+	var appenderErr *AppenderError
+	require.ErrorAs(t, err, &appenderErr)
+	require.Equal(t, int64(2), appenderErr.Row)
+	require.Equal(t, 1, appenderErr.Column)
+	testError(t, producer.AppendRow(int32(2), int32(3)), errAppenderAppendRow.Error())
+	require.NoError(t, producer.AppendRow(int32(2)))
+	require.NoError(t, producer.Close())
+	testError(t, producer.Close(), errAppenderDoubleClose.Error())
+	testError(t, producer.AppendRow(int32(3)), errAppenderAppendAfterClose.Error())
+	require.NoError(t, ca.Flush())
+
+	// Closing the appender discards the rows of open producers.
+	open := ca.NewProducer()
+	require.NoError(t, open.AppendRow(int32(4)))
+	testError(t, ca.Close(), errAppenderClose.Error(), "1 producers not closed")
+	testError(t, open.Close(), errAppenderAppendAfterClose.Error())
+	testError(t, ca.NewProducer().AppendRow(int32(5)), errAppenderAppendAfterClose.Error())
+
+	var sum int64
+	require.NoError(t, sql.OpenDB(c).QueryRow(`SELECT sum(i) FROM test`).Scan(&sum))
+	require.Equal(t, int64(3), sum)
+	require.NoError(t, con.Close())
+	require.NoError(t, c.Close())
+}
@@ -0,0 +1,218 @@
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"database/sql/driver"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+	"unsafe"
+
+	"github.com/google/uuid"
+)
+
+// CSVParser converts a CSV field to the value appended to its column.
+// The value must have the Go type expected by the Appender for the column type.
+type CSVParser func(field string) (driver.Value, error)
+
+// CSVOptions configures AppendCSV.
+type CSVOptions struct {
+	// Comma is the field delimiter. It defaults to ','.
+	Comma rune
+	// Comment, if not 0, is the comment character. Lines beginning with it are skipped.
+	Comment rune
+	// Header skips the first record of the input.
+	Header bool
+	// Nulls contains the fields that are appended as NULL, e.g., "" or "NULL".
+	// By default, no field is NULL.
+	Nulls []string
+	// Parsers contains the parser of each column. A missing or nil parser defaults to
+	// a parser for the type of the column. Default parsers exist for numeric, BOOLEAN, VARCHAR, BLOB,
+	// UUID, DATE, and TIMESTAMP columns. Timestamps are parsed in RFC 3339 or in DuckDB's format,
+	// e.g., 2006-01-02 15:04:05.999999.
+	Parsers []CSVParser
+	// Transform, if not nil, transforms each record before parsing its fields.
+	Transform func(record []string) ([]string, error)
+	// FlushRows flushes the Appender after each FlushRows appended rows. If zero, AppendCSV only flushes
+	// after reading all of r.
+	FlushRows int
+}
+
+// AppendCSV reads CSV records from r and appends each of them as a row through the Appender.
+// The number of fields of each record must match the number of columns of the table.
+// AppendCSV does not close the Appender. It returns the number of appended rows, and,
+// on a parse or conversion error, an error containing the line number of the record.
+func AppendCSV(a *Appender, r io.Reader, opts CSVOptions) (int, error) {
+	if a.closed {
+		return 0, getError(errAppenderAppendAfterClose, nil)
+	}
+
+	parsers := make([]CSVParser, a.columnCount())
+	for i := range parsers {
+		if i < len(opts.Parsers) && opts.Parsers[i] != nil {
+			parsers[i] = opts.Parsers[i]
+			continue
+		}
+		parsers[i] = defaultCSVParser(a.vectors[i].duckdbType)
+		if parsers[i] == nil {
+			return 0, getError(errAppendCSV, columnError(unsupportedTypeError(typeName(a.vectors[i].duckdbType)), i+1))
+		}
+	}
+
+	nulls := make(map[string]struct{}, len(opts.Nulls))
+	for _, null := range opts.Nulls {
+		nulls[null] = struct{}{}
+	}
+
+	reader := csv.NewReader(r)
+	if opts.Comma != 0 {
+		reader.Comma = opts.Comma
+	}
+	reader.Comment = opts.Comment
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+
+	rowCount := 0
+	row := make([]driver.Value, len(parsers))
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return rowCount, getError(errAppendCSV, err)
+		}
+		if first && opts.Header {
+			continue
+		}
+
+		line, _ := reader.FieldPos(0)
+		if opts.Transform != nil {
+			if record, err = opts.Transform(record); err != nil {
+				return rowCount, getError(errAppendCSV, csvLineError(err, line))
+			}
+		}
+		if len(record) != len(parsers) {
+			return rowCount, getError(errAppendCSV, csvLineError(columnCountError(len(record), len(parsers)), line))
+		}
+
+		for i, field := range record {
+			if _, ok := nulls[field]; ok {
+				row[i] = nil
+				continue
+			}
+			if row[i], err = parsers[i](field); err != nil {
+				return rowCount, getError(errAppendCSV, csvLineError(columnError(err, i+1), line))
+			}
+		}
+
+		if err = a.appendRowSlice(row); err != nil {
+			return rowCount, getError(errAppendCSV, csvLineError(err, line))
+		}
+		rowCount++
+
+		if opts.FlushRows > 0 && rowCount%opts.FlushRows == 0 {
+			err = a.Flush()
+		} else {
+			err = a.autoFlush()
+		}
+		if err != nil {
+			return rowCount, err
+		}
+	}
+
+	return rowCount, a.Flush()
+}
+
+func csvLineError(err error, line int) error {
+	return fmt.Errorf("line %d: %w", line, err)
+}
+
+// defaultCSVParser returns the parser for a column type, or nil, if there is no default parser.
+func defaultCSVParser(t C.duckdb_type) CSVParser {
+	switch t {
+	case C.DUCKDB_TYPE_TINYINT:
+		return parseCSVInt[int8]
+	case C.DUCKDB_TYPE_SMALLINT:
+		return parseCSVInt[int16]
+	case C.DUCKDB_TYPE_INTEGER:
+		return parseCSVInt[int32]
+	case C.DUCKDB_TYPE_BIGINT:
+		return parseCSVInt[int64]
+	case C.DUCKDB_TYPE_UTINYINT:
+		return parseCSVUint[uint8]
+	case C.DUCKDB_TYPE_USMALLINT:
+		return parseCSVUint[uint16]
+	case C.DUCKDB_TYPE_UINTEGER:
+		return parseCSVUint[uint32]
+	case C.DUCKDB_TYPE_UBIGINT:
+		return parseCSVUint[uint64]
+	case C.DUCKDB_TYPE_FLOAT:
+		return func(field string) (driver.Value, error) {
+			v, err := strconv.ParseFloat(field, 32)
+			return float32(v), err
+		}
+	case C.DUCKDB_TYPE_DOUBLE:
+		return func(field string) (driver.Value, error) {
+			return strconv.ParseFloat(field, 64)
+		}
+	case C.DUCKDB_TYPE_BOOLEAN:
+		return func(field string) (driver.Value, error) {
+			return strconv.ParseBool(field)
+		}
+	case C.DUCKDB_TYPE_VARCHAR:
+		return func(field string) (driver.Value, error) {
+			return field, nil
+		}
+	case C.DUCKDB_TYPE_BLOB:
+		return func(field string) (driver.Value, error) {
+			return []byte(field), nil
+		}
+	case C.DUCKDB_TYPE_UUID:
+		return func(field string) (driver.Value, error) {
+			id, err := uuid.Parse(field)
+			return UUID(id), err
+		}
+	case C.DUCKDB_TYPE_DATE:
+		return func(field string) (driver.Value, error) {
+			return time.Parse(time.DateOnly, field)
+		}
+	case C.DUCKDB_TYPE_TIMESTAMP, C.DUCKDB_TYPE_TIMESTAMP_S, C.DUCKDB_TYPE_TIMESTAMP_MS,
+		C.DUCKDB_TYPE_TIMESTAMP_NS, C.DUCKDB_TYPE_TIMESTAMP_TZ:
+		return parseCSVTimestamp
+	}
+	return nil
+}
+
+func parseCSVInt[T int8 | int16 | int32 | int64](field string) (driver.Value, error) {
+	var zero T
+	v, err := strconv.ParseInt(field, 10, int(unsafe.Sizeof(zero)*8))
+	return T(v), err
+}
+
+func parseCSVUint[T uint8 | uint16 | uint32 | uint64](field string) (driver.Value, error) {
+	var zero T
+	v, err := strconv.ParseUint(field, 10, int(unsafe.Sizeof(zero)*8))
+	return T(v), err
+}
+
+// csvTimestampLayouts are the accepted layouts of TIMESTAMP fields.
+var csvTimestampLayouts = []string{time.RFC3339Nano, "2006-01-02 15:04:05.999999999", time.DateOnly}
+
+func parseCSVTimestamp(field string) (driver.Value, error) {
+	var err error
+	for _, layout := range csvTimestampLayouts {
+		var ts time.Time
+		if ts, err = time.Parse(layout, field); err == nil {
+			return ts, nil
+		}
+	}
+	return nil, err
+}
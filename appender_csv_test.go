@@ -0,0 +1,105 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppendCSV(t *testing.T) {
+	t.Run("default parsers", func(t *testing.T) {
+		c, con, a := prepareAppender(t, `CREATE TABLE test (
+			id UBIGINT, level TINYINT, msg VARCHAR, ok BOOLEAN, latency DOUBLE, ts TIMESTAMP, day DATE
+		)`)
+
+		input := `id,level,msg,ok,latency,ts,day
+1,-3,"hello, world",true,1.5,2024-01-02 03:04:05.123456,2024-01-02
+# skipped
+2,4,,false,NULL,2024-01-02T03:04:05Z,2024-02-03
+`
+		n, err := AppendCSV(a, strings.NewReader(input), CSVOptions{Header: true, Comment: '#', Nulls: []string{"NULL"}})
+		require.NoError(t, err)
+		require.Equal(t, 2, n)
+
+		rows, err := sql.OpenDB(c).QueryContext(context.Background(), `SELECT * FROM test ORDER BY id`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var res []string
+		for rows.Next() {
+			var (
+				id      uint64
+				level   int8
+				msg     string
+				ok      bool
+				latency sql.NullFloat64
+				ts, day time.Time
+			)
+			require.NoError(t, rows.Scan(&id, &level, &msg, &ok, &latency, &ts, &day))
+			res = append(res, fmt.Sprintf("%d %d %q %t %v %s %s", id, level, msg, ok, latency,
+				ts.Format(time.RFC3339Nano), day.Format(time.DateOnly)))
+		}
+		require.NoError(t, rows.Err())
+		require.Equal(t, []string{
+			`1 -3 "hello, world" true {1.5 true} 2024-01-02T03:04:05.123456Z 2024-01-02`,
+			`2 4 "" false {0 false} 2024-01-02T03:04:05Z 2024-02-03`,
+		}, res)
+		cleanupAppender(t, c, con, a)
+	})
+
+	t.Run("custom parsers and flushing", func(t *testing.T) {
+		c, con, a := prepareAppender(t, `CREATE TABLE test (id INTEGER, msg VARCHAR)`)
+
+		var input strings.Builder
+		for i := 0; i < 5000; i++ {
+			input.WriteString(fmt.Sprintf("%d|msg %d\n", i, i))
+		}
+
+		n, err := AppendCSV(a, strings.NewReader(input.String()), CSVOptions{
+			Comma: '|',
+			Parsers: []CSVParser{nil, func(field string) (driver.Value, error) {
+				return strings.ToUpper(field), nil
+			}},
+			Transform: func(record []string) ([]string, error) {
+				return []string{record[0], strings.TrimPrefix(record[1], "msg ")}, nil
+			},
+			FlushRows: 1000,
+		})
+		require.NoError(t, err)
+		require.Equal(t, 5000, n)
+
+		var count int
+		var maxMsg string
+		row := sql.OpenDB(c).QueryRowContext(context.Background(), `SELECT count(*), max(msg::INTEGER) FROM test WHERE id::VARCHAR = msg`)
+		require.NoError(t, row.Scan(&count, &maxMsg))
+		require.Equal(t, 5000, count)
+		require.Equal(t, "4999", maxMsg)
+		cleanupAppender(t, c, con, a)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		c, con, a := prepareAppender(t, `CREATE TABLE test (id INTEGER, msg VARCHAR)`)
+
+		n, err := AppendCSV(a, strings.NewReader("1,a\n2,b\nx,c\n"), CSVOptions{})
+		testError(t, err, errAppendCSV.Error(), "line 3", `parsing "x"`, columnErrMsg)
+		require.Equal(t, 2, n)
+
+		_, err = AppendCSV(a, strings.NewReader("1,a\n2\n"), CSVOptions{})
+		testError(t, err, errAppendCSV.Error(), "line 2", columnCountErrMsg)
+
+		_, err = AppendCSV(a, strings.NewReader("1,\"a\n"), CSVOptions{})
+		testError(t, err, errAppendCSV.Error(), "line 1")
+		cleanupAppender(t, c, con, a)
+
+		c, con, a = prepareAppender(t, `CREATE TABLE test (l INTEGER[])`)
+		_, err = AppendCSV(a, strings.NewReader("[1]\n"), CSVOptions{})
+		testError(t, err, errAppendCSV.Error(), unsupportedTypeErrMsg)
+		cleanupAppender(t, c, con, a)
+	})
+}
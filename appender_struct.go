@@ -0,0 +1,127 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+)
+
+// AppendStruct appends the fields of a struct, or of a pointer to a struct, as a row. The fields match
+// the columns of the table like the fields of a StructScanner match the columns of a query, i.e., by their
+// `db` tags or their names. Each column must match a field, and fields without a matching column are ignored.
+// A nil pointer field appends NULL, and a field implementing driver.Valuer appends its value.
+// The Appender caches the mapping of each struct type to the columns of its table.
+func (a *Appender) AppendStruct(v any) error {
+	if a.closed {
+		return getError(errAppenderAppendAfterClose, nil)
+	}
+
+	row, err := a.structRow(reflect.ValueOf(v))
+	if err != nil {
+		return a.sessionError(getError(errAppenderAppendRow, err))
+	}
+	if err = a.appendRowSlice(row); err != nil {
+		return a.sessionError(getError(errAppenderAppendRow, err))
+	}
+	return a.autoFlush()
+}
+
+// AppendStructs appends the elements of a slice of structs, or of pointers to structs, as rows, see AppendStruct.
+// It stops at the first element failing to append, and its error reports the index of the element.
+func (a *Appender) AppendStructs(slice any) error {
+	if a.closed {
+		return getError(errAppenderAppendAfterClose, nil)
+	}
+
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Slice && v.Kind() != reflect.Array {
+		return getError(errAppenderAppendRow, castError(fmt.Sprintf("%T", slice), "slice of structs"))
+	}
+	for i := 0; i < v.Len(); i++ {
+		row, err := a.structRow(v.Index(i))
+		if err == nil {
+			err = a.appendRowSlice(row)
+		}
+		if err != nil {
+			return a.sessionError(getError(errAppenderAppendRow, fmt.Errorf("element %d: %w", i, err)))
+		}
+		if err = a.autoFlush(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// structRow returns the values of the fields of a struct, ordered by their columns.
+func (a *Appender) structRow(v reflect.Value) ([]driver.Value, error) {
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, castError(v.Type().String(), "struct or pointer to struct")
+	}
+
+	indexes, err := a.structIndexes(v.Type())
+	if err != nil {
+		return nil, err
+	}
+
+	row := make([]driver.Value, len(indexes))
+	for i, index := range indexes {
+		field, err := v.FieldByIndexErr(index)
+		if err != nil {
+			// A nil embedded pointer has no fields, so its promoted fields append NULL.
+			continue
+		}
+		if valuer, ok := field.Interface().(driver.Valuer); ok {
+			if field.Kind() == reflect.Pointer && field.IsNil() {
+				continue
+			}
+			if row[i], err = valuer.Value(); err != nil {
+				return nil, columnError(err, i+1)
+			}
+			continue
+		}
+		row[i] = field.Interface()
+	}
+	return row, nil
+}
+
+// structIndexes returns the index of the matching field of the struct type for each column of the table.
+func (a *Appender) structIndexes(t reflect.Type) ([][]int, error) {
+	if indexes, ok := a.structCache[t]; ok {
+		return indexes, nil
+	}
+
+	if a.columnNames == nil {
+		names, err := a.queryColumnNames()
+		if err != nil {
+			return nil, err
+		}
+		a.columnNames = names
+	}
+	indexes, err := StructScanner{}.fieldIndexes(t, a.columnNames)
+	if err != nil {
+		return nil, err
+	}
+
+	if a.structCache == nil {
+		a.structCache = make(map[reflect.Type][][]int)
+	}
+	a.structCache[t] = indexes
+	return indexes, nil
+}
+
+// queryColumnNames returns the names of the columns of the table, in order.
+func (a *Appender) queryColumnNames() ([]string, error) {
+	columns, err := DescribeTable(context.Background(), a.con, "", a.schema, a.table)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(columns))
+	for i, column := range columns {
+		names[i] = column.Name
+	}
+	return names, nil
+}
@@ -0,0 +1,80 @@
+package duckdb
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type appendedBase struct {
+	ID int64 `db:"id"`
+}
+
+type appendedItem struct {
+	appendedBase
+	Name    string         `db:"item_name"`
+	Price   *float64       `db:"price"`
+	Comment sql.NullString `db:"comment"`
+	Ignored string         `db:"-"`
+	Extra   bool
+}
+
+func TestAppendStruct(t *testing.T) {
+	c, con, a := prepareAppender(t,
+		`CREATE TABLE test (id BIGINT, price DOUBLE, item_name VARCHAR, comment VARCHAR, "Extra" BOOLEAN)`)
+
+	price := 2.5
+	require.NoError(t, a.AppendStruct(appendedItem{
+		appendedBase: appendedBase{ID: 1}, Name: "a", Price: &price, Comment: sql.NullString{String: "x", Valid: true},
+	}))
+	require.NoError(t, a.AppendStruct(&appendedItem{appendedBase: appendedBase{ID: 2}, Name: "b", Extra: true}))
+	require.NoError(t, a.AppendStructs([]*appendedItem{
+		{appendedBase: appendedBase{ID: 3}, Name: "c"},
+		{appendedBase: appendedBase{ID: 4}, Name: "d"},
+	}))
+	require.NoError(t, a.Flush())
+
+	db := sql.OpenDB(c)
+	rows, err := db.Query(`SELECT id, price, item_name, comment, "Extra" FROM test ORDER BY id`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var items []appendedItem
+	for rows.Next() {
+		var item appendedItem
+		require.NoError(t, ScanStruct(rows, &item))
+		items = append(items, item)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []appendedItem{
+		{appendedBase: appendedBase{ID: 1}, Name: "a", Price: &price, Comment: sql.NullString{String: "x", Valid: true}},
+		{appendedBase: appendedBase{ID: 2}, Name: "b", Extra: true},
+		{appendedBase: appendedBase{ID: 3}, Name: "c"},
+		{appendedBase: appendedBase{ID: 4}, Name: "d"},
+	}, items)
+
+	cleanupAppender(t, c, con, a)
+}
+
+func TestAppendStructErrors(t *testing.T) {
+	c, con, a := prepareAppender(t, `CREATE TABLE test (id BIGINT, missing VARCHAR)`)
+
+	err := a.AppendStruct(appendedBase{ID: 1})
+	testError(t, err, errAppenderAppendRow.Error(), missingFieldErrMsg, "missing")
+
+	err = a.AppendStruct(42)
+	testError(t, err, errAppenderAppendRow.Error(), castErrMsg)
+
+	err = a.AppendStructs(appendedBase{})
+	testError(t, err, errAppenderAppendRow.Error(), castErrMsg)
+
+	type wrongType struct {
+		ID      string
+		Missing string
+	}
+	err = a.AppendStructs([]wrongType{{ID: "x"}})
+	testError(t, err, errAppenderAppendRow.Error(), "element 0", castErrMsg)
+
+	cleanupAppender(t, c, con, a)
+}
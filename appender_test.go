@@ -332,6 +332,117 @@ func TestAppenderList(t *testing.T) {
 	cleanupAppender(t, c, con, a)
 }
 
+func TestAppenderArray(t *testing.T) {
+	c, con, a := prepareAppender(t, `
+	CREATE TABLE test (
+		id INTEGER,
+		embedding FLOAT[3],
+		embeddings FLOAT[2][],
+		names VARCHAR[2]
+	)`)
+
+	// More rows than a chunk holds, to append the elements of several chunks.
+	const rowCount = 3000
+	for i := 0; i < rowCount; i++ {
+		f := float32(i)
+		require.NoError(t, a.AppendRow(int32(i), [3]float32{f, f + 0.5, f + 1}, [][2]float32{{f, 1}, {2, f}},
+			[]string{"a", fmt.Sprint(i)}))
+	}
+	require.NoError(t, a.AppendRow(int32(rowCount), nil, [][]float32{nil}, []any{nil, "b"}))
+
+	err := a.AppendRow(int32(0), []float32{1, 2}, nil, nil)
+	testError(t, err, errAppenderAppendRow.Error(), castErrMsg, "array of size 3")
+	err = a.AppendRow(int32(0), "x", nil, nil)
+	testError(t, err, errAppenderAppendRow.Error(), castErrMsg)
+	require.NoError(t, a.Flush())
+
+	// Verify results.
+	res, err := sql.OpenDB(c).QueryContext(context.Background(), `SELECT * FROM test ORDER BY id`)
+	require.NoError(t, err)
+
+	i := 0
+	for res.Next() {
+		var id int32
+		var embedding List[float32]
+		var embeddings List[*[2]float32]
+		var names List[*string]
+		require.NoError(t, res.Scan(&id, &embedding, &embeddings, &names))
+		require.Equal(t, int32(i), id)
+		if i == rowCount {
+			require.Nil(t, embedding)
+			require.Equal(t, List[*[2]float32]{nil}, embeddings)
+			require.Nil(t, names[0])
+			require.Equal(t, "b", *names[1])
+			break
+		}
+
+		f := float32(i)
+		require.Equal(t, List[float32]{f, f + 0.5, f + 1}, embedding)
+		require.Equal(t, List[*[2]float32]{{f, 1}, {2, f}}, embeddings)
+		require.Equal(t, fmt.Sprint(i), *names[1])
+		i++
+	}
+
+	require.Equal(t, rowCount, i)
+	require.NoError(t, res.Close())
+	cleanupAppender(t, c, con, a)
+}
+
+func TestAppenderMap(t *testing.T) {
+	c, con, a := prepareAppender(t, `
+	CREATE TABLE test (
+		id INTEGER,
+		counts MAP(VARCHAR, INTEGER),
+		tags MAP(INTEGER, VARCHAR[]),
+		attrs STRUCT(name VARCHAR, props MAP(VARCHAR, DOUBLE))
+	)`)
+
+	// More rows than a chunk holds, to append the entries of several chunks.
+	const rowCount = 3000
+	for i := 0; i < rowCount; i++ {
+		require.NoError(t, a.AppendRow(int32(i), map[string]int32{"a": int32(i), "b": 1},
+			Map{int32(i): []string{"x", "y"}}, map[string]any{"name": fmt.Sprint(i), "props": map[string]float64{"p": 0.5}}))
+	}
+	require.NoError(t, a.AppendRow(int32(rowCount), nil, Map{int32(1): nil}, map[string]any{"name": "n", "props": nil}))
+
+	err := a.AppendRow(int32(0), map[int32]int32{1: 1}, nil, nil)
+	testError(t, err, errAppenderAppendRow.Error(), castErrMsg)
+	err = a.AppendRow(int32(0), []int32{1}, nil, nil)
+	testError(t, err, errAppenderAppendRow.Error(), castErrMsg)
+	err = a.AppendRow(int32(0), Map{nil: int32(1)}, nil, nil)
+	testError(t, err, errAppenderAppendRow.Error(), castErrMsg, "MAP key")
+	require.NoError(t, a.Flush())
+
+	// Verify results.
+	res, err := sql.OpenDB(c).QueryContext(context.Background(), `SELECT * FROM test ORDER BY id`)
+	require.NoError(t, err)
+
+	i := 0
+	for res.Next() {
+		var id int32
+		var counts any
+		var tags Map
+		var attrs map[string]any
+		require.NoError(t, res.Scan(&id, &counts, &tags, &attrs))
+		require.Equal(t, int32(i), id)
+		if i == rowCount {
+			require.Nil(t, counts)
+			require.Equal(t, Map{int32(1): nil}, tags)
+			require.Equal(t, map[string]any{"name": "n", "props": nil}, attrs)
+			break
+		}
+
+		require.Equal(t, Map{"a": int32(i), "b": int32(1)}, counts)
+		require.Equal(t, Map{int32(i): []any{"x", "y"}}, tags)
+		require.Equal(t, map[string]any{"name": fmt.Sprint(i), "props": Map{"p": 0.5}}, attrs)
+		i++
+	}
+
+	require.Equal(t, rowCount, i)
+	require.NoError(t, res.Close())
+	cleanupAppender(t, c, con, a)
+}
+
 func TestAppenderNested(t *testing.T) {
 	c, con, a := prepareAppender(t, `
 		CREATE TABLE test (
@@ -631,6 +742,57 @@ func TestAppenderNullIntAndString(t *testing.T) {
 	cleanupAppender(t, c, con, a)
 }
 
+func TestAppenderNullsAndZeros(t *testing.T) {
+	c, con, a := prepareAppender(t, `CREATE TABLE test (id INTEGER, i INTEGER, str VARCHAR, l INTEGER[], s STRUCT(v DOUBLE))`)
+
+	zeroInt := int32(0)
+	zeroStr := ""
+	var nilInt *int32
+	var nilStr *string
+
+	// Rows 1 and 3 contain NULLs, rows 2 and 4 contain zero values.
+	require.NoError(t, a.AppendRow(int32(1), nil, nil, nil, nil))
+	require.NoError(t, a.AppendRow(int32(2), int32(0), "", []int32{}, map[string]any{"v": float64(0)}))
+	require.NoError(t, a.AppendRow(int32(3), nilInt, nilStr, []*int32{nilInt, &zeroInt}, nil))
+	require.NoError(t, a.AppendRow(int32(4), &zeroInt, &zeroStr, []int32{0}, map[string]any{"v": nil}))
+	require.NoError(t, a.Flush())
+
+	db := sql.OpenDB(c)
+	var nullIDs, zeroIDs []int32
+	rows, err := db.Query(`SELECT id, i IS NULL, str IS NULL FROM test ORDER BY id`)
+	require.NoError(t, err)
+	for rows.Next() {
+		var id int32
+		var intNull, strNull bool
+		require.NoError(t, rows.Scan(&id, &intNull, &strNull))
+		require.Equal(t, intNull, strNull)
+		if intNull {
+			nullIDs = append(nullIDs, id)
+		} else {
+			zeroIDs = append(zeroIDs, id)
+		}
+	}
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+	require.Equal(t, []int32{1, 3}, nullIDs)
+	require.Equal(t, []int32{2, 4}, zeroIDs)
+
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM test WHERE i = 0 AND str = ''`).Scan(&count))
+	require.Equal(t, 2, count)
+
+	var list []any
+	require.NoError(t, db.QueryRow(`SELECT l FROM test WHERE id = 3`).Scan(&list))
+	require.Equal(t, []any{nil, int32(0)}, list)
+
+	var nullStruct, nullField bool
+	require.NoError(t, db.QueryRow(`SELECT s IS NULL, s.v IS NULL FROM test WHERE id = 4`).Scan(&nullStruct, &nullField))
+	require.False(t, nullStruct)
+	require.True(t, nullField)
+
+	cleanupAppender(t, c, con, a)
+}
+
 func TestAppenderUUID(t *testing.T) {
 	c, con, a := prepareAppender(t, `CREATE TABLE test (id UUID)`)
 
@@ -663,6 +825,33 @@ func TestAppenderTime(t *testing.T) {
 	cleanupAppender(t, c, con, a)
 }
 
+func TestAppenderTimeOfDay(t *testing.T) {
+	c, con, a := prepareAppender(t, `CREATE TABLE test (t TIME, tz TIMETZ)`)
+
+	v := time.Date(2022, time.January, 1, 12, 30, 5, 1000, time.FixedZone("", 2*60*60))
+	require.NoError(t, a.AppendRow(v, v))
+	require.NoError(t, a.AppendRow(nil, nil))
+	require.NoError(t, a.Flush())
+
+	// Verify results.
+	rows, err := sql.OpenDB(c).QueryContext(context.Background(), `SELECT t::VARCHAR, tz::VARCHAR FROM test`)
+	require.NoError(t, err)
+
+	var res []string
+	for rows.Next() {
+		var tm, tz *string
+		require.NoError(t, rows.Scan(&tm, &tz))
+		res = append(res, fmt.Sprint(tm != nil && tz != nil))
+		if tm != nil {
+			res = append(res, *tm, *tz)
+		}
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []string{"true", "12:30:05.000001", "12:30:05.000001+02", "false"}, res)
+	require.NoError(t, rows.Close())
+	cleanupAppender(t, c, con, a)
+}
+
 func TestAppenderDate(t *testing.T) {
 	c, con, a := prepareAppender(t, `CREATE TABLE test (date DATE)`)
 
@@ -845,3 +1034,299 @@ func TestAppenderWithJSON(t *testing.T) {
 	require.NoError(t, res.Close())
 	cleanupAppender(t, c, con, a)
 }
+
+func TestAppenderWithTransaction(t *testing.T) {
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	db := sql.OpenDB(c)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE test (i INTEGER)`)
+	require.NoError(t, err)
+
+	con, err := c.Connect(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	countRows := func() int {
+		var count int
+		require.NoError(t, db.QueryRow(`SELECT count(*) FROM test`).Scan(&count))
+		return count
+	}
+	appendRows := func(a *Appender, n int) {
+		for i := 0; i < n; i++ {
+			require.NoError(t, a.AppendRow(int32(i)))
+		}
+	}
+
+	t.Run("commit on close", func(t *testing.T) {
+		a, err := NewAppenderFromConn(con, "", "test", WithTransaction())
+		require.NoError(t, err)
+		appendRows(a, 3000)
+		require.NoError(t, a.Flush())
+		appendRows(a, 10)
+
+		// Other connections do not see the rows before the commit.
+		require.Equal(t, 0, countRows())
+		require.NoError(t, a.Close())
+		require.Equal(t, 3010, countRows())
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		a, err := NewAppenderFromConn(con, "", "test", WithTransaction())
+		require.NoError(t, err)
+		appendRows(a, 3000)
+		require.NoError(t, a.Flush())
+		appendRows(a, 10)
+		require.NoError(t, a.Rollback())
+		require.Equal(t, 3010, countRows())
+
+		testError(t, a.Close(), errAppenderDoubleClose.Error())
+	})
+
+	t.Run("rollback on close after error", func(t *testing.T) {
+		a, err := NewAppenderFromConn(con, "", "test", WithTransaction())
+		require.NoError(t, err)
+		appendRows(a, 3000)
+		require.NoError(t, a.Flush())
+		testError(t, a.AppendRow("not an integer"), errAppenderAppendRow.Error())
+		appendRows(a, 10)
+
+		testError(t, a.Close(), errAppenderClose.Error(), "rolled back")
+		require.Equal(t, 3010, countRows())
+	})
+
+	t.Run("connection usable afterward", func(t *testing.T) {
+		a, err := NewAppenderFromConn(con, "", "test")
+		require.NoError(t, err)
+		appendRows(a, 5)
+		testError(t, a.Rollback(), errAppenderRollback.Error(), "WithTransaction")
+		require.NoError(t, a.Close())
+		require.Equal(t, 3015, countRows())
+	})
+
+	t.Run("connection in a transaction", func(t *testing.T) {
+		tx, err := con.(driver.ConnBeginTx).BeginTx(context.Background(), driver.TxOptions{})
+		require.NoError(t, err)
+		_, err = NewAppenderFromConn(con, "", "test", WithTransaction())
+		testError(t, err, errAppenderCreation.Error(), "already in a transaction")
+		require.NoError(t, tx.Rollback())
+	})
+
+	t.Run("invalid table", func(t *testing.T) {
+		_, err := NewAppenderFromConn(con, "", "missing", WithTransaction())
+		testError(t, err, errAppenderCreation.Error())
+
+		a, err := NewAppenderFromConn(con, "", "test", WithTransaction())
+		require.NoError(t, err)
+		require.NoError(t, a.Close())
+	})
+}
+
+func TestAppenderWithColumns(t *testing.T) {
+	t.Parallel()
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	db := sql.OpenDB(c)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE SEQUENCE ids;
+		CREATE TABLE events (id BIGINT PRIMARY KEY DEFAULT nextval('ids'), name VARCHAR NOT NULL,
+			level INTEGER DEFAULT 3, note VARCHAR)`)
+	require.NoError(t, err)
+
+	con, err := c.Connect(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	type event struct {
+		ID    int64
+		Name  string
+		Level int32
+		Note  *string
+	}
+	events := func(t *testing.T) []event {
+		rows, err := db.Query(`SELECT * FROM events ORDER BY id`)
+		require.NoError(t, err)
+		result, err := ScanRows[event](rows)
+		require.NoError(t, err)
+		return result
+	}
+	reset := func(t *testing.T) {
+		_, err := db.Exec(`DELETE FROM events`)
+		require.NoError(t, err)
+	}
+
+	t.Run("subset of columns", func(t *testing.T) {
+		defer reset(t)
+		a, err := NewAppenderFromConn(con, "", "events", WithColumns("NAME", "note"))
+		require.NoError(t, err)
+		for i := 0; i < 3000; i++ {
+			require.NoError(t, a.AppendRow(fmt.Sprintf("event %d", i), nil))
+		}
+		require.NoError(t, a.Flush())
+		require.NoError(t, a.AppendStruct(struct {
+			Note string
+			Name string
+		}{Note: "struct", Name: "last"}))
+		require.NoError(t, a.Close())
+
+		result := events(t)
+		require.Len(t, result, 3001)
+		for i, e := range result[:3000] {
+			// The sequence numbers the rows in the order of appending.
+			require.Equal(t, result[0].ID+int64(i), e.ID)
+			require.Equal(t, fmt.Sprintf("event %d", i), e.Name)
+			require.Equal(t, int32(3), e.Level)
+			require.Nil(t, e.Note)
+		}
+		require.Equal(t, "last", result[3000].Name)
+		require.Equal(t, "struct", *result[3000].Note)
+	})
+
+	t.Run("default values", func(t *testing.T) {
+		defer reset(t)
+		a, err := NewAppenderFromConn(con, "", "events", WithColumns("id", "name", "level", "note"))
+		require.NoError(t, err)
+		require.NoError(t, a.AppendRow(int64(-1), "explicit", int32(1), "x"))
+		require.NoError(t, a.AppendRow(Default, "defaults", Default, Default))
+		require.NoError(t, a.Close())
+
+		result := events(t)
+		require.Len(t, result, 2)
+		require.Equal(t, event{ID: -1, Name: "explicit", Level: 1, Note: result[0].Note}, result[0])
+		require.Equal(t, "x", *result[0].Note)
+		require.Positive(t, result[1].ID)
+		require.Equal(t, int32(3), result[1].Level)
+		require.Nil(t, result[1].Note)
+	})
+
+	t.Run("constraint violation", func(t *testing.T) {
+		defer reset(t)
+		a, err := NewAppenderFromConn(con, "", "events", WithColumns("name"), WithTransaction())
+		require.NoError(t, err)
+		require.NoError(t, a.AppendRow("valid"))
+		require.NoError(t, a.AppendRow(nil))
+
+		err = a.Flush()
+		var appenderErr *AppenderError
+		require.ErrorAs(t, err, &appenderErr)
+		require.Equal(t, int64(1), appenderErr.Row)
+		require.Equal(t, int64(2), appenderErr.Rows)
+		require.ErrorIs(t, err, ErrConstraintNotNull)
+		require.Error(t, a.Close())
+		require.Empty(t, events(t))
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := NewAppenderFromConn(con, "", "events", WithColumns("missing"))
+		testError(t, err, errAppenderCreation.Error(), "column not found: missing")
+		_, err = NewAppenderFromConn(con, "", "events", WithColumns("name", "Name"))
+		testError(t, err, errAppenderCreation.Error(), "duplicate column: Name")
+
+		a, err := NewAppenderFromConn(con, "", "events", WithColumns("name"))
+		require.NoError(t, err)
+		testError(t, a.AppendRow("a", "b"), errAppenderAppendRow.Error(), columnCountErrMsg)
+		require.NoError(t, a.Close())
+
+		a, err = NewAppenderFromConn(con, "", "events")
+		require.NoError(t, err)
+		testError(t, a.AppendRow(Default, "name", Default, nil), errAppenderAppendRow.Error(), errAppenderDefault.Error())
+		require.NoError(t, a.Close())
+
+		// The staging tables of the connection are dropped.
+		tables, err := QueryMaps(context.Background(), con, `SELECT table_name FROM duckdb_tables() WHERE temporary`)
+		require.NoError(t, err)
+		require.Empty(t, tables)
+	})
+}
+
+func TestAppenderErrors(t *testing.T) {
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	defer c.Close()
+	db := sql.OpenDB(c)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE test (id INTEGER PRIMARY KEY, name VARCHAR NOT NULL)`)
+	require.NoError(t, err)
+
+	con, err := c.Connect(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	countRows := func() int {
+		var count int
+		require.NoError(t, db.QueryRow(`SELECT count(*) FROM test`).Scan(&count))
+		return count
+	}
+
+	t.Run("failing value", func(t *testing.T) {
+		a, err := NewAppenderFromConn(con, "", "test")
+		require.NoError(t, err)
+		require.NoError(t, a.AppendRow(int32(1), "a"))
+		err = a.AppendRow(int32(2), 2)
+		testError(t, err, errAppenderAppendRow.Error(), "row 2", castErrMsg, columnErrMsg)
+
+		var appenderErr *AppenderError
+		require.ErrorAs(t, err, &appenderErr)
+		require.Equal(t, int64(2), appenderErr.Row)
+		require.Equal(t, int64(1), appenderErr.Rows)
+		require.Equal(t, 2, appenderErr.Column)
+
+		err = a.AppendRow(int32(2))
+		require.ErrorAs(t, err, &appenderErr)
+		require.Equal(t, int64(2), appenderErr.Row)
+		require.Zero(t, appenderErr.Column)
+		require.NoError(t, a.Close())
+		require.Equal(t, 1, countRows())
+	})
+
+	t.Run("constraint violation", func(t *testing.T) {
+		a, err := NewAppenderFromConn(con, "", "test")
+		require.NoError(t, err)
+		require.NoError(t, a.AppendRow(int32(2), "b"))
+		require.NoError(t, a.Flush())
+		require.NoError(t, a.AppendRow(int32(3), "c"))
+		require.NoError(t, a.AppendRow(int32(1), "duplicate"))
+
+		err = a.Flush()
+		testError(t, err, errAppenderFlush.Error(), "rows 2 to 3", "violates primary key constraint")
+		require.ErrorIs(t, err, ErrConstraintUnique)
+		var appenderErr *AppenderError
+		require.ErrorAs(t, err, &appenderErr)
+		require.Equal(t, int64(2), appenderErr.Row)
+		require.Equal(t, int64(2), appenderErr.Rows)
+		require.Zero(t, appenderErr.Column)
+
+		err = a.Close()
+		testError(t, err, errAppenderClose.Error(), "rows 2 to 3")
+		require.ErrorIs(t, err, ErrConstraintUnique)
+		testError(t, a.Flush(), errAppenderFlush.Error(), "closed")
+		require.Equal(t, 2, countRows())
+	})
+
+	t.Run("auto flush", func(t *testing.T) {
+		_, err := NewAppenderFromConn(con, "", "test", WithAutoFlush(-1))
+		testError(t, err, errAppenderCreation.Error(), "negative")
+
+		a, err := NewAppenderFromConn(con, "", "test", WithAutoFlush(100))
+		require.NoError(t, err)
+		for i := 0; i < 250; i++ {
+			require.NoError(t, a.AppendRow(int32(100+i), "auto"))
+		}
+		// The last 50 rows are not flushed yet.
+		require.Equal(t, 202, countRows())
+		require.NoError(t, a.Close())
+		require.Equal(t, 252, countRows())
+
+		a, err = NewAppenderFromConn(con, "", "test", WithAutoFlush(2))
+		require.NoError(t, err)
+		require.NoError(t, a.AppendRow(int32(1000), "ok"))
+		err = a.AppendRow(int32(1001), nil)
+		testError(t, err, errAppenderFlush.Error(), "rows 1 to 2")
+		require.ErrorIs(t, err, ErrConstraintNotNull)
+		require.Error(t, a.Close())
+		require.Equal(t, 252, countRows())
+	})
+}
@@ -7,6 +7,7 @@ package duckdb
 import "C"
 
 import (
+	"fmt"
 	"reflect"
 	"strconv"
 	"time"
@@ -28,6 +29,8 @@ type vector struct {
 	childNames []string
 	// The child vectors of nested data types.
 	childVectors []vector
+	// The number of elements of ARRAY vectors.
+	arraySize int
 }
 
 // fnSetVectorValue is the setter callback function for any (nested) vectors.
@@ -38,6 +41,14 @@ func (vec *vector) tryCast(val any) (any, error) {
 		return val, nil
 	}
 
+	// A typed nil pointer appends NULL, and any other pointer appends the value it points to.
+	if v := reflect.ValueOf(val); v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil, nil
+		}
+		return vec.tryCast(v.Elem().Interface())
+	}
+
 	switch vec.duckdbType {
 	case C.DUCKDB_TYPE_UTINYINT:
 		return tryNumericCast[uint8](val, reflect.Uint8.String())
@@ -66,14 +77,23 @@ func (vec *vector) tryCast(val any) (any, error) {
 	case C.DUCKDB_TYPE_BLOB:
 		return tryPrimitiveCast[[]byte](val, reflect.TypeOf([]byte{}).String())
 	case C.DUCKDB_TYPE_TIMESTAMP, C.DUCKDB_TYPE_TIMESTAMP_S, C.DUCKDB_TYPE_TIMESTAMP_MS,
-		C.DUCKDB_TYPE_TIMESTAMP_NS, C.DUCKDB_TYPE_TIMESTAMP_TZ, C.DUCKDB_TYPE_DATE:
+		C.DUCKDB_TYPE_TIMESTAMP_NS, C.DUCKDB_TYPE_TIMESTAMP_TZ, C.DUCKDB_TYPE_TIME, C.DUCKDB_TYPE_TIME_TZ:
+		return tryPrimitiveCast[time.Time](val, reflect.TypeOf(time.Time{}).String())
+	case C.DUCKDB_TYPE_DATE:
+		if d, ok := val.(Date); ok {
+			return d.In(time.UTC), nil
+		}
 		return tryPrimitiveCast[time.Time](val, reflect.TypeOf(time.Time{}).String())
 	case C.DUCKDB_TYPE_UUID:
 		return tryPrimitiveCast[UUID](val, reflect.TypeOf(UUID{}).String())
 	case C.DUCKDB_TYPE_LIST:
 		return vec.tryCastList(val)
+	case C.DUCKDB_TYPE_ARRAY:
+		return vec.tryCastArray(val)
 	case C.DUCKDB_TYPE_STRUCT:
 		return vec.tryCastStruct(val)
+	case C.DUCKDB_TYPE_MAP:
+		return vec.tryCastMap(val)
 	}
 
 	return nil, getError(errDriver, nil)
@@ -139,6 +159,36 @@ func (vec *vector) tryCastList(val any) ([]any, error) {
 	return list, nil
 }
 
+// tryCastArray casts a slice or a Go array with the size of the ARRAY, e.g., a [3]float32 for a FLOAT[3].
+func (vec *vector) tryCastArray(val any) ([]any, error) {
+	goType := reflect.TypeOf(val)
+	if goType.Kind() != reflect.Slice && goType.Kind() != reflect.Array {
+		return nil, castError(goType.String(), reflect.Array.String())
+	}
+
+	v := reflect.ValueOf(val)
+	if v.Len() != vec.arraySize {
+		return nil, castError(goType.String(), fmt.Sprintf("array of size %d", vec.arraySize))
+	}
+
+	list := make([]any, v.Len())
+	childVector := vec.childVectors[0]
+	for i := 0; i < v.Len(); i++ {
+		idx := v.Index(i)
+		if vec.canNil(idx) && idx.IsNil() {
+			list[i] = nil
+			continue
+		}
+
+		var err error
+		list[i], err = childVector.tryCast(idx.Interface())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return list, nil
+}
+
 func (vec *vector) tryCastStruct(val any) (map[string]any, error) {
 	m, isMap := val.(map[string]any)
 
@@ -185,6 +235,47 @@ func (vec *vector) tryCastStruct(val any) (map[string]any, error) {
 	return m, nil
 }
 
+// mapEntry is a key-value pair of a MAP value.
+type mapEntry struct {
+	key   any
+	value any
+}
+
+// tryCastMap casts a Map or any other Go map into the key-value pairs of a MAP.
+func (vec *vector) tryCastMap(val any) ([]mapEntry, error) {
+	goType := reflect.TypeOf(val)
+	if goType.Kind() != reflect.Map {
+		return nil, castError(goType.String(), reflect.Map.String())
+	}
+
+	v := reflect.ValueOf(val)
+	entries := make([]mapEntry, 0, v.Len())
+	keyVector := vec.childVectors[0]
+	valueVector := vec.childVectors[1]
+
+	iter := v.MapRange()
+	for iter.Next() {
+		k := iter.Key()
+		if vec.canNil(k) && k.IsNil() {
+			return nil, castError("nil", "MAP key")
+		}
+		key, err := keyVector.tryCast(k.Interface())
+		if err != nil {
+			return nil, err
+		}
+
+		var value any
+		if e := iter.Value(); !vec.canNil(e) || !e.IsNil() {
+			value, err = valueVector.tryCast(e.Interface())
+			if err != nil {
+				return nil, err
+			}
+		}
+		entries = append(entries, mapEntry{key: key, value: value})
+	}
+	return entries, nil
+}
+
 func (vec *vector) init(logicalType C.duckdb_logical_type, colIdx int) error {
 	duckdbType := C.duckdb_get_type_id(logicalType)
 
@@ -220,10 +311,16 @@ func (vec *vector) init(logicalType C.duckdb_logical_type, colIdx int) error {
 		vec.initUUID()
 	case C.DUCKDB_TYPE_DATE:
 		vec.initDate()
+	case C.DUCKDB_TYPE_TIME, C.DUCKDB_TYPE_TIME_TZ:
+		vec.initTimeOfDay(duckdbType)
 	case C.DUCKDB_TYPE_LIST:
 		return vec.initList(logicalType, colIdx)
+	case C.DUCKDB_TYPE_ARRAY:
+		return vec.initArray(logicalType, colIdx)
 	case C.DUCKDB_TYPE_STRUCT:
 		return vec.initStruct(logicalType)
+	case C.DUCKDB_TYPE_MAP:
+		return vec.initMap(logicalType, colIdx)
 	default:
 		name, found := unsupportedAppenderTypeMap[duckdbType]
 		if !found {
@@ -243,12 +340,26 @@ func (vec *vector) getChildVectors(vector C.duckdb_vector) {
 		vec.childVectors[0].duckdbVector = child
 		vec.childVectors[0].getChildVectors(child)
 
+	case C.DUCKDB_TYPE_ARRAY:
+		child := C.duckdb_array_vector_get_child(vector)
+		vec.childVectors[0].duckdbVector = child
+		vec.childVectors[0].getChildVectors(child)
+
 	case C.DUCKDB_TYPE_STRUCT:
 		for i := 0; i < len(vec.childVectors); i++ {
 			child := C.duckdb_struct_vector_get_child(vector, C.idx_t(i))
 			vec.childVectors[i].duckdbVector = child
 			vec.childVectors[i].getChildVectors(child)
 		}
+
+	case C.DUCKDB_TYPE_MAP:
+		// A MAP is a LIST of STRUCT(key, value) entries.
+		entries := C.duckdb_list_vector_get_child(vector)
+		for i := 0; i < len(vec.childVectors); i++ {
+			child := C.duckdb_struct_vector_get_child(entries, C.idx_t(i))
+			vec.childVectors[i].duckdbVector = child
+			vec.childVectors[i].getChildVectors(child)
+		}
 	}
 }
 
@@ -257,10 +368,17 @@ func (vec *vector) setNull(rowIdx C.idx_t) {
 	mask := C.duckdb_vector_get_validity(vec.duckdbVector)
 	C.duckdb_validity_set_row_invalid(mask, rowIdx)
 
-	if vec.duckdbType == C.DUCKDB_TYPE_STRUCT {
+	switch vec.duckdbType {
+	case C.DUCKDB_TYPE_STRUCT:
 		for i := 0; i < len(vec.childVectors); i++ {
 			vec.childVectors[i].setNull(rowIdx)
 		}
+	case C.DUCKDB_TYPE_ARRAY:
+		// The elements of a NULL array are NULL, too.
+		childVector := vec.childVectors[0]
+		for i := 0; i < vec.arraySize; i++ {
+			childVector.setNull(rowIdx*C.idx_t(vec.arraySize) + C.idx_t(i))
+		}
 	}
 }
 
@@ -334,6 +452,50 @@ func (vec *vector) setList(rowIdx C.idx_t, val any) {
 	}
 }
 
+func (vec *vector) setMap(rowIdx C.idx_t, val any) {
+	if val == nil {
+		vec.setNull(rowIdx)
+		return
+	}
+
+	entries := val.([]mapEntry)
+	childVectorSize := C.duckdb_list_vector_get_size(vec.duckdbVector)
+
+	// A MAP vector is a LIST vector, so its entries follow the current size of the child vector.
+	listEntry := C.duckdb_list_entry{
+		offset: C.idx_t(childVectorSize),
+		length: C.idx_t(len(entries)),
+	}
+	setPrimitive[C.duckdb_list_entry](vec, rowIdx, listEntry)
+
+	newLength := C.idx_t(len(entries)) + childVectorSize
+	C.duckdb_list_vector_set_size(vec.duckdbVector, newLength)
+	C.duckdb_list_vector_reserve(vec.duckdbVector, newLength)
+
+	// Insert the keys and values into the child vectors.
+	keyVector := vec.childVectors[0]
+	valueVector := vec.childVectors[1]
+	for i, e := range entries {
+		offset := C.idx_t(i) + childVectorSize
+		keyVector.fn(&keyVector, offset, e.key)
+		valueVector.fn(&valueVector, offset, e.value)
+	}
+}
+
+func (vec *vector) setArray(rowIdx C.idx_t, val any) {
+	if val == nil {
+		vec.setNull(rowIdx)
+		return
+	}
+
+	// The child vector stores the elements of each row consecutively, so it has a fixed offset for each row.
+	childVector := vec.childVectors[0]
+	offset := rowIdx * C.idx_t(vec.arraySize)
+	for i, e := range val.([]any) {
+		childVector.fn(&childVector, offset+C.idx_t(i), e)
+	}
+}
+
 func (vec *vector) setStruct(rowIdx C.idx_t, val any) {
 	if val == nil {
 		vec.setNull(rowIdx)
@@ -410,6 +572,27 @@ func (vec *vector) initDate() {
 	vec.duckdbType = C.DUCKDB_TYPE_DATE
 }
 
+// initTimeOfDay initializes a TIME or TIME_TZ vector, which stores the wall clock time of a time.Time,
+// and the offset of its location for TIME_TZ.
+func (vec *vector) initTimeOfDay(duckdbType C.duckdb_type) {
+	vec.fn = func(vec *vector, rowIdx C.idx_t, val any) {
+		if val == nil {
+			vec.setNull(rowIdx)
+			return
+		}
+
+		v := val.(time.Time)
+		micros := C.int64_t(timeOfDayMicros(v))
+		if duckdbType == C.DUCKDB_TYPE_TIME {
+			setPrimitive[C.duckdb_time](vec, rowIdx, C.duckdb_time{micros: micros})
+			return
+		}
+		_, offset := v.Zone()
+		setPrimitive[C.duckdb_time_tz](vec, rowIdx, C.duckdb_create_time_tz(micros, C.int32_t(offset)))
+	}
+	vec.duckdbType = duckdbType
+}
+
 func (vec *vector) initList(logicalType C.duckdb_logical_type, colIdx int) error {
 	// Get the child vector type.
 	childType := C.duckdb_list_type_child_type(logicalType)
@@ -429,6 +612,48 @@ func (vec *vector) initList(logicalType C.duckdb_logical_type, colIdx int) error
 	return nil
 }
 
+func (vec *vector) initArray(logicalType C.duckdb_logical_type, colIdx int) error {
+	// Get the child vector type.
+	childType := C.duckdb_array_type_child_type(logicalType)
+	defer C.duckdb_destroy_logical_type(&childType)
+
+	// Recurse into the child.
+	vec.childVectors = make([]vector, 1)
+	err := vec.childVectors[0].init(childType, colIdx)
+	if err != nil {
+		return err
+	}
+
+	vec.fn = func(vec *vector, rowIdx C.idx_t, val any) {
+		vec.setArray(rowIdx, val)
+	}
+	vec.duckdbType = C.DUCKDB_TYPE_ARRAY
+	vec.arraySize = int(C.duckdb_array_type_array_size(logicalType))
+	return nil
+}
+
+func (vec *vector) initMap(logicalType C.duckdb_logical_type, colIdx int) error {
+	keyType := C.duckdb_map_type_key_type(logicalType)
+	defer C.duckdb_destroy_logical_type(&keyType)
+	valueType := C.duckdb_map_type_value_type(logicalType)
+	defer C.duckdb_destroy_logical_type(&valueType)
+
+	// Recurse into the key and value children.
+	vec.childVectors = make([]vector, 2)
+	if err := vec.childVectors[0].init(keyType, colIdx); err != nil {
+		return err
+	}
+	if err := vec.childVectors[1].init(valueType, colIdx); err != nil {
+		return err
+	}
+
+	vec.fn = func(vec *vector, rowIdx C.idx_t, val any) {
+		vec.setMap(rowIdx, val)
+	}
+	vec.duckdbType = C.DUCKDB_TYPE_MAP
+	return nil
+}
+
 func (vec *vector) initStruct(logicalType C.duckdb_logical_type) error {
 	childCount := int(C.duckdb_struct_type_child_count(logicalType))
 	var childNames []string
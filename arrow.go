@@ -46,6 +46,29 @@ struct ArrowArray {
 };
 
 #endif  // ARROW_C_DATA_INTERFACE
+
+#ifndef ARROW_C_STREAM_INTERFACE
+#define ARROW_C_STREAM_INTERFACE
+
+struct ArrowArrayStream {
+  // Callbacks providing stream functionality
+  int (*get_schema)(struct ArrowArrayStream*, struct ArrowSchema* out);
+  int (*get_next)(struct ArrowArrayStream*, struct ArrowArray* out);
+  const char* (*get_last_error)(struct ArrowArrayStream*);
+
+  // Release callback
+  void (*release)(struct ArrowArrayStream*);
+  // Opaque producer-specific data
+  void* private_data;
+};
+
+static void release_arrow_array_stream(struct ArrowArrayStream* stream) {
+  if (stream->release != NULL) {
+    stream->release(stream);
+  }
+}
+
+#endif  // ARROW_C_STREAM_INTERFACE
 */
 import "C"
 
@@ -54,6 +77,7 @@ import (
 	"database/sql/driver"
 	"errors"
 	"fmt"
+	"sync"
 	"unsafe"
 
 	"github.com/apache/arrow/go/v14/arrow"
@@ -75,7 +99,7 @@ func NewArrowFromConn(driverConn driver.Conn) (*Arrow, error) {
 	}
 
 	if dbConn.closed {
-		panic("database/sql/driver: misuse of duckdb driver: Arrow after Close")
+		return nil, getError(errClosedCon, nil)
 	}
 
 	return &Arrow{c: dbConn}, nil
@@ -84,54 +108,97 @@ func NewArrowFromConn(driverConn driver.Conn) (*Arrow, error) {
 // QueryContext prepares statements, executes them, returns Apache Arrow array.RecordReader as a result of the last
 // executed statement. Arguments are bound to the last statement.
 func (a *Arrow) QueryContext(ctx context.Context, query string, args ...any) (array.RecordReader, error) {
+	var sc *arrow.Schema
+	var recs []arrow.Record
+	defer func() {
+		for _, r := range recs {
+			r.Release()
+		}
+	}()
+
+	err := a.queryRecords(ctx, query, args, func(schema *arrow.Schema) error {
+		sc = schema
+		return nil
+	}, func(rec arrow.Record) error {
+		rec.Retain()
+		recs = append(recs, rec)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return array.NewRecordReader(sc, recs)
+}
+
+// queryRecords prepares statements, executes them, and calls onSchema with the schema of the result of the last
+// executed statement, and onRecord with each of its records, which is released after onRecord returns.
+func (a *Arrow) queryRecords(ctx context.Context, query string, args []any, onSchema func(*arrow.Schema) error,
+	onRecord func(arrow.Record) error,
+) error {
 	if a.c.closed {
-		panic("database/sql/driver: misuse of duckdb driver: Arrow.Query after Close")
+		return getError(errClosedCon, nil)
 	}
 
+	if stmt := a.c.stmts.get(query); stmt != nil {
+		return a.queryStmtRecords(ctx, stmt, args, onSchema, onRecord)
+	}
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	stmts, size, err := a.c.extractStmts(query)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer C.duckdb_destroy_extracted(&stmts)
 
 	// execute all statements without args, except the last one
 	for i := C.idx_t(0); i < size-1; i++ {
-		stmt, err := a.c.prepareExtractedStmt(stmts, i)
+		stmt, err := a.c.prepareExtractedStmtContext(ctx, stmts, i)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		// send nil args to execute statement and ignore result (using ExecContext since we're ignoring the result anyway)
 		_, err = stmt.ExecContext(ctx, nil)
 		stmt.Close()
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	// prepare and execute last statement with args and return result
-	stmt, err := a.c.prepareExtractedStmt(stmts, size-1)
+	stmt, err := a.c.prepareExtractedStmtContext(ctx, stmts, size-1)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer stmt.Close()
+	stmt.query = query
+	if size == 1 {
+		a.c.stmts.put(query, stmt)
+	}
+	if !stmt.cached {
+		defer stmt.Close()
+	}
+	return a.queryStmtRecords(ctx, stmt, args, onSchema, onRecord)
+}
 
-	res, err := a.execute(stmt, a.anyArgsToNamedArgs(args))
+// queryStmtRecords executes the statement with the arguments, and calls onSchema with the schema of its result, and
+// onRecord with each of its records.
+func (a *Arrow) queryStmtRecords(ctx context.Context, stmt *stmt, args []any, onSchema func(*arrow.Schema) error,
+	onRecord func(arrow.Record) error,
+) error {
+	res, err := a.execute(ctx, stmt, anyArgsToNamedArgs(args))
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer C.duckdb_destroy_arrow(res)
 
 	sc, err := a.queryArrowSchema(res)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	if err = onSchema(sc); err != nil {
+		return err
 	}
-
-	var recs []arrow.Record
-	defer func() {
-		for _, r := range recs {
-			r.Release()
-		}
-	}()
 
 	rowCount := uint64(C.duckdb_arrow_row_count(*res))
 
@@ -140,21 +207,23 @@ func (a *Arrow) QueryContext(ctx context.Context, query string, args ...any) (ar
 	for retrievedRows < rowCount {
 		select {
 		case <-ctx.Done():
-			return nil, ctx.Err()
+			return ctx.Err()
 		default:
 		}
 
 		rec, err := a.queryArrowArray(res, sc)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		recs = append(recs, rec)
-
 		retrievedRows += uint64(rec.NumRows())
+		err = onRecord(rec)
+		rec.Release()
+		if err != nil {
+			return err
+		}
 	}
-
-	return array.NewRecordReader(sc, recs)
+	return nil
 }
 
 // queryArrowSchema fetches the internal arrow schema from the arrow result.
@@ -206,17 +275,28 @@ func (a *Arrow) queryArrowArray(res *C.duckdb_arrow, sc *arrow.Schema) (arrow.Re
 	return rec, nil
 }
 
-func (a *Arrow) execute(s *stmt, args []driver.NamedValue) (*C.duckdb_arrow, error) {
+func (a *Arrow) execute(ctx context.Context, s *stmt, args []driver.NamedValue) (*C.duckdb_arrow, error) {
 	if s.closed {
-		panic("database/sql/driver: misuse of duckdb driver: executeArrow after Close")
+		return nil, getError(errClosedStmt, nil)
 	}
 
+	a.c.mu.Lock()
+	defer a.c.mu.Unlock()
+
 	if err := s.bind(args); err != nil {
 		return nil, err
 	}
+	s.trackSession()
 
 	var res C.duckdb_arrow
-	if state := C.duckdb_execute_prepared_arrow(*s.stmt, &res); state == C.DuckDBError {
+	stop := a.c.interruptOnDone(ctx)
+	state := C.duckdb_execute_prepared_arrow(*s.stmt, &res)
+	stop()
+	if state == C.DuckDBError {
+		if ctx.Err() != nil {
+			C.duckdb_destroy_arrow(&res)
+			return nil, ctx.Err()
+		}
 		dbErr := C.GoString(C.duckdb_query_arrow_error(res))
 		C.duckdb_destroy_arrow(&res)
 		return nil, fmt.Errorf("duckdb_execute_prepared_arrow: %v", dbErr)
@@ -225,15 +305,76 @@ func (a *Arrow) execute(s *stmt, args []driver.NamedValue) (*C.duckdb_arrow, err
 	return &res, nil
 }
 
-func (a *Arrow) anyArgsToNamedArgs(args []any) []driver.NamedValue {
-	if len(args) == 0 {
-		return nil
+// RegisterView registers the record reader as a view with the given name in the current schema, so that queries
+// of the connection can scan and join it with DuckDB tables, without copying it first. DuckDB reads the records of
+// the reader when a query scans the view, so only the first such query sees them, and scanning the view again
+// fails. The returned release function drops the view and releases the reader, and must be called once the view
+// is no longer needed. Calling it again has no effect.
+func (a *Arrow) RegisterView(reader array.RecordReader, name string) (release func(), err error) {
+	if a.c.closed {
+		return nil, getError(errClosedCon, nil)
 	}
 
-	values := make([]driver.Value, len(args))
-	for i, arg := range args {
-		values[i] = arg
+	stream := C.calloc(1, C.sizeof_struct_ArrowArrayStream)
+	freeStream := func() {
+		C.release_arrow_array_stream((*C.struct_ArrowArrayStream)(stream))
+		C.free(stream)
 	}
+	cdata.ExportRecordReader(&viewReader{RecordReader: reader}, (*cdata.CArrowArrayStream)(stream))
+
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+
+	a.c.mu.Lock()
+	state := C.duckdb_arrow_scan(a.c.duckdbCon, cName, (C.duckdb_arrow_stream)(stream))
+	a.c.mu.Unlock()
+	if state == C.DuckDBError {
+		freeStream()
+		return nil, errors.New("duckdb_arrow_scan")
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			// Queries of the view must not scan the released stream.
+			a.c.mu.Lock()
+			if !a.c.closed {
+				if err := a.c.rawExec("DROP VIEW IF EXISTS " + quoteIdentifier(name)); err != nil {
+					a.c.logError("duckdb could not drop the arrow view", err)
+				}
+			}
+			a.c.mu.Unlock()
+			freeStream()
+		})
+	}, nil
+}
 
-	return argsToNamedArgs(values)
+// errViewScanned is the error of scanning the records of a view of RegisterView again.
+var errViewScanned = errors.New("the records of the view were already scanned")
+
+// viewReader is the record reader of a view of RegisterView. Once a scan of the view reached the end of the
+// records, it fails, instead of returning no records to the next scan.
+type viewReader struct {
+	array.RecordReader
+	done bool
+	err  error
+}
+
+func (r *viewReader) Next() bool {
+	if r.done {
+		r.err = errViewScanned
+		return false
+	}
+	if r.RecordReader.Next() {
+		return true
+	}
+	r.done = true
+	return false
+}
+
+func (r *viewReader) Err() error {
+	if r.err != nil {
+		return r.err
+	}
+	return r.RecordReader.Err()
 }
@@ -3,8 +3,13 @@ package duckdb
 import (
 	"context"
 	"database/sql/driver"
+	"io"
 	"testing"
+	"time"
 
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/memory"
 	"github.com/stretchr/testify/require"
 )
 
@@ -112,4 +117,83 @@ func TestArrow(t *testing.T) {
 		})
 		require.NoError(t, err)
 	})
+	t.Run("query timeout", func(t *testing.T) {
+		c, err := NewConnector("", nil)
+		require.NoError(t, err)
+		defer c.Close()
+
+		conn, err := c.Connect(context.Background())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		ar, err := NewArrowFromConn(conn)
+		require.NoError(t, err)
+
+		ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+		defer cancel()
+
+		// The query takes much longer than 10 seconds.
+		now := time.Now()
+		_, err = ar.QueryContext(ctx, "SELECT count(*) FROM range(10000000) t1, range(1000000) t2")
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.Less(t, time.Since(now), 10*time.Second)
+	})
+	t.Run("register view", func(t *testing.T) {
+		c, err := NewConnector("", nil)
+		require.NoError(t, err)
+		defer c.Close()
+
+		conn, err := c.Connect(context.Background())
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, err = conn.(driver.ExecerContext).ExecContext(context.Background(),
+			`CREATE TABLE names AS SELECT * FROM (VALUES (1, 'one'), (2, 'two'), (3, 'three')) t(id, name)`, nil)
+		require.NoError(t, err)
+
+		schema := arrow.NewSchema([]arrow.Field{
+			{Name: "id", Type: arrow.PrimitiveTypes.Int32},
+			{Name: "score", Type: arrow.PrimitiveTypes.Float64},
+		}, nil)
+		b := array.NewRecordBuilder(memory.DefaultAllocator, schema)
+		defer b.Release()
+		b.Field(0).(*array.Int32Builder).AppendValues([]int32{1, 3, 4}, nil)
+		b.Field(1).(*array.Float64Builder).AppendValues([]float64{0.5, 1.5, 2.5}, nil)
+		rec := b.NewRecord()
+		defer rec.Release()
+
+		reader, err := array.NewRecordReader(schema, []arrow.Record{rec})
+		require.NoError(t, err)
+		defer reader.Release()
+
+		ar, err := NewArrowFromConn(conn)
+		require.NoError(t, err)
+		release, err := ar.RegisterView(reader, "scores")
+		require.NoError(t, err)
+		defer release()
+
+		rows, err := conn.(driver.QueryerContext).QueryContext(context.Background(),
+			`SELECT name, score FROM scores JOIN names USING (id) ORDER BY id`, nil)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		values := make([]driver.Value, 2)
+		require.NoError(t, rows.Next(values))
+		require.Equal(t, []driver.Value{"one", 0.5}, values)
+		require.NoError(t, rows.Next(values))
+		require.Equal(t, []driver.Value{"three", 1.5}, values)
+		require.ErrorIs(t, rows.Next(values), io.EOF)
+		require.NoError(t, rows.Close())
+
+		// The reader has no records left for a second scan.
+		_, err = conn.(driver.QueryerContext).QueryContext(context.Background(), `SELECT count(*) FROM scores`, nil)
+		require.ErrorContains(t, err, errViewScanned.Error())
+
+		// Releasing the reader drops the view, so that queries cannot scan the released reader.
+		release()
+		_, err = conn.(driver.QueryerContext).QueryContext(context.Background(), `SELECT * FROM scores`, nil)
+		require.ErrorContains(t, err, "Table with name scores does not exist")
+
+		// The deferred call of release has no effect.
+	})
 }
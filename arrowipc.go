@@ -0,0 +1,42 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/ipc"
+)
+
+// WriteArrowIPC writes the result of the query to the writer as an Apache Arrow IPC stream, i.e., the format of the
+// application/vnd.apache.arrow.stream media type, and returns the number of rows. The arguments are bound to the
+// parameters of the query. Each record is written as soon as DuckDB produces it, so the result is not buffered in
+// memory. A query failing after writing some records leaves the records written so far in the writer, without the
+// end-of-stream marker.
+func WriteArrowIPC(ctx context.Context, driverConn driver.Conn, query string, w io.Writer, args ...any) (int64, error) {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return 0, err
+	}
+	a := &Arrow{c: con}
+
+	var ipcWriter *ipc.Writer
+	var rows int64
+	err = a.queryRecords(ctx, query, args, func(sc *arrow.Schema) error {
+		ipcWriter = ipc.NewWriter(w, ipc.WithSchema(sc))
+		return nil
+	}, func(rec arrow.Record) error {
+		rows += rec.NumRows()
+		return ipcWriter.Write(rec)
+	})
+	if err != nil {
+		return 0, getError(errWriteArrowIPC, err)
+	}
+
+	// Close writes the schema of an empty result, and the end-of-stream marker.
+	if err = ipcWriter.Close(); err != nil {
+		return 0, getError(errWriteArrowIPC, err)
+	}
+	return rows, nil
+}
@@ -0,0 +1,83 @@
+package duckdb
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/ipc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWriteArrowIPC(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	con, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	writeArrowIPC := func(query string, w io.Writer, args ...any) (int64, error) {
+		var rows int64
+		err := con.Raw(func(driverConn any) error {
+			var err error
+			rows, err = WriteArrowIPC(context.Background(), driverConn.(driver.Conn), query, w, args...)
+			return err
+		})
+		return rows, err
+	}
+
+	t.Run("stream", func(t *testing.T) {
+		var buf bytes.Buffer
+		rows, err := writeArrowIPC(`SELECT range AS i, range::VARCHAR AS s FROM range(?)`, &buf, 10000)
+		require.NoError(t, err)
+		require.Equal(t, int64(10000), rows)
+
+		r, err := ipc.NewReader(&buf)
+		require.NoError(t, err)
+		defer r.Release()
+		require.Equal(t, "i", r.Schema().Field(0).Name)
+		require.Equal(t, "s", r.Schema().Field(1).Name)
+
+		var n int64
+		for r.Next() {
+			rec := r.Record()
+			require.Equal(t, n, rec.Column(0).(*array.Int64).Value(0))
+			n += rec.NumRows()
+		}
+		require.NoError(t, r.Err())
+		require.Equal(t, rows, n)
+	})
+
+	t.Run("empty result", func(t *testing.T) {
+		var buf bytes.Buffer
+		rows, err := writeArrowIPC(`SELECT 42 AS answer WHERE false`, &buf)
+		require.NoError(t, err)
+		require.Zero(t, rows)
+
+		r, err := ipc.NewReader(&buf)
+		require.NoError(t, err)
+		defer r.Release()
+		require.Equal(t, "answer", r.Schema().Field(0).Name)
+		require.False(t, r.Next())
+	})
+
+	t.Run("writer error", func(t *testing.T) {
+		_, err := writeArrowIPC(`SELECT range AS i FROM range(1000000)`, &failingWriter{n: 1000})
+		testError(t, err, errWriteArrowIPC.Error(), "client disconnected")
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := writeArrowIPC(`SELECT * FROM missing`, &buf)
+		testError(t, err, errWriteArrowIPC.Error(), "missing")
+		require.Zero(t, buf.Len())
+
+		_, err = WriteArrowIPC(context.Background(), nil, `SELECT 1`, &buf)
+		testError(t, err, errInvalidCon.Error())
+	})
+}
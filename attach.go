@@ -0,0 +1,111 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+)
+
+// AttachOptions are the options of Attach.
+type AttachOptions struct {
+	// Alias is the name of the attached database. It defaults to the base name of the path, without its extension.
+	Alias string
+	// ReadOnly attaches the database in read-only mode.
+	ReadOnly bool
+	// Type is the type of the database, e.g., SQLITE or POSTGRES, if it is not a DuckDB file.
+	// The extension providing the type, e.g., sqlite or postgres, must be installed.
+	Type string
+}
+
+// AttachedDatabase is a database attached to the database of a connection, see ListAttached.
+type AttachedDatabase struct {
+	// Name is the name, i.e., the catalog, of the database.
+	Name string
+	// Path is the path of the database, or empty for an in-memory database.
+	Path string
+	// Type is the type of the database, e.g., duckdb or sqlite.
+	Type string
+	// ReadOnly is true, if the database is attached in read-only mode.
+	ReadOnly bool
+}
+
+// Attach attaches the database at the path, e.g., another DuckDB file, :memory:, the path of a SQLite file,
+// or the connection string of a Postgres database, to the database of the connection.
+// All connections of the database can query the attached database, e.g., SELECT * FROM alias.my_table.
+// Like credentials of CreateSecret, the path is neither part of ActiveQueries nor of error details.
+func Attach(ctx context.Context, driverConn driver.Conn, path string, opts AttachOptions) error {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return getError(errAttach, errors.New("empty path"))
+	}
+
+	query := "ATTACH " + quoteString(path)
+	if opts.Alias != "" {
+		query += " AS " + quoteIdentifier(opts.Alias)
+	}
+	var options []string
+	if opts.Type != "" {
+		options = append(options, "TYPE "+quoteIdentifier(opts.Type))
+	}
+	if opts.ReadOnly {
+		options = append(options, "READ_ONLY")
+	}
+	if len(options) != 0 {
+		query += " (" + strings.Join(options, ", ") + ")"
+	}
+
+	if err = con.execSensitive(ctx, query); err != nil {
+		return getError(errAttach, err)
+	}
+	return nil
+}
+
+// Detach detaches the attached database from the database of the connection.
+func Detach(ctx context.Context, driverConn driver.Conn, name string) error {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return err
+	}
+	if _, err = con.ExecContext(ctx, "DETACH "+quoteIdentifier(name), nil); err != nil {
+		return getError(errAttach, err)
+	}
+	return nil
+}
+
+// ListAttached returns the databases of the connection, ordered by their name.
+// It includes the database of the connection, but not DuckDB's internal system and temp databases.
+func ListAttached(ctx context.Context, driverConn driver.Conn) ([]AttachedDatabase, error) {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return nil, err
+	}
+
+	driverRows, err := con.QueryContext(ctx, `SELECT database_name, path, type, readonly
+		FROM duckdb_databases() WHERE NOT internal ORDER BY database_name`, nil)
+	if err != nil {
+		return nil, getError(errAttach, err)
+	}
+	defer driverRows.Close()
+
+	var databases []AttachedDatabase
+	values := make([]driver.Value, 4)
+	for {
+		if err = driverRows.Next(values); err == io.EOF {
+			return databases, nil
+		} else if err != nil {
+			return nil, getError(errAttach, err)
+		}
+
+		database := AttachedDatabase{}
+		database.Name, _ = values[0].(string)
+		database.Path, _ = values[1].(string)
+		database.Type, _ = values[2].(string)
+		database.ReadOnly, _ = values[3].(bool)
+		databases = append(databases, database)
+	}
+}
@@ -0,0 +1,89 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAttach(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	con, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	ctx := context.Background()
+	raw := func(fn func(driverConn driver.Conn) error) error {
+		return con.Raw(func(driverConn any) error {
+			return fn(driverConn.(driver.Conn))
+		})
+	}
+	listAttached := func() []AttachedDatabase {
+		var databases []AttachedDatabase
+		require.NoError(t, raw(func(driverConn driver.Conn) error {
+			databases, err = ListAttached(ctx, driverConn)
+			return err
+		}))
+		return databases
+	}
+
+	path := filepath.Join(t.TempDir(), "other.db")
+	require.NoError(t, raw(func(driverConn driver.Conn) error {
+		return Attach(ctx, driverConn, path, AttachOptions{})
+	}))
+	_, err = db.Exec(`CREATE TABLE other.t AS SELECT 42 AS i`)
+	require.NoError(t, err)
+	require.NoError(t, raw(func(driverConn driver.Conn) error {
+		return Detach(ctx, driverConn, "other")
+	}))
+
+	require.NoError(t, raw(func(driverConn driver.Conn) error {
+		return Attach(ctx, driverConn, path, AttachOptions{Alias: "read only", ReadOnly: true})
+	}))
+	require.NoError(t, raw(func(driverConn driver.Conn) error {
+		return Attach(ctx, driverConn, ":memory:", AttachOptions{Alias: "scratch", Type: "duckdb"})
+	}))
+
+	var i int
+	require.NoError(t, db.QueryRow(`SELECT i FROM "read only".t`).Scan(&i))
+	require.Equal(t, 42, i)
+	_, err = db.Exec(`INSERT INTO "read only".t VALUES (1)`)
+	require.ErrorIs(t, err, ErrReadOnly)
+
+	require.Equal(t, []AttachedDatabase{
+		{Name: "memory", Type: "duckdb"},
+		{Name: "read only", Path: path, Type: "duckdb", ReadOnly: true},
+		{Name: "scratch", Type: "duckdb"},
+	}, listAttached())
+
+	require.NoError(t, raw(func(driverConn driver.Conn) error {
+		return Detach(ctx, driverConn, "read only")
+	}))
+	require.Len(t, listAttached(), 2)
+
+	err = raw(func(driverConn driver.Conn) error {
+		return Attach(ctx, driverConn, path, AttachOptions{Alias: "scratch"})
+	})
+	testError(t, err, errAttach.Error(), "scratch")
+	err = raw(func(driverConn driver.Conn) error {
+		return Attach(ctx, driverConn, "", AttachOptions{})
+	})
+	testError(t, err, errAttach.Error(), "empty path")
+	err = raw(func(driverConn driver.Conn) error {
+		return Detach(ctx, driverConn, "missing")
+	})
+	testError(t, err, errAttach.Error(), "missing")
+
+	err = Attach(ctx, nil, path, AttachOptions{})
+	testError(t, err, errInvalidCon.Error())
+	err = Detach(ctx, nil, "other")
+	testError(t, err, errInvalidCon.Error())
+	_, err = ListAttached(ctx, nil)
+	testError(t, err, errInvalidCon.Error())
+}
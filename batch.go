@@ -0,0 +1,81 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+)
+
+// ExecBatch prepares the query once, executes it for each set of arguments, and returns the total number
+// of affected rows. Unlike executing the query through database/sql, it binds the arguments of each execution
+// directly to the prepared statement, which reduces the overhead of medium-sized inserts, for which an Appender
+// is overkill. Outside of a transaction, ExecBatch executes the query within a transaction, so that it either
+// executes all sets of arguments, or none. The error of a failing execution reports the index of its arguments.
+func ExecBatch(ctx context.Context, driverConn driver.Conn, query string, args [][]any) (int64, error) {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return 0, err
+	}
+	if len(args) == 0 {
+		return 0, nil
+	}
+
+	s, err := con.prepareStmtContext(ctx, query)
+	if err != nil {
+		return 0, getError(errExecBatch, err)
+	}
+	defer s.Close()
+
+	var tx driver.Tx
+	if !con.tx {
+		if tx, err = con.BeginTx(ctx, driver.TxOptions{}); err != nil {
+			return 0, getError(errExecBatch, err)
+		}
+	}
+	fail := func(err error) (int64, error) {
+		if tx != nil {
+			_ = tx.Rollback()
+		}
+		return 0, getError(errExecBatch, err)
+	}
+
+	var total int64
+	for i, values := range args {
+		nargs, err := con.batchArgs(values)
+		if err != nil {
+			return fail(fmt.Errorf("arguments %d: %w", i, err))
+		}
+		res, err := s.ExecContext(ctx, nargs)
+		if err != nil {
+			return fail(fmt.Errorf("arguments %d: %w", i, err))
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return fail(err)
+		}
+		total += affected
+	}
+
+	if tx != nil {
+		if err = tx.Commit(); err != nil {
+			return 0, getError(errExecBatch, err)
+		}
+	}
+	return total, nil
+}
+
+// batchArgs converts the arguments of an execution of ExecBatch, or of Stmt.Bind, like database/sql converts
+// the arguments of a query.
+func (c *conn) batchArgs(values []any) ([]driver.NamedValue, error) {
+	nargs := anyArgsToNamedArgs(values)
+	for i := range nargs {
+		err := c.CheckNamedValue(&nargs[i])
+		if err == driver.ErrSkip {
+			nargs[i].Value, err = driver.DefaultParameterConverter.ConvertValue(nargs[i].Value)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nargs, nil
+}
@@ -0,0 +1,76 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecBatch(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE batch (id INTEGER PRIMARY KEY, name VARCHAR, price DOUBLE)`)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	con, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer con.Close()
+
+	type name string
+	execBatch := func(query string, args [][]any) (affected int64, err error) {
+		require.NoError(t, con.Raw(func(driverConn any) error {
+			affected, err = ExecBatch(ctx, driverConn.(driver.Conn), query, args)
+			return nil
+		}))
+		return affected, err
+	}
+
+	t.Run("insert", func(t *testing.T) {
+		const n = 1000
+		args := make([][]any, n)
+		for i := range args {
+			args[i] = []any{i, name("item"), float64(i) / 2}
+		}
+		affected, err := execBatch(`INSERT INTO batch VALUES (?, ?, ?)`, args)
+		require.NoError(t, err)
+		require.Equal(t, int64(n), affected)
+
+		var count int
+		var sum float64
+		require.NoError(t, db.QueryRow(`SELECT count(*), sum(price) FROM batch WHERE name = 'item'`).Scan(&count, &sum))
+		require.Equal(t, n, count)
+		require.Equal(t, float64(n*(n-1))/4, sum)
+	})
+
+	t.Run("update", func(t *testing.T) {
+		affected, err := execBatch(`UPDATE batch SET name = ? WHERE id < ?`, [][]any{{"a", 10}, {"b", 5}})
+		require.NoError(t, err)
+		require.Equal(t, int64(15), affected)
+	})
+
+	t.Run("failing execution", func(t *testing.T) {
+		_, err := execBatch(`INSERT INTO batch VALUES (?, ?, ?)`, [][]any{{-1, "new", 1.0}, {0, "duplicate", 1.0}})
+		require.ErrorContains(t, err, "arguments 1")
+		require.ErrorContains(t, err, "Constraint Error")
+
+		// The executions before the failing execution were rolled back.
+		var count int
+		require.NoError(t, db.QueryRow(`SELECT count(*) FROM batch WHERE id = -1`).Scan(&count))
+		require.Zero(t, count)
+	})
+
+	t.Run("argument count", func(t *testing.T) {
+		_, err := execBatch(`INSERT INTO batch VALUES (?, ?, ?)`, [][]any{{-1, "new"}})
+		require.ErrorContains(t, err, "arguments 0: incorrect argument count")
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		_, err := execBatch(`INSERT INTO missing VALUES (?)`, [][]any{{1}})
+		require.ErrorContains(t, err, "Catalog Error")
+	})
+}
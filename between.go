@@ -0,0 +1,51 @@
+package duckdb
+
+import (
+	"fmt"
+	"time"
+)
+
+// TimeBounds defines whether the bounds of a time range are part of the range.
+type TimeBounds int
+
+const (
+	// InclusiveBounds includes both bounds: start <= column <= end. This is equivalent to BETWEEN.
+	InclusiveBounds TimeBounds = iota
+	// ExclusiveEndBounds includes the start, but not the end: start <= column < end.
+	// Consecutive ranges with these bounds do not overlap, e.g., when filtering by day.
+	ExclusiveEndBounds
+	// ExclusiveStartBounds includes the end, but not the start: start < column <= end.
+	ExclusiveStartBounds
+	// ExclusiveBounds excludes both bounds: start < column < end.
+	ExclusiveBounds
+)
+
+// BetweenTime returns a condition selecting the rows whose column value is within the time range,
+// and the arguments of its placeholders. The column name is quoted, and it may be qualified, e.g., "tbl.ts".
+// It returns an error, if start is after end.
+//
+// The bounds bind to the type of the column. For a TIMESTAMPTZ column, they bind as instants.
+// For a TIMESTAMP column, they bind as wall clock times, see WithTimestampLocation.
+func BetweenTime(column string, start time.Time, end time.Time, bounds TimeBounds) (string, []any, error) {
+	if start.After(end) {
+		return "", nil, getError(errTimeRange, fmt.Errorf("start %s is after end %s", start, end))
+	}
+
+	quoted := quoteQualifiedIdentifier(column)
+	startOp, endOp := ">=", "<="
+	switch bounds {
+	case InclusiveBounds:
+		return quoted + " BETWEEN ? AND ?", []any{start, end}, nil
+	case ExclusiveEndBounds:
+		endOp = "<"
+	case ExclusiveStartBounds:
+		startOp = ">"
+	case ExclusiveBounds:
+		startOp, endOp = ">", "<"
+	default:
+		return "", nil, getError(errTimeRange, fmt.Errorf("invalid bounds %d", bounds))
+	}
+
+	cond := fmt.Sprintf("%s %s ? AND %s %s ?", quoted, startOp, quoted, endOp)
+	return cond, []any{start, end}, nil
+}
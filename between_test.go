@@ -0,0 +1,75 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBetweenTime(t *testing.T) {
+	t.Parallel()
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	c, err := NewConnector("", nil, WithTimestampLocation(berlin))
+	require.NoError(t, err)
+	db := sql.OpenDB(c)
+	defer db.Close()
+
+	// The TIMESTAMP column stores Berlin wall clock times, and the TIMESTAMPTZ column stores the same instants.
+	_, err = db.Exec(`CREATE TABLE events AS SELECT
+		TIMESTAMP '2024-01-01 00:00:00' + INTERVAL (i) HOUR AS ts,
+		(TIMESTAMP '2023-12-31 23:00:00' + INTERVAL (i) HOUR)::TIMESTAMPTZ AS tz
+		FROM range(48) t(i)`)
+	require.NoError(t, err)
+
+	start := time.Date(2024, 1, 1, 10, 0, 0, 0, berlin)
+	end := time.Date(2024, 1, 1, 14, 0, 0, 0, berlin)
+
+	tests := []struct {
+		bounds TimeBounds
+		count  int
+	}{
+		{InclusiveBounds, 5},
+		{ExclusiveEndBounds, 4},
+		{ExclusiveStartBounds, 4},
+		{ExclusiveBounds, 3},
+	}
+	for _, column := range []string{"ts", "events.tz"} {
+		for _, test := range tests {
+			cond, args, err := BetweenTime(column, start, end.UTC(), test.bounds)
+			require.NoError(t, err)
+
+			var count int
+			var minTime time.Time
+			row := db.QueryRowContext(context.Background(), `SELECT count(*), min(tz) FROM events WHERE `+cond, args...)
+			require.NoError(t, row.Scan(&count, &minTime))
+			require.Equal(t, test.count, count, "%s %d", column, test.bounds)
+
+			expectedMin := start
+			if test.bounds == ExclusiveStartBounds || test.bounds == ExclusiveBounds {
+				expectedMin = start.Add(time.Hour)
+			}
+			require.True(t, expectedMin.Equal(minTime), "%s %d: %s", column, test.bounds, minTime)
+		}
+	}
+
+	t.Run("SQL", func(t *testing.T) {
+		cond, args, err := BetweenTime(`s.tbl.My"Col`, start, start, ExclusiveEndBounds)
+		require.NoError(t, err)
+		require.Equal(t, `"s"."tbl"."My""Col" >= ? AND "s"."tbl"."My""Col" < ?`, cond)
+		require.Equal(t, []any{start, start}, args)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, _, err := BetweenTime("ts", end, start, InclusiveBounds)
+		testError(t, err, errTimeRange.Error(), "after")
+
+		_, _, err = BetweenTime("ts", start, end, TimeBounds(42))
+		testError(t, err, errTimeRange.Error())
+	})
+}
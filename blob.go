@@ -0,0 +1,133 @@
+package duckdb
+
+/*
+#include <stdlib.h>
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"unsafe"
+)
+
+// blobReadSize is the initial size of the buffer of a BLOB parameter read from an io.Reader without a known length.
+const blobReadSize = 32 << 10
+
+// BlobReader scans a BLOB value without copying it, and reads it incrementally, e.g., to write a large value
+// to a file or an HTTP response with io.Copy. Unlike scanning into a []byte, which copies the value, the reader
+// points into the result of the query. Like a sql.RawBytes, it is only valid until the next call of Next, Scan,
+// or Close of the rows, so that it cannot scan the row of a QueryRow, which closes its rows. It also scans the bytes of VARCHAR values, and a NULL value as an empty, invalid reader.
+type BlobReader struct {
+	// Valid is false, if the value is NULL.
+	Valid bool
+	r     bytes.Reader
+}
+
+func (b *BlobReader) Scan(v any) error {
+	switch value := v.(type) {
+	case []byte:
+		b.r.Reset(value)
+	case string:
+		b.r.Reset(unsafe.Slice(unsafe.StringData(value), len(value)))
+	case nil:
+		b.r.Reset(nil)
+		b.Valid = false
+		return nil
+	default:
+		return castError(fmt.Sprintf("%T", v), "BlobReader")
+	}
+	b.Valid = true
+	return nil
+}
+
+// Read implements io.Reader.
+func (b *BlobReader) Read(p []byte) (int, error) {
+	return b.r.Read(p)
+}
+
+// WriteTo implements io.WriterTo, so that io.Copy writes the value without an intermediate buffer.
+func (b *BlobReader) WriteTo(w io.Writer) (int64, error) {
+	return b.r.WriteTo(w)
+}
+
+// Len returns the number of unread bytes of the value.
+func (b *BlobReader) Len() int {
+	return b.r.Len()
+}
+
+// Size returns the length of the value in bytes.
+func (b *BlobReader) Size() int64 {
+	return b.r.Size()
+}
+
+// BlobBuffer scans a BLOB value into the caller-provided Buf, whose capacity it reuses, so that scanning the values
+// of many rows allocates only for values exceeding the capacity of Buf. It also scans the bytes of VARCHAR values.
+type BlobBuffer struct {
+	// Buf holds the value after scanning it. Its capacity is reused by the next scan.
+	Buf []byte
+	// Valid is false, if the value is NULL.
+	Valid bool
+}
+
+func (b *BlobBuffer) Scan(v any) error {
+	switch value := v.(type) {
+	case []byte:
+		b.Buf = append(b.Buf[:0], value...)
+	case string:
+		b.Buf = append(b.Buf[:0], value...)
+	case nil:
+		b.Buf = b.Buf[:0]
+		b.Valid = false
+		return nil
+	default:
+		return castError(fmt.Sprintf("%T", v), "BlobBuffer")
+	}
+	b.Valid = true
+	return nil
+}
+
+// bindBlobReader binds the bytes of the reader as a BLOB parameter. It reads them into C memory,
+// which DuckDB copies into the parameter, instead of reading them into Go memory first.
+func (s *stmt) bindBlobReader(i int, r io.Reader) error {
+	size := blobReadSize
+	if l, ok := r.(interface{ Len() int }); ok {
+		// Read one more byte to detect the end of the reader without growing the buffer.
+		size = l.Len() + 1
+	}
+	buf := C.malloc(C.size_t(size))
+	if buf == nil {
+		return fmt.Errorf("%w: could not allocate %d bytes for BLOB parameter", errCouldNotBind, size)
+	}
+	defer func() {
+		C.free(buf)
+	}()
+
+	n := 0
+	for {
+		if n == size {
+			grown := C.realloc(buf, C.size_t(2*size))
+			if grown == nil {
+				// The deferred free releases the original buffer.
+				return fmt.Errorf("%w: could not allocate %d bytes for BLOB parameter", errCouldNotBind, 2*size)
+			}
+			buf = grown
+			size *= 2
+		}
+		m, err := r.Read(unsafe.Slice((*byte)(buf), size)[n:])
+		n += m
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return fmt.Errorf("%w: reading BLOB parameter: %s", errCouldNotBind, err.Error())
+		}
+	}
+
+	if rv := C.duckdb_bind_blob(*s.stmt, C.idx_t(i+1), buf, C.uint64_t(n)); rv == C.DuckDBError {
+		return errCouldNotBind
+	}
+	return nil
+}
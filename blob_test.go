@@ -0,0 +1,113 @@
+package duckdb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlobStreaming(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE blobs (id INTEGER, data BLOB)`)
+	require.NoError(t, err)
+
+	large := bytes.Repeat([]byte("0123456789abcdef"), 1<<16)
+	t.Run("bind reader", func(t *testing.T) {
+		// A reader with a length, and a reader without one, which grows the buffer.
+		_, err := db.Exec(`INSERT INTO blobs VALUES (1, ?), (2, ?), (3, ?)`, bytes.NewReader(large),
+			io.LimitReader(bytes.NewReader(large), int64(len(large))), strings.NewReader(""))
+		require.NoError(t, err)
+
+		var n int
+		require.NoError(t, db.QueryRow(`SELECT octet_length(data) FROM blobs WHERE id = 1`).Scan(&n))
+		require.Equal(t, len(large), n)
+		require.NoError(t, db.QueryRow(`SELECT count(*) FROM blobs WHERE data = ?`, large).Scan(&n))
+		require.Equal(t, 2, n)
+		require.NoError(t, db.QueryRow(`SELECT octet_length(data) FROM blobs WHERE id = 3`).Scan(&n))
+		require.Zero(t, n)
+
+		_, err = db.Exec(`INSERT INTO blobs VALUES (4, ?)`, iotest.ErrReader(errors.New("disk failure")))
+		require.ErrorIs(t, err, errCouldNotBind)
+		require.ErrorContains(t, err, "disk failure")
+	})
+
+	t.Run("bind bytes", func(t *testing.T) {
+		var empty, equal bool
+		require.NoError(t, db.QueryRow(`SELECT ? = ''::BLOB, ? = '\x01\x02'::BLOB`, []byte{}, []byte{1, 2}).Scan(&empty, &equal))
+		require.True(t, empty)
+		require.True(t, equal)
+	})
+
+	t.Run("blob reader", func(t *testing.T) {
+		rows, err := db.QueryContext(context.Background(),
+			`SELECT data FROM blobs WHERE id = 1 UNION ALL SELECT NULL UNION ALL SELECT 'text'`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var r BlobReader
+		require.True(t, rows.Next())
+		require.NoError(t, rows.Scan(&r))
+		require.True(t, r.Valid)
+		require.Equal(t, int64(len(large)), r.Size())
+
+		var buf bytes.Buffer
+		n, err := io.Copy(&buf, &r)
+		require.NoError(t, err)
+		require.Equal(t, int64(len(large)), n)
+		require.Equal(t, large, buf.Bytes())
+		require.Zero(t, r.Len())
+
+		require.True(t, rows.Next())
+		require.NoError(t, rows.Scan(&r))
+		require.False(t, r.Valid)
+		require.Zero(t, r.Size())
+
+		require.True(t, rows.Next())
+		require.NoError(t, rows.Scan(&r))
+		require.True(t, r.Valid)
+		data, err := io.ReadAll(&r)
+		require.NoError(t, err)
+		require.Equal(t, []byte("text"), data)
+		require.False(t, rows.Next())
+		require.NoError(t, rows.Err())
+
+		err = db.QueryRow(`SELECT 42`).Scan(&r)
+		require.ErrorContains(t, err, castErrMsg)
+	})
+
+	t.Run("blob buffer", func(t *testing.T) {
+		rows, err := db.QueryContext(context.Background(),
+			`SELECT data FROM blobs WHERE id IN (1, 3) UNION ALL SELECT NULL ORDER BY 1 DESC NULLS LAST`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		b := BlobBuffer{Buf: make([]byte, 0, len(large))}
+		require.True(t, rows.Next())
+		require.NoError(t, rows.Scan(&b))
+		require.True(t, b.Valid)
+		require.Equal(t, large, b.Buf)
+		first := &b.Buf[0]
+
+		require.True(t, rows.Next())
+		require.NoError(t, rows.Scan(&b))
+		require.True(t, b.Valid)
+		require.Empty(t, b.Buf)
+
+		require.True(t, rows.Next())
+		require.NoError(t, rows.Scan(&b))
+		require.False(t, b.Valid)
+
+		// The buffer is reused.
+		require.Same(t, first, &b.Buf[:1][0])
+		require.False(t, rows.Next())
+	})
+}
@@ -0,0 +1,136 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Execer executes statements. It is implemented by *sql.DB, *sql.Conn, and *sql.Tx.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// defaultChunkParams is the default maximum number of parameters of a single statement.
+const defaultChunkParams = 1000
+
+// BulkOptions configures the bulk helpers InsertValues and ExecIn.
+type BulkOptions struct {
+	// ChunkParams is the maximum number of parameters of a single statement. Larger inputs are split into
+	// several statements. It defaults to 1000. The cost of preparing a statement grows faster than
+	// its number of parameters, so larger chunks perform worse.
+	ChunkParams int
+}
+
+func (o BulkOptions) chunkParams() int {
+	if o.ChunkParams <= 0 {
+		return defaultChunkParams
+	}
+	return o.ChunkParams
+}
+
+// InsertValues inserts the rows into the columns of a table with INSERT INTO ... VALUES statements,
+// and returns the total number of inserted rows. The table name may be schema-qualified.
+// The rows are split into chunks of at most ChunkParams parameters. If db can begin a transaction,
+// i.e., if it is a *sql.DB or a *sql.Conn, then all chunks are inserted within a single transaction.
+// For large inputs, an Appender is faster.
+func InsertValues(ctx context.Context, db Execer, table string, columns []string, rows [][]any,
+	opts BulkOptions,
+) (int64, error) {
+	if len(columns) == 0 {
+		return 0, getError(errBulk, fmt.Errorf("no columns to insert into %s", table))
+	}
+	for i, row := range rows {
+		if len(row) != len(columns) {
+			return 0, getError(errBulk, fmt.Errorf("row %d: %w", i, columnCountError(len(row), len(columns))))
+		}
+	}
+
+	quoted := make([]string, len(columns))
+	for i, column := range columns {
+		quoted[i] = quoteIdentifier(column)
+	}
+	prefix := fmt.Sprintf("INSERT INTO %s (%s) VALUES ", quoteQualifiedIdentifier(table), strings.Join(quoted, ", "))
+	tuple := "(" + placeholders(len(columns)) + ")"
+
+	rowsPerChunk := max(opts.chunkParams()/len(columns), 1)
+	return execChunks(ctx, db, len(rows), rowsPerChunk, func(start, end int) (string, []any) {
+		tuples := make([]string, end-start)
+		args := make([]any, 0, (end-start)*len(columns))
+		for i, row := range rows[start:end] {
+			tuples[i] = tuple
+			args = append(args, row...)
+		}
+		return prefix + strings.Join(tuples, ", "), args
+	})
+}
+
+// InListMarker marks the position of the IN-list in the query of ExecIn.
+const InListMarker = "(?...)"
+
+// ExecIn executes a query containing an IN-list for all values, and returns the total number of affected rows.
+// The query contains InListMarker once, e.g., "DELETE FROM tbl WHERE id IN (?...)", and no other placeholders.
+// The values are split into chunks of at most ChunkParams parameters, and the query executes once for each chunk.
+// If db can begin a transaction, i.e., if it is a *sql.DB or a *sql.Conn, then all chunks execute within
+// a single transaction.
+func ExecIn(ctx context.Context, db Execer, query string, values []any, opts BulkOptions) (int64, error) {
+	if strings.Count(query, InListMarker) != 1 {
+		return 0, getError(errBulk, fmt.Errorf("the query must contain %s exactly once", InListMarker))
+	}
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	prefix, suffix, _ := strings.Cut(query, InListMarker)
+	return execChunks(ctx, db, len(values), opts.chunkParams(), func(start, end int) (string, []any) {
+		return prefix + "(" + placeholders(end-start) + ")" + suffix, values[start:end]
+	})
+}
+
+// execChunks executes the statement of each chunk of at most chunkSize items, and sums their affected rows.
+func execChunks(ctx context.Context, db Execer, count int, chunkSize int,
+	chunk func(start, end int) (string, []any),
+) (int64, error) {
+	if count == 0 {
+		return 0, nil
+	}
+
+	var tx *sql.Tx
+	if beginner, ok := db.(interface {
+		BeginTx(ctx context.Context, opts *sql.TxOptions) (*sql.Tx, error)
+	}); ok {
+		var err error
+		if tx, err = beginner.BeginTx(ctx, nil); err != nil {
+			return 0, err
+		}
+		defer tx.Rollback()
+		db = tx
+	}
+
+	var total int64
+	for start := 0; start < count; start += chunkSize {
+		query, args := chunk(start, min(start+chunkSize, count))
+		res, err := db.ExecContext(ctx, query, args...)
+		if err != nil {
+			return 0, err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return 0, err
+		}
+		total += affected
+	}
+
+	if tx != nil {
+		if err := tx.Commit(); err != nil {
+			return 0, err
+		}
+	}
+	return total, nil
+}
+
+// placeholders returns n comma-separated ? placeholders.
+func placeholders(n int) string {
+	return strings.TrimSuffix(strings.Repeat("?, ", n), ", ")
+}
@@ -0,0 +1,104 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestInsertValues(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE SCHEMA s; CREATE TABLE s."Items" (id INTEGER, "Name" VARCHAR, price DOUBLE)`)
+	require.NoError(t, err)
+
+	t.Run("large slice", func(t *testing.T) {
+		const n = 20000
+		rows := make([][]any, n)
+		for i := range rows {
+			rows[i] = []any{i, "item", float64(i) / 2}
+		}
+
+		affected, err := InsertValues(context.Background(), db, `s."Items"`, []string{"id", "Name", "price"}, rows,
+			BulkOptions{})
+		require.NoError(t, err)
+		require.Equal(t, int64(n), affected)
+
+		var count, distinct int
+		var sum float64
+		require.NoError(t, db.QueryRow(`SELECT count(*), count(DISTINCT id), sum(price) FROM s."Items"`).
+			Scan(&count, &distinct, &sum))
+		require.Equal(t, n, count)
+		require.Equal(t, n, distinct)
+		require.Equal(t, float64(n*(n-1))/4, sum)
+	})
+
+	t.Run("chunk size smaller than a row", func(t *testing.T) {
+		affected, err := InsertValues(context.Background(), db, `s.Items`, []string{"id", "Name"},
+			[][]any{{-1, "a"}, {-2, nil}}, BulkOptions{ChunkParams: 1})
+		require.NoError(t, err)
+		require.Equal(t, int64(2), affected)
+	})
+
+	t.Run("rollback", func(t *testing.T) {
+		_, err := db.Exec(`CREATE TABLE unique_ids (id INTEGER PRIMARY KEY)`)
+		require.NoError(t, err)
+
+		// The second chunk violates the primary key, so the first chunk is rolled back.
+		_, err = InsertValues(context.Background(), db, "unique_ids", []string{"id"},
+			[][]any{{1}, {2}, {3}, {1}}, BulkOptions{ChunkParams: 3})
+		require.ErrorContains(t, err, "Constraint Error")
+
+		var count int
+		require.NoError(t, db.QueryRow(`SELECT count(*) FROM unique_ids`).Scan(&count))
+		require.Equal(t, 0, count)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := InsertValues(context.Background(), db, "s.Items", nil, nil, BulkOptions{})
+		testError(t, err, errBulk.Error())
+
+		_, err = InsertValues(context.Background(), db, "s.Items", []string{"id"}, [][]any{{1, 2}}, BulkOptions{})
+		testError(t, err, errBulk.Error(), columnCountErrMsg)
+	})
+}
+
+func TestExecIn(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE tbl AS SELECT i AS id FROM range(40000) t(i)`)
+	require.NoError(t, err)
+
+	// Delete all even ids within a transaction.
+	tx, err := db.Begin()
+	require.NoError(t, err)
+
+	values := make([]any, 0, 20000)
+	for i := 0; i < 40000; i += 2 {
+		values = append(values, i)
+	}
+	affected, err := ExecIn(context.Background(), tx, `DELETE FROM tbl WHERE id IN (?...)`, values,
+		BulkOptions{ChunkParams: 500})
+	require.NoError(t, err)
+	require.Equal(t, int64(20000), affected)
+	require.NoError(t, tx.Commit())
+
+	var count int
+	var odd sql.NullBool
+	require.NoError(t, db.QueryRow(`SELECT count(*), bool_and(id % 2 = 1) FROM tbl`).Scan(&count, &odd))
+	require.Equal(t, 20000, count)
+	require.True(t, odd.Bool)
+
+	affected, err = ExecIn(context.Background(), db, `DELETE FROM tbl WHERE id IN (?...)`, nil, BulkOptions{})
+	require.NoError(t, err)
+	require.Equal(t, int64(0), affected)
+
+	_, err = ExecIn(context.Background(), db, `DELETE FROM tbl WHERE id IN (?)`, values, BulkOptions{})
+	testError(t, err, errBulk.Error(), InListMarker)
+}
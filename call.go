@@ -0,0 +1,61 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+)
+
+// CallOptions configures CallWithOptions.
+type CallOptions struct {
+	// From selects all columns from the table function, i.e., SELECT * FROM function(args),
+	// instead of calling it with CALL function(args). Both return the same rows.
+	From bool
+}
+
+// Call calls the table function with the arguments, and returns its rows, e.g.,
+// Call(ctx, db, "pragma_storage_info", "tbl") executes CALL pragma_storage_info(?).
+// The arguments bind as parameters. An sql.NamedArg binds to the named parameter of the function,
+// e.g., sql.Named("header", true) of read_csv. The function name may be qualified by its schema, and each part is quoted.
+func Call(ctx context.Context, db Queryer, function string, args ...any) (*sql.Rows, error) {
+	return CallWithOptions(ctx, db, CallOptions{}, function, args...)
+}
+
+// CallWithOptions is like Call, but it allows configuring the call with CallOptions.
+func CallWithOptions(ctx context.Context, db Queryer, opts CallOptions, function string, args ...any) (
+	*sql.Rows, error,
+) {
+	query, params, err := callSQL(opts, function, args)
+	if err != nil {
+		return nil, getError(errCall, err)
+	}
+	return db.QueryContext(ctx, query, params...)
+}
+
+func callSQL(opts CallOptions, function string, args []any) (string, []any, error) {
+	if function == "" {
+		return "", nil, errors.New("empty function name")
+	}
+
+	// Named arguments bind to the named parameters of the function, and their values bind as positional parameters.
+	exprs := make([]string, len(args))
+	params := make([]any, len(args))
+	for i, arg := range args {
+		exprs[i] = "?"
+		params[i] = arg
+		if named, ok := arg.(sql.NamedArg); ok {
+			if named.Name == "" {
+				return "", nil, errors.New("empty name of named argument")
+			}
+			exprs[i] = quoteIdentifier(named.Name) + " = ?"
+			params[i] = named.Value
+		}
+	}
+
+	call := quoteQualifiedIdentifier(function) + "(" + strings.Join(exprs, ", ") + ")"
+	if opts.From {
+		return "SELECT * FROM " + call, params, nil
+	}
+	return "CALL " + call, params, nil
+}
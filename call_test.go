@@ -0,0 +1,110 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCall(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE tbl (id INTEGER, name VARCHAR)`)
+	require.NoError(t, err)
+
+	t.Run("CALL", func(t *testing.T) {
+		rows, err := Call(context.Background(), db, "pragma_table_info", "tbl")
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var names []string
+		for rows.Next() {
+			var info struct {
+				CID     int            `db:"cid"`
+				Name    string         `db:"name"`
+				Type    string         `db:"type"`
+				NotNull bool           `db:"notnull"`
+				Default sql.NullString `db:"dflt_value"`
+				PK      bool           `db:"pk"`
+			}
+			require.NoError(t, ScanStruct(rows, &info))
+			names = append(names, info.Name+" "+info.Type)
+		}
+		require.NoError(t, rows.Err())
+		require.Equal(t, []string{"id INTEGER", "name VARCHAR"}, names)
+	})
+
+	t.Run("FROM", func(t *testing.T) {
+		for _, opts := range []CallOptions{{}, {From: true}} {
+			rows, err := CallWithOptions(context.Background(), db, opts, "range", 2, 5)
+			require.NoError(t, err)
+
+			var values []int
+			for rows.Next() {
+				var v int
+				require.NoError(t, rows.Scan(&v))
+				values = append(values, v)
+			}
+			require.NoError(t, rows.Err())
+			require.NoError(t, rows.Close())
+			require.Equal(t, []int{2, 3, 4}, values)
+		}
+	})
+
+	t.Run("named arguments", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "data.csv")
+		require.NoError(t, os.WriteFile(path, []byte("a;b\n1;x\n2;y\n"), 0o600))
+
+		rows, err := Call(context.Background(), db, "read_csv", path, sql.Named("delim", ";"),
+			sql.Named("header", true))
+		require.NoError(t, err)
+		defer rows.Close()
+
+		columns, err := rows.Columns()
+		require.NoError(t, err)
+		require.Equal(t, []string{"a", "b"}, columns)
+
+		count := 0
+		for rows.Next() {
+			count++
+		}
+		require.NoError(t, rows.Err())
+		require.Equal(t, 2, count)
+	})
+
+	t.Run("quoted function name", func(t *testing.T) {
+		_, err := Call(context.Background(), db, "range(1); DROP TABLE tbl; --")
+		require.ErrorContains(t, err, "Catalog Error")
+
+		var count int
+		require.NoError(t, db.QueryRow(`SELECT count(*) FROM tbl`).Scan(&count))
+	})
+
+	t.Run("invalid arguments", func(t *testing.T) {
+		_, err := Call(context.Background(), db, "")
+		testError(t, err, errCall.Error())
+		_, err = Call(context.Background(), db, "range", sql.Named("", 1))
+		testError(t, err, errCall.Error())
+	})
+}
+
+func TestCallSQL(t *testing.T) {
+	t.Parallel()
+
+	query, args, err := callSQL(CallOptions{}, "read_csv", []any{"f.csv", sql.Named("header", true)})
+	require.NoError(t, err)
+	require.Equal(t, `CALL "read_csv"(?, "header" = ?)`, query)
+	require.Equal(t, []any{"f.csv", true}, args)
+
+	query, args, err = callSQL(CallOptions{From: true}, "main.my_func", nil)
+	require.NoError(t, err)
+	require.Equal(t, `SELECT * FROM "main"."my_func"()`, query)
+	require.Empty(t, args)
+}
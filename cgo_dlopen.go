@@ -0,0 +1,38 @@
+//go:build duckdb_dlopen
+
+package duckdb
+
+/*
+#cgo linux LDFLAGS: -ldl
+#cgo freebsd LDFLAGS: -lpthread
+#include <stdlib.h>
+#include <duckdb.h>
+
+int duckdb_go_load_library(const char *path, const char **err);
+*/
+import "C"
+
+import (
+	"errors"
+	"unsafe"
+)
+
+// LoadLibrary loads the DuckDB library at the path, e.g., a system-installed libduckdb.so, or a libduckdb.dylib
+// next to the executable. With the build tag duckdb_dlopen, the driver neither links DuckDB at build time, nor
+// requires it to build, but loads the library, when it first calls DuckDB. Without calling LoadLibrary, it loads the
+// library of the DUCKDB_LIBRARY_PATH environment variable, or else the libduckdb of the search path of the
+// dynamic linker, and aborts the process, if it fails. LoadLibrary must be called before using the driver,
+// and fails, if a library is already loaded.
+func LoadLibrary(path string) error {
+	cPath := C.CString(path)
+	defer C.free(unsafe.Pointer(cPath))
+
+	var cErr *C.char
+	switch C.duckdb_go_load_library(cPath, &cErr) {
+	case 1:
+		return getError(errLoadLibrary, errors.New("a DuckDB library is already loaded"))
+	case -1:
+		return getError(errLoadLibrary, errors.New(C.GoString(cErr)))
+	}
+	return nil
+}
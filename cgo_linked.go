@@ -0,0 +1,11 @@
+//go:build !duckdb_dlopen
+
+package duckdb
+
+import "errors"
+
+// LoadLibrary loads the DuckDB library at the path, if the driver is built with the build tag duckdb_dlopen.
+// Otherwise, the driver links DuckDB at build time, and LoadLibrary fails.
+func LoadLibrary(path string) error {
+	return getError(errLoadLibrary, errors.New("DuckDB is linked at build time, build with -tags=duckdb_dlopen to load it"))
+}
@@ -1,4 +1,4 @@
-//go:build duckdb_use_lib
+//go:build duckdb_use_lib && !duckdb_dlopen
 
 package duckdb
 
@@ -0,0 +1,51 @@
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+)
+
+// Checkpoint writes the WAL of the database of the connection into the database file, and truncates the WAL.
+// DuckDB checkpoints automatically, once the WAL exceeds the checkpoint threshold, see WithCheckpointThreshold.
+// A long-lived writer can checkpoint explicitly, e.g., after a bulk load, to keep the WAL short.
+// Checkpoint fails, if other transactions of the database are running. It cannot run in a transaction.
+func Checkpoint(ctx context.Context, driverConn driver.Conn) error {
+	return checkpoint(ctx, driverConn, "CHECKPOINT")
+}
+
+// ForceCheckpoint checkpoints like Checkpoint, but aborts the other running transactions of the database,
+// instead of failing.
+func ForceCheckpoint(ctx context.Context, driverConn driver.Conn) error {
+	return checkpoint(ctx, driverConn, "FORCE CHECKPOINT")
+}
+
+func checkpoint(ctx context.Context, driverConn driver.Conn, query string) error {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return err
+	}
+	if _, err = con.ExecContext(ctx, query, nil); err != nil {
+		return getError(errCheckpoint, err)
+	}
+	return nil
+}
+
+// checkpointOnClose checkpoints the database on a temporary connection, see WithCheckpointOnClose.
+func (c *Connector) checkpointOnClose() error {
+	var duckdbCon C.duckdb_connection
+	if state := C.duckdb_connect(c.db, &duckdbCon); state == C.DuckDBError {
+		return getError(errCheckpoint, getError(errConnect, nil))
+	}
+	con := &conn{duckdbCon: duckdbCon, config: c.config, queries: &c.queries, connectorClosed: &c.closed}
+	defer con.Close()
+
+	if _, err := con.ExecContext(context.Background(), "CHECKPOINT", nil); err != nil {
+		return getError(errCheckpoint, err)
+	}
+	return nil
+}
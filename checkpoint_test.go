@@ -0,0 +1,62 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckpoint(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "checkpoint.db")
+	connector, err := NewConnector(path, nil, WithCheckpointOnClose())
+	require.NoError(t, err)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE tbl AS SELECT range AS i FROM range(1000)`)
+	require.NoError(t, err)
+	walSize := func() int64 {
+		info, err := os.Stat(path + ".wal")
+		if os.IsNotExist(err) {
+			return 0
+		}
+		require.NoError(t, err)
+		return info.Size()
+	}
+	require.NotZero(t, walSize())
+
+	ctx := context.Background()
+	con, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer con.Close()
+	checkpoint := func(fn func(ctx context.Context, driverConn driver.Conn) error) error {
+		return con.Raw(func(driverConn any) error {
+			return fn(ctx, driverConn.(driver.Conn))
+		})
+	}
+	require.NoError(t, checkpoint(Checkpoint))
+	require.Zero(t, walSize())
+
+	// A transaction with changes of another connection blocks a checkpoint, unless it is forced.
+	tx, err := db.Begin()
+	require.NoError(t, err)
+	_, err = tx.Exec(`INSERT INTO tbl VALUES (-1)`)
+	require.NoError(t, err)
+	err = checkpoint(Checkpoint)
+	require.ErrorIs(t, err, errCheckpoint)
+	require.NoError(t, checkpoint(ForceCheckpoint))
+	_ = tx.Rollback()
+
+	_, err = db.Exec(`INSERT INTO tbl VALUES (1000)`)
+	require.NoError(t, err)
+	require.NoError(t, con.Close())
+	require.NoError(t, db.Close())
+	require.NoError(t, connector.Close())
+	require.Zero(t, walSize())
+}
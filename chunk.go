@@ -0,0 +1,357 @@
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"unsafe"
+)
+
+// Chunk is a chunk of a query result in DuckDB's columnar format.
+// A Chunk is only valid within the callback of ReadChunks, as DuckDB frees its memory after the callback returns.
+// Thus, the column slices, except for strings, must not be retained after the callback.
+type Chunk struct {
+	chunk    C.duckdb_data_chunk
+	rowCount int
+	closed   bool
+}
+
+// Validity is the validity bitmap of a column of a Chunk.
+// A nil bitmap means that all values are valid, i.e., that the column contains no NULL values.
+type Validity []uint64
+
+// IsValid returns true, if the value of the row is not NULL.
+func (v Validity) IsValid(row int) bool {
+	if v == nil {
+		return true
+	}
+	return v[row/64]&(1<<(row%64)) != 0
+}
+
+// ReadChunks calls fn for each chunk of the rows, and frees each chunk after fn returns.
+// If Next already fetched a chunk of the rows, then ReadChunks starts with the chunk after it.
+// The driver rows must be the result of a QueryContext of a DuckDB driver connection.
+// ReadChunks stops at the first error returned by fn, and returns it. Reading closed rows fails.
+func ReadChunks(driverRows driver.Rows, fn func(chunk *Chunk) error) error {
+	r, ok := driverRows.(*rows)
+	if !ok {
+		return getError(errReadChunks, castError(fmt.Sprintf("%T", driverRows), fmt.Sprintf("%T", r)))
+	}
+
+	for {
+		next, err := r.nextChunk()
+		if err != nil || next == nil {
+			return err
+		}
+
+		chunk := &Chunk{chunk: next, rowCount: int(C.duckdb_data_chunk_get_size(next))}
+		err = fn(chunk)
+		chunk.closed = true
+		C.duckdb_destroy_data_chunk(&chunk.chunk)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// RowCount returns the number of rows of the chunk.
+func (c *Chunk) RowCount() int {
+	return c.rowCount
+}
+
+// ColumnCount returns the number of columns of the chunk.
+func (c *Chunk) ColumnCount() int {
+	if c.closed {
+		return 0
+	}
+	return int(C.duckdb_data_chunk_get_column_count(c.chunk))
+}
+
+// BoolColumn returns the values of a BOOLEAN column, and its validity bitmap.
+func (c *Chunk) BoolColumn(colIdx int) ([]bool, Validity, error) {
+	return chunkColumn[bool](c, colIdx, C.DUCKDB_TYPE_BOOLEAN)
+}
+
+// Int8Column returns the values of a TINYINT column, and its validity bitmap.
+func (c *Chunk) Int8Column(colIdx int) ([]int8, Validity, error) {
+	return chunkColumn[int8](c, colIdx, C.DUCKDB_TYPE_TINYINT)
+}
+
+// Int16Column returns the values of a SMALLINT column, and its validity bitmap.
+func (c *Chunk) Int16Column(colIdx int) ([]int16, Validity, error) {
+	return chunkColumn[int16](c, colIdx, C.DUCKDB_TYPE_SMALLINT)
+}
+
+// Int32Column returns the values of an INTEGER column, and its validity bitmap.
+func (c *Chunk) Int32Column(colIdx int) ([]int32, Validity, error) {
+	return chunkColumn[int32](c, colIdx, C.DUCKDB_TYPE_INTEGER)
+}
+
+// Int64Column returns the values of a BIGINT column, and its validity bitmap.
+func (c *Chunk) Int64Column(colIdx int) ([]int64, Validity, error) {
+	return chunkColumn[int64](c, colIdx, C.DUCKDB_TYPE_BIGINT)
+}
+
+// Uint8Column returns the values of a UTINYINT column, and its validity bitmap.
+func (c *Chunk) Uint8Column(colIdx int) ([]uint8, Validity, error) {
+	return chunkColumn[uint8](c, colIdx, C.DUCKDB_TYPE_UTINYINT)
+}
+
+// Uint16Column returns the values of a USMALLINT column, and its validity bitmap.
+func (c *Chunk) Uint16Column(colIdx int) ([]uint16, Validity, error) {
+	return chunkColumn[uint16](c, colIdx, C.DUCKDB_TYPE_USMALLINT)
+}
+
+// Uint32Column returns the values of a UINTEGER column, and its validity bitmap.
+func (c *Chunk) Uint32Column(colIdx int) ([]uint32, Validity, error) {
+	return chunkColumn[uint32](c, colIdx, C.DUCKDB_TYPE_UINTEGER)
+}
+
+// Uint64Column returns the values of a UBIGINT column, and its validity bitmap.
+func (c *Chunk) Uint64Column(colIdx int) ([]uint64, Validity, error) {
+	return chunkColumn[uint64](c, colIdx, C.DUCKDB_TYPE_UBIGINT)
+}
+
+// Float32Column returns the values of a FLOAT column, and its validity bitmap.
+func (c *Chunk) Float32Column(colIdx int) ([]float32, Validity, error) {
+	return chunkColumn[float32](c, colIdx, C.DUCKDB_TYPE_FLOAT)
+}
+
+// Float64Column returns the values of a DOUBLE column, and its validity bitmap.
+func (c *Chunk) Float64Column(colIdx int) ([]float64, Validity, error) {
+	return chunkColumn[float64](c, colIdx, C.DUCKDB_TYPE_DOUBLE)
+}
+
+// StringColumn returns the values of a VARCHAR column, and its validity bitmap.
+// Unlike the other accessors, it copies the values into Go memory, so they remain valid after the callback.
+// NULL values are empty strings.
+func (c *Chunk) StringColumn(colIdx int) ([]string, Validity, error) {
+	vector, validity, err := c.vector(colIdx, C.DUCKDB_TYPE_VARCHAR)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	values := make([]string, c.rowCount)
+	for i := range values {
+		if validity.IsValid(i) {
+			values[i] = scanString(vector, C.idx_t(i))
+		}
+	}
+	return values, validity, nil
+}
+
+// chunkColumn returns the values of a column of a primitive type without copying them.
+// The values of NULL rows are undefined.
+func chunkColumn[T any](c *Chunk, colIdx int, typeID C.duckdb_type) ([]T, Validity, error) {
+	vector, validity, err := c.vector(colIdx, typeID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if c.rowCount == 0 {
+		return []T{}, validity, nil
+	}
+
+	ptr := C.duckdb_vector_get_data(vector)
+	return unsafe.Slice((*T)(ptr), c.rowCount), validity, nil
+}
+
+// vector returns the vector of a column, and its validity bitmap, if the column has the expected type.
+func (c *Chunk) vector(colIdx int, typeID C.duckdb_type) (C.duckdb_vector, Validity, error) {
+	if c.closed {
+		return nil, nil, getError(errReadChunks, errClosedChunk)
+	}
+	if colIdx < 0 || colIdx >= c.ColumnCount() {
+		return nil, nil, getError(errReadChunks, fmt.Errorf("%s %d out of range", columnErrMsg, colIdx))
+	}
+
+	vector := C.duckdb_data_chunk_get_vector(c.chunk, C.idx_t(colIdx))
+	logicalType := C.duckdb_vector_get_column_type(vector)
+	defer C.duckdb_destroy_logical_type(&logicalType)
+
+	if actual := C.duckdb_get_type_id(logicalType); actual != typeID {
+		return nil, nil, getError(errReadChunks, columnError(castError(typeName(actual), typeName(typeID)), colIdx+1))
+	}
+
+	var validity Validity
+	if mask := C.duckdb_vector_get_validity(vector); mask != nil && c.rowCount > 0 {
+		validity = unsafe.Slice((*uint64)(unsafe.Pointer(mask)), (c.rowCount+63)/64)
+	}
+	return vector, validity, nil
+}
+
+// Vector is a column of a Chunk, or the child vector of a nested column. Like the Chunk, it is only valid
+// within the callback of ReadChunks.
+type Vector struct {
+	chunk  *Chunk
+	vector C.duckdb_vector
+	size   int
+}
+
+// ListEntry is the range of the elements of a row of a LIST vector in its child vector.
+type ListEntry struct {
+	Offset uint64
+	Length uint64
+}
+
+// VectorValue is the Go type of the values of a vector of a primitive type, see VectorValues.
+type VectorValue interface {
+	bool | int8 | int16 | int32 | int64 | uint8 | uint16 | uint32 | uint64 | float32 | float64
+}
+
+// Column returns the vector of the column.
+func (c *Chunk) Column(colIdx int) (*Vector, error) {
+	if c.closed {
+		return nil, getError(errReadChunks, errClosedChunk)
+	}
+	if colIdx < 0 || colIdx >= c.ColumnCount() {
+		return nil, getError(errReadChunks, fmt.Errorf("%s %d out of range", columnErrMsg, colIdx))
+	}
+	return &Vector{chunk: c, vector: C.duckdb_data_chunk_get_vector(c.chunk, C.idx_t(colIdx)), size: c.rowCount}, nil
+}
+
+// Len returns the number of values of the vector. The child vector of a LIST can have more values than rows.
+func (v *Vector) Len() int {
+	return v.size
+}
+
+// Type returns the TypeDescriptor of the vector.
+func (v *Vector) Type() (*TypeDescriptor, error) {
+	if v.chunk.closed {
+		return nil, getError(errReadChunks, errClosedChunk)
+	}
+	logicalType := C.duckdb_vector_get_column_type(v.vector)
+	defer C.duckdb_destroy_logical_type(&logicalType)
+	return newTypeDescriptor(logicalType), nil
+}
+
+// Validity returns the validity bitmap of the vector.
+func (v *Vector) Validity() (Validity, error) {
+	if v.chunk.closed {
+		return nil, getError(errReadChunks, errClosedChunk)
+	}
+	return v.validity(), nil
+}
+
+// VectorValues returns the values of a vector of a primitive type without copying them, e.g.,
+// VectorValues[int64] of a BIGINT vector. The values of NULL rows are undefined.
+func VectorValues[T VectorValue](v *Vector) ([]T, error) {
+	var typeID C.duckdb_type
+	switch any(*new(T)).(type) {
+	case bool:
+		typeID = C.DUCKDB_TYPE_BOOLEAN
+	case int8:
+		typeID = C.DUCKDB_TYPE_TINYINT
+	case int16:
+		typeID = C.DUCKDB_TYPE_SMALLINT
+	case int32:
+		typeID = C.DUCKDB_TYPE_INTEGER
+	case int64:
+		typeID = C.DUCKDB_TYPE_BIGINT
+	case uint8:
+		typeID = C.DUCKDB_TYPE_UTINYINT
+	case uint16:
+		typeID = C.DUCKDB_TYPE_USMALLINT
+	case uint32:
+		typeID = C.DUCKDB_TYPE_UINTEGER
+	case uint64:
+		typeID = C.DUCKDB_TYPE_UBIGINT
+	case float32:
+		typeID = C.DUCKDB_TYPE_FLOAT
+	case float64:
+		typeID = C.DUCKDB_TYPE_DOUBLE
+	}
+	if err := v.checkType(typeID); err != nil {
+		return nil, err
+	}
+	if v.size == 0 {
+		return []T{}, nil
+	}
+	return unsafe.Slice((*T)(C.duckdb_vector_get_data(v.vector)), v.size), nil
+}
+
+// Strings returns the values of a VARCHAR vector. Like StringColumn, it copies them into Go memory.
+// NULL values are empty strings.
+func (v *Vector) Strings() ([]string, error) {
+	if err := v.checkType(C.DUCKDB_TYPE_VARCHAR); err != nil {
+		return nil, err
+	}
+	validity := v.validity()
+	values := make([]string, v.size)
+	for i := range values {
+		if validity.IsValid(i) {
+			values[i] = scanString(v.vector, C.idx_t(i))
+		}
+	}
+	return values, nil
+}
+
+// ListEntries returns the ranges of the elements of the rows of a LIST vector in its child vector,
+// without copying them. The entries of NULL rows are undefined.
+func (v *Vector) ListEntries() ([]ListEntry, error) {
+	if err := v.checkType(C.DUCKDB_TYPE_LIST); err != nil {
+		return nil, err
+	}
+	if v.size == 0 {
+		return []ListEntry{}, nil
+	}
+	return unsafe.Slice((*ListEntry)(C.duckdb_vector_get_data(v.vector)), v.size), nil
+}
+
+// ListChild returns the child vector of a LIST vector, which contains the elements of all rows.
+func (v *Vector) ListChild() (*Vector, error) {
+	if err := v.checkType(C.DUCKDB_TYPE_LIST); err != nil {
+		return nil, err
+	}
+	size := int(C.duckdb_list_vector_get_size(v.vector))
+	return &Vector{chunk: v.chunk, vector: C.duckdb_list_vector_get_child(v.vector), size: size}, nil
+}
+
+// ArrayChild returns the child vector of an ARRAY vector. The elements of row i of an ARRAY of size n
+// are the values [i * n, (i + 1) * n) of the child vector.
+func (v *Vector) ArrayChild() (*Vector, error) {
+	if err := v.checkType(C.DUCKDB_TYPE_ARRAY); err != nil {
+		return nil, err
+	}
+	logicalType := C.duckdb_vector_get_column_type(v.vector)
+	defer C.duckdb_destroy_logical_type(&logicalType)
+	size := v.size * int(C.duckdb_array_type_array_size(logicalType))
+	return &Vector{chunk: v.chunk, vector: C.duckdb_array_vector_get_child(v.vector), size: size}, nil
+}
+
+// StructField returns the vector of the field of a STRUCT vector, by the index of the field.
+func (v *Vector) StructField(fieldIdx int) (*Vector, error) {
+	if err := v.checkType(C.DUCKDB_TYPE_STRUCT); err != nil {
+		return nil, err
+	}
+	logicalType := C.duckdb_vector_get_column_type(v.vector)
+	defer C.duckdb_destroy_logical_type(&logicalType)
+	if fieldIdx < 0 || fieldIdx >= int(C.duckdb_struct_type_child_count(logicalType)) {
+		return nil, getError(errReadChunks, fmt.Errorf("STRUCT field index %d out of range", fieldIdx))
+	}
+	return &Vector{chunk: v.chunk, vector: C.duckdb_struct_vector_get_child(v.vector, C.idx_t(fieldIdx)), size: v.size}, nil
+}
+
+// checkType returns an error, if the chunk of the vector is closed, or if the vector does not have the type.
+func (v *Vector) checkType(typeID C.duckdb_type) error {
+	if v.chunk.closed {
+		return getError(errReadChunks, errClosedChunk)
+	}
+	logicalType := C.duckdb_vector_get_column_type(v.vector)
+	defer C.duckdb_destroy_logical_type(&logicalType)
+
+	if actual := C.duckdb_get_type_id(logicalType); actual != typeID {
+		return getError(errReadChunks, castError(typeName(actual), typeName(typeID)))
+	}
+	return nil
+}
+
+func (v *Vector) validity() Validity {
+	if mask := C.duckdb_vector_get_validity(v.vector); mask != nil && v.size > 0 {
+		return unsafe.Slice((*uint64)(unsafe.Pointer(mask)), (v.size+63)/64)
+	}
+	return nil
+}
@@ -0,0 +1,251 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func queryDriverRows(t testing.TB, con driver.Conn, query string) driver.Rows {
+	driverRows, err := con.(driver.QueryerContext).QueryContext(context.Background(), query, nil)
+	require.NoError(t, err)
+	return driverRows
+}
+
+func TestReadChunks(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	con, err := c.Connect(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	t.Run("typed columns", func(t *testing.T) {
+		driverRows := queryDriverRows(t, con, `SELECT i, i::INTEGER, i / 2, i % 2 = 0,
+			CASE WHEN i % 3 = 0 THEN NULL ELSE 'row ' || i END FROM range(5000) t(i)`)
+		defer driverRows.Close()
+
+		rowIdx := 0
+		chunks := 0
+		err := ReadChunks(driverRows, func(chunk *Chunk) error {
+			chunks++
+			require.Equal(t, 5, chunk.ColumnCount())
+
+			ints, validity, err := chunk.Int64Column(0)
+			require.NoError(t, err)
+			require.True(t, validity.IsValid(0))
+			require.Len(t, ints, chunk.RowCount())
+
+			int32s, _, err := chunk.Int32Column(1)
+			require.NoError(t, err)
+			halves, _, err := chunk.Float64Column(2)
+			require.NoError(t, err)
+			even, _, err := chunk.BoolColumn(3)
+			require.NoError(t, err)
+			strs, strValidity, err := chunk.StringColumn(4)
+			require.NoError(t, err)
+
+			for i := 0; i < chunk.RowCount(); i++ {
+				require.Equal(t, int64(rowIdx), ints[i])
+				require.Equal(t, int32(rowIdx), int32s[i])
+				require.Equal(t, float64(rowIdx)/2, halves[i])
+				require.Equal(t, rowIdx%2 == 0, even[i])
+				require.Equal(t, rowIdx%3 != 0, strValidity.IsValid(i))
+				if rowIdx%3 != 0 {
+					require.Equal(t, fmt.Sprintf("row %d", rowIdx), strs[i])
+				}
+				rowIdx++
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 5000, rowIdx)
+		require.Greater(t, chunks, 1)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		driverRows := queryDriverRows(t, con, `SELECT 'a' FROM range(5000)`)
+		defer driverRows.Close()
+
+		var retained *Chunk
+		err := ReadChunks(driverRows, func(chunk *Chunk) error {
+			_, _, err := chunk.Int64Column(0)
+			testError(t, err, errReadChunks.Error(), castErrMsg)
+
+			_, _, err = chunk.StringColumn(1)
+			testError(t, err, errReadChunks.Error(), columnErrMsg)
+
+			retained = chunk
+			return errors.New("stop")
+		})
+		require.EqualError(t, err, "stop")
+
+		_, _, err = retained.StringColumn(0)
+		testError(t, err, errReadChunks.Error(), errClosedChunk.Error())
+
+		// The next chunk is not affected by the failed callback.
+		require.NoError(t, ReadChunks(driverRows, func(chunk *Chunk) error {
+			strs, _, err := chunk.StringColumn(0)
+			require.NoError(t, err)
+			require.Equal(t, "a", strs[0])
+			return nil
+		}))
+
+		err = ReadChunks(nil, func(chunk *Chunk) error { return nil })
+		testError(t, err, errReadChunks.Error(), castErrMsg)
+	})
+
+	t.Run("nested vectors", func(t *testing.T) {
+		driverRows := queryDriverRows(t, con, `SELECT CASE WHEN i % 4 = 0 THEN NULL ELSE range(i % 4) END,
+			{'a': i, 'b': 'x' || i}, [i, i * 2]::BIGINT[2] FROM range(3000) t(i)`)
+		defer driverRows.Close()
+
+		rowIdx := 0
+		err := ReadChunks(driverRows, func(chunk *Chunk) error {
+			lists, err := chunk.Column(0)
+			require.NoError(t, err)
+			desc, err := lists.Type()
+			require.NoError(t, err)
+			require.Equal(t, "BIGINT[]", desc.Name)
+
+			entries, err := lists.ListEntries()
+			require.NoError(t, err)
+			validity, err := lists.Validity()
+			require.NoError(t, err)
+			elems, err := lists.ListChild()
+			require.NoError(t, err)
+			elemValues, err := VectorValues[int64](elems)
+			require.NoError(t, err)
+
+			structs, err := chunk.Column(1)
+			require.NoError(t, err)
+			a, err := structs.StructField(0)
+			require.NoError(t, err)
+			aValues, err := VectorValues[int64](a)
+			require.NoError(t, err)
+			b, err := structs.StructField(1)
+			require.NoError(t, err)
+			bValues, err := b.Strings()
+			require.NoError(t, err)
+
+			arrays, err := chunk.Column(2)
+			require.NoError(t, err)
+			arrayElems, err := arrays.ArrayChild()
+			require.NoError(t, err)
+			require.Equal(t, 2*chunk.RowCount(), arrayElems.Len())
+			arrayValues, err := VectorValues[int64](arrayElems)
+			require.NoError(t, err)
+
+			for i := 0; i < chunk.RowCount(); i++ {
+				require.Equal(t, rowIdx%4 != 0, validity.IsValid(i))
+				if rowIdx%4 != 0 {
+					entry := entries[i]
+					require.Equal(t, uint64(rowIdx%4), entry.Length)
+					for j := uint64(0); j < entry.Length; j++ {
+						require.Equal(t, int64(j), elemValues[entry.Offset+j])
+					}
+				}
+				require.Equal(t, int64(rowIdx), aValues[i])
+				require.Equal(t, fmt.Sprintf("x%d", rowIdx), bValues[i])
+				require.Equal(t, []int64{int64(rowIdx), int64(rowIdx) * 2}, arrayValues[2*i:2*i+2])
+				rowIdx++
+			}
+
+			_, err = VectorValues[int32](a)
+			testError(t, err, errReadChunks.Error(), castErrMsg)
+			_, err = structs.StructField(2)
+			testError(t, err, errReadChunks.Error(), "STRUCT field index 2 out of range")
+			_, err = structs.ListChild()
+			testError(t, err, errReadChunks.Error(), castErrMsg)
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3000, rowIdx)
+	})
+
+	t.Run("closed rows", func(t *testing.T) {
+		for _, mode := range []FetchMode{FetchMaterialized, FetchStreaming} {
+			ctx := ContextWithFetchMode(context.Background(), mode)
+			driverRows, err := con.(driver.QueryerContext).QueryContext(ctx, `SELECT i FROM range(5000) t(i)`, nil)
+			require.NoError(t, err)
+			require.NoError(t, driverRows.Close())
+			require.ErrorIs(t, ReadChunks(driverRows, func(chunk *Chunk) error { return nil }), ErrClosed)
+
+			// Closing the rows in the callback stops reading the chunks.
+			driverRows, err = con.(driver.QueryerContext).QueryContext(ctx, `SELECT i FROM range(5000) t(i)`, nil)
+			require.NoError(t, err)
+			chunks := 0
+			err = ReadChunks(driverRows, func(chunk *Chunk) error {
+				chunks++
+				return driverRows.Close()
+			})
+			require.ErrorIs(t, err, ErrClosed)
+			require.Equal(t, 1, chunks)
+		}
+	})
+}
+
+const benchmarkChunkQuery = `SELECT i, i::DOUBLE FROM range(1000000) t(i)`
+
+func BenchmarkReadChunks(b *testing.B) {
+	c, err := NewConnector("", nil)
+	require.NoError(b, err)
+	defer c.Close()
+
+	con, err := c.Connect(context.Background())
+	require.NoError(b, err)
+	defer con.Close()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		driverRows := queryDriverRows(b, con, benchmarkChunkQuery)
+
+		var sum float64
+		err := ReadChunks(driverRows, func(chunk *Chunk) error {
+			ints, _, err := chunk.Int64Column(0)
+			if err != nil {
+				return err
+			}
+			floats, _, err := chunk.Float64Column(1)
+			if err != nil {
+				return err
+			}
+			for j := range ints {
+				sum += float64(ints[j]) + floats[j]
+			}
+			return nil
+		})
+		require.NoError(b, err)
+		require.NoError(b, driverRows.Close())
+	}
+}
+
+func BenchmarkScanRows(b *testing.B) {
+	db := openDB(b)
+	defer db.Close()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		rows, err := db.Query(benchmarkChunkQuery)
+		require.NoError(b, err)
+
+		var sum float64
+		var intVal int64
+		var floatVal float64
+		for rows.Next() {
+			require.NoError(b, rows.Scan(&intVal, &floatVal))
+			sum += float64(intVal) + floatVal
+		}
+		require.NoError(b, rows.Err())
+		require.NoError(b, rows.Close())
+	}
+}
@@ -11,29 +11,163 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"errors"
+	"fmt"
+	"io"
 	"math/big"
+	"reflect"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 	"unsafe"
 )
 
 type conn struct {
 	duckdbCon C.duckdb_connection
+	config    *connectorConfig
 	closed    bool
 	tx        bool
+	// invalidated is set, if DuckDB invalidated the database of the connection after a fatal error, see IsValid.
+	invalidated bool
+	// readOnlyTx is set during a read-only transaction, which rejects statements writing to the database.
+	readOnlyTx bool
+	// mu serializes all calls of the C API using the connection, which is not safe for concurrent use.
+	// It does not parallelize a single connection: concurrent calls wait for each other.
+	// Interrupting a query does not acquire it.
+	mu sync.Mutex
+	// id identifies the connection among the connections of its Connector.
+	id uint64
+	// queries tracks the queries in flight on the connections of the Connector, see ActiveQueries.
+	queries *queryRegistry
+	// connectorClosed is set, if the Connector of the connection is closed, see IsValid.
+	connectorClosed *atomic.Bool
+	// stats collects the statistics of the connections of the Connector, if any, see Connector.Stats.
+	stats *connectorStats
+	// stmts caches the prepared statements of single-statement queries, if enabled, see WithStatementCache.
+	stmts *stmtCache
+	// profilingOutput is the file of the profiling output of the connection, see EnableProfiling.
+	profilingOutput string
+	// initialSessionState is the fingerprint of the session of the initialized connection, or empty, until
+	// the connection executes a statement that may change its session, see trackSession.
+	initialSessionState string
+	// sessionDirty is set, if the connection executed a statement that may change its session, see ResetSession.
+	sessionDirty bool
+	// progressEnabled is set, if DuckDB tracks the progress of the queries of the connection, see ContextWithProgress.
+	progressEnabled bool
+	// memoryLimitSem serializes the statements with a memory limit on the connections of the Connector,
+	// see ContextWithMemoryLimit.
+	memoryLimitSem chan struct{}
+	// connector is the Connector of the connection, which samples the memory usage of its database,
+	// see ContextWithMemoryUsage.
+	connector *Connector
 }
 
 func (c *conn) CheckNamedValue(nv *driver.NamedValue) error {
-	switch nv.Value.(type) {
-	case *big.Int, Interval:
+	value, err := c.config.convertParam(nv.Value)
+	if err != nil {
+		return err
+	}
+	nv.Value = value
+
+	switch v := nv.Value.(type) {
+	case *big.Int, Interval, Decimal, DecimalValuer, Date:
+		return nil
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64, string, []byte,
+		time.Time:
+		// Bind the primitive types directly, instead of converting them to int64 or float64 like database/sql,
+		// so that they bind parameters of their DuckDB type, e.g., a float32 a FLOAT, and a large uint64 a UBIGINT.
+		return nil
+	case TypedParam:
+		// Convert the value like database/sql converts the values of other arguments.
+		value, err := c.config.convertParam(v.Value)
+		if err != nil {
+			return err
+		}
+		v.Value = value
+		switch v.Value.(type) {
+		case *big.Int, Interval, Decimal, DecimalValuer, Date:
+		default:
+			if reflect.ValueOf(v.Value).Kind() == reflect.Map {
+				break
+			}
+			if u, ok := unsignedValue(v.Value); ok {
+				v.Value = u
+				break
+			}
+			value, err := driver.DefaultParameterConverter.ConvertValue(v.Value)
+			if err != nil {
+				return err
+			}
+			v.Value = value
+		}
+		nv.Value = v
+		return nil
+	}
+	if reflect.ValueOf(nv.Value).Kind() == reflect.Map {
+		// Maps bind to MAP parameters.
+		return nil
+	}
+	if isJSONParam(nv.Value) {
+		// JSON parameters bind their JSON text, see valuerValue.
+		return nil
+	}
+	if _, ok := nv.Value.(io.Reader); ok {
+		if _, ok = nv.Value.(driver.Valuer); !ok {
+			// Readers bind their bytes to BLOB parameters, see bindBlobReader.
+			return nil
+		}
+	}
+	if u, ok := unsignedValue(nv.Value); ok {
+		nv.Value = u
 		return nil
 	}
 	return driver.ErrSkip
 }
 
+// unsignedValue returns the value of an unsigned 64-bit integer of a named type, e.g., type ID uint64, as a uint64.
+// database/sql rejects such values, if their high bit is set, but they bind UBIGINT parameters.
+// Valuers keep their own conversion.
+func unsignedValue(v any) (uint64, bool) {
+	if _, ok := v.(driver.Valuer); ok {
+		return 0, false
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Uint64 && rv.Kind() != reflect.Uint {
+		return 0, false
+	}
+	return rv.Uint(), true
+}
+
 func (c *conn) ExecContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
 	if c.closed {
-		panic("database/sql/driver: misuse of duckdb driver: ExecContext after Close")
+		return nil, getError(errClosedCon, nil)
+	}
+
+	query, args = castTypedParams(query, args)
+	end := c.startHooks(ctx, QueryKindExec, query, args)
+	res, err := c.execContext(ctx, query, args)
+	if err != nil {
+		err = c.explainError(ctx, query, args, err)
+		end(-1, -1, err)
+		return nil, err
+	}
+	end(resultRows(res), 0, nil)
+	return res, nil
+}
+
+func (c *conn) execContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	query, args, err := c.inlineExecuteArgs(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	if stmt := c.stmts.get(query); stmt != nil {
+		return stmt.ExecContext(ctx, args)
 	}
 
+	// The context is checked before each step, i.e., extracting, preparing, and executing each statement.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	stmts, size, err := c.extractStmts(query)
 	if err != nil {
 		return nil, err
@@ -42,74 +176,173 @@ func (c *conn) ExecContext(ctx context.Context, query string, args []driver.Name
 
 	// execute all statements without args, except the last one
 	for i := C.idx_t(0); i < size-1; i++ {
-		stmt, err := c.prepareExtractedStmt(stmts, i)
+		stmt, err := c.prepareExtractedStmtContext(ctx, stmts, i)
 		if err != nil {
-			return nil, err
+			return nil, statementError(err, i)
 		}
+		stmt.query = query
+		stmt.multiStmt = true
 		// send nil args to execute statement and ignore result
 		_, err = stmt.ExecContext(ctx, nil)
 		stmt.Close()
 		if err != nil {
-			return nil, err
+			return nil, statementError(err, i)
 		}
 	}
 
 	// prepare and execute last statement with args and return result
-	stmt, err := c.prepareExtractedStmt(stmts, size-1)
+	stmt, err := c.prepareExtractedStmtContext(ctx, stmts, size-1)
 	if err != nil {
-		return nil, err
+		return nil, multiStatementError(err, size)
+	}
+	stmt.query = query
+	stmt.multiStmt = size > 1
+	if size == 1 {
+		c.stmts.put(query, stmt)
 	}
-	defer stmt.Close()
-	return stmt.ExecContext(ctx, args)
+	if !stmt.cached {
+		defer stmt.Close()
+	}
+	res, err := stmt.ExecContext(ctx, args)
+	if err != nil {
+		return nil, multiStatementError(err, size)
+	}
+	return res, nil
 }
 
 func (c *conn) QueryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
 	if c.closed {
-		panic("database/sql/driver: misuse of duckdb driver: QueryContext after Close")
+		return nil, getError(errClosedCon, nil)
 	}
 
+	query, args = castTypedParams(query, args)
+	end := c.startHooks(ctx, QueryKindQuery, query, args)
+	rows, err := c.queryContext(ctx, query, args)
+	if err != nil {
+		err = c.explainError(ctx, query, args, err)
+		end(-1, -1, err)
+		return nil, err
+	}
+	numRows, numChunks := rowCount(rows)
+	end(numRows, numChunks, nil)
+	return rows, nil
+}
+
+func (c *conn) queryContext(ctx context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	query, args, err := c.inlineExecuteArgs(ctx, query, args)
+	if err != nil {
+		return nil, err
+	}
+	if stmt := c.stmts.get(query); stmt != nil {
+		return stmt.QueryContext(ctx, args)
+	}
+
+	// The context is checked before each step, like in execContext.
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	stmts, size, err := c.extractStmts(query)
 	if err != nil {
 		return nil, err
 	}
 	defer C.duckdb_destroy_extracted(&stmts)
 
-	// execute all statements without args, except the last one
+	// execute all statements without args, except the last one, and keep the results of those returning rows
+	var resultSets []C.duckdb_result
+	destroyResultSets := func() {
+		for i := range resultSets {
+			C.duckdb_destroy_result(&resultSets[i])
+		}
+	}
 	for i := C.idx_t(0); i < size-1; i++ {
-		stmt, err := c.prepareExtractedStmt(stmts, i)
+		stmt, err := c.prepareExtractedStmtContext(ctx, stmts, i)
 		if err != nil {
-			return nil, err
+			destroyResultSets()
+			return nil, statementError(err, i)
 		}
-		// send nil args to execute statement and ignore result (using ExecContext since we're ignoring the result anyway)
-		_, err = stmt.ExecContext(ctx, nil)
+		stmt.query = query
+		stmt.multiStmt = true
+		res, err := stmt.executeMaterialized(ctx, nil)
 		stmt.Close()
 		if err != nil {
-			return nil, err
+			destroyResultSets()
+			return nil, statementError(err, i)
+		}
+		if C.duckdb_result_return_type(*res) == C.DUCKDB_RESULT_TYPE_QUERY_RESULT {
+			resultSets = append(resultSets, *res)
+		} else {
+			C.duckdb_destroy_result(res)
 		}
 	}
 
 	// prepare and execute last statement with args and return result
-	stmt, err := c.prepareExtractedStmt(stmts, size-1)
+	stmt, err := c.prepareExtractedStmtContext(ctx, stmts, size-1)
 	if err != nil {
-		return nil, err
+		destroyResultSets()
+		return nil, multiStatementError(err, size)
+	}
+	stmt.query = query
+	stmt.multiStmt = size > 1
+	if size == 1 {
+		c.stmts.put(query, stmt)
 	}
 
-	rows, err := stmt.QueryContext(ctx, args)
+	driverRows, err := stmt.QueryContext(ctx, args)
 	if err != nil {
-		stmt.Close()
-		return nil, err
+		destroyResultSets()
+		if !stmt.cached {
+			stmt.Close()
+		}
+		return nil, multiStatementError(err, size)
 	}
 
 	// we can't close the statement before the query result rows are closed
-	stmt.closeOnRowsClose = true
-	return rows, err
+	stmt.closeOnRowsClose = !stmt.cached
+	if len(resultSets) > 0 {
+		// the result of the last statement follows the results of the preceding statements
+		r := driverRows.(*rows)
+		r.resultSets = append(resultSets[1:], r.res)
+		r.setResult(resultSets[0])
+	}
+	return driverRows, err
 }
 
+// Deprecated: Use PrepareContext instead.
 func (c *conn) Prepare(cmd string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), cmd)
+}
+
+// PrepareContext prepares the statement, unless the context is done before or while preparing it.
+// DuckDB cannot interrupt preparing a statement, so a statement prepared after the context is done is closed again.
+func (c *conn) PrepareContext(ctx context.Context, cmd string) (driver.Stmt, error) {
 	if c.closed {
-		panic("database/sql/driver: misuse of duckdb driver: Prepare after Close")
+		return nil, getError(errClosedCon, nil)
+	}
+	end := c.startHooks(ctx, QueryKindPrepare, cmd, nil)
+	s, err := c.prepareStmtContext(ctx, cmd)
+	if err != nil {
+		end(-1, -1, err)
+		return nil, err
 	}
-	return c.prepareStmt(cmd)
+	end(0, 0, nil)
+	s.prepared = true
+	s.leak = newLeakCheck(c, "duckdb statement garbage collected without Close", cmd)
+	return s, nil
+}
+
+func (c *conn) prepareStmtContext(ctx context.Context, cmd string) (*stmt, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	s, err := c.prepareStmt(cmd)
+	if err != nil {
+		return nil, err
+	}
+	if err := ctx.Err(); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
 }
 
 // Deprecated: Use BeginTx instead.
@@ -122,14 +355,11 @@ func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, e
 		panic("database/sql/driver: misuse of duckdb driver: multiple Tx")
 	}
 
-	if opts.ReadOnly {
-		return nil, errors.New("read-only transactions are not supported")
-	}
-
-	switch sql.IsolationLevel(opts.Isolation) {
-	case sql.LevelDefault:
+	// DuckDB isolates transactions by snapshots of the database.
+	switch level := sql.IsolationLevel(opts.Isolation); level {
+	case sql.LevelDefault, sql.LevelSnapshot:
 	default:
-		return nil, errors.New("isolation levels other than default are not supported")
+		return nil, fmt.Errorf("isolation level %s is not supported: DuckDB only supports snapshot isolation", level)
 	}
 
 	if _, err := c.ExecContext(ctx, "BEGIN TRANSACTION", nil); err != nil {
@@ -137,17 +367,27 @@ func (c *conn) BeginTx(ctx context.Context, opts driver.TxOptions) (driver.Tx, e
 	}
 
 	c.tx = true
+	c.readOnlyTx = opts.ReadOnly
 	return &tx{c}, nil
 }
 
 func (c *conn) Close() error {
 	if c.closed {
-		panic("database/sql/driver: misuse of duckdb driver: Close of already closed connection")
+		return getError(errClosedCon, nil)
 	}
 	c.closed = true
+	c.stmts.close()
+	if c.stats != nil {
+		c.stats.openConnections.Add(-1)
+	}
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
 	C.duckdb_disconnect(&c.duckdbCon)
 
+	if c.profilingOutput != "" {
+		return c.removeProfilingOutput()
+	}
 	return nil
 }
 
@@ -155,27 +395,33 @@ func (c *conn) prepareStmt(cmd string) (*stmt, error) {
 	cmdstr := C.CString(cmd)
 	defer C.free(unsafe.Pointer(cmdstr))
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	var s C.duckdb_prepared_statement
 	if state := C.duckdb_prepare(c.duckdbCon, cmdstr, &s); state == C.DuckDBError {
 		dbErr := C.GoString(C.duckdb_prepare_error(s))
 		C.duckdb_destroy_prepare(&s)
-		return nil, errors.New(dbErr)
+		return nil, c.queryError(dbErr)
 	}
 
-	return &stmt{c: c, stmt: &s}, nil
+	return &stmt{c: c, stmt: &s, query: cmd, closing: make(chan struct{})}, nil
 }
 
 func (c *conn) extractStmts(query string) (C.duckdb_extracted_statements, C.idx_t, error) {
 	cquery := C.CString(query)
 	defer C.free(unsafe.Pointer(cquery))
 
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	var stmts C.duckdb_extracted_statements
 	stmtsCount := C.duckdb_extract_statements(c.duckdbCon, cquery, &stmts)
 	if stmtsCount == 0 {
 		err := C.GoString(C.duckdb_extract_statements_error(stmts))
 		C.duckdb_destroy_extracted(&stmts)
 		if err != "" {
-			return nil, 0, errors.New(err)
+			return nil, 0, c.queryError(err)
 		}
 		return nil, 0, errors.New("no statements found")
 	}
@@ -184,12 +430,91 @@ func (c *conn) extractStmts(query string) (C.duckdb_extracted_statements, C.idx_
 }
 
 func (c *conn) prepareExtractedStmt(extractedStmts C.duckdb_extracted_statements, index C.idx_t) (*stmt, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
 	var s C.duckdb_prepared_statement
 	if state := C.duckdb_prepare_extracted_statement(c.duckdbCon, extractedStmts, index, &s); state == C.DuckDBError {
 		dbErr := C.GoString(C.duckdb_prepare_error(s))
 		C.duckdb_destroy_prepare(&s)
-		return nil, errors.New(dbErr)
+		return nil, c.queryError(dbErr)
 	}
 
-	return &stmt{c: c, stmt: &s}, nil
+	return &stmt{c: c, stmt: &s, closing: make(chan struct{})}, nil
+}
+
+// prepareExtractedStmtContext prepares the extracted statement at index, unless the context is done.
+func (c *conn) prepareExtractedStmtContext(ctx context.Context, extractedStmts C.duckdb_extracted_statements,
+	index C.idx_t) (*stmt, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return c.prepareExtractedStmt(extractedStmts, index)
+}
+
+// statementError records the position of the failed statement at index of a multi-statement query
+// in a DuckDB error, see Error.Statement.
+func statementError(err error, index C.idx_t) error {
+	var duckdbErr *Error
+	if errors.As(err, &duckdbErr) {
+		duckdbErr.Statement = int(index) + 1
+	}
+	return err
+}
+
+// multiStatementError records the position of the failed last statement of a query in a DuckDB error,
+// if the query has multiple statements.
+func multiStatementError(err error, size C.idx_t) error {
+	if size == 1 {
+		return err
+	}
+	return statementError(err, size-1)
+}
+
+// explainError attaches the EXPLAIN output of a single-statement query to a DuckDB error,
+// if the Connector was created with WithExplainOnError.
+func (c *conn) explainError(ctx context.Context, query string, args []driver.NamedValue, err error) error {
+	var duckdbErr *Error
+	if c.config == nil || !c.config.explainOnError || ctx.Err() != nil || !errors.As(err, &duckdbErr) {
+		return err
+	}
+
+	// Explaining a multi-statement query would execute all statements except the first one.
+	stmts, size, extractErr := c.extractStmts(query)
+	if extractErr != nil {
+		return err
+	}
+	C.duckdb_destroy_extracted(&stmts)
+
+	duckdbErr.Detail = "query: " + query
+	if size == 1 {
+		if explain, explainErr := c.explain(ctx, query, args); explainErr == nil {
+			duckdbErr.Detail = explain
+		}
+	}
+	return err
+}
+
+// explain returns the EXPLAIN output of a single-statement query.
+func (c *conn) explain(ctx context.Context, query string, args []driver.NamedValue) (string, error) {
+	s, err := c.prepareStmt("EXPLAIN " + query)
+	if err != nil {
+		return "", err
+	}
+	defer s.Close()
+
+	res, err := s.execute(ctx, args, false)
+	if err != nil {
+		return "", err
+	}
+	defer C.duckdb_destroy_result(res)
+
+	// The second column contains the plan, the first column contains its type.
+	var plans []string
+	for i := C.idx_t(0); i < C.duckdb_row_count(res); i++ {
+		plan := C.duckdb_value_varchar(res, 1, i)
+		plans = append(plans, C.GoString(plan))
+		C.duckdb_free(unsafe.Pointer(plan))
+	}
+	return strings.Join(plans, "\n"), nil
 }
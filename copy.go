@@ -0,0 +1,281 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// CopyFormat is the format of the data of CopyFrom and CopyTo.
+type CopyFormat string
+
+const (
+	// CopyCSV is CSV data. It is the default format.
+	CopyCSV CopyFormat = "csv"
+	// CopyJSON is newline-delimited JSON data, which requires the json extension.
+	CopyJSON CopyFormat = "json"
+	// CopyParquet is Parquet data.
+	CopyParquet CopyFormat = "parquet"
+)
+
+// CopyOptions configures CopyFrom.
+type CopyOptions struct {
+	// Table is the name of the table, into which CopyFrom inserts the rows, e.g., my_schema.my_table.
+	Table string
+	// Columns are the columns of the table, into which CopyFrom inserts the columns of the data, in order.
+	// They default to all columns of the table.
+	Columns []string
+	// Format is the format of the data. It defaults to CopyCSV.
+	Format CopyFormat
+	// Options are the named parameters of the function reading the data, e.g., {"delim": ";", "header": true}
+	// for read_csv. The values bind as parameters.
+	Options map[string]any
+}
+
+var copyOptionName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// CopyFrom reads the data of the reader into the table, and returns the number of inserted rows.
+// CSV and JSON data streams through a named pipe, so it is neither buffered in memory nor in a temporary file.
+// DuckDB needs random access to Parquet data, so CopyFrom writes it to a temporary file first.
+// On Windows, CopyFrom also writes CSV and JSON data to a temporary file.
+// Outside of a transaction, CopyFrom inserts the rows within a transaction, so that it either inserts all rows,
+// or none. A failing reader, e.g., a broken network stream, fails CopyFrom with the error of the reader.
+func CopyFrom(ctx context.Context, driverConn driver.Conn, r io.Reader, opts CopyOptions) (int64, error) {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return 0, err
+	}
+	query, err := copyFromSQL(opts)
+	if err != nil {
+		return 0, getError(errCopyFrom, err)
+	}
+
+	var src *copyFile
+	if opts.Format == CopyParquet {
+		src, err = newCopyFile(r)
+	} else {
+		src, err = newCopyPipe(con, r)
+	}
+	if err != nil {
+		return 0, getError(errCopyFrom, err)
+	}
+
+	args := []driver.NamedValue{{Ordinal: 1, Value: src.path}}
+	names := make([]string, 0, len(opts.Options))
+	for name := range opts.Options {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		args = append(args, driver.NamedValue{Ordinal: len(args) + 1, Value: opts.Options[name]})
+	}
+
+	var tx driver.Tx
+	if !con.tx {
+		if tx, err = con.BeginTx(ctx, driver.TxOptions{}); err != nil {
+			_ = src.close(false)
+			return 0, getError(errCopyFrom, err)
+		}
+	}
+
+	var rows int64
+	res, err := con.ExecContext(ctx, query, args)
+	if err == nil {
+		rows, err = res.RowsAffected()
+	}
+	// The error of the reader causes the error of the query. Thus, it takes precedence.
+	if srcErr := src.close(err == nil); srcErr != nil {
+		err = srcErr
+	}
+
+	if err != nil {
+		if tx != nil {
+			_ = tx.Rollback()
+		}
+		return 0, getError(errCopyFrom, err)
+	}
+	if tx != nil {
+		if err = tx.Commit(); err != nil {
+			return 0, getError(errCopyFrom, err)
+		}
+	}
+	return rows, nil
+}
+
+// copyFromSQL returns the INSERT statement of CopyFrom. Its first parameter is the path of the data,
+// and the other parameters are the values of the options, ordered by their name.
+func copyFromSQL(opts CopyOptions) (string, error) {
+	if opts.Table == "" {
+		return "", errors.New("empty table name")
+	}
+
+	var function string
+	switch opts.Format {
+	case "", CopyCSV:
+		function = "read_csv"
+	case CopyJSON:
+		function = "read_json"
+	case CopyParquet:
+		function = "read_parquet"
+	default:
+		return "", fmt.Errorf("unsupported format %q", opts.Format)
+	}
+
+	names := make([]string, 0, len(opts.Options))
+	for name := range opts.Options {
+		if !copyOptionName.MatchString(name) {
+			return "", fmt.Errorf("invalid option name %q", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var query strings.Builder
+	query.WriteString("INSERT INTO " + quoteQualifiedIdentifier(opts.Table))
+	if len(opts.Columns) != 0 {
+		columns := make([]string, len(opts.Columns))
+		for i, column := range opts.Columns {
+			columns[i] = quoteIdentifier(column)
+		}
+		query.WriteString(" (" + strings.Join(columns, ", ") + ")")
+	}
+	query.WriteString(" SELECT * FROM " + function + "(?")
+	for _, name := range names {
+		query.WriteString(", " + name + " = ?")
+	}
+	query.WriteString(")")
+	return query.String(), nil
+}
+
+// CopyToOptions configures CopyTo.
+type CopyToOptions struct {
+	// Format is the format of the data. It defaults to CopyCSV.
+	Format CopyFormat
+	// Options are the options of the COPY statement, e.g., {"delimiter": ";", "header": true} for CSV data,
+	// or {"compression": "zstd"} for Parquet data. The values can be strings, booleans, integers, and floats.
+	Options map[string]any
+}
+
+// CopyTo writes the result of the query to the writer, e.g., an HTTP response, and returns the number of rows.
+// The arguments are bound to the parameters of the query. The data streams through a named pipe, so it is neither
+// buffered in memory nor in a temporary file. On Windows, CopyTo writes the data to a temporary file first.
+// A query failing after writing some data leaves the data written so far in the writer.
+// A failing writer fails CopyTo with the error of the writer.
+func CopyTo(ctx context.Context, driverConn driver.Conn, query string, w io.Writer, opts CopyToOptions,
+	args ...any,
+) (int64, error) {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return 0, err
+	}
+
+	dst, err := newCopyToPipe(con, w)
+	if err != nil {
+		return 0, getError(errCopyTo, err)
+	}
+	copySQL, err := copyToSQL(query, dst.path, opts)
+	if err != nil {
+		_ = dst.close(false)
+		return 0, getError(errCopyTo, err)
+	}
+
+	var rows int64
+	res, err := con.ExecContext(ctx, copySQL, anyArgsToNamedArgs(args))
+	if err == nil {
+		rows, err = res.RowsAffected()
+	}
+	// The error of the writer causes the error of the query. Thus, it takes precedence.
+	if dstErr := dst.close(err == nil); dstErr != nil {
+		err = dstErr
+	}
+	if err != nil {
+		return 0, getError(errCopyTo, err)
+	}
+	return rows, nil
+}
+
+// copyToSQL returns the COPY statement of CopyTo.
+func copyToSQL(query string, path string, opts CopyToOptions) (string, error) {
+	switch opts.Format {
+	case "":
+		opts.Format = CopyCSV
+	case CopyCSV, CopyJSON, CopyParquet:
+	default:
+		return "", fmt.Errorf("unsupported format %q", opts.Format)
+	}
+
+	names := make([]string, 0, len(opts.Options))
+	for name := range opts.Options {
+		if !copyOptionName.MatchString(name) {
+			return "", fmt.Errorf("invalid option name %q", name)
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// The pipe is not a regular file, which DuckDB cannot replace by a temporary file.
+	options := []string{"FORMAT " + string(opts.Format), "USE_TMP_FILE false"}
+	for _, name := range names {
+		var literal string
+		switch v := opts.Options[name].(type) {
+		case string:
+			literal = quoteString(v)
+		case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			literal = fmt.Sprint(v)
+		default:
+			return "", fmt.Errorf("unsupported value %T of option %s", v, name)
+		}
+		options = append(options, name+" "+literal)
+	}
+	return "COPY (" + query + ") TO " + quoteString(path) + " (" + strings.Join(options, ", ") + ")", nil
+}
+
+// copyFile is the file of the data of CopyFrom or CopyTo, i.e., a temporary file, or a named pipe.
+type copyFile struct {
+	dir  string
+	path string
+	// wait waits for the copy between the named pipe, if any, and the reader or writer, and returns its error.
+	// It reports whether the query succeeded.
+	wait func(ok bool) error
+}
+
+// newCopyFile writes the data of the reader to a temporary file.
+func newCopyFile(r io.Reader) (*copyFile, error) {
+	dir, err := os.MkdirTemp("", "duckdb-copy-*")
+	if err != nil {
+		return nil, err
+	}
+	file := &copyFile{dir: dir, path: filepath.Join(dir, "data")}
+
+	f, err := os.Create(file.path)
+	if err == nil {
+		_, err = io.Copy(f, r)
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+	}
+	if err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	return file, nil
+}
+
+// close waits for the copy of the data, if any, and removes the file.
+func (f *copyFile) close(ok bool) error {
+	var err error
+	if f.wait != nil {
+		err = f.wait(ok)
+	}
+	if removeErr := os.RemoveAll(f.dir); err == nil {
+		err = removeErr
+	}
+	return err
+}
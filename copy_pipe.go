@@ -0,0 +1,150 @@
+//go:build !windows
+
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"syscall"
+	"time"
+)
+
+// copyPipeRetryDelay is the delay between attempts to unblock the copy of a named pipe, which DuckDB did not open.
+const copyPipeRetryDelay = 10 * time.Millisecond
+
+// copyReader records the error of reading the data of CopyFrom.
+type copyReader struct {
+	r   io.Reader
+	err error
+}
+
+func (r *copyReader) Read(p []byte) (int, error) {
+	n, err := r.r.Read(p)
+	if err != nil && err != io.EOF {
+		r.err = err
+	}
+	return n, err
+}
+
+// newCopyPipe creates a named pipe, and copies the data of the reader into it while DuckDB reads it.
+func newCopyPipe(con *conn, r io.Reader) (*copyFile, error) {
+	file, err := newNamedPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		// Opening the pipe blocks until DuckDB opens it for reading.
+		f, err := os.OpenFile(file.path, os.O_WRONLY, 0)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		src := &copyReader{r: r}
+		_, err = io.Copy(f, src)
+		if src.err != nil {
+			// Fail the query, instead of letting DuckDB insert the data read so far.
+			C.duckdb_interrupt(con.duckdbCon)
+			err = src.err
+		} else if errors.Is(err, syscall.EPIPE) {
+			// DuckDB stopped reading, e.g., because the query failed, which reports its own error.
+			err = nil
+		}
+		if closeErr := f.Close(); err == nil && !errors.Is(closeErr, syscall.EPIPE) {
+			err = closeErr
+		}
+		errCh <- err
+	}()
+
+	file.wait = func(bool) error {
+		return waitNamedPipe(file.path, os.O_RDONLY, errCh)
+	}
+	return file, nil
+}
+
+// newCopyToPipe creates a named pipe, and copies the data DuckDB writes into it to the writer.
+func newCopyToPipe(con *conn, w io.Writer) (*copyFile, error) {
+	file, err := newNamedPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		// Opening the pipe blocks until DuckDB opens it for writing.
+		f, err := os.OpenFile(file.path, os.O_RDONLY, 0)
+		if err != nil {
+			errCh <- err
+			return
+		}
+		defer f.Close()
+
+		buf := make([]byte, 64*1024)
+		for {
+			n, readErr := f.Read(buf)
+			if n > 0 && err == nil {
+				if _, err = w.Write(buf[:n]); err != nil {
+					// Fail the query. Closing the pipe instead could raise SIGPIPE in a thread of DuckDB.
+					C.duckdb_interrupt(con.duckdbCon)
+				}
+			}
+			if readErr == io.EOF {
+				break
+			} else if readErr != nil {
+				if err == nil {
+					err = readErr
+				}
+				break
+			}
+		}
+		errCh <- err
+	}()
+
+	file.wait = func(bool) error {
+		return waitNamedPipe(file.path, os.O_WRONLY, errCh)
+	}
+	return file, nil
+}
+
+// newNamedPipe creates a named pipe in a temporary directory.
+func newNamedPipe() (*copyFile, error) {
+	dir, err := os.MkdirTemp("", "duckdb-copy-*")
+	if err != nil {
+		return nil, err
+	}
+	path := filepath.Join(dir, "data")
+	if err = syscall.Mkfifo(path, 0o600); err != nil {
+		_ = os.RemoveAll(dir)
+		return nil, err
+	}
+	return &copyFile{dir: dir, path: path}, nil
+}
+
+// waitNamedPipe waits for the copy of the named pipe, and returns its error.
+// If DuckDB did not open the pipe, e.g., because the query failed, then opening and closing it with the flag
+// unblocks the copy waiting to open the other end.
+func waitNamedPipe(path string, flag int, errCh <-chan error) error {
+	for {
+		select {
+		case err := <-errCh:
+			return err
+		default:
+		}
+		if f, err := os.OpenFile(path, flag|syscall.O_NONBLOCK, 0); err == nil {
+			_ = f.Close()
+		}
+		select {
+		case err := <-errCh:
+			return err
+		case <-time.After(copyPipeRetryDelay):
+		}
+	}
+}
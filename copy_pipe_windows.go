@@ -0,0 +1,37 @@
+package duckdb
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// newCopyPipe writes the data of the reader to a temporary file, as DuckDB cannot read named pipes on Windows.
+func newCopyPipe(_ *conn, r io.Reader) (*copyFile, error) {
+	return newCopyFile(r)
+}
+
+// newCopyToPipe returns a temporary file, which it copies to the writer after DuckDB wrote it.
+func newCopyToPipe(_ *conn, w io.Writer) (*copyFile, error) {
+	dir, err := os.MkdirTemp("", "duckdb-copy-*")
+	if err != nil {
+		return nil, err
+	}
+	file := &copyFile{dir: dir, path: filepath.Join(dir, "data")}
+	file.wait = func(ok bool) error {
+		if !ok {
+			return nil
+		}
+		f, err := os.Open(file.path)
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(w, f)
+		return err
+	}
+	return file, nil
+}
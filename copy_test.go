@@ -0,0 +1,225 @@
+package duckdb
+
+import (
+	"bytes"
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/iotest"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCopyFrom(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	con, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	_, err = con.ExecContext(context.Background(), `CREATE TABLE t (i INTEGER, s VARCHAR)`)
+	require.NoError(t, err)
+
+	copyFrom := func(r io.Reader, opts CopyOptions) (int64, error) {
+		var rows int64
+		err := con.Raw(func(driverConn any) error {
+			var err error
+			rows, err = CopyFrom(context.Background(), driverConn.(driver.Conn), r, opts)
+			return err
+		})
+		return rows, err
+	}
+	count := func() int {
+		var n int
+		require.NoError(t, con.QueryRowContext(context.Background(), `SELECT count(*) FROM t`).Scan(&n))
+		return n
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		var data strings.Builder
+		data.WriteString("i,s\n")
+		for i := 0; i < 100000; i++ {
+			fmt.Fprintf(&data, "%d,row %d\n", i, i)
+		}
+		rows, err := copyFrom(strings.NewReader(data.String()), CopyOptions{Table: "t"})
+		require.NoError(t, err)
+		require.Equal(t, int64(100000), rows)
+
+		var s string
+		require.NoError(t, con.QueryRowContext(context.Background(), `SELECT s FROM t WHERE i = 99999`).Scan(&s))
+		require.Equal(t, "row 99999", s)
+	})
+
+	t.Run("options and columns", func(t *testing.T) {
+		_, err := con.ExecContext(context.Background(), `DELETE FROM t`)
+		require.NoError(t, err)
+
+		rows, err := copyFrom(strings.NewReader("x;7\ny;8\n"), CopyOptions{
+			Table:   "main.t",
+			Columns: []string{"s", "i"},
+			Options: map[string]any{"delim": ";", "header": false},
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(2), rows)
+
+		var sum int
+		require.NoError(t, con.QueryRowContext(context.Background(), `SELECT sum(i) FROM t WHERE s IN ('x', 'y')`).
+			Scan(&sum))
+		require.Equal(t, 15, sum)
+	})
+
+	t.Run("parquet", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "data.parquet")
+		_, err := con.ExecContext(context.Background(),
+			`COPY (SELECT range::INTEGER AS i, 'p' AS s FROM range(10)) TO '`+path+`' (FORMAT PARQUET)`)
+		require.NoError(t, err)
+
+		f, err := os.Open(path)
+		require.NoError(t, err)
+		defer f.Close()
+
+		before := count()
+		rows, err := copyFrom(f, CopyOptions{Table: "t", Format: CopyParquet})
+		require.NoError(t, err)
+		require.Equal(t, int64(10), rows)
+		require.Equal(t, before+10, count())
+	})
+
+	t.Run("reader error", func(t *testing.T) {
+		before := count()
+		r := io.MultiReader(strings.NewReader("i,s\n1,a\n2,b\n"), iotest.ErrReader(errors.New("connection reset")))
+		_, err := copyFrom(r, CopyOptions{Table: "t"})
+		testError(t, err, errCopyFrom.Error(), "connection reset")
+		require.Equal(t, before, count())
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		_, err := copyFrom(strings.NewReader("i,s\n1,a\n"), CopyOptions{Table: "missing"})
+		testError(t, err, errCopyFrom.Error(), "missing")
+
+		_, err = copyFrom(strings.NewReader("no,numbers\nx,y\n"), CopyOptions{Table: "t"})
+		testError(t, err, errCopyFrom.Error(), "Conversion Error")
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		_, err := copyFrom(strings.NewReader(""), CopyOptions{})
+		testError(t, err, errCopyFrom.Error(), "empty table name")
+
+		_, err = copyFrom(strings.NewReader(""), CopyOptions{Table: "t", Format: "xml"})
+		testError(t, err, errCopyFrom.Error(), `unsupported format "xml"`)
+
+		_, err = copyFrom(strings.NewReader(""), CopyOptions{Table: "t", Options: map[string]any{"a = 1) --": 1}})
+		testError(t, err, errCopyFrom.Error(), "invalid option name")
+
+		_, err = CopyFrom(context.Background(), nil, strings.NewReader(""), CopyOptions{Table: "t"})
+		testError(t, err, errInvalidCon.Error())
+	})
+}
+
+// failingWriter fails after writing n bytes.
+type failingWriter struct {
+	n int
+}
+
+func (w *failingWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		return 0, errors.New("client disconnected")
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+func TestCopyTo(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	con, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	copyTo := func(query string, w io.Writer, opts CopyToOptions, args ...any) (int64, error) {
+		var rows int64
+		err := con.Raw(func(driverConn any) error {
+			var err error
+			rows, err = CopyTo(context.Background(), driverConn.(driver.Conn), query, w, opts, args...)
+			return err
+		})
+		return rows, err
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		var buf bytes.Buffer
+		rows, err := copyTo(`SELECT range AS i, 'row ' || range AS s FROM range(?)`, &buf, CopyToOptions{
+			Options: map[string]any{"delimiter": ";", "header": true},
+		}, 100000)
+		require.NoError(t, err)
+		require.Equal(t, int64(100000), rows)
+
+		lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+		require.Len(t, lines, 100001)
+		require.Equal(t, "i;s", lines[0])
+		require.Equal(t, "99999;row 99999", lines[100000])
+	})
+
+	t.Run("parquet round trip", func(t *testing.T) {
+		var buf bytes.Buffer
+		rows, err := copyTo(`SELECT range::INTEGER AS i, 'p' || range AS s FROM range(1000)`, &buf,
+			CopyToOptions{Format: CopyParquet, Options: map[string]any{"compression": "zstd"}})
+		require.NoError(t, err)
+		require.Equal(t, int64(1000), rows)
+		require.Equal(t, "PAR1", buf.String()[:4])
+
+		_, err = con.ExecContext(context.Background(), `CREATE TABLE parquet_copy (i INTEGER, s VARCHAR)`)
+		require.NoError(t, err)
+		err = con.Raw(func(driverConn any) error {
+			rows, err = CopyFrom(context.Background(), driverConn.(driver.Conn), &buf,
+				CopyOptions{Table: "parquet_copy", Format: CopyParquet})
+			return err
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(1000), rows)
+
+		var s string
+		require.NoError(t, con.QueryRowContext(context.Background(), `SELECT s FROM parquet_copy WHERE i = 999`).Scan(&s))
+		require.Equal(t, "p999", s)
+	})
+
+	t.Run("writer error", func(t *testing.T) {
+		_, err := copyTo(`SELECT range AS i FROM range(1000000)`, &failingWriter{n: 1000}, CopyToOptions{})
+		testError(t, err, errCopyTo.Error(), "client disconnected")
+
+		var i int
+		require.NoError(t, con.QueryRowContext(context.Background(), `SELECT 1`).Scan(&i))
+	})
+
+	t.Run("query error", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := copyTo(`SELECT * FROM missing`, &buf, CopyToOptions{})
+		testError(t, err, errCopyTo.Error(), "missing")
+		require.Zero(t, buf.Len())
+	})
+
+	t.Run("invalid options", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := copyTo(`SELECT 1`, &buf, CopyToOptions{Format: "xml"})
+		testError(t, err, errCopyTo.Error(), `unsupported format "xml"`)
+
+		_, err = copyTo(`SELECT 1`, &buf, CopyToOptions{Options: map[string]any{"header) --": true}})
+		testError(t, err, errCopyTo.Error(), "invalid option name")
+
+		_, err = copyTo(`SELECT 1`, &buf, CopyToOptions{Options: map[string]any{"header": []int{1}}})
+		testError(t, err, errCopyTo.Error(), "unsupported value []int of option header")
+
+		_, err = CopyTo(context.Background(), nil, `SELECT 1`, &buf, CopyToOptions{})
+		testError(t, err, errInvalidCon.Error())
+	})
+}
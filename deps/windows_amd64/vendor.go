@@ -0,0 +1,3 @@
+// Package windows_amd64 is required to provide support for vendoring modules
+// DO NOT REMOVE
+package windows_amd64
@@ -0,0 +1,2480 @@
+//go:build duckdb_dlopen
+
+// Code generated by gen_dlopen.go from duckdb.h; DO NOT EDIT.
+
+#include <dlfcn.h>
+#include <pthread.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <duckdb.h>
+
+#if defined(__APPLE__)
+#define DUCKDB_GO_DEFAULT_LIBRARY "libduckdb.dylib"
+#else
+#define DUCKDB_GO_DEFAULT_LIBRARY "libduckdb.so"
+#endif
+
+static void *duckdb_go_handle;
+static pthread_mutex_t duckdb_go_mutex = PTHREAD_MUTEX_INITIALIZER;
+
+static const char *duckdb_go_load_locked(const char *path) {
+	duckdb_go_handle = dlopen(path, RTLD_NOW | RTLD_LOCAL);
+	return duckdb_go_handle ? NULL : dlerror();
+}
+
+// duckdb_go_load_library loads the library at the path. It returns 1, if a library is already loaded,
+// and -1 with the error of dlopen, if it fails.
+int duckdb_go_load_library(const char *path, const char **err) {
+	pthread_mutex_lock(&duckdb_go_mutex);
+	int result = 0;
+	if (duckdb_go_handle) {
+		result = 1;
+	} else if ((*err = duckdb_go_load_locked(path))) {
+		result = -1;
+	}
+	pthread_mutex_unlock(&duckdb_go_mutex);
+	return result;
+}
+
+// duckdb_go_symbol returns the function of the loaded library. It loads the library of the DUCKDB_LIBRARY_PATH
+// environment variable, or the default library of the dynamic linker, if none is loaded. As the functions of the
+// C API cannot report an error, it aborts, if loading the library, or looking up the function fails.
+static void *duckdb_go_symbol(const char *name) {
+	pthread_mutex_lock(&duckdb_go_mutex);
+	if (!duckdb_go_handle) {
+		const char *path = getenv("DUCKDB_LIBRARY_PATH");
+		const char *err = duckdb_go_load_locked(path && *path ? path : DUCKDB_GO_DEFAULT_LIBRARY);
+		if (err) {
+			fprintf(stderr, "go-duckdb: could not load the DuckDB library: %s\n", err);
+			abort();
+		}
+	}
+	void *fn = dlsym(duckdb_go_handle, name);
+	pthread_mutex_unlock(&duckdb_go_mutex);
+	if (!fn) {
+		fprintf(stderr, "go-duckdb: the DuckDB library does not provide %s\n", name);
+		abort();
+	}
+	return fn;
+}
+
+typedef duckdb_state (*duckdb_open_fn)(const char *path, duckdb_database *out_database);
+duckdb_state duckdb_open(const char *path, duckdb_database *out_database) {
+	static duckdb_open_fn fn;
+	if (!fn) {
+		fn = (duckdb_open_fn)duckdb_go_symbol("duckdb_open");
+	}
+	return fn(path, out_database);
+}
+
+typedef duckdb_state (*duckdb_open_ext_fn)(const char *path, duckdb_database *out_database, duckdb_config config, char **out_error);
+duckdb_state duckdb_open_ext(const char *path, duckdb_database *out_database, duckdb_config config, char **out_error) {
+	static duckdb_open_ext_fn fn;
+	if (!fn) {
+		fn = (duckdb_open_ext_fn)duckdb_go_symbol("duckdb_open_ext");
+	}
+	return fn(path, out_database, config, out_error);
+}
+
+typedef void (*duckdb_close_fn)(duckdb_database *database);
+void duckdb_close(duckdb_database *database) {
+	static duckdb_close_fn fn;
+	if (!fn) {
+		fn = (duckdb_close_fn)duckdb_go_symbol("duckdb_close");
+	}
+	fn(database);
+}
+
+typedef duckdb_state (*duckdb_connect_fn)(duckdb_database database, duckdb_connection *out_connection);
+duckdb_state duckdb_connect(duckdb_database database, duckdb_connection *out_connection) {
+	static duckdb_connect_fn fn;
+	if (!fn) {
+		fn = (duckdb_connect_fn)duckdb_go_symbol("duckdb_connect");
+	}
+	return fn(database, out_connection);
+}
+
+typedef void (*duckdb_interrupt_fn)(duckdb_connection connection);
+void duckdb_interrupt(duckdb_connection connection) {
+	static duckdb_interrupt_fn fn;
+	if (!fn) {
+		fn = (duckdb_interrupt_fn)duckdb_go_symbol("duckdb_interrupt");
+	}
+	fn(connection);
+}
+
+typedef duckdb_query_progress_type (*duckdb_query_progress_fn)(duckdb_connection connection);
+duckdb_query_progress_type duckdb_query_progress(duckdb_connection connection) {
+	static duckdb_query_progress_fn fn;
+	if (!fn) {
+		fn = (duckdb_query_progress_fn)duckdb_go_symbol("duckdb_query_progress");
+	}
+	return fn(connection);
+}
+
+typedef void (*duckdb_disconnect_fn)(duckdb_connection *connection);
+void duckdb_disconnect(duckdb_connection *connection) {
+	static duckdb_disconnect_fn fn;
+	if (!fn) {
+		fn = (duckdb_disconnect_fn)duckdb_go_symbol("duckdb_disconnect");
+	}
+	fn(connection);
+}
+
+typedef const char * (*duckdb_library_version_fn)();
+const char * duckdb_library_version() {
+	static duckdb_library_version_fn fn;
+	if (!fn) {
+		fn = (duckdb_library_version_fn)duckdb_go_symbol("duckdb_library_version");
+	}
+	return fn();
+}
+
+typedef duckdb_state (*duckdb_create_config_fn)(duckdb_config *out_config);
+duckdb_state duckdb_create_config(duckdb_config *out_config) {
+	static duckdb_create_config_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_config_fn)duckdb_go_symbol("duckdb_create_config");
+	}
+	return fn(out_config);
+}
+
+typedef size_t (*duckdb_config_count_fn)();
+size_t duckdb_config_count() {
+	static duckdb_config_count_fn fn;
+	if (!fn) {
+		fn = (duckdb_config_count_fn)duckdb_go_symbol("duckdb_config_count");
+	}
+	return fn();
+}
+
+typedef duckdb_state (*duckdb_get_config_flag_fn)(size_t index, const char **out_name, const char **out_description);
+duckdb_state duckdb_get_config_flag(size_t index, const char **out_name, const char **out_description) {
+	static duckdb_get_config_flag_fn fn;
+	if (!fn) {
+		fn = (duckdb_get_config_flag_fn)duckdb_go_symbol("duckdb_get_config_flag");
+	}
+	return fn(index, out_name, out_description);
+}
+
+typedef duckdb_state (*duckdb_set_config_fn)(duckdb_config config, const char *name, const char *option);
+duckdb_state duckdb_set_config(duckdb_config config, const char *name, const char *option) {
+	static duckdb_set_config_fn fn;
+	if (!fn) {
+		fn = (duckdb_set_config_fn)duckdb_go_symbol("duckdb_set_config");
+	}
+	return fn(config, name, option);
+}
+
+typedef void (*duckdb_destroy_config_fn)(duckdb_config *config);
+void duckdb_destroy_config(duckdb_config *config) {
+	static duckdb_destroy_config_fn fn;
+	if (!fn) {
+		fn = (duckdb_destroy_config_fn)duckdb_go_symbol("duckdb_destroy_config");
+	}
+	fn(config);
+}
+
+typedef duckdb_state (*duckdb_query_fn)(duckdb_connection connection, const char *query, duckdb_result *out_result);
+duckdb_state duckdb_query(duckdb_connection connection, const char *query, duckdb_result *out_result) {
+	static duckdb_query_fn fn;
+	if (!fn) {
+		fn = (duckdb_query_fn)duckdb_go_symbol("duckdb_query");
+	}
+	return fn(connection, query, out_result);
+}
+
+typedef void (*duckdb_destroy_result_fn)(duckdb_result *result);
+void duckdb_destroy_result(duckdb_result *result) {
+	static duckdb_destroy_result_fn fn;
+	if (!fn) {
+		fn = (duckdb_destroy_result_fn)duckdb_go_symbol("duckdb_destroy_result");
+	}
+	fn(result);
+}
+
+typedef const char * (*duckdb_column_name_fn)(duckdb_result *result, idx_t col);
+const char * duckdb_column_name(duckdb_result *result, idx_t col) {
+	static duckdb_column_name_fn fn;
+	if (!fn) {
+		fn = (duckdb_column_name_fn)duckdb_go_symbol("duckdb_column_name");
+	}
+	return fn(result, col);
+}
+
+typedef duckdb_type (*duckdb_column_type_fn)(duckdb_result *result, idx_t col);
+duckdb_type duckdb_column_type(duckdb_result *result, idx_t col) {
+	static duckdb_column_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_column_type_fn)duckdb_go_symbol("duckdb_column_type");
+	}
+	return fn(result, col);
+}
+
+typedef duckdb_statement_type (*duckdb_result_statement_type_fn)(duckdb_result result);
+duckdb_statement_type duckdb_result_statement_type(duckdb_result result) {
+	static duckdb_result_statement_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_result_statement_type_fn)duckdb_go_symbol("duckdb_result_statement_type");
+	}
+	return fn(result);
+}
+
+typedef duckdb_logical_type (*duckdb_column_logical_type_fn)(duckdb_result *result, idx_t col);
+duckdb_logical_type duckdb_column_logical_type(duckdb_result *result, idx_t col) {
+	static duckdb_column_logical_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_column_logical_type_fn)duckdb_go_symbol("duckdb_column_logical_type");
+	}
+	return fn(result, col);
+}
+
+typedef idx_t (*duckdb_column_count_fn)(duckdb_result *result);
+idx_t duckdb_column_count(duckdb_result *result) {
+	static duckdb_column_count_fn fn;
+	if (!fn) {
+		fn = (duckdb_column_count_fn)duckdb_go_symbol("duckdb_column_count");
+	}
+	return fn(result);
+}
+
+typedef idx_t (*duckdb_row_count_fn)(duckdb_result *result);
+idx_t duckdb_row_count(duckdb_result *result) {
+	static duckdb_row_count_fn fn;
+	if (!fn) {
+		fn = (duckdb_row_count_fn)duckdb_go_symbol("duckdb_row_count");
+	}
+	return fn(result);
+}
+
+typedef idx_t (*duckdb_rows_changed_fn)(duckdb_result *result);
+idx_t duckdb_rows_changed(duckdb_result *result) {
+	static duckdb_rows_changed_fn fn;
+	if (!fn) {
+		fn = (duckdb_rows_changed_fn)duckdb_go_symbol("duckdb_rows_changed");
+	}
+	return fn(result);
+}
+
+typedef void * (*duckdb_column_data_fn)(duckdb_result *result, idx_t col);
+void * duckdb_column_data(duckdb_result *result, idx_t col) {
+	static duckdb_column_data_fn fn;
+	if (!fn) {
+		fn = (duckdb_column_data_fn)duckdb_go_symbol("duckdb_column_data");
+	}
+	return fn(result, col);
+}
+
+typedef bool * (*duckdb_nullmask_data_fn)(duckdb_result *result, idx_t col);
+bool * duckdb_nullmask_data(duckdb_result *result, idx_t col) {
+	static duckdb_nullmask_data_fn fn;
+	if (!fn) {
+		fn = (duckdb_nullmask_data_fn)duckdb_go_symbol("duckdb_nullmask_data");
+	}
+	return fn(result, col);
+}
+
+typedef const char * (*duckdb_result_error_fn)(duckdb_result *result);
+const char * duckdb_result_error(duckdb_result *result) {
+	static duckdb_result_error_fn fn;
+	if (!fn) {
+		fn = (duckdb_result_error_fn)duckdb_go_symbol("duckdb_result_error");
+	}
+	return fn(result);
+}
+
+typedef duckdb_data_chunk (*duckdb_result_get_chunk_fn)(duckdb_result result, idx_t chunk_index);
+duckdb_data_chunk duckdb_result_get_chunk(duckdb_result result, idx_t chunk_index) {
+	static duckdb_result_get_chunk_fn fn;
+	if (!fn) {
+		fn = (duckdb_result_get_chunk_fn)duckdb_go_symbol("duckdb_result_get_chunk");
+	}
+	return fn(result, chunk_index);
+}
+
+typedef bool (*duckdb_result_is_streaming_fn)(duckdb_result result);
+bool duckdb_result_is_streaming(duckdb_result result) {
+	static duckdb_result_is_streaming_fn fn;
+	if (!fn) {
+		fn = (duckdb_result_is_streaming_fn)duckdb_go_symbol("duckdb_result_is_streaming");
+	}
+	return fn(result);
+}
+
+typedef idx_t (*duckdb_result_chunk_count_fn)(duckdb_result result);
+idx_t duckdb_result_chunk_count(duckdb_result result) {
+	static duckdb_result_chunk_count_fn fn;
+	if (!fn) {
+		fn = (duckdb_result_chunk_count_fn)duckdb_go_symbol("duckdb_result_chunk_count");
+	}
+	return fn(result);
+}
+
+typedef duckdb_result_type (*duckdb_result_return_type_fn)(duckdb_result result);
+duckdb_result_type duckdb_result_return_type(duckdb_result result) {
+	static duckdb_result_return_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_result_return_type_fn)duckdb_go_symbol("duckdb_result_return_type");
+	}
+	return fn(result);
+}
+
+typedef bool (*duckdb_value_boolean_fn)(duckdb_result *result, idx_t col, idx_t row);
+bool duckdb_value_boolean(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_boolean_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_boolean_fn)duckdb_go_symbol("duckdb_value_boolean");
+	}
+	return fn(result, col, row);
+}
+
+typedef int8_t (*duckdb_value_int8_fn)(duckdb_result *result, idx_t col, idx_t row);
+int8_t duckdb_value_int8(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_int8_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_int8_fn)duckdb_go_symbol("duckdb_value_int8");
+	}
+	return fn(result, col, row);
+}
+
+typedef int16_t (*duckdb_value_int16_fn)(duckdb_result *result, idx_t col, idx_t row);
+int16_t duckdb_value_int16(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_int16_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_int16_fn)duckdb_go_symbol("duckdb_value_int16");
+	}
+	return fn(result, col, row);
+}
+
+typedef int32_t (*duckdb_value_int32_fn)(duckdb_result *result, idx_t col, idx_t row);
+int32_t duckdb_value_int32(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_int32_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_int32_fn)duckdb_go_symbol("duckdb_value_int32");
+	}
+	return fn(result, col, row);
+}
+
+typedef int64_t (*duckdb_value_int64_fn)(duckdb_result *result, idx_t col, idx_t row);
+int64_t duckdb_value_int64(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_int64_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_int64_fn)duckdb_go_symbol("duckdb_value_int64");
+	}
+	return fn(result, col, row);
+}
+
+typedef duckdb_hugeint (*duckdb_value_hugeint_fn)(duckdb_result *result, idx_t col, idx_t row);
+duckdb_hugeint duckdb_value_hugeint(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_hugeint_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_hugeint_fn)duckdb_go_symbol("duckdb_value_hugeint");
+	}
+	return fn(result, col, row);
+}
+
+typedef duckdb_uhugeint (*duckdb_value_uhugeint_fn)(duckdb_result *result, idx_t col, idx_t row);
+duckdb_uhugeint duckdb_value_uhugeint(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_uhugeint_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_uhugeint_fn)duckdb_go_symbol("duckdb_value_uhugeint");
+	}
+	return fn(result, col, row);
+}
+
+typedef duckdb_decimal (*duckdb_value_decimal_fn)(duckdb_result *result, idx_t col, idx_t row);
+duckdb_decimal duckdb_value_decimal(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_decimal_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_decimal_fn)duckdb_go_symbol("duckdb_value_decimal");
+	}
+	return fn(result, col, row);
+}
+
+typedef uint8_t (*duckdb_value_uint8_fn)(duckdb_result *result, idx_t col, idx_t row);
+uint8_t duckdb_value_uint8(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_uint8_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_uint8_fn)duckdb_go_symbol("duckdb_value_uint8");
+	}
+	return fn(result, col, row);
+}
+
+typedef uint16_t (*duckdb_value_uint16_fn)(duckdb_result *result, idx_t col, idx_t row);
+uint16_t duckdb_value_uint16(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_uint16_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_uint16_fn)duckdb_go_symbol("duckdb_value_uint16");
+	}
+	return fn(result, col, row);
+}
+
+typedef uint32_t (*duckdb_value_uint32_fn)(duckdb_result *result, idx_t col, idx_t row);
+uint32_t duckdb_value_uint32(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_uint32_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_uint32_fn)duckdb_go_symbol("duckdb_value_uint32");
+	}
+	return fn(result, col, row);
+}
+
+typedef uint64_t (*duckdb_value_uint64_fn)(duckdb_result *result, idx_t col, idx_t row);
+uint64_t duckdb_value_uint64(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_uint64_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_uint64_fn)duckdb_go_symbol("duckdb_value_uint64");
+	}
+	return fn(result, col, row);
+}
+
+typedef float (*duckdb_value_float_fn)(duckdb_result *result, idx_t col, idx_t row);
+float duckdb_value_float(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_float_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_float_fn)duckdb_go_symbol("duckdb_value_float");
+	}
+	return fn(result, col, row);
+}
+
+typedef double (*duckdb_value_double_fn)(duckdb_result *result, idx_t col, idx_t row);
+double duckdb_value_double(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_double_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_double_fn)duckdb_go_symbol("duckdb_value_double");
+	}
+	return fn(result, col, row);
+}
+
+typedef duckdb_date (*duckdb_value_date_fn)(duckdb_result *result, idx_t col, idx_t row);
+duckdb_date duckdb_value_date(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_date_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_date_fn)duckdb_go_symbol("duckdb_value_date");
+	}
+	return fn(result, col, row);
+}
+
+typedef duckdb_time (*duckdb_value_time_fn)(duckdb_result *result, idx_t col, idx_t row);
+duckdb_time duckdb_value_time(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_time_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_time_fn)duckdb_go_symbol("duckdb_value_time");
+	}
+	return fn(result, col, row);
+}
+
+typedef duckdb_timestamp (*duckdb_value_timestamp_fn)(duckdb_result *result, idx_t col, idx_t row);
+duckdb_timestamp duckdb_value_timestamp(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_timestamp_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_timestamp_fn)duckdb_go_symbol("duckdb_value_timestamp");
+	}
+	return fn(result, col, row);
+}
+
+typedef duckdb_interval (*duckdb_value_interval_fn)(duckdb_result *result, idx_t col, idx_t row);
+duckdb_interval duckdb_value_interval(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_interval_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_interval_fn)duckdb_go_symbol("duckdb_value_interval");
+	}
+	return fn(result, col, row);
+}
+
+typedef char * (*duckdb_value_varchar_fn)(duckdb_result *result, idx_t col, idx_t row);
+char * duckdb_value_varchar(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_varchar_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_varchar_fn)duckdb_go_symbol("duckdb_value_varchar");
+	}
+	return fn(result, col, row);
+}
+
+typedef duckdb_string (*duckdb_value_string_fn)(duckdb_result *result, idx_t col, idx_t row);
+duckdb_string duckdb_value_string(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_string_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_string_fn)duckdb_go_symbol("duckdb_value_string");
+	}
+	return fn(result, col, row);
+}
+
+typedef char * (*duckdb_value_varchar_internal_fn)(duckdb_result *result, idx_t col, idx_t row);
+char * duckdb_value_varchar_internal(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_varchar_internal_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_varchar_internal_fn)duckdb_go_symbol("duckdb_value_varchar_internal");
+	}
+	return fn(result, col, row);
+}
+
+typedef duckdb_string (*duckdb_value_string_internal_fn)(duckdb_result *result, idx_t col, idx_t row);
+duckdb_string duckdb_value_string_internal(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_string_internal_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_string_internal_fn)duckdb_go_symbol("duckdb_value_string_internal");
+	}
+	return fn(result, col, row);
+}
+
+typedef duckdb_blob (*duckdb_value_blob_fn)(duckdb_result *result, idx_t col, idx_t row);
+duckdb_blob duckdb_value_blob(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_blob_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_blob_fn)duckdb_go_symbol("duckdb_value_blob");
+	}
+	return fn(result, col, row);
+}
+
+typedef bool (*duckdb_value_is_null_fn)(duckdb_result *result, idx_t col, idx_t row);
+bool duckdb_value_is_null(duckdb_result *result, idx_t col, idx_t row) {
+	static duckdb_value_is_null_fn fn;
+	if (!fn) {
+		fn = (duckdb_value_is_null_fn)duckdb_go_symbol("duckdb_value_is_null");
+	}
+	return fn(result, col, row);
+}
+
+typedef void * (*duckdb_malloc_fn)(size_t size);
+void * duckdb_malloc(size_t size) {
+	static duckdb_malloc_fn fn;
+	if (!fn) {
+		fn = (duckdb_malloc_fn)duckdb_go_symbol("duckdb_malloc");
+	}
+	return fn(size);
+}
+
+typedef void (*duckdb_free_fn)(void *ptr);
+void duckdb_free(void *ptr) {
+	static duckdb_free_fn fn;
+	if (!fn) {
+		fn = (duckdb_free_fn)duckdb_go_symbol("duckdb_free");
+	}
+	fn(ptr);
+}
+
+typedef idx_t (*duckdb_vector_size_fn)();
+idx_t duckdb_vector_size() {
+	static duckdb_vector_size_fn fn;
+	if (!fn) {
+		fn = (duckdb_vector_size_fn)duckdb_go_symbol("duckdb_vector_size");
+	}
+	return fn();
+}
+
+typedef bool (*duckdb_string_is_inlined_fn)(duckdb_string_t string);
+bool duckdb_string_is_inlined(duckdb_string_t string) {
+	static duckdb_string_is_inlined_fn fn;
+	if (!fn) {
+		fn = (duckdb_string_is_inlined_fn)duckdb_go_symbol("duckdb_string_is_inlined");
+	}
+	return fn(string);
+}
+
+typedef duckdb_date_struct (*duckdb_from_date_fn)(duckdb_date date);
+duckdb_date_struct duckdb_from_date(duckdb_date date) {
+	static duckdb_from_date_fn fn;
+	if (!fn) {
+		fn = (duckdb_from_date_fn)duckdb_go_symbol("duckdb_from_date");
+	}
+	return fn(date);
+}
+
+typedef duckdb_date (*duckdb_to_date_fn)(duckdb_date_struct date);
+duckdb_date duckdb_to_date(duckdb_date_struct date) {
+	static duckdb_to_date_fn fn;
+	if (!fn) {
+		fn = (duckdb_to_date_fn)duckdb_go_symbol("duckdb_to_date");
+	}
+	return fn(date);
+}
+
+typedef bool (*duckdb_is_finite_date_fn)(duckdb_date date);
+bool duckdb_is_finite_date(duckdb_date date) {
+	static duckdb_is_finite_date_fn fn;
+	if (!fn) {
+		fn = (duckdb_is_finite_date_fn)duckdb_go_symbol("duckdb_is_finite_date");
+	}
+	return fn(date);
+}
+
+typedef duckdb_time_struct (*duckdb_from_time_fn)(duckdb_time time);
+duckdb_time_struct duckdb_from_time(duckdb_time time) {
+	static duckdb_from_time_fn fn;
+	if (!fn) {
+		fn = (duckdb_from_time_fn)duckdb_go_symbol("duckdb_from_time");
+	}
+	return fn(time);
+}
+
+typedef duckdb_time_tz (*duckdb_create_time_tz_fn)(int64_t micros, int32_t offset);
+duckdb_time_tz duckdb_create_time_tz(int64_t micros, int32_t offset) {
+	static duckdb_create_time_tz_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_time_tz_fn)duckdb_go_symbol("duckdb_create_time_tz");
+	}
+	return fn(micros, offset);
+}
+
+typedef duckdb_time_tz_struct (*duckdb_from_time_tz_fn)(duckdb_time_tz micros);
+duckdb_time_tz_struct duckdb_from_time_tz(duckdb_time_tz micros) {
+	static duckdb_from_time_tz_fn fn;
+	if (!fn) {
+		fn = (duckdb_from_time_tz_fn)duckdb_go_symbol("duckdb_from_time_tz");
+	}
+	return fn(micros);
+}
+
+typedef duckdb_time (*duckdb_to_time_fn)(duckdb_time_struct time);
+duckdb_time duckdb_to_time(duckdb_time_struct time) {
+	static duckdb_to_time_fn fn;
+	if (!fn) {
+		fn = (duckdb_to_time_fn)duckdb_go_symbol("duckdb_to_time");
+	}
+	return fn(time);
+}
+
+typedef duckdb_timestamp_struct (*duckdb_from_timestamp_fn)(duckdb_timestamp ts);
+duckdb_timestamp_struct duckdb_from_timestamp(duckdb_timestamp ts) {
+	static duckdb_from_timestamp_fn fn;
+	if (!fn) {
+		fn = (duckdb_from_timestamp_fn)duckdb_go_symbol("duckdb_from_timestamp");
+	}
+	return fn(ts);
+}
+
+typedef duckdb_timestamp (*duckdb_to_timestamp_fn)(duckdb_timestamp_struct ts);
+duckdb_timestamp duckdb_to_timestamp(duckdb_timestamp_struct ts) {
+	static duckdb_to_timestamp_fn fn;
+	if (!fn) {
+		fn = (duckdb_to_timestamp_fn)duckdb_go_symbol("duckdb_to_timestamp");
+	}
+	return fn(ts);
+}
+
+typedef bool (*duckdb_is_finite_timestamp_fn)(duckdb_timestamp ts);
+bool duckdb_is_finite_timestamp(duckdb_timestamp ts) {
+	static duckdb_is_finite_timestamp_fn fn;
+	if (!fn) {
+		fn = (duckdb_is_finite_timestamp_fn)duckdb_go_symbol("duckdb_is_finite_timestamp");
+	}
+	return fn(ts);
+}
+
+typedef double (*duckdb_hugeint_to_double_fn)(duckdb_hugeint val);
+double duckdb_hugeint_to_double(duckdb_hugeint val) {
+	static duckdb_hugeint_to_double_fn fn;
+	if (!fn) {
+		fn = (duckdb_hugeint_to_double_fn)duckdb_go_symbol("duckdb_hugeint_to_double");
+	}
+	return fn(val);
+}
+
+typedef duckdb_hugeint (*duckdb_double_to_hugeint_fn)(double val);
+duckdb_hugeint duckdb_double_to_hugeint(double val) {
+	static duckdb_double_to_hugeint_fn fn;
+	if (!fn) {
+		fn = (duckdb_double_to_hugeint_fn)duckdb_go_symbol("duckdb_double_to_hugeint");
+	}
+	return fn(val);
+}
+
+typedef double (*duckdb_uhugeint_to_double_fn)(duckdb_uhugeint val);
+double duckdb_uhugeint_to_double(duckdb_uhugeint val) {
+	static duckdb_uhugeint_to_double_fn fn;
+	if (!fn) {
+		fn = (duckdb_uhugeint_to_double_fn)duckdb_go_symbol("duckdb_uhugeint_to_double");
+	}
+	return fn(val);
+}
+
+typedef duckdb_uhugeint (*duckdb_double_to_uhugeint_fn)(double val);
+duckdb_uhugeint duckdb_double_to_uhugeint(double val) {
+	static duckdb_double_to_uhugeint_fn fn;
+	if (!fn) {
+		fn = (duckdb_double_to_uhugeint_fn)duckdb_go_symbol("duckdb_double_to_uhugeint");
+	}
+	return fn(val);
+}
+
+typedef duckdb_decimal (*duckdb_double_to_decimal_fn)(double val, uint8_t width, uint8_t scale);
+duckdb_decimal duckdb_double_to_decimal(double val, uint8_t width, uint8_t scale) {
+	static duckdb_double_to_decimal_fn fn;
+	if (!fn) {
+		fn = (duckdb_double_to_decimal_fn)duckdb_go_symbol("duckdb_double_to_decimal");
+	}
+	return fn(val, width, scale);
+}
+
+typedef double (*duckdb_decimal_to_double_fn)(duckdb_decimal val);
+double duckdb_decimal_to_double(duckdb_decimal val) {
+	static duckdb_decimal_to_double_fn fn;
+	if (!fn) {
+		fn = (duckdb_decimal_to_double_fn)duckdb_go_symbol("duckdb_decimal_to_double");
+	}
+	return fn(val);
+}
+
+typedef duckdb_state (*duckdb_prepare_fn)(duckdb_connection connection, const char *query, duckdb_prepared_statement *out_prepared_statement);
+duckdb_state duckdb_prepare(duckdb_connection connection, const char *query, duckdb_prepared_statement *out_prepared_statement) {
+	static duckdb_prepare_fn fn;
+	if (!fn) {
+		fn = (duckdb_prepare_fn)duckdb_go_symbol("duckdb_prepare");
+	}
+	return fn(connection, query, out_prepared_statement);
+}
+
+typedef void (*duckdb_destroy_prepare_fn)(duckdb_prepared_statement *prepared_statement);
+void duckdb_destroy_prepare(duckdb_prepared_statement *prepared_statement) {
+	static duckdb_destroy_prepare_fn fn;
+	if (!fn) {
+		fn = (duckdb_destroy_prepare_fn)duckdb_go_symbol("duckdb_destroy_prepare");
+	}
+	fn(prepared_statement);
+}
+
+typedef const char * (*duckdb_prepare_error_fn)(duckdb_prepared_statement prepared_statement);
+const char * duckdb_prepare_error(duckdb_prepared_statement prepared_statement) {
+	static duckdb_prepare_error_fn fn;
+	if (!fn) {
+		fn = (duckdb_prepare_error_fn)duckdb_go_symbol("duckdb_prepare_error");
+	}
+	return fn(prepared_statement);
+}
+
+typedef idx_t (*duckdb_nparams_fn)(duckdb_prepared_statement prepared_statement);
+idx_t duckdb_nparams(duckdb_prepared_statement prepared_statement) {
+	static duckdb_nparams_fn fn;
+	if (!fn) {
+		fn = (duckdb_nparams_fn)duckdb_go_symbol("duckdb_nparams");
+	}
+	return fn(prepared_statement);
+}
+
+typedef const char * (*duckdb_parameter_name_fn)(duckdb_prepared_statement prepared_statement, idx_t index);
+const char * duckdb_parameter_name(duckdb_prepared_statement prepared_statement, idx_t index) {
+	static duckdb_parameter_name_fn fn;
+	if (!fn) {
+		fn = (duckdb_parameter_name_fn)duckdb_go_symbol("duckdb_parameter_name");
+	}
+	return fn(prepared_statement, index);
+}
+
+typedef duckdb_type (*duckdb_param_type_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx);
+duckdb_type duckdb_param_type(duckdb_prepared_statement prepared_statement, idx_t param_idx) {
+	static duckdb_param_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_param_type_fn)duckdb_go_symbol("duckdb_param_type");
+	}
+	return fn(prepared_statement, param_idx);
+}
+
+typedef duckdb_state (*duckdb_clear_bindings_fn)(duckdb_prepared_statement prepared_statement);
+duckdb_state duckdb_clear_bindings(duckdb_prepared_statement prepared_statement) {
+	static duckdb_clear_bindings_fn fn;
+	if (!fn) {
+		fn = (duckdb_clear_bindings_fn)duckdb_go_symbol("duckdb_clear_bindings");
+	}
+	return fn(prepared_statement);
+}
+
+typedef duckdb_statement_type (*duckdb_prepared_statement_type_fn)(duckdb_prepared_statement statement);
+duckdb_statement_type duckdb_prepared_statement_type(duckdb_prepared_statement statement) {
+	static duckdb_prepared_statement_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_prepared_statement_type_fn)duckdb_go_symbol("duckdb_prepared_statement_type");
+	}
+	return fn(statement);
+}
+
+typedef duckdb_state (*duckdb_bind_value_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, duckdb_value val);
+duckdb_state duckdb_bind_value(duckdb_prepared_statement prepared_statement, idx_t param_idx, duckdb_value val) {
+	static duckdb_bind_value_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_value_fn)duckdb_go_symbol("duckdb_bind_value");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_parameter_index_fn)(duckdb_prepared_statement prepared_statement, idx_t *param_idx_out, const char *name);
+duckdb_state duckdb_bind_parameter_index(duckdb_prepared_statement prepared_statement, idx_t *param_idx_out, const char *name) {
+	static duckdb_bind_parameter_index_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_parameter_index_fn)duckdb_go_symbol("duckdb_bind_parameter_index");
+	}
+	return fn(prepared_statement, param_idx_out, name);
+}
+
+typedef duckdb_state (*duckdb_bind_boolean_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, bool val);
+duckdb_state duckdb_bind_boolean(duckdb_prepared_statement prepared_statement, idx_t param_idx, bool val) {
+	static duckdb_bind_boolean_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_boolean_fn)duckdb_go_symbol("duckdb_bind_boolean");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_int8_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, int8_t val);
+duckdb_state duckdb_bind_int8(duckdb_prepared_statement prepared_statement, idx_t param_idx, int8_t val) {
+	static duckdb_bind_int8_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_int8_fn)duckdb_go_symbol("duckdb_bind_int8");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_int16_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, int16_t val);
+duckdb_state duckdb_bind_int16(duckdb_prepared_statement prepared_statement, idx_t param_idx, int16_t val) {
+	static duckdb_bind_int16_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_int16_fn)duckdb_go_symbol("duckdb_bind_int16");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_int32_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, int32_t val);
+duckdb_state duckdb_bind_int32(duckdb_prepared_statement prepared_statement, idx_t param_idx, int32_t val) {
+	static duckdb_bind_int32_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_int32_fn)duckdb_go_symbol("duckdb_bind_int32");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_int64_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, int64_t val);
+duckdb_state duckdb_bind_int64(duckdb_prepared_statement prepared_statement, idx_t param_idx, int64_t val) {
+	static duckdb_bind_int64_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_int64_fn)duckdb_go_symbol("duckdb_bind_int64");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_hugeint_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, duckdb_hugeint val);
+duckdb_state duckdb_bind_hugeint(duckdb_prepared_statement prepared_statement, idx_t param_idx, duckdb_hugeint val) {
+	static duckdb_bind_hugeint_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_hugeint_fn)duckdb_go_symbol("duckdb_bind_hugeint");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_uhugeint_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, duckdb_uhugeint val);
+duckdb_state duckdb_bind_uhugeint(duckdb_prepared_statement prepared_statement, idx_t param_idx, duckdb_uhugeint val) {
+	static duckdb_bind_uhugeint_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_uhugeint_fn)duckdb_go_symbol("duckdb_bind_uhugeint");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_decimal_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, duckdb_decimal val);
+duckdb_state duckdb_bind_decimal(duckdb_prepared_statement prepared_statement, idx_t param_idx, duckdb_decimal val) {
+	static duckdb_bind_decimal_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_decimal_fn)duckdb_go_symbol("duckdb_bind_decimal");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_uint8_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, uint8_t val);
+duckdb_state duckdb_bind_uint8(duckdb_prepared_statement prepared_statement, idx_t param_idx, uint8_t val) {
+	static duckdb_bind_uint8_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_uint8_fn)duckdb_go_symbol("duckdb_bind_uint8");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_uint16_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, uint16_t val);
+duckdb_state duckdb_bind_uint16(duckdb_prepared_statement prepared_statement, idx_t param_idx, uint16_t val) {
+	static duckdb_bind_uint16_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_uint16_fn)duckdb_go_symbol("duckdb_bind_uint16");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_uint32_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, uint32_t val);
+duckdb_state duckdb_bind_uint32(duckdb_prepared_statement prepared_statement, idx_t param_idx, uint32_t val) {
+	static duckdb_bind_uint32_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_uint32_fn)duckdb_go_symbol("duckdb_bind_uint32");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_uint64_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, uint64_t val);
+duckdb_state duckdb_bind_uint64(duckdb_prepared_statement prepared_statement, idx_t param_idx, uint64_t val) {
+	static duckdb_bind_uint64_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_uint64_fn)duckdb_go_symbol("duckdb_bind_uint64");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_float_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, float val);
+duckdb_state duckdb_bind_float(duckdb_prepared_statement prepared_statement, idx_t param_idx, float val) {
+	static duckdb_bind_float_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_float_fn)duckdb_go_symbol("duckdb_bind_float");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_double_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, double val);
+duckdb_state duckdb_bind_double(duckdb_prepared_statement prepared_statement, idx_t param_idx, double val) {
+	static duckdb_bind_double_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_double_fn)duckdb_go_symbol("duckdb_bind_double");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_date_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, duckdb_date val);
+duckdb_state duckdb_bind_date(duckdb_prepared_statement prepared_statement, idx_t param_idx, duckdb_date val) {
+	static duckdb_bind_date_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_date_fn)duckdb_go_symbol("duckdb_bind_date");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_time_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, duckdb_time val);
+duckdb_state duckdb_bind_time(duckdb_prepared_statement prepared_statement, idx_t param_idx, duckdb_time val) {
+	static duckdb_bind_time_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_time_fn)duckdb_go_symbol("duckdb_bind_time");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_timestamp_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, duckdb_timestamp val);
+duckdb_state duckdb_bind_timestamp(duckdb_prepared_statement prepared_statement, idx_t param_idx, duckdb_timestamp val) {
+	static duckdb_bind_timestamp_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_timestamp_fn)duckdb_go_symbol("duckdb_bind_timestamp");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_interval_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, duckdb_interval val);
+duckdb_state duckdb_bind_interval(duckdb_prepared_statement prepared_statement, idx_t param_idx, duckdb_interval val) {
+	static duckdb_bind_interval_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_interval_fn)duckdb_go_symbol("duckdb_bind_interval");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_varchar_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, const char *val);
+duckdb_state duckdb_bind_varchar(duckdb_prepared_statement prepared_statement, idx_t param_idx, const char *val) {
+	static duckdb_bind_varchar_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_varchar_fn)duckdb_go_symbol("duckdb_bind_varchar");
+	}
+	return fn(prepared_statement, param_idx, val);
+}
+
+typedef duckdb_state (*duckdb_bind_varchar_length_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, const char *val, idx_t length);
+duckdb_state duckdb_bind_varchar_length(duckdb_prepared_statement prepared_statement, idx_t param_idx, const char *val, idx_t length) {
+	static duckdb_bind_varchar_length_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_varchar_length_fn)duckdb_go_symbol("duckdb_bind_varchar_length");
+	}
+	return fn(prepared_statement, param_idx, val, length);
+}
+
+typedef duckdb_state (*duckdb_bind_blob_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx, const void *data, idx_t length);
+duckdb_state duckdb_bind_blob(duckdb_prepared_statement prepared_statement, idx_t param_idx, const void *data, idx_t length) {
+	static duckdb_bind_blob_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_blob_fn)duckdb_go_symbol("duckdb_bind_blob");
+	}
+	return fn(prepared_statement, param_idx, data, length);
+}
+
+typedef duckdb_state (*duckdb_bind_null_fn)(duckdb_prepared_statement prepared_statement, idx_t param_idx);
+duckdb_state duckdb_bind_null(duckdb_prepared_statement prepared_statement, idx_t param_idx) {
+	static duckdb_bind_null_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_null_fn)duckdb_go_symbol("duckdb_bind_null");
+	}
+	return fn(prepared_statement, param_idx);
+}
+
+typedef duckdb_state (*duckdb_execute_prepared_fn)(duckdb_prepared_statement prepared_statement, duckdb_result *out_result);
+duckdb_state duckdb_execute_prepared(duckdb_prepared_statement prepared_statement, duckdb_result *out_result) {
+	static duckdb_execute_prepared_fn fn;
+	if (!fn) {
+		fn = (duckdb_execute_prepared_fn)duckdb_go_symbol("duckdb_execute_prepared");
+	}
+	return fn(prepared_statement, out_result);
+}
+
+typedef duckdb_state (*duckdb_execute_prepared_streaming_fn)(duckdb_prepared_statement prepared_statement, duckdb_result *out_result);
+duckdb_state duckdb_execute_prepared_streaming(duckdb_prepared_statement prepared_statement, duckdb_result *out_result) {
+	static duckdb_execute_prepared_streaming_fn fn;
+	if (!fn) {
+		fn = (duckdb_execute_prepared_streaming_fn)duckdb_go_symbol("duckdb_execute_prepared_streaming");
+	}
+	return fn(prepared_statement, out_result);
+}
+
+typedef idx_t (*duckdb_extract_statements_fn)(duckdb_connection connection, const char *query, duckdb_extracted_statements *out_extracted_statements);
+idx_t duckdb_extract_statements(duckdb_connection connection, const char *query, duckdb_extracted_statements *out_extracted_statements) {
+	static duckdb_extract_statements_fn fn;
+	if (!fn) {
+		fn = (duckdb_extract_statements_fn)duckdb_go_symbol("duckdb_extract_statements");
+	}
+	return fn(connection, query, out_extracted_statements);
+}
+
+typedef duckdb_state (*duckdb_prepare_extracted_statement_fn)(duckdb_connection connection, duckdb_extracted_statements extracted_statements, idx_t index, duckdb_prepared_statement *out_prepared_statement);
+duckdb_state duckdb_prepare_extracted_statement(duckdb_connection connection, duckdb_extracted_statements extracted_statements, idx_t index, duckdb_prepared_statement *out_prepared_statement) {
+	static duckdb_prepare_extracted_statement_fn fn;
+	if (!fn) {
+		fn = (duckdb_prepare_extracted_statement_fn)duckdb_go_symbol("duckdb_prepare_extracted_statement");
+	}
+	return fn(connection, extracted_statements, index, out_prepared_statement);
+}
+
+typedef const char * (*duckdb_extract_statements_error_fn)(duckdb_extracted_statements extracted_statements);
+const char * duckdb_extract_statements_error(duckdb_extracted_statements extracted_statements) {
+	static duckdb_extract_statements_error_fn fn;
+	if (!fn) {
+		fn = (duckdb_extract_statements_error_fn)duckdb_go_symbol("duckdb_extract_statements_error");
+	}
+	return fn(extracted_statements);
+}
+
+typedef void (*duckdb_destroy_extracted_fn)(duckdb_extracted_statements *extracted_statements);
+void duckdb_destroy_extracted(duckdb_extracted_statements *extracted_statements) {
+	static duckdb_destroy_extracted_fn fn;
+	if (!fn) {
+		fn = (duckdb_destroy_extracted_fn)duckdb_go_symbol("duckdb_destroy_extracted");
+	}
+	fn(extracted_statements);
+}
+
+typedef duckdb_state (*duckdb_pending_prepared_fn)(duckdb_prepared_statement prepared_statement, duckdb_pending_result *out_result);
+duckdb_state duckdb_pending_prepared(duckdb_prepared_statement prepared_statement, duckdb_pending_result *out_result) {
+	static duckdb_pending_prepared_fn fn;
+	if (!fn) {
+		fn = (duckdb_pending_prepared_fn)duckdb_go_symbol("duckdb_pending_prepared");
+	}
+	return fn(prepared_statement, out_result);
+}
+
+typedef duckdb_state (*duckdb_pending_prepared_streaming_fn)(duckdb_prepared_statement prepared_statement, duckdb_pending_result *out_result);
+duckdb_state duckdb_pending_prepared_streaming(duckdb_prepared_statement prepared_statement, duckdb_pending_result *out_result) {
+	static duckdb_pending_prepared_streaming_fn fn;
+	if (!fn) {
+		fn = (duckdb_pending_prepared_streaming_fn)duckdb_go_symbol("duckdb_pending_prepared_streaming");
+	}
+	return fn(prepared_statement, out_result);
+}
+
+typedef void (*duckdb_destroy_pending_fn)(duckdb_pending_result *pending_result);
+void duckdb_destroy_pending(duckdb_pending_result *pending_result) {
+	static duckdb_destroy_pending_fn fn;
+	if (!fn) {
+		fn = (duckdb_destroy_pending_fn)duckdb_go_symbol("duckdb_destroy_pending");
+	}
+	fn(pending_result);
+}
+
+typedef const char * (*duckdb_pending_error_fn)(duckdb_pending_result pending_result);
+const char * duckdb_pending_error(duckdb_pending_result pending_result) {
+	static duckdb_pending_error_fn fn;
+	if (!fn) {
+		fn = (duckdb_pending_error_fn)duckdb_go_symbol("duckdb_pending_error");
+	}
+	return fn(pending_result);
+}
+
+typedef duckdb_pending_state (*duckdb_pending_execute_task_fn)(duckdb_pending_result pending_result);
+duckdb_pending_state duckdb_pending_execute_task(duckdb_pending_result pending_result) {
+	static duckdb_pending_execute_task_fn fn;
+	if (!fn) {
+		fn = (duckdb_pending_execute_task_fn)duckdb_go_symbol("duckdb_pending_execute_task");
+	}
+	return fn(pending_result);
+}
+
+typedef duckdb_pending_state (*duckdb_pending_execute_check_state_fn)(duckdb_pending_result pending_result);
+duckdb_pending_state duckdb_pending_execute_check_state(duckdb_pending_result pending_result) {
+	static duckdb_pending_execute_check_state_fn fn;
+	if (!fn) {
+		fn = (duckdb_pending_execute_check_state_fn)duckdb_go_symbol("duckdb_pending_execute_check_state");
+	}
+	return fn(pending_result);
+}
+
+typedef duckdb_state (*duckdb_execute_pending_fn)(duckdb_pending_result pending_result, duckdb_result *out_result);
+duckdb_state duckdb_execute_pending(duckdb_pending_result pending_result, duckdb_result *out_result) {
+	static duckdb_execute_pending_fn fn;
+	if (!fn) {
+		fn = (duckdb_execute_pending_fn)duckdb_go_symbol("duckdb_execute_pending");
+	}
+	return fn(pending_result, out_result);
+}
+
+typedef bool (*duckdb_pending_execution_is_finished_fn)(duckdb_pending_state pending_state);
+bool duckdb_pending_execution_is_finished(duckdb_pending_state pending_state) {
+	static duckdb_pending_execution_is_finished_fn fn;
+	if (!fn) {
+		fn = (duckdb_pending_execution_is_finished_fn)duckdb_go_symbol("duckdb_pending_execution_is_finished");
+	}
+	return fn(pending_state);
+}
+
+typedef void (*duckdb_destroy_value_fn)(duckdb_value *value);
+void duckdb_destroy_value(duckdb_value *value) {
+	static duckdb_destroy_value_fn fn;
+	if (!fn) {
+		fn = (duckdb_destroy_value_fn)duckdb_go_symbol("duckdb_destroy_value");
+	}
+	fn(value);
+}
+
+typedef duckdb_value (*duckdb_create_varchar_fn)(const char *text);
+duckdb_value duckdb_create_varchar(const char *text) {
+	static duckdb_create_varchar_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_varchar_fn)duckdb_go_symbol("duckdb_create_varchar");
+	}
+	return fn(text);
+}
+
+typedef duckdb_value (*duckdb_create_varchar_length_fn)(const char *text, idx_t length);
+duckdb_value duckdb_create_varchar_length(const char *text, idx_t length) {
+	static duckdb_create_varchar_length_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_varchar_length_fn)duckdb_go_symbol("duckdb_create_varchar_length");
+	}
+	return fn(text, length);
+}
+
+typedef duckdb_value (*duckdb_create_int64_fn)(int64_t val);
+duckdb_value duckdb_create_int64(int64_t val) {
+	static duckdb_create_int64_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_int64_fn)duckdb_go_symbol("duckdb_create_int64");
+	}
+	return fn(val);
+}
+
+typedef duckdb_value (*duckdb_create_struct_value_fn)(duckdb_logical_type type, duckdb_value *values);
+duckdb_value duckdb_create_struct_value(duckdb_logical_type type, duckdb_value *values) {
+	static duckdb_create_struct_value_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_struct_value_fn)duckdb_go_symbol("duckdb_create_struct_value");
+	}
+	return fn(type, values);
+}
+
+typedef duckdb_value (*duckdb_create_list_value_fn)(duckdb_logical_type type, duckdb_value *values, idx_t value_count);
+duckdb_value duckdb_create_list_value(duckdb_logical_type type, duckdb_value *values, idx_t value_count) {
+	static duckdb_create_list_value_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_list_value_fn)duckdb_go_symbol("duckdb_create_list_value");
+	}
+	return fn(type, values, value_count);
+}
+
+typedef duckdb_value (*duckdb_create_array_value_fn)(duckdb_logical_type type, duckdb_value *values, idx_t value_count);
+duckdb_value duckdb_create_array_value(duckdb_logical_type type, duckdb_value *values, idx_t value_count) {
+	static duckdb_create_array_value_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_array_value_fn)duckdb_go_symbol("duckdb_create_array_value");
+	}
+	return fn(type, values, value_count);
+}
+
+typedef char * (*duckdb_get_varchar_fn)(duckdb_value value);
+char * duckdb_get_varchar(duckdb_value value) {
+	static duckdb_get_varchar_fn fn;
+	if (!fn) {
+		fn = (duckdb_get_varchar_fn)duckdb_go_symbol("duckdb_get_varchar");
+	}
+	return fn(value);
+}
+
+typedef int64_t (*duckdb_get_int64_fn)(duckdb_value value);
+int64_t duckdb_get_int64(duckdb_value value) {
+	static duckdb_get_int64_fn fn;
+	if (!fn) {
+		fn = (duckdb_get_int64_fn)duckdb_go_symbol("duckdb_get_int64");
+	}
+	return fn(value);
+}
+
+typedef duckdb_logical_type (*duckdb_create_logical_type_fn)(duckdb_type type);
+duckdb_logical_type duckdb_create_logical_type(duckdb_type type) {
+	static duckdb_create_logical_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_logical_type_fn)duckdb_go_symbol("duckdb_create_logical_type");
+	}
+	return fn(type);
+}
+
+typedef char * (*duckdb_logical_type_get_alias_fn)(duckdb_logical_type type);
+char * duckdb_logical_type_get_alias(duckdb_logical_type type) {
+	static duckdb_logical_type_get_alias_fn fn;
+	if (!fn) {
+		fn = (duckdb_logical_type_get_alias_fn)duckdb_go_symbol("duckdb_logical_type_get_alias");
+	}
+	return fn(type);
+}
+
+typedef duckdb_logical_type (*duckdb_create_list_type_fn)(duckdb_logical_type type);
+duckdb_logical_type duckdb_create_list_type(duckdb_logical_type type) {
+	static duckdb_create_list_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_list_type_fn)duckdb_go_symbol("duckdb_create_list_type");
+	}
+	return fn(type);
+}
+
+typedef duckdb_logical_type (*duckdb_create_array_type_fn)(duckdb_logical_type type, idx_t array_size);
+duckdb_logical_type duckdb_create_array_type(duckdb_logical_type type, idx_t array_size) {
+	static duckdb_create_array_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_array_type_fn)duckdb_go_symbol("duckdb_create_array_type");
+	}
+	return fn(type, array_size);
+}
+
+typedef duckdb_logical_type (*duckdb_create_map_type_fn)(duckdb_logical_type key_type, duckdb_logical_type value_type);
+duckdb_logical_type duckdb_create_map_type(duckdb_logical_type key_type, duckdb_logical_type value_type) {
+	static duckdb_create_map_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_map_type_fn)duckdb_go_symbol("duckdb_create_map_type");
+	}
+	return fn(key_type, value_type);
+}
+
+typedef duckdb_logical_type (*duckdb_create_union_type_fn)(duckdb_logical_type *member_types, const char **member_names, idx_t member_count);
+duckdb_logical_type duckdb_create_union_type(duckdb_logical_type *member_types, const char **member_names, idx_t member_count) {
+	static duckdb_create_union_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_union_type_fn)duckdb_go_symbol("duckdb_create_union_type");
+	}
+	return fn(member_types, member_names, member_count);
+}
+
+typedef duckdb_logical_type (*duckdb_create_struct_type_fn)(duckdb_logical_type *member_types, const char **member_names, idx_t member_count);
+duckdb_logical_type duckdb_create_struct_type(duckdb_logical_type *member_types, const char **member_names, idx_t member_count) {
+	static duckdb_create_struct_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_struct_type_fn)duckdb_go_symbol("duckdb_create_struct_type");
+	}
+	return fn(member_types, member_names, member_count);
+}
+
+typedef duckdb_logical_type (*duckdb_create_enum_type_fn)(const char **member_names, idx_t member_count);
+duckdb_logical_type duckdb_create_enum_type(const char **member_names, idx_t member_count) {
+	static duckdb_create_enum_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_enum_type_fn)duckdb_go_symbol("duckdb_create_enum_type");
+	}
+	return fn(member_names, member_count);
+}
+
+typedef duckdb_logical_type (*duckdb_create_decimal_type_fn)(uint8_t width, uint8_t scale);
+duckdb_logical_type duckdb_create_decimal_type(uint8_t width, uint8_t scale) {
+	static duckdb_create_decimal_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_decimal_type_fn)duckdb_go_symbol("duckdb_create_decimal_type");
+	}
+	return fn(width, scale);
+}
+
+typedef duckdb_type (*duckdb_get_type_id_fn)(duckdb_logical_type type);
+duckdb_type duckdb_get_type_id(duckdb_logical_type type) {
+	static duckdb_get_type_id_fn fn;
+	if (!fn) {
+		fn = (duckdb_get_type_id_fn)duckdb_go_symbol("duckdb_get_type_id");
+	}
+	return fn(type);
+}
+
+typedef uint8_t (*duckdb_decimal_width_fn)(duckdb_logical_type type);
+uint8_t duckdb_decimal_width(duckdb_logical_type type) {
+	static duckdb_decimal_width_fn fn;
+	if (!fn) {
+		fn = (duckdb_decimal_width_fn)duckdb_go_symbol("duckdb_decimal_width");
+	}
+	return fn(type);
+}
+
+typedef uint8_t (*duckdb_decimal_scale_fn)(duckdb_logical_type type);
+uint8_t duckdb_decimal_scale(duckdb_logical_type type) {
+	static duckdb_decimal_scale_fn fn;
+	if (!fn) {
+		fn = (duckdb_decimal_scale_fn)duckdb_go_symbol("duckdb_decimal_scale");
+	}
+	return fn(type);
+}
+
+typedef duckdb_type (*duckdb_decimal_internal_type_fn)(duckdb_logical_type type);
+duckdb_type duckdb_decimal_internal_type(duckdb_logical_type type) {
+	static duckdb_decimal_internal_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_decimal_internal_type_fn)duckdb_go_symbol("duckdb_decimal_internal_type");
+	}
+	return fn(type);
+}
+
+typedef duckdb_type (*duckdb_enum_internal_type_fn)(duckdb_logical_type type);
+duckdb_type duckdb_enum_internal_type(duckdb_logical_type type) {
+	static duckdb_enum_internal_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_enum_internal_type_fn)duckdb_go_symbol("duckdb_enum_internal_type");
+	}
+	return fn(type);
+}
+
+typedef uint32_t (*duckdb_enum_dictionary_size_fn)(duckdb_logical_type type);
+uint32_t duckdb_enum_dictionary_size(duckdb_logical_type type) {
+	static duckdb_enum_dictionary_size_fn fn;
+	if (!fn) {
+		fn = (duckdb_enum_dictionary_size_fn)duckdb_go_symbol("duckdb_enum_dictionary_size");
+	}
+	return fn(type);
+}
+
+typedef char * (*duckdb_enum_dictionary_value_fn)(duckdb_logical_type type, idx_t index);
+char * duckdb_enum_dictionary_value(duckdb_logical_type type, idx_t index) {
+	static duckdb_enum_dictionary_value_fn fn;
+	if (!fn) {
+		fn = (duckdb_enum_dictionary_value_fn)duckdb_go_symbol("duckdb_enum_dictionary_value");
+	}
+	return fn(type, index);
+}
+
+typedef duckdb_logical_type (*duckdb_list_type_child_type_fn)(duckdb_logical_type type);
+duckdb_logical_type duckdb_list_type_child_type(duckdb_logical_type type) {
+	static duckdb_list_type_child_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_list_type_child_type_fn)duckdb_go_symbol("duckdb_list_type_child_type");
+	}
+	return fn(type);
+}
+
+typedef duckdb_logical_type (*duckdb_array_type_child_type_fn)(duckdb_logical_type type);
+duckdb_logical_type duckdb_array_type_child_type(duckdb_logical_type type) {
+	static duckdb_array_type_child_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_array_type_child_type_fn)duckdb_go_symbol("duckdb_array_type_child_type");
+	}
+	return fn(type);
+}
+
+typedef idx_t (*duckdb_array_type_array_size_fn)(duckdb_logical_type type);
+idx_t duckdb_array_type_array_size(duckdb_logical_type type) {
+	static duckdb_array_type_array_size_fn fn;
+	if (!fn) {
+		fn = (duckdb_array_type_array_size_fn)duckdb_go_symbol("duckdb_array_type_array_size");
+	}
+	return fn(type);
+}
+
+typedef duckdb_logical_type (*duckdb_map_type_key_type_fn)(duckdb_logical_type type);
+duckdb_logical_type duckdb_map_type_key_type(duckdb_logical_type type) {
+	static duckdb_map_type_key_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_map_type_key_type_fn)duckdb_go_symbol("duckdb_map_type_key_type");
+	}
+	return fn(type);
+}
+
+typedef duckdb_logical_type (*duckdb_map_type_value_type_fn)(duckdb_logical_type type);
+duckdb_logical_type duckdb_map_type_value_type(duckdb_logical_type type) {
+	static duckdb_map_type_value_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_map_type_value_type_fn)duckdb_go_symbol("duckdb_map_type_value_type");
+	}
+	return fn(type);
+}
+
+typedef idx_t (*duckdb_struct_type_child_count_fn)(duckdb_logical_type type);
+idx_t duckdb_struct_type_child_count(duckdb_logical_type type) {
+	static duckdb_struct_type_child_count_fn fn;
+	if (!fn) {
+		fn = (duckdb_struct_type_child_count_fn)duckdb_go_symbol("duckdb_struct_type_child_count");
+	}
+	return fn(type);
+}
+
+typedef char * (*duckdb_struct_type_child_name_fn)(duckdb_logical_type type, idx_t index);
+char * duckdb_struct_type_child_name(duckdb_logical_type type, idx_t index) {
+	static duckdb_struct_type_child_name_fn fn;
+	if (!fn) {
+		fn = (duckdb_struct_type_child_name_fn)duckdb_go_symbol("duckdb_struct_type_child_name");
+	}
+	return fn(type, index);
+}
+
+typedef duckdb_logical_type (*duckdb_struct_type_child_type_fn)(duckdb_logical_type type, idx_t index);
+duckdb_logical_type duckdb_struct_type_child_type(duckdb_logical_type type, idx_t index) {
+	static duckdb_struct_type_child_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_struct_type_child_type_fn)duckdb_go_symbol("duckdb_struct_type_child_type");
+	}
+	return fn(type, index);
+}
+
+typedef idx_t (*duckdb_union_type_member_count_fn)(duckdb_logical_type type);
+idx_t duckdb_union_type_member_count(duckdb_logical_type type) {
+	static duckdb_union_type_member_count_fn fn;
+	if (!fn) {
+		fn = (duckdb_union_type_member_count_fn)duckdb_go_symbol("duckdb_union_type_member_count");
+	}
+	return fn(type);
+}
+
+typedef char * (*duckdb_union_type_member_name_fn)(duckdb_logical_type type, idx_t index);
+char * duckdb_union_type_member_name(duckdb_logical_type type, idx_t index) {
+	static duckdb_union_type_member_name_fn fn;
+	if (!fn) {
+		fn = (duckdb_union_type_member_name_fn)duckdb_go_symbol("duckdb_union_type_member_name");
+	}
+	return fn(type, index);
+}
+
+typedef duckdb_logical_type (*duckdb_union_type_member_type_fn)(duckdb_logical_type type, idx_t index);
+duckdb_logical_type duckdb_union_type_member_type(duckdb_logical_type type, idx_t index) {
+	static duckdb_union_type_member_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_union_type_member_type_fn)duckdb_go_symbol("duckdb_union_type_member_type");
+	}
+	return fn(type, index);
+}
+
+typedef void (*duckdb_destroy_logical_type_fn)(duckdb_logical_type *type);
+void duckdb_destroy_logical_type(duckdb_logical_type *type) {
+	static duckdb_destroy_logical_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_destroy_logical_type_fn)duckdb_go_symbol("duckdb_destroy_logical_type");
+	}
+	fn(type);
+}
+
+typedef duckdb_data_chunk (*duckdb_create_data_chunk_fn)(duckdb_logical_type *types, idx_t column_count);
+duckdb_data_chunk duckdb_create_data_chunk(duckdb_logical_type *types, idx_t column_count) {
+	static duckdb_create_data_chunk_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_data_chunk_fn)duckdb_go_symbol("duckdb_create_data_chunk");
+	}
+	return fn(types, column_count);
+}
+
+typedef void (*duckdb_destroy_data_chunk_fn)(duckdb_data_chunk *chunk);
+void duckdb_destroy_data_chunk(duckdb_data_chunk *chunk) {
+	static duckdb_destroy_data_chunk_fn fn;
+	if (!fn) {
+		fn = (duckdb_destroy_data_chunk_fn)duckdb_go_symbol("duckdb_destroy_data_chunk");
+	}
+	fn(chunk);
+}
+
+typedef void (*duckdb_data_chunk_reset_fn)(duckdb_data_chunk chunk);
+void duckdb_data_chunk_reset(duckdb_data_chunk chunk) {
+	static duckdb_data_chunk_reset_fn fn;
+	if (!fn) {
+		fn = (duckdb_data_chunk_reset_fn)duckdb_go_symbol("duckdb_data_chunk_reset");
+	}
+	fn(chunk);
+}
+
+typedef idx_t (*duckdb_data_chunk_get_column_count_fn)(duckdb_data_chunk chunk);
+idx_t duckdb_data_chunk_get_column_count(duckdb_data_chunk chunk) {
+	static duckdb_data_chunk_get_column_count_fn fn;
+	if (!fn) {
+		fn = (duckdb_data_chunk_get_column_count_fn)duckdb_go_symbol("duckdb_data_chunk_get_column_count");
+	}
+	return fn(chunk);
+}
+
+typedef duckdb_vector (*duckdb_data_chunk_get_vector_fn)(duckdb_data_chunk chunk, idx_t col_idx);
+duckdb_vector duckdb_data_chunk_get_vector(duckdb_data_chunk chunk, idx_t col_idx) {
+	static duckdb_data_chunk_get_vector_fn fn;
+	if (!fn) {
+		fn = (duckdb_data_chunk_get_vector_fn)duckdb_go_symbol("duckdb_data_chunk_get_vector");
+	}
+	return fn(chunk, col_idx);
+}
+
+typedef idx_t (*duckdb_data_chunk_get_size_fn)(duckdb_data_chunk chunk);
+idx_t duckdb_data_chunk_get_size(duckdb_data_chunk chunk) {
+	static duckdb_data_chunk_get_size_fn fn;
+	if (!fn) {
+		fn = (duckdb_data_chunk_get_size_fn)duckdb_go_symbol("duckdb_data_chunk_get_size");
+	}
+	return fn(chunk);
+}
+
+typedef void (*duckdb_data_chunk_set_size_fn)(duckdb_data_chunk chunk, idx_t size);
+void duckdb_data_chunk_set_size(duckdb_data_chunk chunk, idx_t size) {
+	static duckdb_data_chunk_set_size_fn fn;
+	if (!fn) {
+		fn = (duckdb_data_chunk_set_size_fn)duckdb_go_symbol("duckdb_data_chunk_set_size");
+	}
+	fn(chunk, size);
+}
+
+typedef duckdb_logical_type (*duckdb_vector_get_column_type_fn)(duckdb_vector vector);
+duckdb_logical_type duckdb_vector_get_column_type(duckdb_vector vector) {
+	static duckdb_vector_get_column_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_vector_get_column_type_fn)duckdb_go_symbol("duckdb_vector_get_column_type");
+	}
+	return fn(vector);
+}
+
+typedef void * (*duckdb_vector_get_data_fn)(duckdb_vector vector);
+void * duckdb_vector_get_data(duckdb_vector vector) {
+	static duckdb_vector_get_data_fn fn;
+	if (!fn) {
+		fn = (duckdb_vector_get_data_fn)duckdb_go_symbol("duckdb_vector_get_data");
+	}
+	return fn(vector);
+}
+
+typedef uint64_t * (*duckdb_vector_get_validity_fn)(duckdb_vector vector);
+uint64_t * duckdb_vector_get_validity(duckdb_vector vector) {
+	static duckdb_vector_get_validity_fn fn;
+	if (!fn) {
+		fn = (duckdb_vector_get_validity_fn)duckdb_go_symbol("duckdb_vector_get_validity");
+	}
+	return fn(vector);
+}
+
+typedef void (*duckdb_vector_ensure_validity_writable_fn)(duckdb_vector vector);
+void duckdb_vector_ensure_validity_writable(duckdb_vector vector) {
+	static duckdb_vector_ensure_validity_writable_fn fn;
+	if (!fn) {
+		fn = (duckdb_vector_ensure_validity_writable_fn)duckdb_go_symbol("duckdb_vector_ensure_validity_writable");
+	}
+	fn(vector);
+}
+
+typedef void (*duckdb_vector_assign_string_element_fn)(duckdb_vector vector, idx_t index, const char *str);
+void duckdb_vector_assign_string_element(duckdb_vector vector, idx_t index, const char *str) {
+	static duckdb_vector_assign_string_element_fn fn;
+	if (!fn) {
+		fn = (duckdb_vector_assign_string_element_fn)duckdb_go_symbol("duckdb_vector_assign_string_element");
+	}
+	fn(vector, index, str);
+}
+
+typedef void (*duckdb_vector_assign_string_element_len_fn)(duckdb_vector vector, idx_t index, const char *str, idx_t str_len);
+void duckdb_vector_assign_string_element_len(duckdb_vector vector, idx_t index, const char *str, idx_t str_len) {
+	static duckdb_vector_assign_string_element_len_fn fn;
+	if (!fn) {
+		fn = (duckdb_vector_assign_string_element_len_fn)duckdb_go_symbol("duckdb_vector_assign_string_element_len");
+	}
+	fn(vector, index, str, str_len);
+}
+
+typedef duckdb_vector (*duckdb_list_vector_get_child_fn)(duckdb_vector vector);
+duckdb_vector duckdb_list_vector_get_child(duckdb_vector vector) {
+	static duckdb_list_vector_get_child_fn fn;
+	if (!fn) {
+		fn = (duckdb_list_vector_get_child_fn)duckdb_go_symbol("duckdb_list_vector_get_child");
+	}
+	return fn(vector);
+}
+
+typedef idx_t (*duckdb_list_vector_get_size_fn)(duckdb_vector vector);
+idx_t duckdb_list_vector_get_size(duckdb_vector vector) {
+	static duckdb_list_vector_get_size_fn fn;
+	if (!fn) {
+		fn = (duckdb_list_vector_get_size_fn)duckdb_go_symbol("duckdb_list_vector_get_size");
+	}
+	return fn(vector);
+}
+
+typedef duckdb_state (*duckdb_list_vector_set_size_fn)(duckdb_vector vector, idx_t size);
+duckdb_state duckdb_list_vector_set_size(duckdb_vector vector, idx_t size) {
+	static duckdb_list_vector_set_size_fn fn;
+	if (!fn) {
+		fn = (duckdb_list_vector_set_size_fn)duckdb_go_symbol("duckdb_list_vector_set_size");
+	}
+	return fn(vector, size);
+}
+
+typedef duckdb_state (*duckdb_list_vector_reserve_fn)(duckdb_vector vector, idx_t required_capacity);
+duckdb_state duckdb_list_vector_reserve(duckdb_vector vector, idx_t required_capacity) {
+	static duckdb_list_vector_reserve_fn fn;
+	if (!fn) {
+		fn = (duckdb_list_vector_reserve_fn)duckdb_go_symbol("duckdb_list_vector_reserve");
+	}
+	return fn(vector, required_capacity);
+}
+
+typedef duckdb_vector (*duckdb_struct_vector_get_child_fn)(duckdb_vector vector, idx_t index);
+duckdb_vector duckdb_struct_vector_get_child(duckdb_vector vector, idx_t index) {
+	static duckdb_struct_vector_get_child_fn fn;
+	if (!fn) {
+		fn = (duckdb_struct_vector_get_child_fn)duckdb_go_symbol("duckdb_struct_vector_get_child");
+	}
+	return fn(vector, index);
+}
+
+typedef duckdb_vector (*duckdb_array_vector_get_child_fn)(duckdb_vector vector);
+duckdb_vector duckdb_array_vector_get_child(duckdb_vector vector) {
+	static duckdb_array_vector_get_child_fn fn;
+	if (!fn) {
+		fn = (duckdb_array_vector_get_child_fn)duckdb_go_symbol("duckdb_array_vector_get_child");
+	}
+	return fn(vector);
+}
+
+typedef bool (*duckdb_validity_row_is_valid_fn)(uint64_t *validity, idx_t row);
+bool duckdb_validity_row_is_valid(uint64_t *validity, idx_t row) {
+	static duckdb_validity_row_is_valid_fn fn;
+	if (!fn) {
+		fn = (duckdb_validity_row_is_valid_fn)duckdb_go_symbol("duckdb_validity_row_is_valid");
+	}
+	return fn(validity, row);
+}
+
+typedef void (*duckdb_validity_set_row_validity_fn)(uint64_t *validity, idx_t row, bool valid);
+void duckdb_validity_set_row_validity(uint64_t *validity, idx_t row, bool valid) {
+	static duckdb_validity_set_row_validity_fn fn;
+	if (!fn) {
+		fn = (duckdb_validity_set_row_validity_fn)duckdb_go_symbol("duckdb_validity_set_row_validity");
+	}
+	fn(validity, row, valid);
+}
+
+typedef void (*duckdb_validity_set_row_invalid_fn)(uint64_t *validity, idx_t row);
+void duckdb_validity_set_row_invalid(uint64_t *validity, idx_t row) {
+	static duckdb_validity_set_row_invalid_fn fn;
+	if (!fn) {
+		fn = (duckdb_validity_set_row_invalid_fn)duckdb_go_symbol("duckdb_validity_set_row_invalid");
+	}
+	fn(validity, row);
+}
+
+typedef void (*duckdb_validity_set_row_valid_fn)(uint64_t *validity, idx_t row);
+void duckdb_validity_set_row_valid(uint64_t *validity, idx_t row) {
+	static duckdb_validity_set_row_valid_fn fn;
+	if (!fn) {
+		fn = (duckdb_validity_set_row_valid_fn)duckdb_go_symbol("duckdb_validity_set_row_valid");
+	}
+	fn(validity, row);
+}
+
+typedef duckdb_table_function (*duckdb_create_table_function_fn)();
+duckdb_table_function duckdb_create_table_function() {
+	static duckdb_create_table_function_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_table_function_fn)duckdb_go_symbol("duckdb_create_table_function");
+	}
+	return fn();
+}
+
+typedef void (*duckdb_destroy_table_function_fn)(duckdb_table_function *table_function);
+void duckdb_destroy_table_function(duckdb_table_function *table_function) {
+	static duckdb_destroy_table_function_fn fn;
+	if (!fn) {
+		fn = (duckdb_destroy_table_function_fn)duckdb_go_symbol("duckdb_destroy_table_function");
+	}
+	fn(table_function);
+}
+
+typedef void (*duckdb_table_function_set_name_fn)(duckdb_table_function table_function, const char *name);
+void duckdb_table_function_set_name(duckdb_table_function table_function, const char *name) {
+	static duckdb_table_function_set_name_fn fn;
+	if (!fn) {
+		fn = (duckdb_table_function_set_name_fn)duckdb_go_symbol("duckdb_table_function_set_name");
+	}
+	fn(table_function, name);
+}
+
+typedef void (*duckdb_table_function_add_parameter_fn)(duckdb_table_function table_function, duckdb_logical_type type);
+void duckdb_table_function_add_parameter(duckdb_table_function table_function, duckdb_logical_type type) {
+	static duckdb_table_function_add_parameter_fn fn;
+	if (!fn) {
+		fn = (duckdb_table_function_add_parameter_fn)duckdb_go_symbol("duckdb_table_function_add_parameter");
+	}
+	fn(table_function, type);
+}
+
+typedef void (*duckdb_table_function_add_named_parameter_fn)(duckdb_table_function table_function, const char *name, duckdb_logical_type type);
+void duckdb_table_function_add_named_parameter(duckdb_table_function table_function, const char *name, duckdb_logical_type type) {
+	static duckdb_table_function_add_named_parameter_fn fn;
+	if (!fn) {
+		fn = (duckdb_table_function_add_named_parameter_fn)duckdb_go_symbol("duckdb_table_function_add_named_parameter");
+	}
+	fn(table_function, name, type);
+}
+
+typedef void (*duckdb_table_function_set_extra_info_fn)(duckdb_table_function table_function, void *extra_info, duckdb_delete_callback_t destroy);
+void duckdb_table_function_set_extra_info(duckdb_table_function table_function, void *extra_info, duckdb_delete_callback_t destroy) {
+	static duckdb_table_function_set_extra_info_fn fn;
+	if (!fn) {
+		fn = (duckdb_table_function_set_extra_info_fn)duckdb_go_symbol("duckdb_table_function_set_extra_info");
+	}
+	fn(table_function, extra_info, destroy);
+}
+
+typedef void (*duckdb_table_function_set_bind_fn)(duckdb_table_function table_function, duckdb_table_function_bind_t bind);
+void duckdb_table_function_set_bind(duckdb_table_function table_function, duckdb_table_function_bind_t bind) {
+	static duckdb_table_function_set_bind_fn fn;
+	if (!fn) {
+		fn = (duckdb_table_function_set_bind_fn)duckdb_go_symbol("duckdb_table_function_set_bind");
+	}
+	fn(table_function, bind);
+}
+
+typedef void (*duckdb_table_function_set_init_fn)(duckdb_table_function table_function, duckdb_table_function_init_t init);
+void duckdb_table_function_set_init(duckdb_table_function table_function, duckdb_table_function_init_t init) {
+	static duckdb_table_function_set_init_fn fn;
+	if (!fn) {
+		fn = (duckdb_table_function_set_init_fn)duckdb_go_symbol("duckdb_table_function_set_init");
+	}
+	fn(table_function, init);
+}
+
+typedef void (*duckdb_table_function_set_local_init_fn)(duckdb_table_function table_function, duckdb_table_function_init_t init);
+void duckdb_table_function_set_local_init(duckdb_table_function table_function, duckdb_table_function_init_t init) {
+	static duckdb_table_function_set_local_init_fn fn;
+	if (!fn) {
+		fn = (duckdb_table_function_set_local_init_fn)duckdb_go_symbol("duckdb_table_function_set_local_init");
+	}
+	fn(table_function, init);
+}
+
+typedef void (*duckdb_table_function_set_function_fn)(duckdb_table_function table_function, duckdb_table_function_t function);
+void duckdb_table_function_set_function(duckdb_table_function table_function, duckdb_table_function_t function) {
+	static duckdb_table_function_set_function_fn fn;
+	if (!fn) {
+		fn = (duckdb_table_function_set_function_fn)duckdb_go_symbol("duckdb_table_function_set_function");
+	}
+	fn(table_function, function);
+}
+
+typedef void (*duckdb_table_function_supports_projection_pushdown_fn)(duckdb_table_function table_function, bool pushdown);
+void duckdb_table_function_supports_projection_pushdown(duckdb_table_function table_function, bool pushdown) {
+	static duckdb_table_function_supports_projection_pushdown_fn fn;
+	if (!fn) {
+		fn = (duckdb_table_function_supports_projection_pushdown_fn)duckdb_go_symbol("duckdb_table_function_supports_projection_pushdown");
+	}
+	fn(table_function, pushdown);
+}
+
+typedef duckdb_state (*duckdb_register_table_function_fn)(duckdb_connection con, duckdb_table_function function);
+duckdb_state duckdb_register_table_function(duckdb_connection con, duckdb_table_function function) {
+	static duckdb_register_table_function_fn fn;
+	if (!fn) {
+		fn = (duckdb_register_table_function_fn)duckdb_go_symbol("duckdb_register_table_function");
+	}
+	return fn(con, function);
+}
+
+typedef void * (*duckdb_bind_get_extra_info_fn)(duckdb_bind_info info);
+void * duckdb_bind_get_extra_info(duckdb_bind_info info) {
+	static duckdb_bind_get_extra_info_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_get_extra_info_fn)duckdb_go_symbol("duckdb_bind_get_extra_info");
+	}
+	return fn(info);
+}
+
+typedef void (*duckdb_bind_add_result_column_fn)(duckdb_bind_info info, const char *name, duckdb_logical_type type);
+void duckdb_bind_add_result_column(duckdb_bind_info info, const char *name, duckdb_logical_type type) {
+	static duckdb_bind_add_result_column_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_add_result_column_fn)duckdb_go_symbol("duckdb_bind_add_result_column");
+	}
+	fn(info, name, type);
+}
+
+typedef idx_t (*duckdb_bind_get_parameter_count_fn)(duckdb_bind_info info);
+idx_t duckdb_bind_get_parameter_count(duckdb_bind_info info) {
+	static duckdb_bind_get_parameter_count_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_get_parameter_count_fn)duckdb_go_symbol("duckdb_bind_get_parameter_count");
+	}
+	return fn(info);
+}
+
+typedef duckdb_value (*duckdb_bind_get_parameter_fn)(duckdb_bind_info info, idx_t index);
+duckdb_value duckdb_bind_get_parameter(duckdb_bind_info info, idx_t index) {
+	static duckdb_bind_get_parameter_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_get_parameter_fn)duckdb_go_symbol("duckdb_bind_get_parameter");
+	}
+	return fn(info, index);
+}
+
+typedef duckdb_value (*duckdb_bind_get_named_parameter_fn)(duckdb_bind_info info, const char *name);
+duckdb_value duckdb_bind_get_named_parameter(duckdb_bind_info info, const char *name) {
+	static duckdb_bind_get_named_parameter_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_get_named_parameter_fn)duckdb_go_symbol("duckdb_bind_get_named_parameter");
+	}
+	return fn(info, name);
+}
+
+typedef void (*duckdb_bind_set_bind_data_fn)(duckdb_bind_info info, void *bind_data, duckdb_delete_callback_t destroy);
+void duckdb_bind_set_bind_data(duckdb_bind_info info, void *bind_data, duckdb_delete_callback_t destroy) {
+	static duckdb_bind_set_bind_data_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_set_bind_data_fn)duckdb_go_symbol("duckdb_bind_set_bind_data");
+	}
+	fn(info, bind_data, destroy);
+}
+
+typedef void (*duckdb_bind_set_cardinality_fn)(duckdb_bind_info info, idx_t cardinality, bool is_exact);
+void duckdb_bind_set_cardinality(duckdb_bind_info info, idx_t cardinality, bool is_exact) {
+	static duckdb_bind_set_cardinality_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_set_cardinality_fn)duckdb_go_symbol("duckdb_bind_set_cardinality");
+	}
+	fn(info, cardinality, is_exact);
+}
+
+typedef void (*duckdb_bind_set_error_fn)(duckdb_bind_info info, const char *error);
+void duckdb_bind_set_error(duckdb_bind_info info, const char *error) {
+	static duckdb_bind_set_error_fn fn;
+	if (!fn) {
+		fn = (duckdb_bind_set_error_fn)duckdb_go_symbol("duckdb_bind_set_error");
+	}
+	fn(info, error);
+}
+
+typedef void * (*duckdb_init_get_extra_info_fn)(duckdb_init_info info);
+void * duckdb_init_get_extra_info(duckdb_init_info info) {
+	static duckdb_init_get_extra_info_fn fn;
+	if (!fn) {
+		fn = (duckdb_init_get_extra_info_fn)duckdb_go_symbol("duckdb_init_get_extra_info");
+	}
+	return fn(info);
+}
+
+typedef void * (*duckdb_init_get_bind_data_fn)(duckdb_init_info info);
+void * duckdb_init_get_bind_data(duckdb_init_info info) {
+	static duckdb_init_get_bind_data_fn fn;
+	if (!fn) {
+		fn = (duckdb_init_get_bind_data_fn)duckdb_go_symbol("duckdb_init_get_bind_data");
+	}
+	return fn(info);
+}
+
+typedef void (*duckdb_init_set_init_data_fn)(duckdb_init_info info, void *init_data, duckdb_delete_callback_t destroy);
+void duckdb_init_set_init_data(duckdb_init_info info, void *init_data, duckdb_delete_callback_t destroy) {
+	static duckdb_init_set_init_data_fn fn;
+	if (!fn) {
+		fn = (duckdb_init_set_init_data_fn)duckdb_go_symbol("duckdb_init_set_init_data");
+	}
+	fn(info, init_data, destroy);
+}
+
+typedef idx_t (*duckdb_init_get_column_count_fn)(duckdb_init_info info);
+idx_t duckdb_init_get_column_count(duckdb_init_info info) {
+	static duckdb_init_get_column_count_fn fn;
+	if (!fn) {
+		fn = (duckdb_init_get_column_count_fn)duckdb_go_symbol("duckdb_init_get_column_count");
+	}
+	return fn(info);
+}
+
+typedef idx_t (*duckdb_init_get_column_index_fn)(duckdb_init_info info, idx_t column_index);
+idx_t duckdb_init_get_column_index(duckdb_init_info info, idx_t column_index) {
+	static duckdb_init_get_column_index_fn fn;
+	if (!fn) {
+		fn = (duckdb_init_get_column_index_fn)duckdb_go_symbol("duckdb_init_get_column_index");
+	}
+	return fn(info, column_index);
+}
+
+typedef void (*duckdb_init_set_max_threads_fn)(duckdb_init_info info, idx_t max_threads);
+void duckdb_init_set_max_threads(duckdb_init_info info, idx_t max_threads) {
+	static duckdb_init_set_max_threads_fn fn;
+	if (!fn) {
+		fn = (duckdb_init_set_max_threads_fn)duckdb_go_symbol("duckdb_init_set_max_threads");
+	}
+	fn(info, max_threads);
+}
+
+typedef void (*duckdb_init_set_error_fn)(duckdb_init_info info, const char *error);
+void duckdb_init_set_error(duckdb_init_info info, const char *error) {
+	static duckdb_init_set_error_fn fn;
+	if (!fn) {
+		fn = (duckdb_init_set_error_fn)duckdb_go_symbol("duckdb_init_set_error");
+	}
+	fn(info, error);
+}
+
+typedef void * (*duckdb_function_get_extra_info_fn)(duckdb_function_info info);
+void * duckdb_function_get_extra_info(duckdb_function_info info) {
+	static duckdb_function_get_extra_info_fn fn;
+	if (!fn) {
+		fn = (duckdb_function_get_extra_info_fn)duckdb_go_symbol("duckdb_function_get_extra_info");
+	}
+	return fn(info);
+}
+
+typedef void * (*duckdb_function_get_bind_data_fn)(duckdb_function_info info);
+void * duckdb_function_get_bind_data(duckdb_function_info info) {
+	static duckdb_function_get_bind_data_fn fn;
+	if (!fn) {
+		fn = (duckdb_function_get_bind_data_fn)duckdb_go_symbol("duckdb_function_get_bind_data");
+	}
+	return fn(info);
+}
+
+typedef void * (*duckdb_function_get_init_data_fn)(duckdb_function_info info);
+void * duckdb_function_get_init_data(duckdb_function_info info) {
+	static duckdb_function_get_init_data_fn fn;
+	if (!fn) {
+		fn = (duckdb_function_get_init_data_fn)duckdb_go_symbol("duckdb_function_get_init_data");
+	}
+	return fn(info);
+}
+
+typedef void * (*duckdb_function_get_local_init_data_fn)(duckdb_function_info info);
+void * duckdb_function_get_local_init_data(duckdb_function_info info) {
+	static duckdb_function_get_local_init_data_fn fn;
+	if (!fn) {
+		fn = (duckdb_function_get_local_init_data_fn)duckdb_go_symbol("duckdb_function_get_local_init_data");
+	}
+	return fn(info);
+}
+
+typedef void (*duckdb_function_set_error_fn)(duckdb_function_info info, const char *error);
+void duckdb_function_set_error(duckdb_function_info info, const char *error) {
+	static duckdb_function_set_error_fn fn;
+	if (!fn) {
+		fn = (duckdb_function_set_error_fn)duckdb_go_symbol("duckdb_function_set_error");
+	}
+	fn(info, error);
+}
+
+typedef void (*duckdb_add_replacement_scan_fn)(duckdb_database db, duckdb_replacement_callback_t replacement, void *extra_data, duckdb_delete_callback_t delete_callback);
+void duckdb_add_replacement_scan(duckdb_database db, duckdb_replacement_callback_t replacement, void *extra_data, duckdb_delete_callback_t delete_callback) {
+	static duckdb_add_replacement_scan_fn fn;
+	if (!fn) {
+		fn = (duckdb_add_replacement_scan_fn)duckdb_go_symbol("duckdb_add_replacement_scan");
+	}
+	fn(db, replacement, extra_data, delete_callback);
+}
+
+typedef void (*duckdb_replacement_scan_set_function_name_fn)(duckdb_replacement_scan_info info, const char *function_name);
+void duckdb_replacement_scan_set_function_name(duckdb_replacement_scan_info info, const char *function_name) {
+	static duckdb_replacement_scan_set_function_name_fn fn;
+	if (!fn) {
+		fn = (duckdb_replacement_scan_set_function_name_fn)duckdb_go_symbol("duckdb_replacement_scan_set_function_name");
+	}
+	fn(info, function_name);
+}
+
+typedef void (*duckdb_replacement_scan_add_parameter_fn)(duckdb_replacement_scan_info info, duckdb_value parameter);
+void duckdb_replacement_scan_add_parameter(duckdb_replacement_scan_info info, duckdb_value parameter) {
+	static duckdb_replacement_scan_add_parameter_fn fn;
+	if (!fn) {
+		fn = (duckdb_replacement_scan_add_parameter_fn)duckdb_go_symbol("duckdb_replacement_scan_add_parameter");
+	}
+	fn(info, parameter);
+}
+
+typedef void (*duckdb_replacement_scan_set_error_fn)(duckdb_replacement_scan_info info, const char *error);
+void duckdb_replacement_scan_set_error(duckdb_replacement_scan_info info, const char *error) {
+	static duckdb_replacement_scan_set_error_fn fn;
+	if (!fn) {
+		fn = (duckdb_replacement_scan_set_error_fn)duckdb_go_symbol("duckdb_replacement_scan_set_error");
+	}
+	fn(info, error);
+}
+
+typedef duckdb_state (*duckdb_appender_create_fn)(duckdb_connection connection, const char *schema, const char *table, duckdb_appender *out_appender);
+duckdb_state duckdb_appender_create(duckdb_connection connection, const char *schema, const char *table, duckdb_appender *out_appender) {
+	static duckdb_appender_create_fn fn;
+	if (!fn) {
+		fn = (duckdb_appender_create_fn)duckdb_go_symbol("duckdb_appender_create");
+	}
+	return fn(connection, schema, table, out_appender);
+}
+
+typedef idx_t (*duckdb_appender_column_count_fn)(duckdb_appender appender);
+idx_t duckdb_appender_column_count(duckdb_appender appender) {
+	static duckdb_appender_column_count_fn fn;
+	if (!fn) {
+		fn = (duckdb_appender_column_count_fn)duckdb_go_symbol("duckdb_appender_column_count");
+	}
+	return fn(appender);
+}
+
+typedef duckdb_logical_type (*duckdb_appender_column_type_fn)(duckdb_appender appender, idx_t col_idx);
+duckdb_logical_type duckdb_appender_column_type(duckdb_appender appender, idx_t col_idx) {
+	static duckdb_appender_column_type_fn fn;
+	if (!fn) {
+		fn = (duckdb_appender_column_type_fn)duckdb_go_symbol("duckdb_appender_column_type");
+	}
+	return fn(appender, col_idx);
+}
+
+typedef const char * (*duckdb_appender_error_fn)(duckdb_appender appender);
+const char * duckdb_appender_error(duckdb_appender appender) {
+	static duckdb_appender_error_fn fn;
+	if (!fn) {
+		fn = (duckdb_appender_error_fn)duckdb_go_symbol("duckdb_appender_error");
+	}
+	return fn(appender);
+}
+
+typedef duckdb_state (*duckdb_appender_flush_fn)(duckdb_appender appender);
+duckdb_state duckdb_appender_flush(duckdb_appender appender) {
+	static duckdb_appender_flush_fn fn;
+	if (!fn) {
+		fn = (duckdb_appender_flush_fn)duckdb_go_symbol("duckdb_appender_flush");
+	}
+	return fn(appender);
+}
+
+typedef duckdb_state (*duckdb_appender_close_fn)(duckdb_appender appender);
+duckdb_state duckdb_appender_close(duckdb_appender appender) {
+	static duckdb_appender_close_fn fn;
+	if (!fn) {
+		fn = (duckdb_appender_close_fn)duckdb_go_symbol("duckdb_appender_close");
+	}
+	return fn(appender);
+}
+
+typedef duckdb_state (*duckdb_appender_destroy_fn)(duckdb_appender *appender);
+duckdb_state duckdb_appender_destroy(duckdb_appender *appender) {
+	static duckdb_appender_destroy_fn fn;
+	if (!fn) {
+		fn = (duckdb_appender_destroy_fn)duckdb_go_symbol("duckdb_appender_destroy");
+	}
+	return fn(appender);
+}
+
+typedef duckdb_state (*duckdb_appender_begin_row_fn)(duckdb_appender appender);
+duckdb_state duckdb_appender_begin_row(duckdb_appender appender) {
+	static duckdb_appender_begin_row_fn fn;
+	if (!fn) {
+		fn = (duckdb_appender_begin_row_fn)duckdb_go_symbol("duckdb_appender_begin_row");
+	}
+	return fn(appender);
+}
+
+typedef duckdb_state (*duckdb_appender_end_row_fn)(duckdb_appender appender);
+duckdb_state duckdb_appender_end_row(duckdb_appender appender) {
+	static duckdb_appender_end_row_fn fn;
+	if (!fn) {
+		fn = (duckdb_appender_end_row_fn)duckdb_go_symbol("duckdb_appender_end_row");
+	}
+	return fn(appender);
+}
+
+typedef duckdb_state (*duckdb_append_bool_fn)(duckdb_appender appender, bool value);
+duckdb_state duckdb_append_bool(duckdb_appender appender, bool value) {
+	static duckdb_append_bool_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_bool_fn)duckdb_go_symbol("duckdb_append_bool");
+	}
+	return fn(appender, value);
+}
+
+typedef duckdb_state (*duckdb_append_int8_fn)(duckdb_appender appender, int8_t value);
+duckdb_state duckdb_append_int8(duckdb_appender appender, int8_t value) {
+	static duckdb_append_int8_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_int8_fn)duckdb_go_symbol("duckdb_append_int8");
+	}
+	return fn(appender, value);
+}
+
+typedef duckdb_state (*duckdb_append_int16_fn)(duckdb_appender appender, int16_t value);
+duckdb_state duckdb_append_int16(duckdb_appender appender, int16_t value) {
+	static duckdb_append_int16_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_int16_fn)duckdb_go_symbol("duckdb_append_int16");
+	}
+	return fn(appender, value);
+}
+
+typedef duckdb_state (*duckdb_append_int32_fn)(duckdb_appender appender, int32_t value);
+duckdb_state duckdb_append_int32(duckdb_appender appender, int32_t value) {
+	static duckdb_append_int32_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_int32_fn)duckdb_go_symbol("duckdb_append_int32");
+	}
+	return fn(appender, value);
+}
+
+typedef duckdb_state (*duckdb_append_int64_fn)(duckdb_appender appender, int64_t value);
+duckdb_state duckdb_append_int64(duckdb_appender appender, int64_t value) {
+	static duckdb_append_int64_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_int64_fn)duckdb_go_symbol("duckdb_append_int64");
+	}
+	return fn(appender, value);
+}
+
+typedef duckdb_state (*duckdb_append_hugeint_fn)(duckdb_appender appender, duckdb_hugeint value);
+duckdb_state duckdb_append_hugeint(duckdb_appender appender, duckdb_hugeint value) {
+	static duckdb_append_hugeint_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_hugeint_fn)duckdb_go_symbol("duckdb_append_hugeint");
+	}
+	return fn(appender, value);
+}
+
+typedef duckdb_state (*duckdb_append_uint8_fn)(duckdb_appender appender, uint8_t value);
+duckdb_state duckdb_append_uint8(duckdb_appender appender, uint8_t value) {
+	static duckdb_append_uint8_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_uint8_fn)duckdb_go_symbol("duckdb_append_uint8");
+	}
+	return fn(appender, value);
+}
+
+typedef duckdb_state (*duckdb_append_uint16_fn)(duckdb_appender appender, uint16_t value);
+duckdb_state duckdb_append_uint16(duckdb_appender appender, uint16_t value) {
+	static duckdb_append_uint16_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_uint16_fn)duckdb_go_symbol("duckdb_append_uint16");
+	}
+	return fn(appender, value);
+}
+
+typedef duckdb_state (*duckdb_append_uint32_fn)(duckdb_appender appender, uint32_t value);
+duckdb_state duckdb_append_uint32(duckdb_appender appender, uint32_t value) {
+	static duckdb_append_uint32_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_uint32_fn)duckdb_go_symbol("duckdb_append_uint32");
+	}
+	return fn(appender, value);
+}
+
+typedef duckdb_state (*duckdb_append_uint64_fn)(duckdb_appender appender, uint64_t value);
+duckdb_state duckdb_append_uint64(duckdb_appender appender, uint64_t value) {
+	static duckdb_append_uint64_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_uint64_fn)duckdb_go_symbol("duckdb_append_uint64");
+	}
+	return fn(appender, value);
+}
+
+typedef duckdb_state (*duckdb_append_uhugeint_fn)(duckdb_appender appender, duckdb_uhugeint value);
+duckdb_state duckdb_append_uhugeint(duckdb_appender appender, duckdb_uhugeint value) {
+	static duckdb_append_uhugeint_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_uhugeint_fn)duckdb_go_symbol("duckdb_append_uhugeint");
+	}
+	return fn(appender, value);
+}
+
+typedef duckdb_state (*duckdb_append_float_fn)(duckdb_appender appender, float value);
+duckdb_state duckdb_append_float(duckdb_appender appender, float value) {
+	static duckdb_append_float_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_float_fn)duckdb_go_symbol("duckdb_append_float");
+	}
+	return fn(appender, value);
+}
+
+typedef duckdb_state (*duckdb_append_double_fn)(duckdb_appender appender, double value);
+duckdb_state duckdb_append_double(duckdb_appender appender, double value) {
+	static duckdb_append_double_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_double_fn)duckdb_go_symbol("duckdb_append_double");
+	}
+	return fn(appender, value);
+}
+
+typedef duckdb_state (*duckdb_append_date_fn)(duckdb_appender appender, duckdb_date value);
+duckdb_state duckdb_append_date(duckdb_appender appender, duckdb_date value) {
+	static duckdb_append_date_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_date_fn)duckdb_go_symbol("duckdb_append_date");
+	}
+	return fn(appender, value);
+}
+
+typedef duckdb_state (*duckdb_append_time_fn)(duckdb_appender appender, duckdb_time value);
+duckdb_state duckdb_append_time(duckdb_appender appender, duckdb_time value) {
+	static duckdb_append_time_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_time_fn)duckdb_go_symbol("duckdb_append_time");
+	}
+	return fn(appender, value);
+}
+
+typedef duckdb_state (*duckdb_append_timestamp_fn)(duckdb_appender appender, duckdb_timestamp value);
+duckdb_state duckdb_append_timestamp(duckdb_appender appender, duckdb_timestamp value) {
+	static duckdb_append_timestamp_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_timestamp_fn)duckdb_go_symbol("duckdb_append_timestamp");
+	}
+	return fn(appender, value);
+}
+
+typedef duckdb_state (*duckdb_append_interval_fn)(duckdb_appender appender, duckdb_interval value);
+duckdb_state duckdb_append_interval(duckdb_appender appender, duckdb_interval value) {
+	static duckdb_append_interval_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_interval_fn)duckdb_go_symbol("duckdb_append_interval");
+	}
+	return fn(appender, value);
+}
+
+typedef duckdb_state (*duckdb_append_varchar_fn)(duckdb_appender appender, const char *val);
+duckdb_state duckdb_append_varchar(duckdb_appender appender, const char *val) {
+	static duckdb_append_varchar_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_varchar_fn)duckdb_go_symbol("duckdb_append_varchar");
+	}
+	return fn(appender, val);
+}
+
+typedef duckdb_state (*duckdb_append_varchar_length_fn)(duckdb_appender appender, const char *val, idx_t length);
+duckdb_state duckdb_append_varchar_length(duckdb_appender appender, const char *val, idx_t length) {
+	static duckdb_append_varchar_length_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_varchar_length_fn)duckdb_go_symbol("duckdb_append_varchar_length");
+	}
+	return fn(appender, val, length);
+}
+
+typedef duckdb_state (*duckdb_append_blob_fn)(duckdb_appender appender, const void *data, idx_t length);
+duckdb_state duckdb_append_blob(duckdb_appender appender, const void *data, idx_t length) {
+	static duckdb_append_blob_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_blob_fn)duckdb_go_symbol("duckdb_append_blob");
+	}
+	return fn(appender, data, length);
+}
+
+typedef duckdb_state (*duckdb_append_null_fn)(duckdb_appender appender);
+duckdb_state duckdb_append_null(duckdb_appender appender) {
+	static duckdb_append_null_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_null_fn)duckdb_go_symbol("duckdb_append_null");
+	}
+	return fn(appender);
+}
+
+typedef duckdb_state (*duckdb_append_data_chunk_fn)(duckdb_appender appender, duckdb_data_chunk chunk);
+duckdb_state duckdb_append_data_chunk(duckdb_appender appender, duckdb_data_chunk chunk) {
+	static duckdb_append_data_chunk_fn fn;
+	if (!fn) {
+		fn = (duckdb_append_data_chunk_fn)duckdb_go_symbol("duckdb_append_data_chunk");
+	}
+	return fn(appender, chunk);
+}
+
+typedef duckdb_state (*duckdb_query_arrow_fn)(duckdb_connection connection, const char *query, duckdb_arrow *out_result);
+duckdb_state duckdb_query_arrow(duckdb_connection connection, const char *query, duckdb_arrow *out_result) {
+	static duckdb_query_arrow_fn fn;
+	if (!fn) {
+		fn = (duckdb_query_arrow_fn)duckdb_go_symbol("duckdb_query_arrow");
+	}
+	return fn(connection, query, out_result);
+}
+
+typedef duckdb_state (*duckdb_query_arrow_schema_fn)(duckdb_arrow result, duckdb_arrow_schema *out_schema);
+duckdb_state duckdb_query_arrow_schema(duckdb_arrow result, duckdb_arrow_schema *out_schema) {
+	static duckdb_query_arrow_schema_fn fn;
+	if (!fn) {
+		fn = (duckdb_query_arrow_schema_fn)duckdb_go_symbol("duckdb_query_arrow_schema");
+	}
+	return fn(result, out_schema);
+}
+
+typedef duckdb_state (*duckdb_prepared_arrow_schema_fn)(duckdb_prepared_statement prepared, duckdb_arrow_schema *out_schema);
+duckdb_state duckdb_prepared_arrow_schema(duckdb_prepared_statement prepared, duckdb_arrow_schema *out_schema) {
+	static duckdb_prepared_arrow_schema_fn fn;
+	if (!fn) {
+		fn = (duckdb_prepared_arrow_schema_fn)duckdb_go_symbol("duckdb_prepared_arrow_schema");
+	}
+	return fn(prepared, out_schema);
+}
+
+typedef void (*duckdb_result_arrow_array_fn)(duckdb_result result, duckdb_data_chunk chunk, duckdb_arrow_array *out_array);
+void duckdb_result_arrow_array(duckdb_result result, duckdb_data_chunk chunk, duckdb_arrow_array *out_array) {
+	static duckdb_result_arrow_array_fn fn;
+	if (!fn) {
+		fn = (duckdb_result_arrow_array_fn)duckdb_go_symbol("duckdb_result_arrow_array");
+	}
+	fn(result, chunk, out_array);
+}
+
+typedef duckdb_state (*duckdb_query_arrow_array_fn)(duckdb_arrow result, duckdb_arrow_array *out_array);
+duckdb_state duckdb_query_arrow_array(duckdb_arrow result, duckdb_arrow_array *out_array) {
+	static duckdb_query_arrow_array_fn fn;
+	if (!fn) {
+		fn = (duckdb_query_arrow_array_fn)duckdb_go_symbol("duckdb_query_arrow_array");
+	}
+	return fn(result, out_array);
+}
+
+typedef idx_t (*duckdb_arrow_column_count_fn)(duckdb_arrow result);
+idx_t duckdb_arrow_column_count(duckdb_arrow result) {
+	static duckdb_arrow_column_count_fn fn;
+	if (!fn) {
+		fn = (duckdb_arrow_column_count_fn)duckdb_go_symbol("duckdb_arrow_column_count");
+	}
+	return fn(result);
+}
+
+typedef idx_t (*duckdb_arrow_row_count_fn)(duckdb_arrow result);
+idx_t duckdb_arrow_row_count(duckdb_arrow result) {
+	static duckdb_arrow_row_count_fn fn;
+	if (!fn) {
+		fn = (duckdb_arrow_row_count_fn)duckdb_go_symbol("duckdb_arrow_row_count");
+	}
+	return fn(result);
+}
+
+typedef idx_t (*duckdb_arrow_rows_changed_fn)(duckdb_arrow result);
+idx_t duckdb_arrow_rows_changed(duckdb_arrow result) {
+	static duckdb_arrow_rows_changed_fn fn;
+	if (!fn) {
+		fn = (duckdb_arrow_rows_changed_fn)duckdb_go_symbol("duckdb_arrow_rows_changed");
+	}
+	return fn(result);
+}
+
+typedef const char * (*duckdb_query_arrow_error_fn)(duckdb_arrow result);
+const char * duckdb_query_arrow_error(duckdb_arrow result) {
+	static duckdb_query_arrow_error_fn fn;
+	if (!fn) {
+		fn = (duckdb_query_arrow_error_fn)duckdb_go_symbol("duckdb_query_arrow_error");
+	}
+	return fn(result);
+}
+
+typedef void (*duckdb_destroy_arrow_fn)(duckdb_arrow *result);
+void duckdb_destroy_arrow(duckdb_arrow *result) {
+	static duckdb_destroy_arrow_fn fn;
+	if (!fn) {
+		fn = (duckdb_destroy_arrow_fn)duckdb_go_symbol("duckdb_destroy_arrow");
+	}
+	fn(result);
+}
+
+typedef void (*duckdb_destroy_arrow_stream_fn)(duckdb_arrow_stream *stream_p);
+void duckdb_destroy_arrow_stream(duckdb_arrow_stream *stream_p) {
+	static duckdb_destroy_arrow_stream_fn fn;
+	if (!fn) {
+		fn = (duckdb_destroy_arrow_stream_fn)duckdb_go_symbol("duckdb_destroy_arrow_stream");
+	}
+	fn(stream_p);
+}
+
+typedef duckdb_state (*duckdb_execute_prepared_arrow_fn)(duckdb_prepared_statement prepared_statement, duckdb_arrow *out_result);
+duckdb_state duckdb_execute_prepared_arrow(duckdb_prepared_statement prepared_statement, duckdb_arrow *out_result) {
+	static duckdb_execute_prepared_arrow_fn fn;
+	if (!fn) {
+		fn = (duckdb_execute_prepared_arrow_fn)duckdb_go_symbol("duckdb_execute_prepared_arrow");
+	}
+	return fn(prepared_statement, out_result);
+}
+
+typedef duckdb_state (*duckdb_arrow_scan_fn)(duckdb_connection connection, const char *table_name, duckdb_arrow_stream arrow);
+duckdb_state duckdb_arrow_scan(duckdb_connection connection, const char *table_name, duckdb_arrow_stream arrow) {
+	static duckdb_arrow_scan_fn fn;
+	if (!fn) {
+		fn = (duckdb_arrow_scan_fn)duckdb_go_symbol("duckdb_arrow_scan");
+	}
+	return fn(connection, table_name, arrow);
+}
+
+typedef duckdb_state (*duckdb_arrow_array_scan_fn)(duckdb_connection connection, const char *table_name, duckdb_arrow_schema arrow_schema, duckdb_arrow_array arrow_array, duckdb_arrow_stream *out_stream);
+duckdb_state duckdb_arrow_array_scan(duckdb_connection connection, const char *table_name, duckdb_arrow_schema arrow_schema, duckdb_arrow_array arrow_array, duckdb_arrow_stream *out_stream) {
+	static duckdb_arrow_array_scan_fn fn;
+	if (!fn) {
+		fn = (duckdb_arrow_array_scan_fn)duckdb_go_symbol("duckdb_arrow_array_scan");
+	}
+	return fn(connection, table_name, arrow_schema, arrow_array, out_stream);
+}
+
+typedef void (*duckdb_execute_tasks_fn)(duckdb_database database, idx_t max_tasks);
+void duckdb_execute_tasks(duckdb_database database, idx_t max_tasks) {
+	static duckdb_execute_tasks_fn fn;
+	if (!fn) {
+		fn = (duckdb_execute_tasks_fn)duckdb_go_symbol("duckdb_execute_tasks");
+	}
+	fn(database, max_tasks);
+}
+
+typedef duckdb_task_state (*duckdb_create_task_state_fn)(duckdb_database database);
+duckdb_task_state duckdb_create_task_state(duckdb_database database) {
+	static duckdb_create_task_state_fn fn;
+	if (!fn) {
+		fn = (duckdb_create_task_state_fn)duckdb_go_symbol("duckdb_create_task_state");
+	}
+	return fn(database);
+}
+
+typedef void (*duckdb_execute_tasks_state_fn)(duckdb_task_state state);
+void duckdb_execute_tasks_state(duckdb_task_state state) {
+	static duckdb_execute_tasks_state_fn fn;
+	if (!fn) {
+		fn = (duckdb_execute_tasks_state_fn)duckdb_go_symbol("duckdb_execute_tasks_state");
+	}
+	fn(state);
+}
+
+typedef idx_t (*duckdb_execute_n_tasks_state_fn)(duckdb_task_state state, idx_t max_tasks);
+idx_t duckdb_execute_n_tasks_state(duckdb_task_state state, idx_t max_tasks) {
+	static duckdb_execute_n_tasks_state_fn fn;
+	if (!fn) {
+		fn = (duckdb_execute_n_tasks_state_fn)duckdb_go_symbol("duckdb_execute_n_tasks_state");
+	}
+	return fn(state, max_tasks);
+}
+
+typedef void (*duckdb_finish_execution_fn)(duckdb_task_state state);
+void duckdb_finish_execution(duckdb_task_state state) {
+	static duckdb_finish_execution_fn fn;
+	if (!fn) {
+		fn = (duckdb_finish_execution_fn)duckdb_go_symbol("duckdb_finish_execution");
+	}
+	fn(state);
+}
+
+typedef bool (*duckdb_task_state_is_finished_fn)(duckdb_task_state state);
+bool duckdb_task_state_is_finished(duckdb_task_state state) {
+	static duckdb_task_state_is_finished_fn fn;
+	if (!fn) {
+		fn = (duckdb_task_state_is_finished_fn)duckdb_go_symbol("duckdb_task_state_is_finished");
+	}
+	return fn(state);
+}
+
+typedef void (*duckdb_destroy_task_state_fn)(duckdb_task_state state);
+void duckdb_destroy_task_state(duckdb_task_state state) {
+	static duckdb_destroy_task_state_fn fn;
+	if (!fn) {
+		fn = (duckdb_destroy_task_state_fn)duckdb_go_symbol("duckdb_destroy_task_state");
+	}
+	fn(state);
+}
+
+typedef bool (*duckdb_execution_is_finished_fn)(duckdb_connection con);
+bool duckdb_execution_is_finished(duckdb_connection con) {
+	static duckdb_execution_is_finished_fn fn;
+	if (!fn) {
+		fn = (duckdb_execution_is_finished_fn)duckdb_go_symbol("duckdb_execution_is_finished");
+	}
+	return fn(con);
+}
+
+typedef duckdb_data_chunk (*duckdb_stream_fetch_chunk_fn)(duckdb_result result);
+duckdb_data_chunk duckdb_stream_fetch_chunk(duckdb_result result) {
+	static duckdb_stream_fetch_chunk_fn fn;
+	if (!fn) {
+		fn = (duckdb_stream_fetch_chunk_fn)duckdb_go_symbol("duckdb_stream_fetch_chunk");
+	}
+	return fn(result);
+}
@@ -4,6 +4,8 @@
 // Package duckdb implements a database/sql driver for the DuckDB database.
 package duckdb
 
+//go:generate go run gen_dlopen.go
+
 /*
 #include <stdlib.h>
 #include <duckdb.h>
@@ -16,7 +18,11 @@ import (
 	"database/sql/driver"
 	"fmt"
 	"net/url"
+	"runtime"
+	"sort"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"unsafe"
 )
 
@@ -41,68 +47,242 @@ func (Driver) OpenConnector(dsn string) (driver.Connector, error) {
 }
 
 // NewConnector opens a new Connector for a DuckDB database.
+// A DSN of a named in-memory database, e.g., :memory:name or :memory:name?cache=shared, opens a database,
+// which all Connectors of the process with the same name share. The configuration options of the first
+// Connector opening it apply, and the database closes with its last Connector.
+// A DSN of a MotherDuck database, e.g., md:my_db, connects to MotherDuck, see WithMotherDuckToken.
 // The user must close the Connector, if it is not passed to the sql.OpenDB function.
 // Otherwise, sql.DB closes the Connector when calling sql.DB.Close().
-func NewConnector(dsn string, connInitFn func(execer driver.ExecerContext) error) (*Connector, error) {
-	var db C.duckdb_database
+// The ConnectorOptions are applied after the configuration options of the DSN, e.g., WithConfig and WithConnInitFn
+// configure the database without URL-encoding the options into the DSN. The connInitFn can be nil.
+func NewConnector(dsn string, connInitFn func(execer driver.ExecerContext) error, opts ...ConnectorOption) (*Connector, error) {
+	path := getConnString(dsn)
+	// url.Parse rejects in-memory paths, e.g., :memory:name, so only their options are parsed.
+	dsnURL := dsn
+	if strings.HasPrefix(path, sharedMemoryPrefix) {
+		dsnURL = dsn[len(path):]
+	}
+	parsedDSN, err := url.Parse(dsnURL)
+	if err != nil {
+		return nil, getError(errParseDSN, err)
+	}
 
-	parsedDSN, err := url.Parse(dsn)
+	shared, err := sharedMemoryName(path, parsedDSN)
 	if err != nil {
 		return nil, getError(errParseDSN, err)
 	}
 
-	config, err := prepareConfig(parsedDSN)
+	initQueries := dsnInitQueries(parsedDSN)
+	connConfig, err := newConnectorConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	connConfig.connInitQueries = append(initQueries, connConfig.connInitQueries...)
+	setMotherDuckToken(path, parsedDSN, connConfig)
+
+	open := func() (C.duckdb_database, error) {
+		if shared != "" {
+			path = ":memory:"
+		}
+		return openDatabase(path, parsedDSN, connConfig)
+	}
+
+	var db C.duckdb_database
+	opened := true
+	if shared != "" {
+		db, opened, err = sharedDatabases.acquire(shared, open)
+	} else {
+		db, err = open()
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Connector{
+		db:         db,
+		connInitFn: connInitFn,
+		config:     connConfig,
+		shared:     shared,
+
+		memoryLimitSem: make(chan struct{}, 1),
+	}
+	if !opened {
+		return c, nil
+	}
+	if err = c.executeBootQueries(); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+// OpenDB opens a new Connector with the options, see NewConnector, and returns a sql.DB of it, which closes the
+// Connector when closing the sql.DB. DuckDB is an embedded database, whose connections are cheap and lose their
+// session state, e.g., temporary tables, when closed, so the pool keeps up to GOMAXPROCS connections open and idle,
+// without closing them after a lifetime or idle time. Setting the pool options of the sql.DB overrides these defaults,
+// e.g., SetMaxOpenConns(1) serializes the statements of a single writer.
+func OpenDB(dsn string, opts ...ConnectorOption) (*sql.DB, error) {
+	connector, err := NewConnector(dsn, nil, opts...)
+	if err != nil {
+		return nil, err
+	}
+	db := sql.OpenDB(connector)
+	conns := runtime.GOMAXPROCS(0)
+	db.SetMaxOpenConns(conns)
+	db.SetMaxIdleConns(conns)
+	db.SetConnMaxLifetime(0)
+	db.SetConnMaxIdleTime(0)
+	return db, nil
+}
+
+// openDatabase opens the database at the path with the configuration options of the DSN and the connector.
+func openDatabase(path string, parsedDSN *url.URL, connConfig *connectorConfig) (C.duckdb_database, error) {
+	config, err := prepareConfig(parsedDSN, connConfig)
 	if err != nil {
 		return nil, err
 	}
 	defer C.duckdb_destroy_config(&config)
 
-	connStr := C.CString(getConnString(dsn))
+	connStr := C.CString(path)
 	defer C.free(unsafe.Pointer(connStr))
 
 	var outError *C.char
 	defer C.duckdb_free(unsafe.Pointer(outError))
 
+	var db C.duckdb_database
 	if state := C.duckdb_open_ext(connStr, &db, config, &outError); state == C.DuckDBError {
-		return nil, getError(errOpen, duckdbError(outError))
+		// DuckDB accepts unknown options when setting them, as they can be options of extensions.
+		// It only rejects them when opening the database, and without listing the valid options.
+		err = duckdbError(outError)
+		if name := unknownConfigFlag(parsedDSN, connConfig); name != "" &&
+			strings.Contains(err.Error(), "Unrecognized configuration property") {
+			return nil, getError(errOpen, fmt.Errorf("%w: %s", err, unknownConfigFlagError(name)))
+		}
+		return nil, getError(errOpen, err)
 	}
+	return db, nil
+}
 
-	return &Connector{
-		db:         db,
-		connInitFn: connInitFn,
-	}, nil
+// executeBootQueries executes the queries of WithBootQueries on a temporary connection.
+func (c *Connector) executeBootQueries() error {
+	if len(c.config.bootQueries) == 0 {
+		return nil
+	}
+
+	var duckdbCon C.duckdb_connection
+	if state := C.duckdb_connect(c.db, &duckdbCon); state == C.DuckDBError {
+		return getError(errConnect, nil)
+	}
+	con := &conn{duckdbCon: duckdbCon, config: c.config, queries: &c.queries, connectorClosed: &c.closed}
+	defer con.Close()
+
+	for _, query := range c.config.bootQueries {
+		if _, err := con.ExecContext(context.Background(), query, nil); err != nil {
+			return getError(errBootQuery, err)
+		}
+	}
+	return nil
 }
 
 type Connector struct {
 	db         C.duckdb_database
 	connInitFn func(execer driver.ExecerContext) error
+	config     *connectorConfig
+
+	// queries tracks the queries in flight on the connections, see ActiveQueries.
+	queries queryRegistry
+
+	// shared is the name of the shared in-memory database of the connector, if any, see NewConnector.
+	shared string
+	// closed is set by Close, so that the connections of the connector are no longer valid, see conn.IsValid.
+	closed atomic.Bool
+	// stats collects the statistics of the connections, see Stats.
+	stats connectorStats
+
+	// extensionsMu serializes installing extensions, see WithExtensions.
+	extensionsMu        sync.Mutex
+	installedExtensions map[string]struct{}
+
+	// memoryLimitSem serializes the statements with a memory limit, see ContextWithMemoryLimit.
+	memoryLimitSem chan struct{}
 }
 
 func (*Connector) Driver() driver.Driver {
 	return Driver{}
 }
 
-func (c *Connector) Connect(context.Context) (driver.Conn, error) {
+// Connect returns a new connection to the database. After closing the Connector, it returns an error matching ErrClosed.
+func (c *Connector) Connect(ctx context.Context) (driver.Conn, error) {
+	if c.closed.Load() {
+		return nil, getError(errConnect, errClosedConnector)
+	}
 	var duckdbCon C.duckdb_connection
 	if state := C.duckdb_connect(c.db, &duckdbCon); state == C.DuckDBError {
 		return nil, getError(errConnect, nil)
 	}
 
-	con := &conn{duckdbCon: duckdbCon}
+	con := &conn{
+		duckdbCon:       duckdbCon,
+		config:          c.config,
+		id:              c.queries.newConnID(),
+		queries:         &c.queries,
+		connectorClosed: &c.closed,
+		stats:           &c.stats,
+		stmts:           newStmtCache(c.config.stmtCacheSize),
+		memoryLimitSem:  c.memoryLimitSem,
+		connector:       c,
+	}
+	c.stats.openConnections.Add(1)
+
+	if err := c.loadExtensions(ctx, con); err != nil {
+		con.Close()
+		return nil, err
+	}
 
 	if c.connInitFn != nil {
 		if err := c.connInitFn(con); err != nil {
+			con.Close()
+			return nil, err
+		}
+	}
+	for _, fn := range c.config.connInitFns {
+		if err := fn(con); err != nil {
+			con.Close()
 			return nil, err
 		}
 	}
+	for _, query := range c.config.connInitQueries {
+		if _, err := con.ExecContext(ctx, query, nil); err != nil {
+			con.Close()
+			return nil, getError(errConnInitQuery, err)
+		}
+	}
 
+	// The session of the initialized connection is its initial session, see ResetSession.
+	con.initialSessionState = ""
+	con.sessionDirty = false
 	return con, nil
 }
 
+// Close closes the Connector, and the database, unless it is shared with other Connectors. Closing a closed Connector
+// does nothing, like closing a closed sql.DB.
 func (c *Connector) Close() error {
-	C.duckdb_close(&c.db)
+	if !c.closed.CompareAndSwap(false, true) {
+		return nil
+	}
+	var err error
+	if c.config.checkpointOnClose && c.db != nil {
+		err = c.checkpointOnClose()
+	}
+	if c.shared != "" {
+		if c.db != nil {
+			sharedDatabases.release(c.shared)
+		}
+	} else {
+		C.duckdb_close(&c.db)
+	}
 	c.db = nil
-	return nil
+	return err
 }
 
 func getConnString(dsn string) string {
@@ -113,7 +293,7 @@ func getConnString(dsn string) string {
 	return dsn[0:idx]
 }
 
-func prepareConfig(parsedDSN *url.URL) (C.duckdb_config, error) {
+func prepareConfig(parsedDSN *url.URL, connConfig *connectorConfig) (C.duckdb_config, error) {
 	var config C.duckdb_config
 	if state := C.duckdb_create_config(&config); state == C.DuckDBError {
 		C.duckdb_destroy_config(&config)
@@ -124,20 +304,25 @@ func prepareConfig(parsedDSN *url.URL) (C.duckdb_config, error) {
 		return nil, err
 	}
 
-	// Early-out, if the DSN does not contain configuration options.
-	if len(parsedDSN.RawQuery) == 0 {
-		return config, nil
-	}
-
 	for k, v := range parsedDSN.Query() {
 		if len(v) == 0 {
 			continue
 		}
+		if _, ok := connConfig.config[k]; ok {
+			// The connector option takes precedence.
+			continue
+		}
 		if err := setConfigOption(config, k, v[0]); err != nil {
 			return nil, err
 		}
 	}
 
+	for k, v := range connConfig.config {
+		if err := setConfigOption(config, k, v); err != nil {
+			return nil, err
+		}
+	}
+
 	return config, nil
 }
 
@@ -156,3 +341,100 @@ func setConfigOption(config C.duckdb_config, name string, option string) error {
 
 	return nil
 }
+
+// ConfigFlag is a configuration option of the linked DuckDB version, see ConfigFlags.
+type ConfigFlag struct {
+	Name        string
+	Description string
+}
+
+// configFlags enumerates the configuration options once, as DuckDB loops over all options for each of them.
+var configFlags = sync.OnceValue(func() []ConfigFlag {
+	count := int(C.duckdb_config_count())
+	flags := make([]ConfigFlag, 0, count)
+	for i := 0; i < count; i++ {
+		var name, description *C.char
+		if C.duckdb_get_config_flag(C.size_t(i), &name, &description) == C.DuckDBError {
+			continue
+		}
+		flags = append(flags, ConfigFlag{Name: C.GoString(name), Description: C.GoString(description)})
+	}
+	sort.Slice(flags, func(i, j int) bool { return flags[i].Name < flags[j].Name })
+	return flags
+})
+
+// ConfigFlags returns the configuration options of the linked DuckDB version, ordered by their names.
+// Some of them are local options of a connection, which the DSN cannot set, e.g., schema.
+func ConfigFlags() []ConfigFlag {
+	return append([]ConfigFlag(nil), configFlags()...)
+}
+
+// isConfigFlag returns true, if the name is a configuration option, case-insensitively like DuckDB.
+func isConfigFlag(name string) bool {
+	for _, flag := range configFlags() {
+		if strings.EqualFold(flag.Name, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// unknownConfigFlag returns the first option of the DSN or the connector, which is not a configuration option.
+func unknownConfigFlag(parsedDSN *url.URL, connConfig *connectorConfig) string {
+	var names []string
+	for name := range parsedDSN.Query() {
+		names = append(names, name)
+	}
+	for name := range connConfig.config {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !isConfigFlag(name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// unknownConfigFlagError returns the error of an unknown configuration option, which suggests the most
+// similar option, and lists all options.
+func unknownConfigFlagError(name string) error {
+	flags := configFlags()
+	names := make([]string, len(flags))
+	suggestion, minDistance := "", -1
+	for i, flag := range flags {
+		names[i] = flag.Name
+		if d := editDistance(strings.ToLower(name), flag.Name); minDistance < 0 || d < minDistance {
+			suggestion, minDistance = flag.Name, d
+		}
+	}
+
+	msg := fmt.Sprintf("%s %s", unknownConfigErrMsg, name)
+	if minDistance >= 0 && minDistance <= len(name)/2 {
+		msg += fmt.Sprintf(", did you mean %s?", suggestion)
+	}
+	return fmt.Errorf("%s (valid options: %s)", msg, strings.Join(names, ", "))
+}
+
+// editDistance returns the Levenshtein distance of the strings.
+func editDistance(a, b string) int {
+	prev := make([]int, len(b)+1)
+	curr := make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		curr[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
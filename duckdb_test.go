@@ -5,10 +5,15 @@ import (
 	"database/sql"
 	"database/sql/driver"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
 	"os"
 	"reflect"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -53,6 +58,76 @@ func TestOpen(t *testing.T) {
 	})
 }
 
+func TestOpenDB(t *testing.T) {
+	t.Parallel()
+
+	db, err := OpenDB(":memory:open_db", WithThreads(2))
+	require.NoError(t, err)
+	require.Equal(t, runtime.GOMAXPROCS(0), db.Stats().MaxOpenConnections)
+
+	var threads int64
+	require.NoError(t, db.QueryRow(`SELECT current_setting('threads')`).Scan(&threads))
+	require.Equal(t, int64(2), threads)
+
+	// The pool keeps the connection open.
+	con, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	_, err = con.ExecContext(context.Background(), `CREATE TABLE tbl (i INTEGER)`)
+	require.NoError(t, err)
+	require.NoError(t, con.Close())
+	require.Equal(t, 1, db.Stats().Idle)
+
+	// Closing the sql.DB closes the Connector, and with it the named in-memory database.
+	require.NoError(t, db.Close())
+	db, err = OpenDB(":memory:open_db")
+	require.NoError(t, err)
+	defer db.Close()
+	_, err = db.Exec(`SELECT * FROM tbl`)
+	require.ErrorContains(t, err, "tbl")
+
+	_, err = OpenDB("", WithThreads(-1))
+	testError(t, err, errInvalidOption.Error(), "threads")
+}
+
+func TestUseAfterClose(t *testing.T) {
+	t.Parallel()
+
+	connector, err := NewConnector("", nil)
+	require.NoError(t, err)
+	ctx := context.Background()
+	driverConn, err := connector.Connect(ctx)
+	require.NoError(t, err)
+	con := driverConn.(*conn)
+
+	s, err := con.PrepareContext(ctx, `SELECT 42`)
+	require.NoError(t, err)
+	require.NoError(t, s.Close())
+	require.ErrorIs(t, s.Close(), ErrClosed)
+	require.Equal(t, -1, s.NumInput())
+	_, err = s.(*stmt).ExecContext(ctx, nil)
+	require.ErrorIs(t, err, ErrClosed)
+
+	r, err := con.QueryContext(ctx, `SELECT 42`, nil)
+	require.NoError(t, err)
+	require.NoError(t, r.Close())
+	require.NoError(t, r.Close())
+	require.ErrorIs(t, r.Next(make([]driver.Value, 1)), ErrClosed)
+
+	require.NoError(t, con.Close())
+	require.ErrorIs(t, con.Close(), ErrClosed)
+	_, err = con.ExecContext(ctx, `SELECT 42`, nil)
+	require.ErrorIs(t, err, ErrClosed)
+	_, err = con.QueryContext(ctx, `SELECT 42`, nil)
+	require.ErrorIs(t, err, ErrClosed)
+	_, err = con.PrepareContext(ctx, `SELECT 42`)
+	require.ErrorIs(t, err, ErrClosed)
+
+	require.NoError(t, connector.Close())
+	require.NoError(t, connector.Close())
+	_, err = connector.Connect(ctx)
+	require.ErrorIs(t, err, ErrClosed)
+}
+
 func TestConnectorBootQueries(t *testing.T) {
 	t.Run("many boot queries", func(t *testing.T) {
 		connector, err := NewConnector("", func(execer driver.ExecerContext) error {
@@ -149,6 +224,56 @@ func TestConnPool(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestConcurrentUseOfConn(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	con, err := c.Connect(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	_, err = con.(driver.ExecerContext).ExecContext(context.Background(), `CREATE TABLE tbl (i INTEGER)`, nil)
+	require.NoError(t, err)
+
+	// Violate the contract of database/sql by using one driver connection from many goroutines.
+	const goroutines, iterations = 16, 50
+	var wg sync.WaitGroup
+	for g := 0; g < goroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := 0; i < iterations; i++ {
+				_, err := con.(driver.ExecerContext).ExecContext(context.Background(), `INSERT INTO tbl VALUES (?)`,
+					[]driver.NamedValue{{Ordinal: 1, Value: int64(g)}})
+				if !assert.NoError(t, err) {
+					return
+				}
+
+				rows, err := con.(driver.QueryerContext).QueryContext(context.Background(),
+					`SELECT count(*) FROM tbl WHERE i = ?`, []driver.NamedValue{{Ordinal: 1, Value: int64(g)}})
+				if !assert.NoError(t, err) {
+					return
+				}
+				values := make([]driver.Value, 1)
+				assert.NoError(t, rows.Next(values))
+				assert.Equal(t, int64(i+1), values[0])
+				assert.NoError(t, rows.Close())
+			}
+		}(g)
+	}
+	wg.Wait()
+
+	rows, err := con.(driver.QueryerContext).QueryContext(context.Background(), `SELECT count(*) FROM tbl`, nil)
+	require.NoError(t, err)
+	defer rows.Close()
+	values := make([]driver.Value, 1)
+	require.NoError(t, rows.Next(values))
+	require.Equal(t, int64(goroutines*iterations), values[0])
+}
+
 func TestConnInit(t *testing.T) {
 	connector, err := NewConnector("", func(execer driver.ExecerContext) error {
 		bootQueries := []string{
@@ -212,6 +337,37 @@ func TestExec(t *testing.T) {
 	require.NotNil(t, createTable(db, t))
 }
 
+func TestRowsAffected(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	rowsAffected := func(query string, args ...any) int64 {
+		res, err := db.Exec(query, args...)
+		require.NoError(t, err)
+		affected, err := res.RowsAffected()
+		require.NoError(t, err)
+		return affected
+	}
+
+	require.Equal(t, int64(0), rowsAffected("CREATE TABLE users (id INTEGER PRIMARY KEY, name VARCHAR)"))
+	require.Equal(t, int64(3), rowsAffected("INSERT INTO users VALUES (1, 'a'), (2, 'b'), (3, 'c')"))
+	require.Equal(t, int64(2), rowsAffected("UPDATE users SET name = upper(name) WHERE id > ?", 1))
+	require.Equal(t, int64(0), rowsAffected("UPDATE users SET name = 'd' WHERE id > 3"))
+
+	// Upserts count the inserted and the updated rows, but not the ignored rows.
+	require.Equal(t, int64(2), rowsAffected(`INSERT INTO users VALUES (3, 'x'), (4, 'd')
+		ON CONFLICT DO UPDATE SET name = excluded.name`))
+	require.Equal(t, int64(1), rowsAffected("INSERT INTO users VALUES (4, 'y'), (5, 'e') ON CONFLICT DO NOTHING"))
+	require.Equal(t, int64(2), rowsAffected("DELETE FROM users WHERE id >= 4 RETURNING id"))
+
+	// Statements, which do not change rows, affect 0 rows.
+	require.Equal(t, int64(0), rowsAffected("SELECT 42"))
+	require.Equal(t, int64(0), rowsAffected("SELECT * FROM users"))
+	require.Equal(t, int64(0), rowsAffected("SET threads = 2"))
+	require.Equal(t, int64(1), rowsAffected("CREATE TABLE tmp (i INTEGER); INSERT INTO tmp VALUES (1)"))
+}
+
 func TestQuery(t *testing.T) {
 	t.Parallel()
 	db := openDB(t)
@@ -332,6 +488,63 @@ func TestStruct(t *testing.T) {
 		}
 		require.Equal(t, want, result)
 	})
+
+	t.Run("scan into map", func(t *testing.T) {
+		var result map[string]any
+		require.NoError(t, db.QueryRow("SELECT {'x': 1, 'y': 'a'}").Scan(&result))
+		require.Equal(t, map[string]any{"x": int32(1), "y": "a"}, result)
+	})
+
+	t.Run("scan into tagged struct", func(t *testing.T) {
+		type address struct {
+			City string `db:"city_name"`
+		}
+		type user struct {
+			ID        int64
+			Name      *string
+			Addresses []address
+			Home      address `db:"home"`
+			Ignored   string  `db:"-"`
+		}
+
+		var result Struct[user]
+		require.NoError(t, db.QueryRow(`SELECT {
+			'id': 1,
+			'name': NULL::VARCHAR,
+			'addresses': [{'city_name': 'Amsterdam'}, {'city_name': 'Berlin'}],
+			'home': {'city_name': 'Paris'}
+		}`).Scan(&result))
+		require.True(t, result.Valid)
+		require.Equal(t, user{
+			ID:        1,
+			Addresses: []address{{"Amsterdam"}, {"Berlin"}},
+			Home:      address{"Paris"},
+		}, result.Value)
+
+		require.NoError(t, db.QueryRow("SELECT NULL::STRUCT(id BIGINT)").Scan(&result))
+		require.False(t, result.Valid)
+		require.Zero(t, result.Value)
+
+		var list List[user]
+		require.NoError(t, db.QueryRow("SELECT [{'id': 2}, {'id': 3}]").Scan(&list))
+		require.Equal(t, List[user]{{ID: 2}, {ID: 3}}, list)
+	})
+
+	t.Run("scan into struct errors", func(t *testing.T) {
+		type point struct {
+			X int8
+		}
+
+		var result Struct[point]
+		err := db.QueryRow("SELECT {'x': 1, 'z': 2}").Scan(&result)
+		testError(t, err, errScanStructVal.Error(), missingKeyErrMsg, "z")
+
+		err = db.QueryRow("SELECT {'x': 1000}").Scan(&result)
+		testError(t, err, errScanStructVal.Error(), castErrMsg, "x")
+
+		err = db.QueryRow("SELECT {'x': NULL::INTEGER}").Scan(&result)
+		testError(t, err, errScanStructVal.Error(), errUnexpectedNull.Error())
+	})
 }
 
 func TestMap(t *testing.T) {
@@ -359,26 +572,30 @@ func TestMap(t *testing.T) {
 			sqlType string
 			input   Map
 		}{
-			// {
-			// 	"MAP(VARCHAR, VARCHAR)",
-			// 	Map{"foo": "bar"},
-			// },
-			// {
-			// 	"MAP(INTEGER, VARCHAR)",
-			// 	Map{int32(1): "bar"},
-			// },
-			// {
-			// 	"MAP(VARCHAR, BIGINT)",
-			// 	Map{"foo": int64(2)},
-			// },
-			// {
-			// 	"MAP(VARCHAR, BOOLEAN)",
-			// 	Map{"foo": true},
-			// },
-			// {
-			// 	"MAP(DOUBLE, VARCHAR)",
-			// 	Map{1.1: "foobar"},
-			// },
+			{
+				"MAP(VARCHAR, VARCHAR)",
+				Map{"foo": "bar", `it's`: `"quoted", {a=b}`},
+			},
+			{
+				"MAP(INTEGER, VARCHAR)",
+				Map{int32(1): "bar"},
+			},
+			{
+				"MAP(VARCHAR, BIGINT)",
+				Map{"foo": int64(2), "bar": nil},
+			},
+			{
+				"MAP(VARCHAR, BOOLEAN)",
+				Map{"foo": true},
+			},
+			{
+				"MAP(DOUBLE, VARCHAR)",
+				Map{1.1: "foobar"},
+			},
+			{
+				"MAP(VARCHAR, INTEGER[])",
+				Map{"foo": []any{int32(1), nil}, "bar": []any{}},
+			},
 		}
 		for i, test := range tests {
 			_, err := db.Exec(fmt.Sprintf("CREATE TABLE map_test_%d(properties %s)", i, test.sqlType))
@@ -392,6 +609,214 @@ func TestMap(t *testing.T) {
 			require.Equal(t, test.input, m)
 		}
 	})
+
+	t.Run("bind go map", func(t *testing.T) {
+		var m Map
+		require.NoError(t, db.QueryRow("SELECT ?::MAP(VARCHAR, MAP(INTEGER, DOUBLE))",
+			map[string]map[int]float64{"a": {1: 1.5}, "b": nil}).Scan(&m))
+		require.Equal(t, Map{"a": Map{int32(1): 1.5}, "b": nil}, m)
+
+		ts := time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC)
+		require.NoError(t, db.QueryRow("SELECT ?::MAP(TIMESTAMP, UBIGINT)",
+			map[time.Time]uint64{ts: math.MaxUint64}).Scan(&m))
+		require.Equal(t, Map{ts: uint64(math.MaxUint64)}, m)
+
+		// Backslashes are kept, unless an unpaired one ends the string.
+		require.NoError(t, db.QueryRow("SELECT ?::MAP(VARCHAR, VARCHAR)",
+			map[string]string{`a\b`: `c\\`, "d": `e"\f`, `g\'`: "h"}).Scan(&m))
+		require.Equal(t, Map{`a\b`: `c\\`, "d": `e"\f`, `g\'`: "h"}, m)
+	})
+
+	t.Run("scan typed map", func(t *testing.T) {
+		var list List[map[string]int64]
+		require.NoError(t, db.QueryRow("SELECT [MAP {'a': 1}, MAP {}]").Scan(&list))
+		require.Equal(t, List[map[string]int64]{{"a": 1}, {}}, list)
+	})
+
+	t.Run("bind go map errors", func(t *testing.T) {
+		var m Map
+		err := db.QueryRow("SELECT ?::MAP(VARCHAR, VARCHAR)", map[string]string{"a": `'"`}).Scan(&m)
+		require.ErrorIs(t, err, errCouldNotBind)
+		require.ErrorContains(t, err, "single and double quotes")
+
+		err = db.QueryRow("SELECT ?::MAP(VARCHAR, VARCHAR)", map[string]string{"a": "NULL"}).Scan(&m)
+		require.ErrorIs(t, err, errCouldNotBind)
+
+		err = db.QueryRow("SELECT ?::MAP(VARCHAR, VARCHAR)", map[string]string{"k": `v\`}).Scan(&m)
+		require.ErrorIs(t, err, errCouldNotBind)
+		require.ErrorContains(t, err, "unpaired backslash")
+
+		err = db.QueryRow("SELECT ?::MAP(VARCHAR, BLOB)", map[string][]byte{"a": {1}}).Scan(&m)
+		require.ErrorIs(t, err, errCouldNotBind)
+		require.ErrorContains(t, err, castErrMsg)
+	})
+}
+
+func TestUnion(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE unions (u UNION(num INTEGER, str VARCHAR, list INTEGER[]))`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO unions VALUES (1), ('a'), ([1, 2]), (NULL), (union_value(num := NULL::INTEGER))`)
+	require.NoError(t, err)
+
+	rows, err := db.Query(`SELECT u FROM unions`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var values []any
+	for rows.Next() {
+		var u any
+		require.NoError(t, rows.Scan(&u))
+		values = append(values, u)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []any{
+		Union{Tag: "num", Value: int32(1)},
+		Union{Tag: "str", Value: "a"},
+		Union{Tag: "list", Value: []any{int32(1), int32(2)}},
+		nil,
+		Union{Tag: "num"},
+	}, values)
+
+	var u Union
+	require.NoError(t, db.QueryRow(`SELECT u FROM unions WHERE union_tag(u) = 'str'`).Scan(&u))
+	require.Equal(t, Union{Tag: "str", Value: "a"}, u)
+}
+
+func TestBitString(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE bits (b BIT)`)
+	require.NoError(t, err)
+
+	for _, text := range []string{"0", "1", "101", "00000001", "111100001", "1010101010101010"} {
+		b, err := ParseBitString(text)
+		require.NoError(t, err)
+		require.Equal(t, text, b.String())
+		require.Equal(t, len(text), b.Len())
+
+		_, err = db.Exec(`INSERT INTO bits VALUES (?)`, b)
+		require.NoError(t, err)
+
+		var scanned BitString
+		var str string
+		require.NoError(t, db.QueryRow(`SELECT b, b::VARCHAR FROM bits WHERE b = ?::BIT`, text).Scan(&scanned, &str))
+		require.Equal(t, b, scanned)
+		require.Equal(t, text, str)
+		_, err = db.Exec(`DELETE FROM bits`)
+		require.NoError(t, err)
+	}
+
+	b, err := ParseBitString("1000000001")
+	require.NoError(t, err)
+	require.Equal(t, []byte{0b10, 0b1}, b.Bytes())
+
+	var scanned BitString
+	require.NoError(t, db.QueryRow(`SELECT '0110'::VARCHAR`).Scan(&scanned))
+	require.Equal(t, "0110", scanned.String())
+	err = db.QueryRow(`SELECT NULL::BIT`).Scan(&scanned)
+	require.ErrorIs(t, err, errUnexpectedNull)
+
+	_, err = ParseBitString("")
+	require.Error(t, err)
+	_, err = ParseBitString("012")
+	require.ErrorContains(t, err, "invalid bit string digit")
+}
+
+func TestNullValues(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TYPE mood AS ENUM ('happy', 'sad')`)
+	require.NoError(t, err)
+	types := []string{
+		"BOOLEAN", "TINYINT", "SMALLINT", "INTEGER", "BIGINT", "UTINYINT", "USMALLINT", "UINTEGER", "UBIGINT",
+		"HUGEINT", "UHUGEINT", "FLOAT", "DOUBLE", "DECIMAL(4,1)", "DECIMAL(9,2)", "DECIMAL(18,3)", "DECIMAL(38,10)",
+		"VARCHAR", "BLOB", "BIT", "UUID", "mood", "DATE", "TIME", "TIMETZ", "TIMESTAMP", "TIMESTAMP_S", "TIMESTAMP_MS",
+		"TIMESTAMP_NS", "TIMESTAMPTZ", "INTERVAL", "INTEGER[]", "VARCHAR[][]", "INTEGER[2]", "STRUCT(a INTEGER)",
+		"MAP(VARCHAR, INTEGER)", "UNION(a INTEGER, b VARCHAR)",
+	}
+
+	modes := map[string]context.Context{
+		"materialized": ContextWithFetchMode(context.Background(), FetchMaterialized),
+		"streaming":    ContextWithFetchMode(context.Background(), FetchStreaming),
+	}
+	for mode, ctx := range modes {
+		for _, typ := range types {
+			t.Run(mode+" "+typ, func(t *testing.T) {
+				rows, err := db.QueryContext(ctx, `SELECT NULL::`+typ+`, NULL::`+typ+`, NULL::`+typ+`,
+					[NULL::`+typ+`], {'a': NULL::`+typ+`}`)
+				require.NoError(t, err)
+				defer rows.Close()
+				columnTypes, err := rows.ColumnTypes()
+				require.NoError(t, err)
+				require.True(t, rows.Next())
+
+				// A pointer to the scan type of the column is nil.
+				var value any
+				ptr := reflect.New(reflect.PointerTo(columnTypes[1].ScanType()))
+				var nullStr sql.NullString
+				var list List[any]
+				var st Struct[struct{ A any }]
+				require.NoError(t, rows.Scan(&value, ptr.Interface(), &nullStr, &list, &st))
+				require.Nil(t, value)
+				require.True(t, ptr.Elem().IsNil())
+				require.False(t, nullStr.Valid)
+				require.Equal(t, List[any]{nil}, list)
+				require.True(t, st.Valid)
+				require.Nil(t, st.Value.A)
+				require.NoError(t, rows.Close())
+			})
+		}
+	}
+
+	t.Run("scanners", func(t *testing.T) {
+		var m Map
+		require.NoError(t, db.QueryRow(`SELECT NULL::MAP(VARCHAR, INTEGER)`).Scan(&m))
+		require.Nil(t, m)
+
+		var date Date
+		err := db.QueryRow(`SELECT NULL::DATE`).Scan(&date)
+		require.ErrorIs(t, err, errUnexpectedNull)
+		datePtr := &date
+		require.NoError(t, db.QueryRow(`SELECT NULL::DATE`).Scan(&datePtr))
+		require.Nil(t, datePtr)
+
+		var id *UUID
+		require.NoError(t, db.QueryRow(`SELECT NULL::UUID`).Scan(&id))
+		require.Nil(t, id)
+	})
+
+	t.Run("nested scanners", func(t *testing.T) {
+		var strs List[sql.NullString]
+		require.NoError(t, db.QueryRow(`SELECT ['a', NULL]`).Scan(&strs))
+		require.Equal(t, List[sql.NullString]{{String: "a", Valid: true}, {}}, strs)
+
+		var dates List[*Date]
+		require.NoError(t, db.QueryRow(`SELECT [DATE '2024-02-29', NULL]`).Scan(&dates))
+		require.Len(t, dates, 2)
+		require.Equal(t, Date{Year: 2024, Month: time.February, Day: 29}, *dates[0])
+		require.Nil(t, dates[1])
+
+		type row struct {
+			ID     sql.NullInt64
+			Name   sql.NullString
+			Counts map[string]sql.NullInt32
+		}
+		var st Struct[row]
+		require.NoError(t, db.QueryRow(`SELECT {'id': NULL::BIGINT, 'name': 'x',
+			'counts': MAP {'a': 1, 'b': NULL}}`).Scan(&st))
+		require.Equal(t, row{
+			Name:   sql.NullString{String: "x", Valid: true},
+			Counts: map[string]sql.NullInt32{"a": {Int32: 1, Valid: true}, "b": {}},
+		}, st.Value)
+	})
 }
 
 func TestList(t *testing.T) {
@@ -408,6 +833,124 @@ func TestList(t *testing.T) {
 			require.Equal(t, i, row.Get()[i])
 		}
 	})
+
+	t.Run("typed lists", func(t *testing.T) {
+		var ints List[int64]
+		require.NoError(t, db.QueryRow("SELECT [1, 2, 3]::INTEGER[]").Scan(&ints))
+		require.Equal(t, List[int64]{1, 2, 3}, ints)
+
+		var nested List[[]string]
+		require.NoError(t, db.QueryRow("SELECT [['a'], [], ['b', 'c'], NULL]").Scan(&nested))
+		require.Equal(t, List[[]string]{{"a"}, {}, {"b", "c"}, nil}, nested)
+
+		var nullable List[*int32]
+		require.NoError(t, db.QueryRow("SELECT [1, NULL]::INTEGER[]").Scan(&nullable))
+		require.Len(t, nullable, 2)
+		require.Equal(t, int32(1), *nullable[0])
+		require.Nil(t, nullable[1])
+
+		var array List[float64]
+		require.NoError(t, db.QueryRow("SELECT [1.5, 2.5]::FLOAT[2]").Scan(&array))
+		require.Equal(t, List[float64]{1.5, 2.5}, array)
+
+		require.NoError(t, db.QueryRow("SELECT NULL::INTEGER[]").Scan(&ints))
+		require.Nil(t, ints)
+	})
+
+	t.Run("typed list errors", func(t *testing.T) {
+		var small List[int8]
+		err := db.QueryRow("SELECT [1000]::INTEGER[]").Scan(&small)
+		testError(t, err, errScanList.Error(), castErrMsg)
+
+		var ints List[int64]
+		err = db.QueryRow("SELECT [1, NULL]::INTEGER[]").Scan(&ints)
+		testError(t, err, errScanList.Error(), errUnexpectedNull.Error())
+
+		var strs List[string]
+		err = db.QueryRow("SELECT [1]").Scan(&strs)
+		testError(t, err, errScanList.Error(), castErrMsg)
+
+		var arrays List[[2]int32]
+		require.NoError(t, db.QueryRow("SELECT [[1, 2], [3, 4]]::INTEGER[2][]").Scan(&arrays))
+		require.Equal(t, List[[2]int32]{{1, 2}, {3, 4}}, arrays)
+		err = db.QueryRow("SELECT [[1, 2, 3]]").Scan(&arrays)
+		testError(t, err, errScanList.Error(), castErrMsg, "length 3")
+	})
+}
+
+// celsius is registered with RegisterType in TestNested, and decodes from a STRUCT with a unit.
+type celsius struct {
+	degrees float64
+}
+
+func TestNested(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	RegisterType(func(v any) (celsius, error) {
+		m, ok := v.(map[string]any)
+		if !ok || m["unit"] != "F" {
+			return celsius{}, errors.New("invalid temperature")
+		}
+		return celsius{degrees: (m["degrees"].(float64) - 32) * 5 / 9}, nil
+	})
+
+	t.Run("user-defined types", func(t *testing.T) {
+		type point struct {
+			X, Y int64
+		}
+		var p point
+		require.NoError(t, db.QueryRow(`SELECT {'x': 1, 'y': 2}`).Scan(Nested(&p)))
+		require.Equal(t, point{X: 1, Y: 2}, p)
+
+		var counts map[string]int64
+		require.NoError(t, db.QueryRow(`SELECT MAP {'a': 1, 'b': 2}`).Scan(Nested(&counts)))
+		require.Equal(t, map[string]int64{"a": 1, "b": 2}, counts)
+
+		var path []point
+		require.NoError(t, db.QueryRow(`SELECT [{'x': 1, 'y': 2}, {'x': 3, 'y': 4}]`).Scan(Nested(&path)))
+		require.Equal(t, []point{{1, 2}, {3, 4}}, path)
+
+		var nullable *point
+		require.NoError(t, db.QueryRow(`SELECT NULL::STRUCT(x BIGINT, y BIGINT)`).Scan(Nested(&nullable)))
+		require.Nil(t, nullable)
+	})
+
+	t.Run("registered types", func(t *testing.T) {
+		var temp celsius
+		require.NoError(t, db.QueryRow(`SELECT {'degrees': 212.0::DOUBLE, 'unit': 'F'}`).Scan(Nested(&temp)))
+		require.Equal(t, celsius{degrees: 100}, temp)
+
+		var temps List[celsius]
+		require.NoError(t, db.QueryRow(`SELECT [{'degrees': 32.0::DOUBLE, 'unit': 'F'}]`).Scan(&temps))
+		require.Equal(t, List[celsius]{{degrees: 0}}, temps)
+
+		type reading struct {
+			ID   int64
+			Temp *celsius
+		}
+		rows, err := db.Query(`SELECT 1 AS id, {'degrees': 212.0::DOUBLE, 'unit': 'F'} AS temp
+			UNION ALL SELECT 2, NULL ORDER BY id`)
+		require.NoError(t, err)
+		readings, err := ScanRows[reading](rows)
+		require.NoError(t, err)
+		require.Len(t, readings, 2)
+		require.Equal(t, celsius{degrees: 100}, *readings[0].Temp)
+		require.Nil(t, readings[1].Temp)
+
+		err = db.QueryRow(`SELECT {'degrees': 0.0::DOUBLE, 'unit': 'K'}`).Scan(Nested(&temp))
+		testError(t, err, errScanNested.Error(), "invalid temperature")
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		var p struct{ X int8 }
+		err := db.QueryRow(`SELECT {'x': 1000}`).Scan(Nested(&p))
+		testError(t, err, errScanNested.Error(), castErrMsg)
+
+		err = db.QueryRow(`SELECT {'x': 1}`).Scan(Nested(p))
+		testError(t, err, errScanNested.Error(), castErrMsg)
+	})
 }
 
 func compareDecimal(t *testing.T, want Decimal, got Decimal) {
@@ -500,6 +1043,26 @@ func TestDecimal(t *testing.T) {
 			require.Equal(t, tc.want, fs.Float64())
 		}
 	})
+
+	t.Run("decimal to string", func(t *testing.T) {
+		tests := []struct {
+			input string
+			want  string
+		}{
+			{input: "1.23::DECIMAL(3, 2)", want: "1.23"},
+			{input: "-1.23::DECIMAL(3, 2)", want: "-1.23"},
+			{input: "0.05::DECIMAL(3, 2)", want: "0.05"},
+			{input: "-0.05::DECIMAL(3, 2)", want: "-0.05"},
+			{input: "0::DECIMAL(3, 2)", want: "0.00"},
+			{input: "42::DECIMAL(4, 0)", want: "42"},
+			{input: "-123456789.01234567890123456789::DECIMAL(29, 20)", want: "-123456789.01234567890123456789"},
+		}
+		for _, tc := range tests {
+			var fs Decimal
+			require.NoError(t, db.QueryRow(fmt.Sprintf("SELECT %s", tc.input)).Scan(&fs))
+			require.Equal(t, tc.want, fs.String(), tc.input)
+		}
+	})
 }
 
 func TestUUID(t *testing.T) {
@@ -530,6 +1093,38 @@ func TestUUID(t *testing.T) {
 		require.NoError(t, db.QueryRow("SELECT ?::uuid", v).Scan(&uuid))
 		require.Equal(t, v, uuid)
 	}
+
+	t.Run("native UUID", func(t *testing.T) {
+		for _, v := range tests {
+			want := UUID(v)
+			require.Equal(t, v.String(), want.String())
+
+			var got UUID
+			require.NoError(t, db.QueryRow("SELECT uuid FROM uuid_test WHERE uuid = ?", want).Scan(&got))
+			require.Equal(t, want, got)
+
+			require.NoError(t, db.QueryRow("SELECT ?::VARCHAR", want).Scan(&got))
+			require.Equal(t, want, got)
+
+			// Both the hyphenated text and the plain hexadecimal digits parse.
+			var parsed UUID
+			require.NoError(t, parsed.UnmarshalText([]byte(strings.ReplaceAll(v.String(), "-", ""))))
+			require.Equal(t, want, parsed)
+
+			data, err := json.Marshal(want)
+			require.NoError(t, err)
+			require.Equal(t, `"`+v.String()+`"`, string(data))
+			var decoded uuid.UUID
+			require.NoError(t, json.Unmarshal(data, &decoded))
+			require.Equal(t, v, decoded)
+		}
+
+		var got UUID
+		err := db.QueryRow("SELECT NULL::UUID").Scan(&got)
+		require.ErrorIs(t, err, errUnexpectedNull)
+		require.Error(t, got.UnmarshalText([]byte("not-a-uuid")))
+		require.Error(t, got.UnmarshalText([]byte("6ba7b810+9dad+11d1+80b4+00c04fd430c8")))
+	})
 }
 
 func TestENUMs(t *testing.T) {
@@ -570,6 +1165,29 @@ func TestENUMs(t *testing.T) {
 	var row Composite[[]environment]
 	require.NoError(t, db.QueryRow("SELECT environments FROM all_enums").Scan(&row))
 	require.ElementsMatch(t, []environment{Air, Sea, Land}, row.Get())
+
+	// NULL values, and an enum with more than 256 values, whose internal type is USMALLINT.
+	var labels []any
+	rows, err := db.Query(`SELECT CASE WHEN i % 2 = 0 THEN ('v' || i)::ENUM(` + enumValues(1000) + `) END
+		FROM range(996, 1000) t(i)`)
+	require.NoError(t, err)
+	defer rows.Close()
+	for rows.Next() {
+		var label any
+		require.NoError(t, rows.Scan(&label))
+		labels = append(labels, label)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []any{"v996", nil, "v998", nil}, labels)
+}
+
+// enumValues returns the labels v0, v1, ... of an ENUM with n values, e.g., 'v0', 'v1'.
+func enumValues(n int) string {
+	values := make([]string, n)
+	for i := range values {
+		values[i] = fmt.Sprintf("'v%d'", i)
+	}
+	return strings.Join(values, ", ")
 }
 
 func TestHugeInt(t *testing.T) {
@@ -614,8 +1232,43 @@ func TestHugeInt(t *testing.T) {
 		tooHuge := big.NewInt(1)
 		tooHuge.SetBit(tooHuge, 129, 1)
 		_, err = db.Exec("INSERT INTO hugeint_test VALUES(?)", tooHuge)
-		require.Error(t, err)
-		require.Contains(t, err.Error(), "too big")
+		require.ErrorContains(t, err, "Could not convert string '"+tooHuge.String())
+
+		// Values exceeding the range of a HUGEINT bind their text, which casts to the type of the parameter.
+		for _, expected := range []float64{1e40, -1e40} {
+			val, _ := big.NewFloat(expected).Int(nil)
+			var res float64
+			require.NoError(t, db.QueryRow(`SELECT ?::DOUBLE`, val).Scan(&res))
+			require.Equal(t, expected, res)
+
+			var text string
+			require.NoError(t, db.QueryRow(`SELECT CAST(? AS VARCHAR)`, val).Scan(&text))
+			require.Equal(t, val.String(), text)
+		}
+	})
+
+	t.Run("uhugeint", func(t *testing.T) {
+		_, err := db.Exec("CREATE TABLE uhugeint_test (number UHUGEINT)")
+		require.NoError(t, err)
+
+		for _, expected := range []string{"0", "18446744073709551616", "340282366920938463463374607431768211455"} {
+			val, ok := new(big.Int).SetString(expected, 10)
+			require.True(t, ok)
+			_, err = db.Exec("INSERT INTO uhugeint_test VALUES(?)", val)
+			require.NoError(t, err)
+
+			var res *big.Int
+			require.NoError(t, db.QueryRow(`SELECT number FROM uhugeint_test WHERE number = ?`, val).Scan(&res))
+			require.Equal(t, expected, res.String())
+		}
+
+		rows, err := db.Query("SELECT number FROM uhugeint_test")
+		require.NoError(t, err)
+		defer rows.Close()
+		cols, err := rows.ColumnTypes()
+		require.NoError(t, err)
+		require.Equal(t, "UHUGEINT", cols[0].DatabaseTypeName())
+		require.Equal(t, reflect.TypeOf(big.NewInt(0)), cols[0].ScanType())
 	})
 }
 
@@ -753,6 +1406,61 @@ func TestJSON(t *testing.T) {
 		require.Equal(t, len(items), 2)
 		require.Equal(t, items, []string{"foo", "bar"})
 	})
+
+	t.Run("scan into json.RawMessage", func(t *testing.T) {
+		var msg json.RawMessage
+		require.NoError(t, db.QueryRow(`SELECT '{"foo": [1, 2]}'::JSON`).Scan(&msg))
+		require.JSONEq(t, `{"foo": [1, 2]}`, string(msg))
+
+		var value any
+		require.NoError(t, db.QueryRow(`SELECT '[1]'::JSON`).Scan(&value))
+		require.Equal(t, []byte(`[1]`), value)
+
+		rows, err := db.Query(`SELECT '{}'::JSON AS j`)
+		require.NoError(t, err)
+		defer rows.Close()
+		types, err := rows.ColumnTypes()
+		require.NoError(t, err)
+		require.Equal(t, "JSON", types[0].DatabaseTypeName())
+		require.Equal(t, reflect.TypeOf([]byte{}), types[0].ScanType())
+	})
+
+	t.Run("bind JSON parameters", func(t *testing.T) {
+		require.NoError(t, db.QueryRow(`SELECT ?->>'foo'`, json.RawMessage(`{"foo": "bar"}`)).Scan(&data))
+		require.Equal(t, "bar", data)
+	})
+}
+
+type jsonPoint struct {
+	X, Y int
+}
+
+func (p jsonPoint) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]int{p.X, p.Y})
+}
+
+func TestJSONParameters(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	var text string
+	require.NoError(t, db.QueryRow(`SELECT ?::VARCHAR`, json.RawMessage(`{"a": 1}`)).Scan(&text))
+	require.Equal(t, `{"a": 1}`, text)
+
+	require.NoError(t, db.QueryRow(`SELECT ?::VARCHAR`, jsonPoint{1, 2}).Scan(&text))
+	require.Equal(t, `[1,2]`, text)
+
+	var null *string
+	require.NoError(t, db.QueryRow(`SELECT ?::VARCHAR`, json.RawMessage(nil)).Scan(&null))
+	require.Nil(t, null)
+	require.NoError(t, db.QueryRow(`SELECT ?::VARCHAR`, (*jsonPoint)(nil)).Scan(&null))
+	require.Nil(t, null)
+
+	// Types with a native parameter type do not bind as JSON.
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	require.NoError(t, db.QueryRow(`SELECT ?::VARCHAR`, ts).Scan(&text))
+	require.Equal(t, "2024-01-02 03:04:05", text)
 }
 
 // CAST(? as DATE) generate result of type Date (time.Time)
@@ -778,6 +1486,38 @@ func TestDate(t *testing.T) {
 	}
 }
 
+func TestTimeOfDay(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE times (t TIME, tz TIMETZ)`)
+	require.NoError(t, err)
+
+	// Both types store the wall clock time of the location, and TIMETZ also stores its offset.
+	loc := time.FixedZone("", -(5*60*60 + 30*60))
+	v := time.Date(2024, 1, 2, 23, 30, 15, 123456000, loc)
+	_, err = db.Exec(`INSERT INTO times VALUES (?, ?)`, v, v)
+	require.NoError(t, err)
+
+	var tText, tzText string
+	var tm, tz time.Time
+	require.NoError(t, db.QueryRow(`SELECT t::VARCHAR, tz::VARCHAR, t, tz FROM times`).Scan(&tText, &tzText, &tm, &tz))
+	require.Equal(t, "23:30:15.123456", tText)
+	require.Equal(t, "23:30:15.123456-05:30", tzText)
+	require.Equal(t, time.Date(1970, 1, 1, 23, 30, 15, 123456000, time.UTC), tm)
+	require.Equal(t, "1970-01-01 23:30:15.123456 -0530", tz.Format("2006-01-02 15:04:05.999999 -0700"))
+
+	var null any
+	require.NoError(t, db.QueryRow(`SELECT NULL::TIMETZ`).Scan(&null))
+	require.Nil(t, null)
+
+	// Other parameters still bind a TIMESTAMP.
+	var ts time.Time
+	require.NoError(t, db.QueryRow(`SELECT ?`, v).Scan(&ts))
+	require.True(t, v.Equal(ts))
+}
+
 func TestTimestamp(t *testing.T) {
 	t.Parallel()
 	db := openDB(t)
@@ -804,9 +1544,324 @@ func TestTimestamp(t *testing.T) {
 	}
 }
 
-func TestInterval(t *testing.T) {
+func TestInfinity(t *testing.T) {
 	t.Parallel()
-	db := openDB(t)
+
+	const query = `SELECT 'infinity'::DATE, '-infinity'::TIMESTAMP, 'infinity'::TIMESTAMP_S, '-infinity'::TIMESTAMP_MS,
+		'infinity'::TIMESTAMP_NS, 'infinity'::TIMESTAMPTZ, ['-infinity'::TIMESTAMP], 'nan'::DOUBLE, '-inf'::FLOAT`
+
+	openInfinityDB := func(t *testing.T, opts ...ConnectorOption) *sql.DB {
+		connector, err := NewConnector("", nil, opts...)
+		require.NoError(t, err)
+		db := sql.OpenDB(connector)
+		t.Cleanup(func() { db.Close() })
+		return db
+	}
+
+	t.Run("sentinel", func(t *testing.T) {
+		db := openInfinityDB(t)
+		var date, ts, tsS, tsMS, tsNS, tsTZ time.Time
+		var list []any
+		var nan, inf float64
+		require.NoError(t, db.QueryRow(query).Scan(&date, &ts, &tsS, &tsMS, &tsNS, &tsTZ, &list, &nan, &inf))
+		require.Equal(t, PositiveInfinityTime, date)
+		require.Equal(t, NegativeInfinityTime, ts)
+		require.Equal(t, PositiveInfinityTime, tsS)
+		require.Equal(t, NegativeInfinityTime, tsMS)
+		require.Equal(t, PositiveInfinityTime, tsNS)
+		require.Equal(t, PositiveInfinityTime, tsTZ)
+		require.Equal(t, []any{NegativeInfinityTime}, list)
+		require.True(t, math.IsNaN(nan))
+		require.True(t, math.IsInf(inf, -1))
+
+		// The sentinels bind infinite values.
+		var isInf bool
+		require.NoError(t, db.QueryRow(`SELECT ?::TIMESTAMP = 'infinity'::TIMESTAMP AND ?::DATE = '-infinity'::DATE`,
+			PositiveInfinityTime, NegativeInfinityTime).Scan(&isInf))
+		require.True(t, isInf)
+	})
+
+	t.Run("sentinel with location", func(t *testing.T) {
+		loc, err := time.LoadLocation("Europe/Berlin")
+		require.NoError(t, err)
+		db := openInfinityDB(t, WithTimestampLocation(loc), WithCivilDates())
+
+		var date Date
+		var ts time.Time
+		require.NoError(t, db.QueryRow(`SELECT '-infinity'::DATE, ?::TIMESTAMP`, PositiveInfinityTime).Scan(&date, &ts))
+		require.Equal(t, DateOf(NegativeInfinityTime), date)
+		require.Equal(t, PositiveInfinityTime, ts)
+	})
+
+	t.Run("null", func(t *testing.T) {
+		db := openInfinityDB(t, WithInfinityMode(InfinityAsNull))
+		var date, ts, tsS, tsMS, tsNS, tsTZ sql.NullTime
+		var list []any
+		var nan, inf sql.NullFloat64
+		require.NoError(t, db.QueryRow(query).Scan(&date, &ts, &tsS, &tsMS, &tsNS, &tsTZ, &list, &nan, &inf))
+		for _, v := range []sql.NullTime{date, ts, tsS, tsMS, tsNS, tsTZ} {
+			require.False(t, v.Valid)
+		}
+		require.Equal(t, []any{nil}, list)
+		require.False(t, nan.Valid)
+		require.False(t, inf.Valid)
+
+		var finite float64
+		require.NoError(t, db.QueryRow(`SELECT 1.5::DOUBLE`).Scan(&finite))
+		require.Equal(t, 1.5, finite)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		db := openInfinityDB(t, WithInfinityMode(InfinityAsError))
+		for _, q := range []string{`SELECT 'infinity'::DATE`, `SELECT ['-infinity'::TIMESTAMP]`, `SELECT 'nan'::DOUBLE`} {
+			var v any
+			err := db.QueryRow(q).Scan(&v)
+			require.ErrorIs(t, err, ErrNonFiniteValue, q)
+		}
+
+		var ts time.Time
+		require.NoError(t, db.QueryRow(`SELECT '2024-01-01'::TIMESTAMP`).Scan(&ts))
+	})
+
+	t.Run("invalid mode", func(t *testing.T) {
+		_, err := NewConnector("", nil, WithInfinityMode(InfinityMode(-1)))
+		testError(t, err, errInvalidOption.Error(), "invalid infinity mode")
+	})
+}
+
+func TestTimestampPrecisions(t *testing.T) {
+	t.Parallel()
+	loc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	connector, err := NewConnector("", nil, WithTimestampLocation(loc))
+	require.NoError(t, err)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE precisions (s TIMESTAMP_S, ms TIMESTAMP_MS, ns TIMESTAMP_NS)`)
+	require.NoError(t, err)
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 123456789, loc)
+
+	t.Run("bind", func(t *testing.T) {
+		_, err := db.Exec(`INSERT INTO precisions VALUES (?, ?, ?)`, ts, ts, ts)
+		require.NoError(t, err)
+
+		// The parameters store the wall clock time in the location, like TIMESTAMP parameters.
+		var s, ms, ns string
+		require.NoError(t, db.QueryRow(`SELECT s::VARCHAR, ms::VARCHAR, ns::VARCHAR FROM precisions`).Scan(&s, &ms, &ns))
+		require.Equal(t, "2024-01-02 03:04:05", s)
+		require.Equal(t, "2024-01-02 03:04:05.123", ms)
+		require.Equal(t, "2024-01-02 03:04:05.123456", ns)
+
+		var sTS, msTS, nsTS time.Time
+		require.NoError(t, db.QueryRow(`SELECT s, ms, ns FROM precisions`).Scan(&sTS, &msTS, &nsTS))
+		require.Equal(t, ts.Truncate(time.Second), sTS)
+		require.Equal(t, ts.Truncate(time.Millisecond), msTS)
+		require.Equal(t, ts.Truncate(time.Microsecond), nsTS)
+		_, err = db.Exec(`DELETE FROM precisions`)
+		require.NoError(t, err)
+	})
+
+	t.Run("scan nanoseconds", func(t *testing.T) {
+		con, err := connector.Connect(context.Background())
+		require.NoError(t, err)
+		defer con.Close()
+
+		// The Appender stores the nanoseconds, which parameters cannot bind.
+		a, err := NewAppenderFromConn(con, "", "precisions")
+		require.NoError(t, err)
+		require.NoError(t, a.AppendRow(ts, ts, ts))
+		require.NoError(t, a.Close())
+
+		var nsTS time.Time
+		var list []any
+		require.NoError(t, db.QueryRow(`SELECT ns, [ns] FROM precisions`).Scan(&nsTS, &list))
+		require.Equal(t, int64(123456789), int64(nsTS.Nanosecond()))
+		require.Equal(t, nsTS, list[0])
+	})
+}
+
+func TestTimestampLocation(t *testing.T) {
+	t.Parallel()
+	loc, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	connector, err := NewConnector("", nil, WithTimestampLocation(loc))
+	require.NoError(t, err)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	t.Run("scan", func(t *testing.T) {
+		var ts, tsList, tsTZ time.Time
+		var list []any
+		require.NoError(t, db.QueryRow(`SELECT '2024-01-01 12:00:00'::TIMESTAMP, ['2024-07-01 12:00:00'::TIMESTAMP],
+			'2024-01-01 12:00:00+00'::TIMESTAMPTZ`).Scan(&ts, &list, &tsTZ))
+		tsList = list[0].(time.Time)
+
+		// The wall clock time is attached to the location without shifting it.
+		require.Equal(t, loc, ts.Location())
+		require.Equal(t, time.Date(2024, 1, 1, 12, 0, 0, 0, loc), ts)
+		require.Equal(t, time.Date(2024, 1, 1, 11, 0, 0, 0, time.UTC), ts.UTC())
+		require.Equal(t, time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC), tsList.UTC())
+
+		// The instant of a TIMESTAMPTZ is not affected.
+		require.Equal(t, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC), tsTZ)
+
+		// Timestamps with other precisions are wall clock times, too.
+		var tsS, tsMS, tsNS time.Time
+		require.NoError(t, db.QueryRow(`SELECT '2024-01-01 12:00:00'::TIMESTAMP_S, '2024-01-01 12:00:00.123'::TIMESTAMP_MS,
+			'2024-01-01 12:00:00.123456'::TIMESTAMP_NS`).Scan(&tsS, &tsMS, &tsNS))
+		require.Equal(t, time.Date(2024, 1, 1, 12, 0, 0, 0, loc), tsS)
+		require.Equal(t, time.Date(2024, 1, 1, 12, 0, 0, 123000000, loc), tsMS)
+		require.Equal(t, time.Date(2024, 1, 1, 12, 0, 0, 123456000, loc), tsNS)
+	})
+
+	t.Run("timestamp with time zone location", func(t *testing.T) {
+		connector, err := NewConnector("", nil, WithTimestampTZLocation(loc))
+		require.NoError(t, err)
+		db := sql.OpenDB(connector)
+		defer db.Close()
+
+		var ts, tsTZ time.Time
+		var list []any
+		require.NoError(t, db.QueryRow(`SELECT '2024-01-01 12:00:00'::TIMESTAMP, '2024-01-01 12:00:00+00'::TIMESTAMPTZ,
+			['2024-07-01 12:00:00+00'::TIMESTAMPTZ]`).Scan(&ts, &tsTZ, &list))
+
+		// The instant is presented in the location, and TIMESTAMP values stay in UTC.
+		require.Equal(t, time.UTC, ts.Location())
+		require.Equal(t, loc, tsTZ.Location())
+		require.Equal(t, time.Date(2024, 1, 1, 13, 0, 0, 0, loc), tsTZ)
+		require.Equal(t, time.Date(2024, 7, 1, 14, 0, 0, 0, loc), list[0])
+
+		con, err := db.Conn(context.Background())
+		require.NoError(t, err)
+		defer con.Close()
+		require.NoError(t, con.Raw(func(driverConn any) error {
+			maps, err := QueryMaps(context.Background(), driverConn.(driver.Conn), `SELECT '2024-01-01 12:00:00+00'::TIMESTAMPTZ AS ts`)
+			require.NoError(t, err)
+			require.Equal(t, time.Date(2024, 1, 1, 13, 0, 0, 0, loc), maps[0]["ts"])
+			return nil
+		}))
+
+		_, err = NewConnector("", nil, WithTimestampTZLocation(nil))
+		testError(t, err, errInvalidOption.Error())
+	})
+
+	t.Run("bind", func(t *testing.T) {
+		_, err := db.Exec(`CREATE TABLE ts_loc (ts TIMESTAMP)`)
+		require.NoError(t, err)
+
+		// 12:00 UTC is 13:00 in Berlin.
+		_, err = db.Exec(`INSERT INTO ts_loc VALUES (?)`, time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC))
+		require.NoError(t, err)
+
+		var wallClock string
+		var ts time.Time
+		require.NoError(t, db.QueryRow(`SELECT ts::VARCHAR, ts FROM ts_loc`).Scan(&wallClock, &ts))
+		require.Equal(t, "2024-01-01 13:00:00", wallClock)
+		require.True(t, ts.Equal(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("invalid location", func(t *testing.T) {
+		_, err := NewConnector("", nil, WithTimestampLocation(nil))
+		testError(t, err, errInvalidOption.Error())
+	})
+}
+
+func TestTimeLocation(t *testing.T) {
+	t.Parallel()
+	loc, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+
+	connector, err := NewConnector("", nil, WithTimeLocation(loc))
+	require.NoError(t, err)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	var date, ts, tsTZ time.Time
+	require.NoError(t, db.QueryRow(`SELECT DATE '2024-03-01', TIMESTAMP '2024-03-01 12:00:00',
+		TIMESTAMPTZ '2024-03-01 12:00:00+00'`).Scan(&date, &ts, &tsTZ))
+	require.Equal(t, time.Date(2024, 3, 1, 0, 0, 0, 0, loc), date)
+	require.Equal(t, time.Date(2024, 3, 1, 12, 0, 0, 0, loc), ts)
+	require.Equal(t, time.Date(2024, 3, 1, 7, 0, 0, 0, loc), tsTZ)
+
+	// TIME and TIMETZ values are not in the location.
+	var tm, tmTZ time.Time
+	require.NoError(t, db.QueryRow(`SELECT TIME '12:00:00', TIMETZ '12:00:00+02'`).Scan(&tm, &tmTZ))
+	require.Equal(t, time.Date(1970, 1, 1, 12, 0, 0, 0, time.UTC), tm)
+	require.Equal(t, 12, tmTZ.Hour())
+	_, offset := tmTZ.Zone()
+	require.Equal(t, 2*60*60, offset)
+
+	// 2024-03-02 01:00 UTC is still March 1st in New York.
+	var dateText string
+	require.NoError(t, db.QueryRow(`SELECT (?::DATE)::VARCHAR`, time.Date(2024, 3, 2, 1, 0, 0, 0, time.UTC)).
+		Scan(&dateText))
+	require.Equal(t, "2024-03-01", dateText)
+
+	_, err = NewConnector("", nil, WithTimeLocation(nil))
+	testError(t, err, errInvalidOption.Error())
+}
+
+func TestCivilDates(t *testing.T) {
+	t.Parallel()
+
+	t.Run("default", func(t *testing.T) {
+		db := openDB(t)
+		defer db.Close()
+
+		// DATE values scan as midnight UTC, and into a *Date.
+		var ts time.Time
+		var d Date
+		require.NoError(t, db.QueryRow(`SELECT DATE '2024-02-29', DATE '2024-02-29'`).Scan(&ts, &d))
+		require.Equal(t, time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC), ts)
+		require.Equal(t, Date{Year: 2024, Month: time.February, Day: 29}, d)
+		require.Equal(t, "2024-02-29", d.String())
+	})
+
+	connector, err := NewConnector("", nil, WithCivilDates())
+	require.NoError(t, err)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	var typeName string
+	var value any
+	var list []any
+	want := Date{Year: 1999, Month: time.December, Day: 31}
+	require.NoError(t, db.QueryRow(`SELECT typeof(?), DATE '1999-12-31', [DATE '1999-12-31']`, want).
+		Scan(&typeName, &value, &list))
+	require.Equal(t, "DATE", typeName)
+	require.Equal(t, want, value)
+	require.Equal(t, []any{want}, list)
+
+	rows, err := db.Query(`SELECT DATE '1999-12-31'`)
+	require.NoError(t, err)
+	types, err := rows.ColumnTypes()
+	require.NoError(t, err)
+	require.Equal(t, reflect.TypeOf(Date{}), types[0].ScanType())
+	require.NoError(t, rows.Close())
+
+	// The Appender appends a Date to a DATE column.
+	_, err = db.Exec(`CREATE TABLE dates (d DATE)`)
+	require.NoError(t, err)
+	conn, err := connector.Connect(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+	appender, err := NewAppenderFromConn(conn, "", "dates")
+	require.NoError(t, err)
+	require.NoError(t, appender.AppendRow(want))
+	require.NoError(t, appender.Close())
+
+	var d Date
+	require.NoError(t, db.QueryRow(`SELECT d FROM dates`).Scan(&d))
+	require.Equal(t, want, d)
+}
+
+func TestInterval(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
 	defer db.Close()
 
 	t.Run("bind interval", func(t *testing.T) {
@@ -844,6 +1899,28 @@ func TestInterval(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("duration", func(t *testing.T) {
+		d := 36*time.Hour + 90*time.Second + 5*time.Microsecond
+		in := IntervalFromDuration(d)
+		require.Equal(t, Interval{Micros: d.Microseconds()}, in)
+
+		var res Interval
+		require.NoError(t, db.QueryRow(`SELECT ?::INTERVAL + INTERVAL 1 DAY`, in).Scan(&res))
+		out, ok := res.Duration()
+		require.True(t, ok)
+		require.Equal(t, d+24*time.Hour, out)
+
+		_, ok = Interval{Months: 1}.Duration()
+		require.False(t, ok)
+		_, ok = Interval{Days: math.MaxInt32}.Duration()
+		require.False(t, ok)
+		_, ok = Interval{Days: 1, Micros: math.MaxInt64}.Duration()
+		require.False(t, ok)
+		out, ok = Interval{Days: -1, Micros: 1}.Duration()
+		require.True(t, ok)
+		require.Equal(t, -24*time.Hour+time.Microsecond, out)
+	})
 }
 
 func TestEmpty(t *testing.T) {
@@ -858,6 +1935,54 @@ func TestEmpty(t *testing.T) {
 	require.NoError(t, rows.Err())
 }
 
+func TestStatementsWithoutOutput(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	t.Run("query without output", func(t *testing.T) {
+		for _, query := range []string{`PRAGMA enable_checkpoint_on_shutdown`, `SET threads = 2`, `CALL checkpoint()`} {
+			rows, err := db.Query(query)
+			require.NoError(t, err, query)
+			require.False(t, rows.Next(), query)
+			require.NoError(t, rows.Err(), query)
+			require.NoError(t, rows.Close(), query)
+		}
+	})
+
+	t.Run("exec without output", func(t *testing.T) {
+		for _, query := range []string{`PRAGMA enable_checkpoint_on_shutdown`, `SET threads = 2`, `CALL checkpoint()`} {
+			res, err := db.Exec(query)
+			require.NoError(t, err, query)
+			affected, err := res.RowsAffected()
+			require.NoError(t, err, query)
+			require.Equal(t, int64(0), affected, query)
+		}
+	})
+
+	t.Run("query with output", func(t *testing.T) {
+		var version, sourceID string
+		require.NoError(t, db.QueryRow(`PRAGMA version`).Scan(&version, &sourceID))
+		require.NotEmpty(t, version)
+
+		rows, err := db.Query(`CALL pragma_version()`)
+		require.NoError(t, err)
+		defer rows.Close()
+		require.True(t, rows.Next())
+		require.NoError(t, rows.Scan(&version, &sourceID))
+		require.False(t, rows.Next())
+		require.NoError(t, rows.Err())
+	})
+
+	t.Run("exec with output", func(t *testing.T) {
+		res, err := db.Exec(`PRAGMA version`)
+		require.NoError(t, err)
+		affected, err := res.RowsAffected()
+		require.NoError(t, err)
+		require.Equal(t, int64(0), affected)
+	})
+}
+
 func TestTypeNamesAndScanTypes(t *testing.T) {
 	tests := []struct {
 		sql      string
@@ -948,6 +2073,12 @@ func TestTypeNamesAndScanTypes(t *testing.T) {
 			value:    time.Date(1970, 1, 1, 11, 30, 0, 0, time.UTC),
 			typeName: "TIME",
 		},
+		// DUCKDB_TYPE_TIME_TZ
+		{
+			sql:      "SELECT '11:30:00+02'::TIMETZ AS col",
+			value:    time.Date(1970, 1, 1, 11, 30, 0, 0, time.FixedZone("", 2*60*60)),
+			typeName: "TIMETZ",
+		},
 		// DUCKDB_TYPE_INTERVAL
 		{
 			sql:      "SELECT INTERVAL 15 MINUTES AS col",
@@ -1002,6 +2133,12 @@ func TestTypeNamesAndScanTypes(t *testing.T) {
 			value:    []any{[]any{"duck", "goose", "heron"}, nil, []any{"frog", "toad"}, []any{}},
 			typeName: "VARCHAR[][]",
 		},
+		// DUCKDB_TYPE_ARRAY
+		{
+			sql:      "SELECT [[1, 2, 3], NULL]::INTEGER[3][2] AS col",
+			value:    []any{[]any{int32(1), int32(2), int32(3)}, nil},
+			typeName: "INTEGER[3][2]",
+		},
 		// DUCKDB_TYPE_STRUCT
 		{
 			sql:      "SELECT {'key1': 'string', 'key2': 1, 'key3': 12.345::DOUBLE} AS col",
@@ -1019,6 +2156,11 @@ func TestTypeNamesAndScanTypes(t *testing.T) {
 			value:    Map{int32(1): "a", int32(5): "e"},
 			typeName: "MAP(INTEGER, VARCHAR)",
 		},
+		{
+			sql:      "SELECT [map([1], [['a']])] AS col",
+			value:    []any{Map{int32(1): []any{"a"}}},
+			typeName: "MAP(INTEGER, VARCHAR[])[]",
+		},
 		// DUCKDB_TYPE_UUID
 		{
 			sql:      "SELECT '53b4e983-b287-481a-94ad-6e3c90489913'::UUID AS col",
@@ -1031,6 +2173,18 @@ func TestTypeNamesAndScanTypes(t *testing.T) {
 			value:    time.Date(1992, 9, 20, 11, 30, 0, 0, time.UTC),
 			typeName: "TIMESTAMPTZ",
 		},
+		// DUCKDB_TYPE_BIT
+		{
+			sql:      "SELECT '101'::BIT AS col",
+			value:    BitString{data: []byte{0b101}, len: 3},
+			typeName: "BIT",
+		},
+		// DUCKDB_TYPE_UNION
+		{
+			sql:      "SELECT union_value(str := 'a')::UNION(num INTEGER, str VARCHAR) AS col",
+			value:    Union{Tag: "str", Value: "a"},
+			typeName: `UNION("num" INTEGER, "str" VARCHAR)`,
+		},
 	}
 
 	db := openDB(t)
@@ -1054,6 +2208,154 @@ func TestTypeNamesAndScanTypes(t *testing.T) {
 	}
 }
 
+func TestColumnTypeMetadata(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	rows, err := db.Query(`SELECT 1.5::DECIMAL(18, 3) AS d, 'x' AS s, 'b'::BLOB AS b, 42 AS i`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	cols, err := rows.ColumnTypes()
+	require.NoError(t, err)
+	require.Len(t, cols, 4)
+
+	precision, scale, ok := cols[0].DecimalSize()
+	require.True(t, ok)
+	require.Equal(t, int64(18), precision)
+	require.Equal(t, int64(3), scale)
+	_, _, ok = cols[3].DecimalSize()
+	require.False(t, ok)
+
+	for _, col := range cols[1:3] {
+		length, ok := col.Length()
+		require.True(t, ok, col.Name())
+		require.Equal(t, int64(math.MaxInt64), length)
+	}
+	_, ok = cols[3].Length()
+	require.False(t, ok)
+
+	// DuckDB does not report the nullability of result columns.
+	_, ok = cols[3].Nullable()
+	require.False(t, ok)
+}
+
+func TestScanAnyNested(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	var val any
+	require.NoError(t, db.QueryRow(`SELECT [{
+		'd': 1.5::DECIMAL(4, 1),
+		'i': INTERVAL 1 DAY,
+		'm': map(['k'], [[1::HUGEINT]]),
+		'a': ['2024-01-01'::DATE, NULL]::DATE[2]
+	}]`).Scan(&val))
+
+	require.Equal(t, []any{map[string]any{
+		"d": Decimal{Width: 4, Scale: 1, Value: big.NewInt(15)},
+		"i": Interval{Days: 1},
+		"m": Map{"k": []any{big.NewInt(1)}},
+		"a": []any{time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC), nil},
+	}}, val)
+}
+
+func TestListsAndMapsNested(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	tests := []struct {
+		name     string
+		query    string
+		expected []any
+	}{
+		{
+			name: "list of maps",
+			query: `SELECT * FROM (VALUES
+				([MAP {'a': 1, 'b': NULL}, MAP {}, NULL]),
+				([]::MAP(VARCHAR, INTEGER)[]),
+				(NULL)) t(v)`,
+			expected: []any{
+				[]any{Map{"a": int32(1), "b": nil}, Map{}, nil},
+				[]any{},
+				nil,
+			},
+		},
+		{
+			name: "map of lists",
+			query: `SELECT * FROM (VALUES
+				(MAP {'a': [1, NULL], 'b': [], 'c': NULL}),
+				(NULL)) t(v)`,
+			expected: []any{
+				Map{"a": []any{int32(1), nil}, "b": []any{}, "c": nil},
+				nil,
+			},
+		},
+		{
+			name: "list of lists of maps",
+			query: `SELECT * FROM (VALUES
+				([[MAP {1: 'x'}], NULL, [NULL, MAP {2: NULL}]])) t(v)`,
+			expected: []any{
+				[]any{[]any{Map{int32(1): "x"}}, nil, []any{nil, Map{int32(2): nil}}},
+			},
+		},
+		{
+			name: "map of maps of lists of structs",
+			query: `SELECT * FROM (VALUES
+				(MAP {1: MAP {'k': [{'a': 1, 'b': MAP {'x': [1.5::DOUBLE]}}, NULL, {'a': NULL, 'b': NULL}]}}),
+				(MAP {2: NULL, 3: MAP {'k': NULL}})) t(v)`,
+			expected: []any{
+				Map{int32(1): Map{"k": []any{
+					map[string]any{"a": int32(1), "b": Map{"x": []any{1.5}}},
+					nil,
+					map[string]any{"a": nil, "b": nil},
+				}}},
+				Map{int32(2): nil, int32(3): Map{"k": nil}},
+			},
+		},
+		{
+			name: "struct of lists of maps",
+			query: `SELECT * FROM (VALUES
+				({'l': [MAP {'a': [[1]]}], 'n': 1}),
+				({'l': NULL, 'n': 2}),
+				(NULL)) t(v)`,
+			expected: []any{
+				map[string]any{"l": []any{Map{"a": []any{[]any{int32(1)}}}}, "n": int32(1)},
+				map[string]any{"l": nil, "n": int32(2)},
+				nil,
+			},
+		},
+		{
+			name: "array of maps",
+			query: `SELECT * FROM (VALUES
+				([MAP {'a': [1]}, NULL]::MAP(VARCHAR, INTEGER[])[2])) t(v)`,
+			expected: []any{
+				[]any{Map{"a": []any{int32(1)}}, nil},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rows, err := db.Query(test.query)
+			require.NoError(t, err)
+			defer rows.Close()
+
+			var values []any
+			for rows.Next() {
+				var v any
+				require.NoError(t, rows.Scan(&v))
+				values = append(values, v)
+			}
+			require.NoError(t, rows.Err())
+			require.Equal(t, test.expected, values)
+		})
+	}
+}
+
 // Running multiple statements in a single query. All statements except the last one are executed and if no error then last statement is executed with args and result returned.
 func TestMultipleStatements(t *testing.T) {
 	db := openDB(t)
@@ -1125,14 +2427,21 @@ func TestMultipleStatements(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, int64(0), ra)
 
-	// multiple selects, but we get results only for the last one
+	// multiple selects, whose results are the result sets of the rows
 	rows, err = conn.QueryContext(context.Background(), "INSERT INTO foo3 VALUES ('lalo', 1234); select bar from foo3 where baz=12345; select bar from foo3 where baz=$1", 1234)
 	require.NoError(t, err)
 	require.True(t, rows.Next())
 	err = rows.Scan(&bar)
 	require.NoError(t, err)
+	require.Equal(t, "lala", bar)
+	require.False(t, rows.Next())
+	require.True(t, rows.NextResultSet())
+	require.True(t, rows.Next())
+	err = rows.Scan(&bar)
+	require.NoError(t, err)
 	require.Equal(t, "lalo", bar)
 	require.False(t, rows.Next())
+	require.False(t, rows.NextResultSet())
 	err = rows.Close()
 	require.NoError(t, err)
 
@@ -1154,6 +2463,57 @@ func TestMultipleStatements(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestNextResultSet(t *testing.T) {
+	db := openDB(t)
+	defer db.Close()
+
+	// The statements without rows have no result sets, except for the last statement.
+	rows, err := db.Query(`CREATE TABLE tbl (i INTEGER); SELECT 1 AS a, 2 AS b; INSERT INTO tbl VALUES (1), (2);
+		SET threads = 1; SELECT i FROM tbl ORDER BY i; INSERT INTO tbl VALUES (?)`, 3)
+	require.NoError(t, err)
+
+	columns, err := rows.Columns()
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, columns)
+	var a, b int32
+	require.True(t, rows.Next())
+	require.NoError(t, rows.Scan(&a, &b))
+	require.Equal(t, int32(1), a)
+	require.Equal(t, int32(2), b)
+	require.False(t, rows.Next())
+
+	require.True(t, rows.NextResultSet())
+	var values []int32
+	for rows.Next() {
+		var i int32
+		require.NoError(t, rows.Scan(&i))
+		values = append(values, i)
+	}
+	require.Equal(t, []int32{1, 2}, values)
+
+	require.True(t, rows.NextResultSet())
+	columns, err = rows.Columns()
+	require.NoError(t, err)
+	require.Equal(t, []string{"Count"}, columns)
+	var count int64
+	require.True(t, rows.Next())
+	require.NoError(t, rows.Scan(&count))
+	require.Equal(t, int64(1), count)
+
+	require.False(t, rows.NextResultSet())
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+
+	// Closing the rows before advancing through all result sets releases them.
+	rows, err = db.Query(`SELECT 1; SELECT 2; SELECT 3`)
+	require.NoError(t, err)
+	require.NoError(t, rows.Close())
+
+	// The result sets of a failing query are released, too.
+	_, err = db.Query(`SELECT 1; SELECT 2; SELECT * FROM missing`)
+	require.Error(t, err)
+}
+
 func TestParquetExtension(t *testing.T) {
 	db := openDB(t)
 	defer db.Close()
@@ -1198,7 +2558,187 @@ func TestQueryTimeout(t *testing.T) {
 	require.Less(t, time.Since(now), 10*time.Second)
 }
 
-func openDB(t *testing.T) *sql.DB {
+func TestStreamingResults(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewConnector("", nil, WithStreamingResults())
+	require.NoError(t, err)
+	db := sql.OpenDB(c)
+	defer db.Close()
+	// Use a single connection to verify that it is usable after closing a streaming result.
+	db.SetMaxOpenConns(1)
+
+	t.Run("close interrupts the query", func(t *testing.T) {
+		now := time.Now()
+		rows, err := db.Query(`SELECT t1.range + t2.range FROM range(10000000) t1, range(1000000) t2`)
+		require.NoError(t, err)
+
+		for i := 0; i < 3; i++ {
+			require.True(t, rows.Next())
+			var v int64
+			require.NoError(t, rows.Scan(&v))
+		}
+		require.NoError(t, rows.Close())
+
+		// Materializing or draining the result would take much longer than 10 seconds.
+		require.Less(t, time.Since(now), 10*time.Second)
+
+		var v int
+		require.NoError(t, db.QueryRow(`SELECT 42`).Scan(&v))
+		require.Equal(t, 42, v)
+	})
+
+	t.Run("cancel interrupts fetching", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+		defer cancel()
+
+		// After the first rows, fetching the next chunk takes much longer than 10 seconds.
+		now := time.Now()
+		rows, err := db.QueryContext(ctx, `SELECT i FROM range(100000000000) t(i) WHERE i < 5000 OR hash(i) % 1000000000000 = 7`)
+		require.NoError(t, err)
+		for rows.Next() {
+		}
+		require.ErrorIs(t, rows.Err(), context.DeadlineExceeded)
+		require.NoError(t, rows.Close())
+		require.Less(t, time.Since(now), 10*time.Second)
+
+		var v int
+		require.NoError(t, db.QueryRow(`SELECT 42`).Scan(&v))
+		require.Equal(t, 42, v)
+	})
+
+	t.Run("read all rows", func(t *testing.T) {
+		rows, err := db.Query(`SELECT range FROM range(10000)`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var expected int64
+		for rows.Next() {
+			var v int64
+			require.NoError(t, rows.Scan(&v))
+			require.Equal(t, expected, v)
+			expected++
+		}
+		require.NoError(t, rows.Err())
+		require.Equal(t, int64(10000), expected)
+	})
+
+	t.Run("error during execution", func(t *testing.T) {
+		rows, err := db.Query(`SELECT (5000 - range)::UINTEGER FROM range(10000)`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		for rows.Next() {
+		}
+		require.ErrorContains(t, rows.Err(), "Conversion Error")
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		res, err := db.Exec(`CREATE TABLE tbl AS SELECT range AS i FROM range(3000)`)
+		require.NoError(t, err)
+		affected, err := res.RowsAffected()
+		require.NoError(t, err)
+		require.Equal(t, int64(3000), affected)
+	})
+
+	t.Run("chunks and matrix", func(t *testing.T) {
+		con, err := db.Conn(context.Background())
+		require.NoError(t, err)
+		defer con.Close()
+
+		err = con.Raw(func(driverConn any) error {
+			driverRows := queryDriverRows(t, driverConn.(driver.Conn), `SELECT i FROM tbl ORDER BY i`)
+			var sum int64
+			require.NoError(t, ReadChunks(driverRows, func(chunk *Chunk) error {
+				values, _, err := chunk.Int64Column(0)
+				for _, v := range values {
+					sum += v
+				}
+				return err
+			}))
+			require.NoError(t, driverRows.Close())
+			require.Equal(t, int64(2999*3000/2), sum)
+
+			matrix, err := QueryMatrix(context.Background(), driverConn.(driver.Conn), `SELECT i, i * 2 FROM tbl ORDER BY i`)
+			require.NoError(t, err)
+			require.Len(t, matrix, 3000)
+			require.Equal(t, []float64{2999, 5998}, matrix[2999])
+			return nil
+		})
+		require.NoError(t, err)
+	})
+}
+
+func TestFetchModes(t *testing.T) {
+	t.Parallel()
+
+	queryAll := func(t *testing.T, db *sql.DB, ctx context.Context, query string) [][]any {
+		rows, err := db.QueryContext(ctx, query)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		columns, err := rows.Columns()
+		require.NoError(t, err)
+		var result [][]any
+		for rows.Next() {
+			values := make([]any, len(columns))
+			ptrs := make([]any, len(columns))
+			for i := range values {
+				ptrs[i] = &values[i]
+			}
+			require.NoError(t, rows.Scan(ptrs...))
+			result = append(result, values)
+		}
+		require.NoError(t, rows.Err())
+		return result
+	}
+
+	queries := []string{
+		`SELECT range, range::VARCHAR, CASE WHEN range % 7 = 0 THEN NULL ELSE range / 3 END FROM range(5000)`,
+		`SELECT [range, range + 1], {'a': range, 'b': 'x' || range}, MAP {range: range::VARCHAR} FROM range(100)`,
+		`SELECT 1 WHERE 1 = 0`,
+	}
+
+	for _, streamingDefault := range []bool{false, true} {
+		var opts []ConnectorOption
+		if streamingDefault {
+			opts = append(opts, WithStreamingResults())
+		}
+		c, err := NewConnector("", nil, opts...)
+		require.NoError(t, err)
+		db := sql.OpenDB(c)
+
+		materialized := ContextWithFetchMode(context.Background(), FetchMaterialized)
+		streaming := ContextWithFetchMode(context.Background(), FetchStreaming)
+		for _, query := range queries {
+			expected := queryAll(t, db, materialized, query)
+			require.Equal(t, expected, queryAll(t, db, streaming, query), query)
+			require.Equal(t, expected, queryAll(t, db, context.Background(), query), query)
+		}
+
+		con, err := db.Conn(context.Background())
+		require.NoError(t, err)
+		err = con.Raw(func(driverConn any) error {
+			for ctx, expected := range map[context.Context]bool{
+				context.Background(): streamingDefault,
+				ContextWithFetchMode(context.Background(), FetchDefault): streamingDefault,
+				materialized: false,
+				streaming:    true,
+			} {
+				driverRows, err := driverConn.(driver.QueryerContext).QueryContext(ctx, `SELECT 1`, nil)
+				require.NoError(t, err)
+				require.Equal(t, expected, driverRows.(*rows).streaming)
+				require.NoError(t, driverRows.Close())
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.NoError(t, con.Close())
+		require.NoError(t, db.Close())
+	}
+}
+
+func openDB(t testing.TB) *sql.DB {
 	db, err := sql.Open("duckdb", "")
 	require.NoError(t, err)
 	require.NoError(t, db.Ping())
@@ -1217,3 +2757,120 @@ func createTable(db *sql.DB, t *testing.T) *sql.Result {
 	require.NoError(t, err)
 	return &res
 }
+
+func TestScanStringsAndBytes(t *testing.T) {
+	db := openDB(t)
+	defer db.Close()
+
+	// More rows than a chunk holds, with inlined and non-inlined strings, and strings longer than an arena block.
+	const query = `SELECT i, 'short_' || i, repeat('long string ', 4) || i, repeat('x', 5000) || i, ('blob' || i)::BLOB
+		FROM range(5000) t(i) ORDER BY i`
+	rows, err := db.Query(query)
+	require.NoError(t, err)
+
+	var shorts, longs, huges []string
+	var blobs [][]byte
+	var anys []any
+	for rows.Next() {
+		var i int64
+		var short, long, huge string
+		var anyBlob any
+		require.NoError(t, rows.Scan(&i, &short, &long, &huge, &anyBlob))
+		shorts, longs, huges = append(shorts, short), append(longs, long), append(huges, huge)
+		blobs = append(blobs, anyBlob.([]byte))
+		anys = append(anys, anyBlob)
+	}
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+
+	// The scanned values stay valid after reading the following chunks and closing the rows.
+	require.Len(t, shorts, 5000)
+	for i := range shorts {
+		require.Equal(t, fmt.Sprintf("short_%d", i), shorts[i])
+		require.Equal(t, strings.Repeat("long string ", 4)+fmt.Sprint(i), longs[i])
+		require.Equal(t, strings.Repeat("x", 5000)+fmt.Sprint(i), huges[i])
+		require.Equal(t, []byte(fmt.Sprintf("blob%d", i)), blobs[i])
+		require.Equal(t, []byte(fmt.Sprintf("blob%d", i)), anys[i])
+	}
+
+	// sql.RawBytes point into the current chunk.
+	rows, err = db.Query(query)
+	require.NoError(t, err)
+	count := 0
+	for rows.Next() {
+		var i int64
+		var short, long, huge, raw sql.RawBytes
+		require.NoError(t, rows.Scan(&i, &short, &long, &huge, &raw))
+		require.Equal(t, fmt.Sprintf("short_%d", i), string(short))
+		require.Equal(t, fmt.Sprintf("blob%d", i), string(raw))
+		count++
+	}
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+	require.Equal(t, 5000, count)
+}
+
+func BenchmarkScanRawBytes(b *testing.B) {
+	db := openDB(b)
+	defer db.Close()
+
+	const query = `SELECT ('blob ' || repeat('x', i % 100))::BLOB FROM range(10000) t(i)`
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		rows, err := db.Query(query)
+		require.NoError(b, err)
+
+		var raw sql.RawBytes
+		for rows.Next() {
+			require.NoError(b, rows.Scan(&raw))
+		}
+		require.NoError(b, rows.Err())
+		require.NoError(b, rows.Close())
+	}
+}
+
+func BenchmarkScanVarchar(b *testing.B) {
+	db := openDB(b)
+	defer db.Close()
+
+	// Scan both inlined (up to 12 bytes) and non-inlined strings.
+	const query = `SELECT 'short_' || (i % 10), repeat('long string ', 4) || i FROM range(10000) t(i)`
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		rows, err := db.Query(query)
+		require.NoError(b, err)
+
+		var short, long string
+		for rows.Next() {
+			require.NoError(b, rows.Scan(&short, &long))
+		}
+		require.NoError(b, rows.Err())
+		require.NoError(b, rows.Close())
+	}
+}
+
+func BenchmarkScanEnum(b *testing.B) {
+	db := openDB(b)
+	defer db.Close()
+
+	const query = `SELECT ('v' || (i % 10))::ENUM('v0', 'v1', 'v2', 'v3', 'v4', 'v5', 'v6', 'v7', 'v8', 'v9')
+		FROM range(10000) t(i)`
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		rows, err := db.Query(query)
+		require.NoError(b, err)
+
+		var label string
+		for rows.Next() {
+			require.NoError(b, rows.Scan(&label))
+		}
+		require.NoError(b, rows.Err())
+		require.NoError(b, rows.Close())
+	}
+}
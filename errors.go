@@ -4,17 +4,267 @@ import "C"
 import (
 	"errors"
 	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
 )
 
+// ErrorType is the class of an error returned by DuckDB, e.g., ErrorTypeBinder for a reference to a missing column.
+type ErrorType int
+
+const (
+	// ErrorTypeUnknown is the type of an error, whose message does not start with a known error class.
+	ErrorTypeUnknown ErrorType = iota
+	ErrorTypeInvalid
+	ErrorTypeOutOfRange
+	ErrorTypeConversion
+	ErrorTypeDecimal
+	ErrorTypeMismatchType
+	ErrorTypeDivideByZero
+	ErrorTypeObjectSize
+	ErrorTypeInvalidType
+	ErrorTypeSerialization
+	ErrorTypeTransaction
+	ErrorTypeNotImplemented
+	ErrorTypeExpression
+	ErrorTypeCatalog
+	ErrorTypeParser
+	ErrorTypeBinder
+	ErrorTypePlanner
+	ErrorTypeScheduler
+	ErrorTypeExecutor
+	ErrorTypeConstraint
+	ErrorTypeIndex
+	ErrorTypeStat
+	ErrorTypeConnection
+	ErrorTypeSyntax
+	ErrorTypeSettings
+	ErrorTypeOptimizer
+	ErrorTypeNullPointer
+	ErrorTypeIO
+	ErrorTypeInterrupt
+	ErrorTypeFatal
+	ErrorTypeInternal
+	ErrorTypeInvalidInput
+	ErrorTypeOutOfMemory
+	ErrorTypePermission
+	ErrorTypeParameterNotResolved
+	ErrorTypeParameterNotAllowed
+	ErrorTypeDependency
+	ErrorTypeHTTP
+	ErrorTypeMissingExtension
+	ErrorTypeAutoLoad
+	ErrorTypeSequence
+)
+
+// errorTypes maps the prefixes of the error messages of DuckDB to their error types.
+var errorTypes = map[string]ErrorType{
+	"Invalid Error":                ErrorTypeInvalid,
+	"Out of Range Error":           ErrorTypeOutOfRange,
+	"Conversion Error":             ErrorTypeConversion,
+	"Decimal Error":                ErrorTypeDecimal,
+	"Mismatch Type Error":          ErrorTypeMismatchType,
+	"Divide by Zero Error":         ErrorTypeDivideByZero,
+	"Object Size Error":            ErrorTypeObjectSize,
+	"Invalid type Error":           ErrorTypeInvalidType,
+	"Serialization Error":          ErrorTypeSerialization,
+	"TransactionContext Error":     ErrorTypeTransaction,
+	"Not implemented Error":        ErrorTypeNotImplemented,
+	"Expression Error":             ErrorTypeExpression,
+	"Catalog Error":                ErrorTypeCatalog,
+	"Parser Error":                 ErrorTypeParser,
+	"Binder Error":                 ErrorTypeBinder,
+	"Planner Error":                ErrorTypePlanner,
+	"Scheduler Error":              ErrorTypeScheduler,
+	"Executor Error":               ErrorTypeExecutor,
+	"Constraint Error":             ErrorTypeConstraint,
+	"Index Error":                  ErrorTypeIndex,
+	"Stat Error":                   ErrorTypeStat,
+	"Connection Error":             ErrorTypeConnection,
+	"Syntax Error":                 ErrorTypeSyntax,
+	"Settings Error":               ErrorTypeSettings,
+	"Optimizer Error":              ErrorTypeOptimizer,
+	"NullPointer Error":            ErrorTypeNullPointer,
+	"IO Error":                     ErrorTypeIO,
+	"INTERRUPT Error":              ErrorTypeInterrupt,
+	"FATAL Error":                  ErrorTypeFatal,
+	"INTERNAL Error":               ErrorTypeInternal,
+	"Invalid Input Error":          ErrorTypeInvalidInput,
+	"Out of Memory Error":          ErrorTypeOutOfMemory,
+	"Permission Error":             ErrorTypePermission,
+	"Parameter Not Resolved Error": ErrorTypeParameterNotResolved,
+	"Parameter Not Allowed Error":  ErrorTypeParameterNotAllowed,
+	"Dependency Error":             ErrorTypeDependency,
+	"HTTP Error":                   ErrorTypeHTTP,
+	"Missing Extension Error":      ErrorTypeMissingExtension,
+	"Extension Autoloading Error":  ErrorTypeAutoLoad,
+	"Sequence Error":               ErrorTypeSequence,
+}
+
+// Error is an error returned by DuckDB when preparing or executing a query.
+// Use errors.As to branch on its Type, e.g., to retry a query failing with ErrorTypeTransaction.
+type Error struct {
+	// Type is the class of the error, which DuckDB reports as the prefix of its message.
+	Type ErrorType
+	// Msg is the error message of DuckDB, including the prefix of its error type.
+	Msg string
+	// Line and Column are the position of the error in the query, starting at 1, if DuckDB reports it.
+	// Otherwise, they are 0. Column is 0, if DuckDB shortened the reported line of the query.
+	Line   int
+	Column int
+	// Detail contains additional context about the error, if any.
+	// For example, the EXPLAIN output of the failed query, see WithExplainOnError.
+	Detail string
+	// Statement is the position of the failed statement of a query with multiple statements, starting at 1.
+	// The statements before it were executed, and the statements after it were not.
+	// It is 0 for a query with a single statement, and for a query failing to parse.
+	Statement int
+}
+
+func (e *Error) Error() string {
+	msg := e.Msg
+	if e.Statement > 0 {
+		msg = fmt.Sprintf("statement %d: %s", e.Statement, e.Msg)
+	}
+	if e.Detail == "" {
+		return msg
+	}
+	return msg + "\n" + e.Detail
+}
+
+// ErrReadOnly is the error of a statement writing to a database attached in read-only mode, e.g.,
+// of a Connector opened with WithReadOnly. DuckDB rejects such statements before executing them,
+// and the returned *Error matches ErrReadOnly with errors.Is. Temporary tables remain writable.
+// Statements other than SELECT and EXPLAIN fail with an error matching ErrReadOnly in a read-only transaction,
+// i.e., of BeginTx with sql.TxOptions{ReadOnly: true}.
+var ErrReadOnly = errors.New("database is read-only")
+
+// The errors of constraint violations. An *Error of DuckDB with ErrorTypeConstraint matches the error
+// of its violated constraint with errors.Is, e.g., to ignore the insertion of a duplicate key.
+var (
+	// ErrConstraintUnique is the error of a duplicate key of a PRIMARY KEY or UNIQUE constraint.
+	ErrConstraintUnique = errors.New("unique constraint violated")
+	// ErrConstraintNotNull is the error of a NULL value in a NOT NULL column.
+	ErrConstraintNotNull = errors.New("not null constraint violated")
+	// ErrConstraintForeignKey is the error of a missing referenced key, or of deleting a referenced key.
+	ErrConstraintForeignKey = errors.New("foreign key constraint violated")
+	// ErrConstraintCheck is the error of a value failing a CHECK constraint.
+	ErrConstraintCheck = errors.New("check constraint violated")
+)
+
+// ErrNonFiniteValue is the error of scanning an infinite DATE or TIMESTAMP value, or a NaN or infinite FLOAT or
+// DOUBLE value with InfinityAsError, see WithInfinityMode.
+var ErrNonFiniteValue = errors.New("non-finite value")
+
+// ErrClosed is the error of using a closed Connector, connection, statement, or rows, e.g., of executing a statement
+// after closing it, or of closing a connection or statement twice. The returned error matches it with errors.Is.
+var ErrClosed = errors.New("closed")
+
+// AppenderError is the error of appending or flushing the rows of an Appender. Use errors.As to get the failing
+// row and column, and errors.Is to branch on the violated constraint, e.g., ErrConstraintUnique.
+type AppenderError struct {
+	// Row is the position of the failing row in the session of the appender, starting at 1.
+	// DuckDB does not report the failing row of a flush, so that Row is the first of the unflushed rows.
+	Row int64
+	// Rows is the number of rows, which contain the failing row. It is 1 for an error of appending a row.
+	Rows int64
+	// Column is the position of the failing column, starting at 1, or 0, if the error is not specific to a column,
+	// e.g., of a constraint violation.
+	Column int
+	// Err is the cause of the error, e.g., the *Error of DuckDB, or a cast error.
+	Err error
+}
+
+func (e *AppenderError) Error() string {
+	if e.Rows > 1 {
+		return fmt.Sprintf("rows %d to %d: %s", e.Row, e.Row+e.Rows-1, e.Err)
+	}
+	return fmt.Sprintf("row %d: %s", e.Row, e.Err)
+}
+
+func (e *AppenderError) Unwrap() error {
+	return e.Err
+}
+
+// constraintErrMsgs are the parts of the error messages of DuckDB, which identify the violated constraint.
+var constraintErrMsgs = []struct {
+	err  error
+	msgs []string
+}{
+	{ErrConstraintUnique, []string{
+		"violates primary key constraint", "violates unique constraint", "PRIMARY KEY or UNIQUE constraint violated",
+	}},
+	{ErrConstraintNotNull, []string{"NOT NULL constraint failed"}},
+	{ErrConstraintForeignKey, []string{"Violates foreign key constraint"}},
+	{ErrConstraintCheck, []string{"CHECK constraint failed"}},
+}
+
+// Is returns true, if the target is ErrReadOnly, and the error is a rejected write to a read-only database,
+// or if the target is the error of the constraint, whose violation caused the error, e.g., ErrConstraintUnique.
+func (e *Error) Is(target error) bool {
+	if target == ErrReadOnly {
+		return strings.Contains(e.Msg, readOnlyErrMsg)
+	}
+	if e.Type != ErrorTypeConstraint {
+		return false
+	}
+	for _, constraint := range constraintErrMsgs {
+		if constraint.err != target {
+			continue
+		}
+		for _, msg := range constraint.msgs {
+			if strings.Contains(e.Msg, msg) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// errorPosition matches the line of the query, which DuckDB appends to an error message, followed by a line
+// with a caret pointing at the position of the error, e.g., "LINE 3:   does_not_exist FROM range(3)\n          ^".
+var errorPosition = regexp.MustCompile(`(?m)^(LINE (\d+): )(.*)\n( *)\^$`)
+
+// newError returns the *Error of an error message of DuckDB.
+func newError(msg string) *Error {
+	e := &Error{Msg: msg}
+	if prefix, _, ok := strings.Cut(msg, ": "); ok {
+		e.Type = errorTypes[prefix]
+	}
+	if m := errorPosition.FindStringSubmatch(msg); m != nil {
+		e.Line, _ = strconv.Atoi(m[2])
+		if !strings.HasPrefix(m[3], "...") && len(m[4]) >= len(m[1]) {
+			e.Column = len(m[4]) - len(m[1]) + 1
+		}
+	}
+	return e
+}
+
 func getError(errDriver error, err error) error {
 	if err == nil {
 		return fmt.Errorf("%s: %w", driverErrMsg, errDriver)
 	}
-	return fmt.Errorf("%s: %w: %s", driverErrMsg, errDriver, err.Error())
+	return fmt.Errorf("%s: %w: %w", driverErrMsg, errDriver, err)
 }
 
 func duckdbError(err *C.char) error {
-	return fmt.Errorf("%s: %w", duckdbErrMsg, errors.New(C.GoString(err)))
+	return fmt.Errorf("%s: %w", duckdbErrMsg, newError(C.GoString(err)))
+}
+
+// appenderFlushError returns the error of a failed flush of an appender. DuckDB reports its message without
+// the prefix of its error type, so that a constraint violation is recognized by the message of its constraint.
+func appenderFlushError(err *C.char) error {
+	e := newError(C.GoString(err))
+	if e.Type == ErrorTypeUnknown {
+		for _, constraint := range constraintErrMsgs {
+			for _, msg := range constraint.msgs {
+				if strings.Contains(e.Msg, msg) {
+					e.Type = ErrorTypeConstraint
+				}
+			}
+		}
+	}
+	return fmt.Errorf("%s: %w", duckdbErrMsg, e)
 }
 
 func castError(actual string, expected string) error {
@@ -53,14 +303,91 @@ const (
 	columnCountErrMsg      = "invalid column count"
 	unsupportedTypeErrMsg  = "unsupported data type"
 	invalidatedAppenderMsg = "appended data has been invalidated due to corrupt row"
+	byteSizeErrMsg         = "invalid byte size"
+	missingFieldErrMsg     = "missing struct field for column"
+	missingKeyErrMsg       = "missing struct field for STRUCT field"
+	missingParamErrMsg     = "missing struct field for parameter"
+	unknownConfigErrMsg    = "unknown configuration option"
+	readOnlyErrMsg         = "attached in read-only mode"
+	readOnlyTxErrMsg       = "a read-only transaction only executes SELECT and EXPLAIN statements"
 )
 
 var (
 	errDriver = errors.New("internal driver error, please file a bug report")
 
-	errParseDSN  = errors.New("could not parse DSN for database")
-	errOpen      = errors.New("could not open database")
-	errSetConfig = errors.New("could not set invalid or local option for global database config")
+	errParseDSN      = errors.New("could not parse DSN for database")
+	errOpen          = errors.New("could not open database")
+	errSetConfig     = errors.New("could not set invalid or local option for global database config")
+	errInvalidOption = errors.New("invalid connector option")
+	errBootQuery     = errors.New("could not execute boot query")
+	errConnInitQuery = errors.New("could not execute connection init query")
+
+	errInvalidCon = errors.New("not a DuckDB driver connection")
+	errClosedCon  = fmt.Errorf("%w connection", ErrClosed)
+
+	errClosedConnector = fmt.Errorf("%w connector", ErrClosed)
+	errClosedRows      = fmt.Errorf("%w rows", ErrClosed)
+
+	errQueryMatrix    = errors.New("could not query matrix")
+	errQueryMaps      = errors.New("could not query maps")
+	errUnexpectedNull = errors.New("unexpected NULL value")
+	errScanList       = errors.New("could not scan list")
+	errScanStructVal  = errors.New("could not scan STRUCT value")
+	errScanNested     = errors.New("could not scan nested value")
+
+	errScanStruct = errors.New("could not scan struct")
+	errBindStruct = errors.New("could not bind struct")
+	errPaginate   = errors.New("could not paginate")
+
+	errValidateQuery      = errors.New("could not validate query")
+	errParameterNames     = errors.New("could not get parameter names")
+	errDescribe           = errors.New("could not describe query")
+	errExplain            = errors.New("could not explain query")
+	errDescribeParameters = errors.New("could not describe parameters")
+	errTimeRange          = errors.New("invalid time range")
+	errShowCreate         = errors.New("could not show CREATE statement")
+	errSchemaJSON         = errors.New("could not export schema")
+	errDescribeTable      = errors.New("could not describe table")
+	errMemoryUsage        = errors.New("could not get memory usage")
+	errConnectorStats     = errors.New("could not get connector statistics")
+	errThreadInfo         = errors.New("could not get thread info")
+	errStorageInfo        = errors.New("could not read storage info")
+	errBulk               = errors.New("could not execute bulk statement")
+	errExecBatch          = errors.New("could not execute batch")
+	errStmtBind           = errors.New("could not bind statement parameters")
+	errClosedStmt         = fmt.Errorf("%w statement", ErrClosed)
+	errStartQuery         = errors.New("could not start query")
+	errReadParquet        = errors.New("could not read Parquet files")
+	errWriteParquet       = errors.New("could not write Parquet files")
+	errCall               = errors.New("could not call table function")
+	errFTSIndex           = errors.New("could not manage full-text search index")
+	errFTSSearch          = errors.New("could not search full-text search index")
+
+	errRegisterTableFunction = errors.New("could not register table function")
+	errRegisterTable         = errors.New("could not register table")
+	errSetTableValue         = errors.New("could not set table function value")
+	errReplacementScan       = errors.New("could not add replacement scan")
+	errProfiling             = errors.New("could not profile queries")
+	errSecret                = errors.New("could not manage secret")
+	errAttach                = errors.New("could not manage attached database")
+	errSetting               = errors.New("could not manage setting")
+	errCheckpoint            = errors.New("could not checkpoint database")
+	errExportDatabase        = errors.New("could not export database")
+	errImportDatabase        = errors.New("could not import database")
+	errCopyDatabase          = errors.New("could not copy database")
+	errQueryLimit            = errors.New("could not apply query limit")
+	errTaskExecutor          = errors.New("could not execute tasks")
+	errLoadLibrary           = errors.New("could not load DuckDB library")
+	errCopyFrom              = errors.New("could not copy from reader")
+	errCopyTo                = errors.New("could not copy to writer")
+	errWriteArrowIPC         = errors.New("could not write Arrow IPC stream")
+
+	errInstallExtension = errors.New("could not install extension")
+	errLoadExtension    = errors.New("could not load extension")
+
+	errReadChunks  = errors.New("could not read chunks")
+	errClosedChunk = errors.New("chunk already freed")
+	errColumnType  = errors.New("could not describe column type")
 
 	errAppenderInvalidCon       = errors.New("could not create appender: not a DuckDB driver connection")
 	errAppenderClosedCon        = errors.New("could not create appender: appender creation on a closed connection")
@@ -68,12 +395,17 @@ var (
 	errAppenderDoubleClose      = errors.New("could not close appender: already closed")
 	errAppenderAppendRow        = errors.New("could not append row")
 	errAppenderAppendAfterClose = errors.New("could not append row: appender already closed")
+	errAppenderDefault          = errors.New("appending Default requires an appender created WithColumns")
 	// FIXME: not covered by tests. Should be triggered by appending a constraint violation, see #210.
 	errAppenderClose = errors.New("could not close appender")
 	// FIXME: not covered by tests. Should be triggered by appending a constraint violation, see #210.
-	errAppenderFlush = errors.New("could not flush appender")
+	errAppenderFlush    = errors.New("could not flush appender")
+	errAppenderRollback = errors.New("could not roll back appender")
+
+	errAppendCSV = errors.New("could not append CSV")
 
 	// Errors not covered in tests.
 	errConnect      = errors.New("could not connect to database")
+	errPing         = errors.New("could not ping database")
 	errCreateConfig = errors.New("could not create config for database")
 )
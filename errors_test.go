@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"errors"
 	"strings"
 	"testing"
 
@@ -43,6 +44,20 @@ func TestErrOpen(t *testing.T) {
 		_, err := sql.Open("duckdb", "?schema=main")
 		testError(t, err, errSetConfig.Error())
 	})
+
+	t.Run("unknown config option", func(t *testing.T) {
+		_, err := sql.Open("duckdb", "?thread=4")
+		testError(t, err, errOpen.Error(), duckdbErrMsg, unknownConfigErrMsg+" thread, did you mean threads?",
+			"valid options: ", "memory_limit")
+
+		_, err = NewConnector("", nil, WithConfig(map[string]string{"no_such_setting_at_all": "1"}))
+		testError(t, err, errOpen.Error(), unknownConfigErrMsg+" no_such_setting_at_all (valid options: ")
+	})
+
+	t.Run(errInvalidOption.Error(), func(t *testing.T) {
+		_, err := NewConnector("", nil, WithCheckpointThreshold("many"))
+		testError(t, err, errInvalidOption.Error(), byteSizeErrMsg)
+	})
 }
 
 func TestErrAppender(t *testing.T) {
@@ -100,7 +115,7 @@ func TestErrAppender(t *testing.T) {
 		c, err := NewConnector("", nil)
 		require.NoError(t, err)
 
-		_, err = sql.OpenDB(c).Exec(`CREATE TABLE test AS SELECT MAP() AS m`)
+		_, err = sql.OpenDB(c).Exec(`CREATE TABLE test AS SELECT INTERVAL 1 DAY AS i`)
 		require.NoError(t, err)
 
 		con, err := c.Connect(context.Background())
@@ -235,3 +250,190 @@ func TestErrAppendNestedList(t *testing.T) {
 
 	cleanupAppender(t, c, con, a)
 }
+
+func TestExplainOnError(t *testing.T) {
+	t.Parallel()
+
+	// Fails during execution, so DuckDB can still explain the query.
+	const failingQuery = `SELECT (CASE WHEN i = 5 THEN 'x' ELSE i::VARCHAR END)::INTEGER FROM range(10) t(i)`
+
+	t.Run("disabled", func(t *testing.T) {
+		db := openDB(t)
+		defer db.Close()
+
+		_, err := db.Exec(failingQuery)
+		var duckdbErr *Error
+		require.ErrorAs(t, err, &duckdbErr)
+		require.Empty(t, duckdbErr.Detail)
+		require.Contains(t, duckdbErr.Msg, "Conversion Error")
+	})
+
+	connector, err := NewConnector("", nil, WithExplainOnError())
+	require.NoError(t, err)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	t.Run("execution error", func(t *testing.T) {
+		rows, err := db.Query(failingQuery)
+		if err == nil {
+			defer rows.Close()
+			for rows.Next() {
+			}
+			err = rows.Err()
+		}
+
+		var duckdbErr *Error
+		require.ErrorAs(t, err, &duckdbErr)
+		require.Contains(t, duckdbErr.Msg, "Conversion Error")
+		require.Contains(t, duckdbErr.Detail, "RANGE")
+		require.Contains(t, err.Error(), duckdbErr.Detail)
+	})
+
+	t.Run("parameters", func(t *testing.T) {
+		_, err := db.Exec(failingQuery+" WHERE i < ?", 8)
+		var duckdbErr *Error
+		require.ErrorAs(t, err, &duckdbErr)
+		require.Equal(t, "query: "+failingQuery+" WHERE i < ?", duckdbErr.Detail)
+	})
+
+	t.Run("binder error", func(t *testing.T) {
+		_, err := db.Exec(`SELECT does_not_exist FROM range(10)`)
+		var duckdbErr *Error
+		require.ErrorAs(t, err, &duckdbErr)
+		require.Contains(t, duckdbErr.Msg, "Binder Error")
+		require.Equal(t, "query: SELECT does_not_exist FROM range(10)", duckdbErr.Detail)
+	})
+
+	t.Run("multiple statements", func(t *testing.T) {
+		_, err := db.Exec(`CREATE TABLE explain_tbl (i INTEGER); ` + failingQuery)
+		var duckdbErr *Error
+		require.ErrorAs(t, err, &duckdbErr)
+		require.NotContains(t, duckdbErr.Detail, "RANGE")
+
+		// Explaining the query did not execute the CREATE TABLE statement again.
+		_, err = db.Exec(`DROP TABLE explain_tbl`)
+		require.NoError(t, err)
+	})
+}
+
+func TestMultiStatementError(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	t.Run("exec", func(t *testing.T) {
+		_, err := db.Exec(`CREATE TABLE migrated (i INTEGER); INSERT INTO migrated VALUES (1); INSERT INTO missing VALUES (2); INSERT INTO migrated VALUES (3)`)
+		var duckdbErr *Error
+		require.ErrorAs(t, err, &duckdbErr)
+		require.Equal(t, 3, duckdbErr.Statement)
+		require.Contains(t, err.Error(), "statement 3: Catalog Error")
+
+		// The statements before the failed statement were executed, and the statements after it were not.
+		var count int
+		require.NoError(t, db.QueryRow(`SELECT count(*) FROM migrated`).Scan(&count))
+		require.Equal(t, 1, count)
+	})
+
+	t.Run("last statement", func(t *testing.T) {
+		_, err := db.Query(`SELECT 1; SELECT does_not_exist`)
+		var duckdbErr *Error
+		require.ErrorAs(t, err, &duckdbErr)
+		require.Equal(t, 2, duckdbErr.Statement)
+	})
+
+	t.Run("single statement", func(t *testing.T) {
+		_, err := db.Exec(`SELECT does_not_exist`)
+		var duckdbErr *Error
+		require.ErrorAs(t, err, &duckdbErr)
+		require.Zero(t, duckdbErr.Statement)
+		require.NotContains(t, err.Error(), "statement")
+	})
+}
+
+func TestErrorType(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE error_tbl (i INTEGER PRIMARY KEY); INSERT INTO error_tbl VALUES (1)`)
+	require.NoError(t, err)
+
+	testCases := []struct {
+		query  string
+		typ    ErrorType
+		line   int
+		column int
+	}{
+		{query: `SELEC 1`, typ: ErrorTypeParser},
+		{query: "SELECT\n  1,\n  does_not_exist FROM range(3)", typ: ErrorTypeBinder, line: 3, column: 3},
+		{query: `SELECT * FROM missing`, typ: ErrorTypeCatalog, line: 1, column: 15},
+		{query: `INSERT INTO error_tbl VALUES (1)`, typ: ErrorTypeConstraint},
+		{query: `SELECT 'a'::INTEGER`, typ: ErrorTypeConversion, line: 1, column: 11},
+		{query: `SELECT * FROM read_csv('/does/not/exist.csv')`, typ: ErrorTypeIO},
+	}
+	for _, tc := range testCases {
+		_, err := db.Exec(tc.query)
+		var duckdbErr *Error
+		require.ErrorAs(t, err, &duckdbErr, tc.query)
+		require.Equal(t, tc.typ, duckdbErr.Type, tc.query)
+		require.Equal(t, tc.line, duckdbErr.Line, tc.query)
+		require.Equal(t, tc.column, duckdbErr.Column, tc.query)
+	}
+
+	t.Run("shortened line", func(t *testing.T) {
+		e := newError("Binder Error: missing\nLINE 1: ...FROM tbl WHERE missing\n                              ^")
+		require.Equal(t, ErrorTypeBinder, e.Type)
+		require.Equal(t, 1, e.Line)
+		require.Zero(t, e.Column)
+	})
+
+	t.Run("unknown type", func(t *testing.T) {
+		require.Equal(t, ErrorTypeUnknown, newError("something failed").Type)
+	})
+
+	t.Run("open", func(t *testing.T) {
+		_, err := NewConnector("/does/not/exist/test.db", nil)
+		var duckdbErr *Error
+		require.ErrorAs(t, err, &duckdbErr)
+		require.Equal(t, ErrorTypeIO, duckdbErr.Type)
+	})
+}
+
+func TestConstraintErrors(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE parent (id INTEGER PRIMARY KEY, code INTEGER UNIQUE, name VARCHAR NOT NULL,
+			price INTEGER CHECK (price > 0));
+		CREATE TABLE child (parent_id INTEGER REFERENCES parent (id));
+		INSERT INTO parent VALUES (1, 1, 'a', 1);
+		INSERT INTO child VALUES (1)`)
+	require.NoError(t, err)
+
+	constraintErrs := []error{ErrConstraintUnique, ErrConstraintNotNull, ErrConstraintForeignKey, ErrConstraintCheck}
+	testCases := []struct {
+		query string
+		err   error
+	}{
+		{query: `INSERT INTO parent VALUES (1, 2, 'b', 1)`, err: ErrConstraintUnique},
+		{query: `INSERT INTO parent VALUES (2, 1, 'b', 1)`, err: ErrConstraintUnique},
+		{query: `INSERT INTO parent VALUES (2, 2, 'b', 1), (2, 3, 'c', 1)`, err: ErrConstraintUnique},
+		{query: `INSERT INTO parent VALUES (2, 2, NULL, 1)`, err: ErrConstraintNotNull},
+		{query: `INSERT INTO child VALUES (5)`, err: ErrConstraintForeignKey},
+		{query: `DELETE FROM parent`, err: ErrConstraintForeignKey},
+		{query: `INSERT INTO parent VALUES (2, 2, 'b', -1)`, err: ErrConstraintCheck},
+	}
+	for _, tc := range testCases {
+		_, err := db.Exec(tc.query)
+		require.Error(t, err, tc.query)
+		for _, constraintErr := range constraintErrs {
+			require.Equal(t, constraintErr == tc.err, errors.Is(err, constraintErr), tc.query)
+		}
+	}
+
+	_, err = db.Exec(`SELECT * FROM missing`)
+	for _, constraintErr := range constraintErrs {
+		require.NotErrorIs(t, err, constraintErr)
+	}
+}
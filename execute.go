@@ -0,0 +1,91 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// executeStatement matches an EXECUTE statement, which executes a statement prepared with PREPARE.
+var executeStatement = regexp.MustCompile(`(?i)^\s*EXECUTE\s`)
+
+// inlineExecuteArgs replaces the placeholders of an EXECUTE statement, e.g., EXECUTE name(?, ?), by the values
+// of their arguments, see inlineArgs. DuckDB cannot prepare an EXECUTE statement with parameters, as it only accepts
+// constants. Other queries are returned unchanged.
+func (c *conn) inlineExecuteArgs(ctx context.Context, query string, args []driver.NamedValue) (
+	string, []driver.NamedValue, error,
+) {
+	if len(args) == 0 || !executeStatement.MatchString(query) {
+		return query, args, nil
+	}
+	return c.inlineArgs(ctx, query, args)
+}
+
+// inlineArgs replaces the placeholders of the query by the values of their arguments. The values are bound to a query,
+// which returns their text and their type, like the arguments of other statements, and each placeholder is replaced
+// by the cast of its text to its type.
+func (c *conn) inlineArgs(ctx context.Context, query string, args []driver.NamedValue) (
+	string, []driver.NamedValue, error,
+) {
+	runes := []rune(query)
+	placeholders := queryPlaceholders(runes)
+	if len(placeholders) == 0 {
+		return query, args, nil
+	}
+
+	// The parameters of the query are referenced by the columns of a subquery, so that DuckDB infers their types
+	// from their values, instead of from the casts to VARCHAR.
+	keys := make([]string, len(placeholders))
+	columns := make(map[string]int)
+	var params, selects []string
+	position := 0
+	for i, p := range placeholders {
+		key := p.name
+		if key == "" {
+			position++
+			key = strconv.Itoa(position)
+		}
+		keys[i] = key
+		if _, ok := columns[key]; ok {
+			continue
+		}
+		columns[key] = len(params)
+		column := "v" + strconv.Itoa(len(params))
+		params = append(params, "$"+key+" AS "+column)
+		selects = append(selects, "CAST("+column+" AS VARCHAR)", "typeof("+column+")")
+	}
+
+	s, err := c.prepareStmtContext(ctx, "SELECT "+strings.Join(selects, ", ")+" FROM (SELECT "+
+		strings.Join(params, ", ")+")")
+	if err != nil {
+		return "", nil, err
+	}
+	defer s.Close()
+	rows, err := s.QueryContext(ctx, args)
+	if err != nil {
+		return "", nil, err
+	}
+	values := make([]driver.Value, len(selects))
+	err = rows.Next(values)
+	rows.Close()
+	if err != nil {
+		return "", nil, err
+	}
+
+	var b strings.Builder
+	last := 0
+	for i, p := range placeholders {
+		b.WriteString(string(runes[last:p.start]))
+		column := columns[keys[i]]
+		if text, ok := values[2*column].(string); ok {
+			b.WriteString("CAST(" + quoteString(text) + " AS " + values[2*column+1].(string) + ")")
+		} else {
+			b.WriteString("NULL")
+		}
+		last = p.end
+	}
+	b.WriteString(string(runes[last:]))
+	return b.String(), nil, nil
+}
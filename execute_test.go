@@ -0,0 +1,71 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecuteStatement(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	// Statements prepared with PREPARE belong to the connection.
+	ctx := context.Background()
+	con, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer con.Close()
+
+	_, err = con.ExecContext(ctx, `CREATE TABLE events (id INTEGER, kind VARCHAR, at TIMESTAMP, payload BLOB,
+		amount DECIMAL(18, 3), tags MAP(VARCHAR, INTEGER));
+		PREPARE insert_event AS INSERT INTO events VALUES ($1, $2, $3, $4, $5, $6);
+		PREPARE events_of_kind AS SELECT id, at FROM events WHERE kind = $1 AND id > $2 ORDER BY id`)
+	require.NoError(t, err)
+
+	at := time.Date(2024, 5, 6, 7, 8, 9, 123456000, time.UTC)
+	res, err := con.ExecContext(ctx, `EXECUTE insert_event(?, ?, ?, ?, ?, ?)`, 1, "it's", at, []byte{0, 0xAA, '\\'},
+		Decimal{Value: big.NewInt(1234), Scale: 3}, map[string]int32{"a": 1})
+	require.NoError(t, err)
+	affected, err := res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), affected)
+
+	_, err = con.ExecContext(ctx, `EXECUTE insert_event($2, $1, NULL, NULL, 1.5, NULL)`, "it's", 2)
+	require.NoError(t, err)
+
+	var payload []byte
+	var amount Decimal
+	var tags Map
+	require.NoError(t, con.QueryRowContext(ctx, `SELECT payload, amount, tags FROM events WHERE id = 1`).Scan(&payload,
+		&amount, &tags))
+	require.Equal(t, []byte{0, 0xAA, '\\'}, payload)
+	require.Equal(t, 1.234, amount.Float64())
+	require.Equal(t, Map{"a": int32(1)}, tags)
+
+	rows, err := con.QueryContext(ctx, `EXECUTE events_of_kind($kind, $min_id)`, sql.Named("kind", "it's"),
+		sql.Named("min_id", 0))
+	require.NoError(t, err)
+	defer rows.Close()
+	var ids []int
+	var times []any
+	for rows.Next() {
+		var id int
+		var ts any
+		require.NoError(t, rows.Scan(&id, &ts))
+		ids = append(ids, id)
+		times = append(times, ts)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []int{1, 2}, ids)
+	require.Equal(t, []any{at, nil}, times)
+
+	_, err = con.ExecContext(ctx, `DEALLOCATE insert_event`)
+	require.NoError(t, err)
+	_, err = con.ExecContext(ctx, `EXECUTE insert_event(?, ?, ?, ?, ?, ?)`, 3, "a", nil, nil, nil, nil)
+	require.ErrorContains(t, err, "insert_event")
+}
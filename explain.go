@@ -0,0 +1,218 @@
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// QueryPlan is the plan of a query, see ExplainQuery.
+type QueryPlan struct {
+	// Logical is the root operator of the optimized logical plan of the query.
+	Logical *PlanOperator
+	// Physical is the root operator of the physical plan of the query.
+	Physical *PlanOperator
+}
+
+// PlanOperator is an operator of the plan of a query.
+type PlanOperator struct {
+	// Name is the name of the operator, e.g., HASH_JOIN or SEQ_SCAN.
+	Name string
+	// EstimatedCardinality is the number of rows DuckDB estimates the operator to produce, or -1, if DuckDB
+	// does not estimate it.
+	EstimatedCardinality int64
+	// ExtraInfo describes the operator, e.g., the join condition of a HASH_JOIN, or the filters of a SEQ_SCAN.
+	// Its lines are the lines of the box of the operator, i.e., DuckDB wraps long expressions across lines.
+	ExtraInfo string
+	// Children are the operators producing the input of the operator.
+	Children []PlanOperator
+}
+
+// ExplainQuery returns the optimized logical plan and the physical plan of the query, without executing it.
+// The arguments bind the parameters of the query, like the arguments of ExecContext.
+// DuckDB v0.10 cannot explain a query in JSON, so ExplainQuery parses the boxes of the rendered plans.
+func ExplainQuery(ctx context.Context, driverConn driver.Conn, query string, args ...any) (*QueryPlan, error) {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return nil, err
+	}
+	nargs, err := con.batchArgs(args)
+	if err != nil {
+		return nil, getError(errExplain, err)
+	}
+
+	// EXPLAIN renders the unoptimized and the optimized logical plans, too, if the explain_output setting is all.
+	con.mu.Lock()
+	output, err := con.rawQueryString(`SELECT current_setting('explain_output')`)
+	if err == nil {
+		err = con.rawExec(`SET explain_output = 'all'`)
+	}
+	con.mu.Unlock()
+	if err != nil {
+		return nil, getError(errExplain, err)
+	}
+	defer func() {
+		con.mu.Lock()
+		defer con.mu.Unlock()
+		if err := con.rawExec(`SET explain_output = ` + quoteString(output)); err != nil {
+			con.logError("duckdb could not restore the explain_output setting", err)
+		}
+	}()
+
+	// DuckDB v0.10 cannot bind the parameters of an EXPLAIN statement, so their values are inlined.
+	query, nargs = castTypedParams(query, nargs)
+	if len(nargs) != 0 {
+		if query, nargs, err = con.inlineArgs(ctx, query, nargs); err != nil {
+			return nil, getError(errExplain, err)
+		}
+	}
+	s, err := con.prepareStmtContext(ctx, "EXPLAIN "+query)
+	if err != nil {
+		return nil, getError(errExplain, err)
+	}
+	defer s.Close()
+	res, err := s.execute(ctx, nargs, false)
+	if err != nil {
+		return nil, getError(errExplain, err)
+	}
+	defer C.duckdb_destroy_result(res)
+
+	plan := &QueryPlan{}
+	for i := C.idx_t(0); i < C.duckdb_row_count(res); i++ {
+		key := C.duckdb_value_varchar(res, 0, i)
+		text := C.duckdb_value_varchar(res, 1, i)
+		root, err := parsePlan(C.GoString(text))
+		switch C.GoString(key) {
+		case "logical_opt":
+			plan.Logical = root
+		case "physical_plan":
+			plan.Physical = root
+		}
+		C.duckdb_free(unsafe.Pointer(key))
+		C.duckdb_free(unsafe.Pointer(text))
+		if err != nil {
+			return nil, getError(errExplain, err)
+		}
+	}
+	return plan, nil
+}
+
+// planBox is the box of an operator of a rendered plan, at the column x of the row y of the rendered tree.
+type planBox struct {
+	x, y  int
+	lines []string
+	op    *PlanOperator
+}
+
+// parsePlan parses the tree of boxes of a plan rendered by EXPLAIN. Each row of the tree renders its boxes on the same
+// lines, and each box has the same width, so that the column of a box is its offset divided by the width.
+// The children of a box are the boxes of the next row, for which it is the nearest box to their left.
+func parsePlan(text string) (*PlanOperator, error) {
+	var rows [][]*planBox
+	var open []*planBox
+	width := 0
+	for _, line := range strings.Split(text, "\n") {
+		runes := []rune(line)
+		if width == 0 {
+			start, end := runeIndex(runes, '┌', 0), runeIndex(runes, '┐', 0)
+			if start < 0 || end < start {
+				continue
+			}
+			width = end - start + 1
+		}
+
+		if len(open) == 0 {
+			var row []*planBox
+			for p := runeIndex(runes, '┌', 0); p >= 0; p = runeIndex(runes, '┌', p+1) {
+				row = append(row, &planBox{x: p / width, y: len(rows)})
+			}
+			if len(row) != 0 {
+				rows = append(rows, row)
+				open = row
+			}
+			continue
+		}
+
+		var stillOpen []*planBox
+		for _, box := range open {
+			p := box.x * width
+			if p < len(runes) && runes[p] == '└' {
+				continue
+			}
+			stillOpen = append(stillOpen, box)
+			if p+width <= len(runes) {
+				box.lines = append(box.lines, strings.TrimSpace(string(runes[p+1:p+width-1])))
+			}
+		}
+		open = stillOpen
+	}
+	if len(rows) == 0 {
+		return nil, errors.New("no operators in plan")
+	}
+
+	for _, row := range rows {
+		for _, box := range row {
+			box.op = newPlanOperator(box.lines)
+		}
+	}
+	// Link the children bottom-up, so that the copies of the children in their parents are complete.
+	for y := len(rows) - 1; y > 0; y-- {
+		for _, box := range rows[y] {
+			var parent *planBox
+			for _, candidate := range rows[y-1] {
+				if candidate.x <= box.x {
+					parent = candidate
+				}
+			}
+			if parent == nil {
+				return nil, errors.New("operator without parent in plan")
+			}
+			parent.op.Children = append(parent.op.Children, *box.op)
+		}
+	}
+	return rows[0][0].op, nil
+}
+
+// newPlanOperator returns the operator of the lines of its box. The lines of its name are followed by sections
+// of extra info, which are separated by dashed lines.
+func newPlanOperator(lines []string) *PlanOperator {
+	op := &PlanOperator{EstimatedCardinality: -1}
+	var name, info []string
+	inName := true
+	for _, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "─ ─"):
+			inName = false
+		case line == "":
+		case inName:
+			name = append(name, line)
+		case strings.HasPrefix(line, "EC: "):
+			if ec, err := strconv.ParseInt(strings.TrimPrefix(line, "EC: "), 10, 64); err == nil {
+				op.EstimatedCardinality = ec
+			}
+		default:
+			info = append(info, line)
+		}
+	}
+	op.Name = strings.Join(name, "")
+	op.ExtraInfo = strings.Join(info, "\n")
+	return op
+}
+
+// runeIndex returns the index of the first occurrence of r in runes at or after index from, or -1, if there is none.
+func runeIndex(runes []rune, r rune, from int) int {
+	for i := from; i < len(runes); i++ {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,112 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplainQuery(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE orders AS SELECT range AS id, range % 10 AS customer FROM range(1000);
+		CREATE TABLE customers AS SELECT range AS id, 'c' || range AS name FROM range(10)`)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	con, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer con.Close()
+
+	var plan *QueryPlan
+	require.NoError(t, con.Raw(func(driverConn any) error {
+		plan, err = ExplainQuery(ctx, driverConn.(driver.Conn), `SELECT c.name, count(*) FROM orders o
+			JOIN customers c ON o.customer = c.id WHERE o.id > ? GROUP BY c.name`, 500)
+		return err
+	}))
+
+	// The physical plan joins the scans of both tables.
+	var names []string
+	var join *PlanOperator
+	var walk func(op *PlanOperator)
+	walk = func(op *PlanOperator) {
+		names = append(names, op.Name)
+		if op.Name == "HASH_JOIN" {
+			join = op
+		}
+		for i := range op.Children {
+			walk(&op.Children[i])
+		}
+	}
+	require.NotNil(t, plan.Physical)
+	walk(plan.Physical)
+	require.Contains(t, names, "HASH_GROUP_BY")
+	require.NotNil(t, join)
+	require.Len(t, join.Children, 2)
+	require.Contains(t, join.ExtraInfo, "customer = id")
+	require.Positive(t, join.EstimatedCardinality)
+
+	var scans []string
+	for _, child := range join.Children {
+		for child.Name != "SEQ_SCAN" {
+			require.Len(t, child.Children, 1)
+			child = child.Children[0]
+		}
+		scans = append(scans, child.ExtraInfo)
+	}
+	require.Len(t, scans, 2)
+	require.Contains(t, scans[0], "orders")
+	require.Contains(t, scans[1], "customers")
+
+	require.NotNil(t, plan.Logical)
+	names = nil
+	walk(plan.Logical)
+	require.Contains(t, names, "COMPARISON_JOIN")
+	require.Contains(t, names, "AGGREGATE")
+
+	// Explaining the query restores the explain_output setting of the connection.
+	var output string
+	require.NoError(t, con.QueryRowContext(ctx, `SELECT current_setting('explain_output')`).Scan(&output))
+	require.Equal(t, "physical_only", output)
+
+	err = con.Raw(func(driverConn any) error {
+		_, err := ExplainQuery(ctx, driverConn.(driver.Conn), `SELECT * FROM missing`)
+		return err
+	})
+	testError(t, err, errExplain.Error(), "missing")
+	_, err = ExplainQuery(ctx, nil, `SELECT 1`)
+	require.ErrorIs(t, err, errInvalidCon)
+}
+
+func TestParsePlan(t *testing.T) {
+	t.Parallel()
+
+	root, err := parsePlan(`
+┌───────────────────────────┐
+│           UNION           ├──────────────┐
+└─────────────┬─────────────┘              │
+┌─────────────┴─────────────┐┌─────────────┴─────────────┐
+│         PROJECTION        ││         DUMMY_SCAN        │
+│   ─ ─ ─ ─ ─ ─ ─ ─ ─ ─ ─   ││                           │
+│             #0            ││                           │
+│   ─ ─ ─ ─ ─ ─ ─ ─ ─ ─ ─   ││                           │
+│           EC: 7           ││                           │
+└─────────────┬─────────────┘└───────────────────────────┘
+┌─────────────┴─────────────┐
+│         SEQ_SCAN          │
+└───────────────────────────┘`)
+	require.NoError(t, err)
+	require.Equal(t, &PlanOperator{Name: "UNION", EstimatedCardinality: -1, Children: []PlanOperator{
+		{Name: "PROJECTION", EstimatedCardinality: 7, ExtraInfo: "#0", Children: []PlanOperator{
+			{Name: "SEQ_SCAN", EstimatedCardinality: -1},
+		}},
+		{Name: "DUMMY_SCAN", EstimatedCardinality: -1},
+	}}, root)
+
+	_, err = parsePlan("")
+	require.ErrorContains(t, err, "no operators")
+}
@@ -0,0 +1,135 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+)
+
+// ExportDatabase exports the schema and the data of the database of the connection into the directory, e.g., as a backup,
+// which ImportDatabase restores. The directory contains the schema.sql and load.sql scripts, and a file of each table
+// in the format, which defaults to CopyCSV. DuckDB creates the directory, if it does not exist.
+// Like other queries, the export reports its progress to the progress reporter of the context, see ContextWithProgress.
+func ExportDatabase(ctx context.Context, driverConn driver.Conn, dir string, format CopyFormat) error {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return err
+	}
+	if dir == "" {
+		return getError(errExportDatabase, errors.New("empty directory"))
+	}
+	switch format {
+	case "":
+		format = CopyCSV
+	case CopyCSV, CopyParquet:
+	default:
+		return getError(errExportDatabase, fmt.Errorf("unsupported format %q", format))
+	}
+
+	query := "EXPORT DATABASE " + quoteString(dir) + " (FORMAT " + string(format) + ")"
+	if _, err = con.ExecContext(ctx, query, nil); err != nil {
+		return getError(errExportDatabase, err)
+	}
+	return nil
+}
+
+// ImportDatabase imports the schema and the data of the directory of ExportDatabase into the database of the connection,
+// by executing its schema.sql and load.sql scripts. The database must not contain any of the exported objects.
+// Like ExportDatabase, it reports its progress to the progress reporter of the context.
+func ImportDatabase(ctx context.Context, driverConn driver.Conn, dir string) error {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return err
+	}
+	if _, err = os.Stat(filepath.Join(dir, "schema.sql")); err != nil {
+		return getError(errImportDatabase, err)
+	}
+
+	if _, err = con.ExecContext(ctx, "IMPORT DATABASE "+quoteString(dir), nil); err != nil {
+		return getError(errImportDatabase, err)
+	}
+	return nil
+}
+
+// copyDatabaseSeq numbers the aliases, under which CopyDatabase attaches its databases, since attached databases
+// are shared by all connections of a database.
+var copyDatabaseSeq atomic.Uint64
+
+// CopyDatabaseOptions are the options of CopyDatabase.
+type CopyDatabaseOptions struct {
+	// Source is the name of the attached database to copy. It defaults to the current database of the connection.
+	Source string
+	// SourcePath is the path of a database file to copy instead, which CopyDatabase attaches read-only.
+	SourcePath string
+}
+
+// CopyDatabase copies the schema and the data of a database into a new database file at the path, e.g., to snapshot
+// an in-memory database, or a live database, for a backup, or for shipping it to consumers. It attaches the file, and
+// copies the database with COPY FROM DATABASE, which reports its progress to the progress reporter of the context,
+// see ContextWithProgress. The file must not exist, and CopyDatabase removes it again, if the copy fails.
+func CopyDatabase(ctx context.Context, driverConn driver.Conn, path string, opts CopyDatabaseOptions) error {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return err
+	}
+	if path == "" {
+		return getError(errCopyDatabase, errors.New("empty path"))
+	}
+	if opts.Source != "" && opts.SourcePath != "" {
+		return getError(errCopyDatabase, errors.New("both a source and a source path"))
+	}
+	if _, err = os.Stat(path); err == nil {
+		return getError(errCopyDatabase, fmt.Errorf("%s already exists", path))
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return getError(errCopyDatabase, err)
+	}
+
+	seq := copyDatabaseSeq.Add(1)
+	source := opts.Source
+	if opts.SourcePath != "" {
+		source = fmt.Sprintf("__copy_source_%d", seq)
+		query := "ATTACH " + quoteString(opts.SourcePath) + " AS " + quoteIdentifier(source) + " (READ_ONLY)"
+		if _, err = con.ExecContext(ctx, query, nil); err != nil {
+			return getError(errCopyDatabase, err)
+		}
+		defer con.ExecContext(context.Background(), "DETACH "+quoteIdentifier(source), nil)
+	} else if source == "" {
+		if source, err = currentDatabase(ctx, con); err != nil {
+			return getError(errCopyDatabase, err)
+		}
+	}
+
+	target := fmt.Sprintf("__copy_target_%d", seq)
+	if _, err = con.ExecContext(ctx, "ATTACH "+quoteString(path)+" AS "+quoteIdentifier(target), nil); err != nil {
+		return getError(errCopyDatabase, err)
+	}
+	query := "COPY FROM DATABASE " + quoteIdentifier(source) + " TO " + quoteIdentifier(target)
+	_, err = con.ExecContext(ctx, query, nil)
+	if _, detachErr := con.ExecContext(context.Background(), "DETACH "+quoteIdentifier(target), nil); err == nil {
+		err = detachErr
+	}
+	if err != nil {
+		os.Remove(path)
+		os.Remove(path + ".wal")
+		return getError(errCopyDatabase, err)
+	}
+	return nil
+}
+
+func currentDatabase(ctx context.Context, con *conn) (string, error) {
+	driverRows, err := con.QueryContext(ctx, "SELECT current_database()", nil)
+	if err != nil {
+		return "", err
+	}
+	defer driverRows.Close()
+
+	values := make([]driver.Value, 1)
+	if err = driverRows.Next(values); err != nil {
+		return "", err
+	}
+	return values[0].(string), nil
+}
@@ -0,0 +1,120 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportDatabase(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+
+	for _, format := range []CopyFormat{"", CopyParquet} {
+		dir := filepath.Join(t.TempDir(), "export")
+
+		db := openDB(t)
+		_, err := db.Exec(`CREATE TABLE items (id INTEGER PRIMARY KEY, name VARCHAR);
+			INSERT INTO items VALUES (1, 'a'), (2, 'b');
+			CREATE VIEW named AS SELECT name FROM items`)
+		require.NoError(t, err)
+
+		con, err := db.Conn(ctx)
+		require.NoError(t, err)
+		require.NoError(t, con.Raw(func(driverConn any) error {
+			return ExportDatabase(ctx, driverConn.(driver.Conn), dir, format)
+		}))
+		require.NoError(t, con.Close())
+		require.NoError(t, db.Close())
+		require.FileExists(t, filepath.Join(dir, "schema.sql"))
+		require.FileExists(t, filepath.Join(dir, "load.sql"))
+
+		db = openDB(t)
+		con, err = db.Conn(ctx)
+		require.NoError(t, err)
+		require.NoError(t, con.Raw(func(driverConn any) error {
+			return ImportDatabase(ctx, driverConn.(driver.Conn), dir)
+		}))
+		require.NoError(t, con.Close())
+
+		var names string
+		require.NoError(t, db.QueryRow(`SELECT string_agg(name, ',' ORDER BY name) FROM named`).Scan(&names))
+		require.Equal(t, "a,b", names)
+		require.NoError(t, db.Close())
+	}
+
+	db := openDB(t)
+	defer db.Close()
+	con, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer con.Close()
+	require.NoError(t, con.Raw(func(driverConn any) error {
+		err := ExportDatabase(ctx, driverConn.(driver.Conn), t.TempDir(), CopyJSON)
+		testError(t, err, errExportDatabase.Error(), "unsupported format")
+
+		err = ImportDatabase(ctx, driverConn.(driver.Conn), t.TempDir())
+		require.ErrorIs(t, err, errImportDatabase)
+		require.ErrorIs(t, err, os.ErrNotExist)
+		return nil
+	}))
+}
+
+func TestCopyDatabase(t *testing.T) {
+	t.Parallel()
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	db := openDB(t)
+	defer db.Close()
+	_, err := db.Exec(`CREATE TABLE items (id INTEGER PRIMARY KEY, name VARCHAR);
+		INSERT INTO items VALUES (1, 'a'), (2, 'b');
+		CREATE VIEW named AS SELECT name FROM items`)
+	require.NoError(t, err)
+
+	con, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer con.Close()
+	copyDatabase := func(path string, opts CopyDatabaseOptions) (err error) {
+		require.NoError(t, con.Raw(func(driverConn any) error {
+			err = CopyDatabase(ctx, driverConn.(driver.Conn), path, opts)
+			return nil
+		}))
+		return err
+	}
+
+	// Snapshot the in-memory database, and copy the snapshot.
+	snapshot := filepath.Join(dir, "snapshot.db")
+	require.NoError(t, copyDatabase(snapshot, CopyDatabaseOptions{}))
+	copied := filepath.Join(dir, "copied.db")
+	require.NoError(t, copyDatabase(copied, CopyDatabaseOptions{SourcePath: snapshot}))
+
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM duckdb_databases() WHERE NOT internal`).Scan(&count))
+	require.Equal(t, 1, count)
+
+	for _, path := range []string{snapshot, copied} {
+		copyDB, err := sql.Open("duckdb", path+"?access_mode=read_only")
+		require.NoError(t, err)
+		var names string
+		require.NoError(t, copyDB.QueryRow(`SELECT string_agg(name, ',' ORDER BY name) FROM named`).Scan(&names))
+		require.Equal(t, "a,b", names)
+		require.NoError(t, copyDB.Close())
+	}
+
+	err = copyDatabase(snapshot, CopyDatabaseOptions{})
+	testError(t, err, errCopyDatabase.Error(), "already exists")
+
+	missing := filepath.Join(dir, "missing.db")
+	err = copyDatabase(missing, CopyDatabaseOptions{Source: "unknown"})
+	require.ErrorIs(t, err, errCopyDatabase)
+	require.NoFileExists(t, missing)
+	require.NoFileExists(t, missing+".wal")
+
+	err = copyDatabase(missing, CopyDatabaseOptions{Source: "memory", SourcePath: snapshot})
+	testError(t, err, errCopyDatabase.Error(), "both a source and a source path")
+}
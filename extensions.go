@@ -0,0 +1,193 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// extensionInstallRetryDelay is the delay before retrying a failed INSTALL.
+const extensionInstallRetryDelay = 100 * time.Millisecond
+
+// WithExtensions installs and loads the extensions on each new connection, before calling connInitFn.
+// An extension is a name, e.g., "json", or the path or URL of an extension file.
+//
+// Installing downloads an extension into the extension directory, which concurrent installs race on.
+// Thus, the connections of a Connector install extensions one at a time, and each extension is installed
+// at most once per Connector. An extension that DuckDB already lists as installed or loaded is not installed again.
+// A failed INSTALL is retried once after a short delay, as it can fail transiently, e.g., if another process
+// installs the same extension. Loading an installed extension does not require a lock, so connections load
+// extensions concurrently. A failed installation or load returns an *ExtensionError.
+func WithExtensions(extensions ...string) ConnectorOption {
+	return func(c *connectorConfig) error {
+		c.extensions = append(c.extensions, extensions...)
+		return nil
+	}
+}
+
+// WithAutoloadExtensions enables DuckDB to load known extensions, e.g., json or httpfs, when a query first uses them.
+// If autoinstall is true, DuckDB also installs a known extension, which is not installed yet, from the extension
+// repository. Otherwise, autoloading fails for extensions that are not installed.
+func WithAutoloadExtensions(autoinstall bool) ConnectorOption {
+	return func(c *connectorConfig) error {
+		c.config["autoload_known_extensions"] = "true"
+		c.config["autoinstall_known_extensions"] = strconv.FormatBool(autoinstall)
+		return nil
+	}
+}
+
+// WithExtensionDirectory sets the directory, into which DuckDB installs extensions, and from which it loads them.
+// It defaults to ~/.duckdb/extensions.
+func WithExtensionDirectory(dir string) ConnectorOption {
+	return func(c *connectorConfig) error {
+		if dir == "" {
+			return errors.New("empty extension directory")
+		}
+		c.config["extension_directory"] = dir
+		return nil
+	}
+}
+
+// WithExtensionRepository sets the URL or directory of the repository, from which DuckDB installs extensions
+// by name, e.g., a mirror of the default repository.
+func WithExtensionRepository(repository string) ConnectorOption {
+	return func(c *connectorConfig) error {
+		if repository == "" {
+			return errors.New("empty extension repository")
+		}
+		c.config["custom_extension_repository"] = repository
+		return nil
+	}
+}
+
+// ExtensionError is the error of installing or loading an extension. It matches the error of DuckDB with errors.As,
+// e.g., an *Error.
+type ExtensionError struct {
+	// Extension is the name, path, or URL of the extension.
+	Extension string
+	// Load is true, if loading the extension failed, and false, if installing it failed.
+	Load bool
+	// Err is the error of installing or loading the extension.
+	Err error
+}
+
+func (e *ExtensionError) Error() string {
+	if e.Load {
+		return getError(errLoadExtension, e.Err).Error()
+	}
+	return getError(errInstallExtension, e.Err).Error()
+}
+
+func (e *ExtensionError) Unwrap() error {
+	return e.Err
+}
+
+// InstallExtension installs the extension, i.e., a name, e.g., "json", or the path or URL of an extension file.
+// An extension that DuckDB already lists as installed or loaded, e.g., because it is statically linked,
+// is not installed again. A failed installation returns an *ExtensionError.
+func InstallExtension(driverConn driver.Conn, extension string) error {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	installed, err := extensionInstalled(ctx, con, extension)
+	if err == nil && !installed {
+		_, err = con.ExecContext(ctx, "INSTALL "+quoteString(extension), nil)
+	}
+	if err != nil {
+		return &ExtensionError{Extension: extension, Err: err}
+	}
+	return nil
+}
+
+// LoadExtension loads the installed extension into the database of the connection, see InstallExtension.
+// A failed load returns an *ExtensionError.
+func LoadExtension(driverConn driver.Conn, extension string) error {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return err
+	}
+	if _, err = con.ExecContext(context.Background(), "LOAD "+quoteString(extension), nil); err != nil {
+		return &ExtensionError{Extension: extension, Load: true, Err: err}
+	}
+	return nil
+}
+
+// loadExtensions installs and loads the extensions of the connector on the connection.
+func (c *Connector) loadExtensions(ctx context.Context, con *conn) error {
+	for _, extension := range c.config.extensions {
+		if err := c.installExtension(ctx, con, extension); err != nil {
+			return &ExtensionError{Extension: extension, Err: err}
+		}
+		if _, err := con.ExecContext(ctx, "LOAD "+quoteString(extension), nil); err != nil {
+			return &ExtensionError{Extension: extension, Load: true, Err: err}
+		}
+	}
+	return nil
+}
+
+func (c *Connector) installExtension(ctx context.Context, con *conn, extension string) error {
+	c.extensionsMu.Lock()
+	defer c.extensionsMu.Unlock()
+
+	if _, ok := c.installedExtensions[extension]; ok {
+		return nil
+	}
+
+	installed, err := extensionInstalled(ctx, con, extension)
+	if err != nil {
+		return err
+	}
+	if !installed {
+		query := "INSTALL " + quoteString(extension)
+		if _, err = con.ExecContext(ctx, query, nil); err != nil {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(extensionInstallRetryDelay):
+			}
+			if _, err = con.ExecContext(ctx, query, nil); err != nil {
+				return err
+			}
+		}
+	}
+
+	if c.installedExtensions == nil {
+		c.installedExtensions = map[string]struct{}{}
+	}
+	c.installedExtensions[extension] = struct{}{}
+	return nil
+}
+
+// extensionInstalled returns true, if DuckDB lists the extension as installed or loaded,
+// e.g., because it is statically linked.
+func extensionInstalled(ctx context.Context, con *conn, extension string) (bool, error) {
+	driverRows, err := con.QueryContext(ctx, `SELECT installed OR loaded FROM duckdb_extensions()
+		WHERE extension_name = ? OR ? IN (SELECT unnest(aliases))`, []driver.NamedValue{
+		{Ordinal: 1, Value: extension}, {Ordinal: 2, Value: extension},
+	})
+	if err != nil {
+		return false, err
+	}
+	defer driverRows.Close()
+
+	values := make([]driver.Value, 1)
+	if err = driverRows.Next(values); err == io.EOF {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	installed, _ := values[0].(bool)
+	return installed, nil
+}
+
+// quoteString quotes a string literal.
+func quoteString(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
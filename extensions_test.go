@@ -0,0 +1,138 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithExtensions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("concurrent connections", func(t *testing.T) {
+		initCalls := 0
+		var mu sync.Mutex
+		c, err := NewConnector("", func(execer driver.ExecerContext) error {
+			mu.Lock()
+			defer mu.Unlock()
+			initCalls++
+			return nil
+		}, WithExtensions("parquet"))
+		require.NoError(t, err)
+		defer c.Close()
+
+		var wg sync.WaitGroup
+		cons := make([]driver.Conn, 8)
+		errs := make([]error, len(cons))
+		for i := range cons {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				cons[i], errs[i] = c.Connect(context.Background())
+			}(i)
+		}
+		wg.Wait()
+
+		for i := range cons {
+			require.NoError(t, errs[i])
+			require.NoError(t, cons[i].Close())
+		}
+		require.Equal(t, len(cons), initCalls)
+		require.Equal(t, map[string]struct{}{"parquet": {}}, c.installedExtensions)
+
+		db := sql.OpenDB(c)
+		defer db.Close()
+		var loaded bool
+		require.NoError(t, db.QueryRow(`SELECT loaded FROM duckdb_extensions() WHERE extension_name = 'parquet'`).
+			Scan(&loaded))
+		require.True(t, loaded)
+	})
+
+	t.Run("install error", func(t *testing.T) {
+		c, err := NewConnector("", nil, WithExtensions("/nonexistent/ext.duckdb_extension"))
+		require.NoError(t, err)
+		defer c.Close()
+
+		_, err = c.Connect(context.Background())
+		testError(t, err, errInstallExtension.Error(), "/nonexistent/ext.duckdb_extension")
+		require.Empty(t, c.installedExtensions)
+
+		var extErr *ExtensionError
+		require.True(t, errors.As(err, &extErr))
+		require.Equal(t, "/nonexistent/ext.duckdb_extension", extErr.Extension)
+		require.False(t, extErr.Load)
+	})
+}
+
+func TestInstallLoadExtension(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	con, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	require.NoError(t, con.Raw(func(driverConn any) error {
+		if err := InstallExtension(driverConn.(driver.Conn), "parquet"); err != nil {
+			return err
+		}
+		return LoadExtension(driverConn.(driver.Conn), "parquet")
+	}))
+
+	err = con.Raw(func(driverConn any) error {
+		return InstallExtension(driverConn.(driver.Conn), "/nonexistent/ext.duckdb_extension")
+	})
+	testError(t, err, errInstallExtension.Error(), "/nonexistent/ext.duckdb_extension")
+	var extErr *ExtensionError
+	require.True(t, errors.As(err, &extErr))
+	require.False(t, extErr.Load)
+	var duckdbErr *Error
+	require.True(t, errors.As(err, &duckdbErr))
+
+	err = con.Raw(func(driverConn any) error {
+		return LoadExtension(driverConn.(driver.Conn), "/nonexistent/ext.duckdb_extension")
+	})
+	testError(t, err, errLoadExtension.Error(), "/nonexistent/ext.duckdb_extension")
+	require.True(t, errors.As(err, &extErr))
+	require.True(t, extErr.Load)
+	require.Equal(t, "/nonexistent/ext.duckdb_extension", extErr.Extension)
+
+	err = InstallExtension(nil, "parquet")
+	testError(t, err, errInvalidCon.Error())
+	err = LoadExtension(nil, "parquet")
+	testError(t, err, errInvalidCon.Error())
+}
+
+func TestExtensionOptions(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	c, err := NewConnector("", nil,
+		WithAutoloadExtensions(false),
+		WithExtensionDirectory(dir),
+		WithExtensionRepository("http://localhost:1/extensions"),
+	)
+	require.NoError(t, err)
+	db := sql.OpenDB(c)
+	defer db.Close()
+
+	var autoload, autoinstall bool
+	var extensionDir, repository string
+	require.NoError(t, db.QueryRow(`SELECT current_setting('autoload_known_extensions'),
+		current_setting('autoinstall_known_extensions'), current_setting('extension_directory'),
+		current_setting('custom_extension_repository')`).Scan(&autoload, &autoinstall, &extensionDir, &repository))
+	require.True(t, autoload)
+	require.False(t, autoinstall)
+	require.Equal(t, dir, extensionDir)
+	require.Equal(t, "http://localhost:1/extensions", repository)
+
+	_, err = NewConnector("", nil, WithExtensionDirectory(""))
+	require.ErrorContains(t, err, "empty extension directory")
+	_, err = NewConnector("", nil, WithExtensionRepository(""))
+	require.ErrorContains(t, err, "empty extension repository")
+}
@@ -0,0 +1,531 @@
+// Package flightsqlduckdb serves a DuckDB database of go-duckdb over Arrow Flight SQL, so that BI tools and remote
+// clients, e.g., the Flight SQL JDBC driver or ADBC, can execute queries and prepared statements, whose results
+// stream as Apache Arrow records. It is a separate module, so that the driver does not depend on gRPC.
+package flightsqlduckdb
+
+import (
+	"context"
+	"crypto/rand"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/flight"
+	"github.com/apache/arrow/go/v14/arrow/flight/flightsql"
+	"github.com/apache/arrow/go/v14/arrow/ipc"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	"github.com/marcboeker/go-duckdb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// serverName is the name of the server reported by its SQL info.
+const serverName = "go-duckdb"
+
+// Server is a Flight SQL server of the DuckDB database of a sql.DB. Each query executes on a connection of the pool
+// of the sql.DB, and streams its records as DuckDB produces them. Register it with flightsql.NewFlightServer:
+//
+//	srv := flight.NewServerWithMiddleware(nil)
+//	srv.RegisterFlightService(flightsql.NewFlightServer(server))
+//
+// The server does not support transactions, Substrait plans, or the catalog metadata commands.
+type Server struct {
+	flightsql.BaseServer
+	db *sql.DB
+
+	// PreparedStatementIdleTimeout is the duration, after which the server closes a prepared statement, which has not
+	// been used, so that the prepared statements of clients, which do not close them, do not accumulate. NewServer sets
+	// it to 10 minutes. If it is 0, prepared statements stay open until their clients close them.
+	PreparedStatementIdleTimeout time.Duration
+
+	// The prepared statements by their handles.
+	prepared sync.Map
+}
+
+// NewServer returns a new Flight SQL server of the database, which must be opened with the duckdb driver.
+// Queries of prepared statements execute through duckdb.WriteArrowIPC, which reuses the statements prepared on each
+// connection, if the database is opened with duckdb.WithStatementCache.
+func NewServer(db *sql.DB) (*Server, error) {
+	s := &Server{db: db, PreparedStatementIdleTimeout: 10 * time.Minute}
+	s.Alloc = memory.DefaultAllocator
+	info := map[flightsql.SqlInfo]any{
+		flightsql.SqlInfoFlightSqlServerName:         serverName,
+		flightsql.SqlInfoFlightSqlServerVersion:      duckdb.LibraryVersion(),
+		flightsql.SqlInfoFlightSqlServerArrowVersion: arrow.PkgVersion,
+		flightsql.SqlInfoFlightSqlServerReadOnly:     false,
+		flightsql.SqlInfoFlightSqlServerSql:          true,
+		flightsql.SqlInfoFlightSqlServerSubstrait:    false,
+		flightsql.SqlInfoFlightSqlServerTransaction:  int32(flightsql.SqlTransactionNone),
+	}
+	for id, value := range info {
+		if err := s.RegisterSqlInfo(id, value); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// preparedStatement is a statement prepared by CreatePreparedStatement. The rows of its parameters are bound by
+// DoPutPreparedStatementQuery.
+type preparedStatement struct {
+	query string
+	// The statement prepared on the connections of the database, which executes the updates of the statement.
+	stmt *sql.Stmt
+	// The time of the last use of the statement in Unix nanoseconds, see Server.PreparedStatementIdleTimeout.
+	lastUsed atomic.Int64
+
+	mu   sync.Mutex
+	args [][]any
+}
+
+// touch records the use of the statement.
+func (p *preparedStatement) touch() {
+	p.lastUsed.Store(time.Now().UnixNano())
+}
+
+// GetFlightInfoStatement returns the ticket of the query, which DoGetStatement executes.
+func (s *Server) GetFlightInfoStatement(_ context.Context, cmd flightsql.StatementQuery,
+	desc *flight.FlightDescriptor,
+) (*flight.FlightInfo, error) {
+	if len(cmd.GetTransactionId()) != 0 {
+		return nil, transactionError()
+	}
+	ticket, err := flightsql.CreateStatementQueryTicket([]byte(cmd.GetQuery()))
+	if err != nil {
+		return nil, err
+	}
+	return flightInfo(desc, ticket), nil
+}
+
+// DoGetStatement executes the query of the ticket, and streams its records.
+func (s *Server) DoGetStatement(ctx context.Context, cmd flightsql.StatementQueryTicket) (
+	*arrow.Schema, <-chan flight.StreamChunk, error,
+) {
+	return s.doGet(ctx, string(cmd.GetStatementHandle()), nil)
+}
+
+// DoPutCommandStatementUpdate executes the query, and returns the number of affected rows.
+func (s *Server) DoPutCommandStatementUpdate(ctx context.Context, cmd flightsql.StatementUpdate) (int64, error) {
+	if len(cmd.GetTransactionId()) != 0 {
+		return 0, transactionError()
+	}
+	res, err := s.db.ExecContext(ctx, cmd.GetQuery())
+	if err != nil {
+		return 0, statusError(err)
+	}
+	n, err := res.RowsAffected()
+	return n, statusError(err)
+}
+
+// CreatePreparedStatement prepares the query, and returns the handle of the prepared statement, and the schema of
+// its parameters, see duckdb.DescribeParameters. Parameters, whose type DuckDB cannot infer, have the null type,
+// i.e., they accept any value. The schema of its result is reported with its records.
+func (s *Server) CreatePreparedStatement(ctx context.Context, req flightsql.ActionCreatePreparedStatementRequest) (
+	flightsql.ActionCreatePreparedStatementResult, error,
+) {
+	var result flightsql.ActionCreatePreparedStatementResult
+	if len(req.GetTransactionId()) != 0 {
+		return result, transactionError()
+	}
+	s.closeIdlePreparedStatements()
+
+	stmt, err := s.db.PrepareContext(ctx, req.GetQuery())
+	if err != nil {
+		return result, statusError(err)
+	}
+	if result.ParameterSchema, err = s.parameterSchema(ctx, req.GetQuery()); err != nil {
+		stmt.Close()
+		return result, statusError(err)
+	}
+
+	handle := make([]byte, 16)
+	if _, err = rand.Read(handle); err != nil {
+		stmt.Close()
+		return result, status.Error(codes.Internal, err.Error())
+	}
+	result.Handle = []byte(hex.EncodeToString(handle))
+	prepared := &preparedStatement{query: req.GetQuery(), stmt: stmt}
+	prepared.touch()
+	s.prepared.Store(string(result.Handle), prepared)
+	return result, nil
+}
+
+// parameterSchema returns the schema of the parameters of the query.
+func (s *Server) parameterSchema(ctx context.Context, query string) (*arrow.Schema, error) {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	var params []duckdb.ParameterDescriptor
+	err = duckdb.RawConn(conn, func(c *duckdb.Conn) error {
+		params, err = duckdb.DescribeParameters(ctx, c.Driver(), query)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	fields := make([]arrow.Field, len(params))
+	for i, param := range params {
+		fields[i] = arrow.Field{Name: param.Name, Type: parameterType(param.Type), Nullable: true}
+	}
+	return arrow.NewSchema(fields, nil), nil
+}
+
+// ClosePreparedStatement closes the prepared statement of the handle.
+func (s *Server) ClosePreparedStatement(_ context.Context, req flightsql.ActionClosePreparedStatementRequest) error {
+	stmt, ok := s.prepared.LoadAndDelete(string(req.GetPreparedStatementHandle()))
+	if !ok {
+		return preparedStatementError()
+	}
+	return statusError(stmt.(*preparedStatement).stmt.Close())
+}
+
+// closeIdlePreparedStatements closes the prepared statements, which have not been used within the
+// PreparedStatementIdleTimeout.
+func (s *Server) closeIdlePreparedStatements() {
+	if s.PreparedStatementIdleTimeout <= 0 {
+		return
+	}
+	idleSince := time.Now().Add(-s.PreparedStatementIdleTimeout).UnixNano()
+	s.prepared.Range(func(handle, value any) bool {
+		if stmt := value.(*preparedStatement); stmt.lastUsed.Load() < idleSince {
+			if _, ok := s.prepared.LoadAndDelete(handle); ok {
+				stmt.stmt.Close()
+			}
+		}
+		return true
+	})
+}
+
+// GetFlightInfoPreparedStatement returns the ticket of the prepared statement, which DoGetPreparedStatement executes.
+func (s *Server) GetFlightInfoPreparedStatement(_ context.Context, cmd flightsql.PreparedStatementQuery,
+	desc *flight.FlightDescriptor,
+) (*flight.FlightInfo, error) {
+	if _, err := s.preparedStatement(cmd.GetPreparedStatementHandle()); err != nil {
+		return nil, err
+	}
+	return flightInfo(desc, desc.Cmd), nil
+}
+
+// DoGetPreparedStatement executes the prepared statement with its bound parameters, and streams its records.
+// Only a single row of parameters can be bound.
+func (s *Server) DoGetPreparedStatement(ctx context.Context, cmd flightsql.PreparedStatementQuery) (
+	*arrow.Schema, <-chan flight.StreamChunk, error,
+) {
+	stmt, err := s.preparedStatement(cmd.GetPreparedStatementHandle())
+	if err != nil {
+		return nil, nil, err
+	}
+	stmt.mu.Lock()
+	args := stmt.args
+	stmt.mu.Unlock()
+	if len(args) > 1 {
+		return nil, nil, status.Error(codes.InvalidArgument, "cannot query with more than one row of parameters")
+	}
+
+	var params []any
+	if len(args) == 1 {
+		params = args[0]
+	}
+	return s.doGet(ctx, stmt.query, params)
+}
+
+// DoPutPreparedStatementQuery binds the rows of the records to the parameters of the prepared statement.
+func (s *Server) DoPutPreparedStatementQuery(_ context.Context, cmd flightsql.PreparedStatementQuery,
+	rdr flight.MessageReader, _ flight.MetadataWriter,
+) error {
+	stmt, err := s.preparedStatement(cmd.GetPreparedStatementHandle())
+	if err != nil {
+		return err
+	}
+	args, err := recordArgs(rdr)
+	if err != nil {
+		return err
+	}
+	stmt.mu.Lock()
+	defer stmt.mu.Unlock()
+	stmt.args = args
+	return nil
+}
+
+// DoPutPreparedStatementUpdate executes the prepared statement once for each row of the records in a transaction,
+// or once without parameters, and returns the number of affected rows.
+func (s *Server) DoPutPreparedStatementUpdate(ctx context.Context, cmd flightsql.PreparedStatementUpdate,
+	rdr flight.MessageReader,
+) (int64, error) {
+	stmt, err := s.preparedStatement(cmd.GetPreparedStatementHandle())
+	if err != nil {
+		return 0, err
+	}
+	args, err := recordArgs(rdr)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(args) == 0 {
+		res, err := stmt.stmt.ExecContext(ctx)
+		if err != nil {
+			return 0, statusError(err)
+		}
+		n, err := res.RowsAffected()
+		return n, statusError(err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, statusError(err)
+	}
+	defer tx.Rollback()
+	txStmt := tx.StmtContext(ctx, stmt.stmt)
+	var total int64
+	for i, values := range args {
+		res, err := txStmt.ExecContext(ctx, values...)
+		if err != nil {
+			return 0, statusError(fmt.Errorf("arguments %d: %w", i, err))
+		}
+		n, err := res.RowsAffected()
+		if err != nil {
+			return 0, statusError(err)
+		}
+		total += n
+	}
+	return total, statusError(tx.Commit())
+}
+
+// preparedStatement returns the prepared statement of the handle.
+func (s *Server) preparedStatement(handle []byte) (*preparedStatement, error) {
+	stmt, ok := s.prepared.Load(string(handle))
+	if !ok {
+		return nil, preparedStatementError()
+	}
+	stmt.(*preparedStatement).touch()
+	return stmt.(*preparedStatement), nil
+}
+
+// doGet executes the query with the arguments on a connection of the database, and streams its records. The records
+// are written as an Arrow IPC stream through a pipe, see duckdb.WriteArrowIPC, so that the records stream without
+// buffering the result. Canceling the context, e.g., once the client disconnects, interrupts the query.
+func (s *Server) doGet(ctx context.Context, query string, args []any) (*arrow.Schema, <-chan flight.StreamChunk, error) {
+	conn, err := s.db.Conn(ctx)
+	if err != nil {
+		return nil, nil, statusError(err)
+	}
+
+	pr, pw := io.Pipe()
+	go func() {
+		err := duckdb.RawConn(conn, func(c *duckdb.Conn) error {
+			_, err := duckdb.WriteArrowIPC(ctx, c.Driver(), query, pw, args...)
+			return err
+		})
+		conn.Close()
+		pw.CloseWithError(err)
+	}()
+
+	rdr, err := ipc.NewReader(pr, ipc.WithAllocator(s.Alloc))
+	if err != nil {
+		// Unblock the query, if it failed after writing its schema.
+		pr.CloseWithError(err)
+		return nil, nil, statusError(err)
+	}
+
+	ch := make(chan flight.StreamChunk)
+	go func() {
+		defer close(ch)
+		defer rdr.Release()
+		defer pr.Close()
+		for rdr.Next() {
+			rec := rdr.Record()
+			rec.Retain()
+			select {
+			case ch <- flight.StreamChunk{Data: rec}:
+			case <-ctx.Done():
+				rec.Release()
+				return
+			}
+		}
+		if err := rdr.Err(); err != nil {
+			select {
+			case ch <- flight.StreamChunk{Err: statusError(err)}:
+			case <-ctx.Done():
+			}
+		}
+	}()
+	return rdr.Schema(), ch, nil
+}
+
+// flightInfo returns the flight info of the command, whose single endpoint has the ticket. The schema, and the number
+// of records and bytes are unknown before executing the command.
+func flightInfo(desc *flight.FlightDescriptor, ticket []byte) *flight.FlightInfo {
+	return &flight.FlightInfo{
+		Endpoint:         []*flight.FlightEndpoint{{Ticket: &flight.Ticket{Ticket: ticket}}},
+		FlightDescriptor: desc,
+		TotalRecords:     -1,
+		TotalBytes:       -1,
+	}
+}
+
+// recordArgs returns the rows of the records as the arguments of executions, or nil, if there are no records.
+func recordArgs(rdr array.RecordReader) ([][]any, error) {
+	var args [][]any
+	for rdr.Next() {
+		rec := rdr.Record()
+		for row := 0; row < int(rec.NumRows()); row++ {
+			values := make([]any, rec.NumCols())
+			for col := range values {
+				values[col] = arrowValue(rec.Column(col), row)
+			}
+			args = append(args, values)
+		}
+	}
+	if err := rdr.Err(); err != nil && !errors.Is(err, io.EOF) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	return args, nil
+}
+
+// arrowValue returns the Go value of a parameter in an Arrow array.
+func arrowValue(arr arrow.Array, i int) any {
+	if arr.IsNull(i) {
+		return nil
+	}
+	switch a := arr.(type) {
+	case *array.Boolean:
+		return a.Value(i)
+	case *array.Int8:
+		return a.Value(i)
+	case *array.Int16:
+		return a.Value(i)
+	case *array.Int32:
+		return a.Value(i)
+	case *array.Int64:
+		return a.Value(i)
+	case *array.Uint8:
+		return a.Value(i)
+	case *array.Uint16:
+		return a.Value(i)
+	case *array.Uint32:
+		return a.Value(i)
+	case *array.Uint64:
+		return a.Value(i)
+	case *array.Float32:
+		return a.Value(i)
+	case *array.Float64:
+		return a.Value(i)
+	case *array.String:
+		return a.Value(i)
+	case *array.LargeString:
+		return a.Value(i)
+	case *array.Binary:
+		return a.Value(i)
+	case *array.LargeBinary:
+		return a.Value(i)
+	case *array.Date32:
+		return a.Value(i).ToTime()
+	case *array.Date64:
+		return a.Value(i).ToTime()
+	case *array.Timestamp:
+		return a.Value(i).ToTime(a.DataType().(*arrow.TimestampType).Unit)
+	}
+	return arr.GetOneForMarshal(i)
+}
+
+// parameterTypes are the Arrow types of the parameters by the names of their DuckDB types.
+var parameterTypes = map[string]arrow.DataType{
+	"BOOLEAN":   arrow.FixedWidthTypes.Boolean,
+	"TINYINT":   arrow.PrimitiveTypes.Int8,
+	"SMALLINT":  arrow.PrimitiveTypes.Int16,
+	"INTEGER":   arrow.PrimitiveTypes.Int32,
+	"BIGINT":    arrow.PrimitiveTypes.Int64,
+	"UTINYINT":  arrow.PrimitiveTypes.Uint8,
+	"USMALLINT": arrow.PrimitiveTypes.Uint16,
+	"UINTEGER":  arrow.PrimitiveTypes.Uint32,
+	"UBIGINT":   arrow.PrimitiveTypes.Uint64,
+	"FLOAT":     arrow.PrimitiveTypes.Float32,
+	"DOUBLE":    arrow.PrimitiveTypes.Float64,
+	"VARCHAR":   arrow.BinaryTypes.String,
+	"BLOB":      arrow.BinaryTypes.Binary,
+	"DATE":      arrow.FixedWidthTypes.Date32,
+	"TIMESTAMP": arrow.FixedWidthTypes.Timestamp_us,
+}
+
+// parameterType returns the Arrow type of a parameter of the DuckDB type, or the null type, if the type is unknown.
+func parameterType(name string) arrow.DataType {
+	if t, ok := parameterTypes[name]; ok {
+		return t
+	}
+	return arrow.Null
+}
+
+// errorCodes are the gRPC codes of the types of DuckDB errors. All other types have the code codes.Unknown.
+var errorCodes = map[duckdb.ErrorType]codes.Code{
+	duckdb.ErrorTypeConstraint:           codes.FailedPrecondition,
+	duckdb.ErrorTypeParser:               codes.InvalidArgument,
+	duckdb.ErrorTypeSyntax:               codes.InvalidArgument,
+	duckdb.ErrorTypeBinder:               codes.InvalidArgument,
+	duckdb.ErrorTypeMismatchType:         codes.InvalidArgument,
+	duckdb.ErrorTypeInvalidInput:         codes.InvalidArgument,
+	duckdb.ErrorTypeParameterNotResolved: codes.InvalidArgument,
+	duckdb.ErrorTypeParameterNotAllowed:  codes.InvalidArgument,
+	duckdb.ErrorTypeConversion:           codes.InvalidArgument,
+	duckdb.ErrorTypeOutOfRange:           codes.OutOfRange,
+	duckdb.ErrorTypeDivideByZero:         codes.InvalidArgument,
+	duckdb.ErrorTypeDecimal:              codes.InvalidArgument,
+	duckdb.ErrorTypeNotImplemented:       codes.Unimplemented,
+	duckdb.ErrorTypeTransaction:          codes.FailedPrecondition,
+	duckdb.ErrorTypeIO:                   codes.Unavailable,
+	duckdb.ErrorTypeHTTP:                 codes.Unavailable,
+	duckdb.ErrorTypeInterrupt:            codes.Canceled,
+	duckdb.ErrorTypePermission:           codes.PermissionDenied,
+	duckdb.ErrorTypeInternal:             codes.Internal,
+	duckdb.ErrorTypeFatal:                codes.Internal,
+	duckdb.ErrorTypeCatalog:              codes.NotFound,
+}
+
+// statusError converts an error of the driver into a gRPC status error, whose code depends on the type of
+// the DuckDB error.
+func statusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	code := codes.Unknown
+	var duckdbErr *duckdb.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		code = codes.Canceled
+	case errors.Is(err, context.DeadlineExceeded):
+		code = codes.DeadlineExceeded
+	case errors.Is(err, driver.ErrBadConn), errors.Is(err, duckdb.ErrClosed):
+		code = codes.Unavailable
+	case errors.As(err, &duckdbErr):
+		if c, ok := errorCodes[duckdbErr.Type]; ok {
+			code = c
+		}
+		if duckdbErr.Type == duckdb.ErrorTypeCatalog && strings.Contains(duckdbErr.Msg, "already exists") {
+			code = codes.AlreadyExists
+		}
+	}
+	return status.Error(code, err.Error())
+}
+
+func transactionError() error {
+	return status.Error(codes.Unimplemented, "transactions are not supported")
+}
+
+func preparedStatementError() error {
+	return status.Error(codes.InvalidArgument, "prepared statement not found")
+}
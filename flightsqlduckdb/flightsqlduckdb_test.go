@@ -0,0 +1,167 @@
+package flightsqlduckdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/apache/arrow/go/v14/arrow"
+	"github.com/apache/arrow/go/v14/arrow/array"
+	"github.com/apache/arrow/go/v14/arrow/flight"
+	"github.com/apache/arrow/go/v14/arrow/flight/flightsql"
+	"github.com/apache/arrow/go/v14/arrow/memory"
+	_ "github.com/marcboeker/go-duckdb"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+// openClient serves the in-memory database on a local port, and returns a client of the server, and the server.
+func openClient(t *testing.T) (*flightsql.Client, *Server) {
+	db, err := sql.Open("duckdb", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, db.Close()) })
+
+	server, err := NewServer(db)
+	require.NoError(t, err)
+	srv := flight.NewServerWithMiddleware(nil)
+	srv.RegisterFlightService(flightsql.NewFlightServer(server))
+	require.NoError(t, srv.Init("localhost:0"))
+	go func() { _ = srv.Serve() }()
+	t.Cleanup(srv.Shutdown)
+
+	client, err := flightsql.NewClient(srv.Addr().String(), nil, nil,
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { require.NoError(t, client.Close()) })
+	return client, server
+}
+
+// readInt64s returns the values of the first column of the records of the flight info.
+func readInt64s(t *testing.T, client *flightsql.Client, info *flight.FlightInfo) []int64 {
+	rdr, err := client.DoGet(context.Background(), info.Endpoint[0].Ticket)
+	require.NoError(t, err)
+	defer rdr.Release()
+
+	var values []int64
+	for rdr.Next() {
+		values = append(values, rdr.Record().Column(0).(*array.Int64).Int64Values()...)
+	}
+	require.NoError(t, rdr.Err())
+	return values
+}
+
+func TestStatement(t *testing.T) {
+	t.Parallel()
+	client, _ := openClient(t)
+	ctx := context.Background()
+
+	n, err := client.ExecuteUpdate(ctx, `CREATE TABLE tbl AS SELECT range AS i FROM range(10000)`)
+	require.NoError(t, err)
+	require.Equal(t, int64(10000), n)
+	n, err = client.ExecuteUpdate(ctx, `DELETE FROM tbl WHERE i >= 5000`)
+	require.NoError(t, err)
+	require.Equal(t, int64(5000), n)
+
+	// The result spans multiple records.
+	info, err := client.Execute(ctx, `SELECT i FROM tbl ORDER BY i`)
+	require.NoError(t, err)
+	values := readInt64s(t, client, info)
+	require.Len(t, values, 5000)
+	require.Equal(t, int64(4999), values[4999])
+
+	// The query executes, once the client gets its ticket.
+	info, err = client.Execute(ctx, `SELECT * FROM missing`)
+	require.NoError(t, err)
+	_, err = client.DoGet(ctx, info.Endpoint[0].Ticket)
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	_, err = client.ExecuteUpdate(ctx, `SELEC 1`)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestPreparedStatement(t *testing.T) {
+	t.Parallel()
+	client, _ := openClient(t)
+	ctx := context.Background()
+
+	_, err := client.ExecuteUpdate(ctx, `CREATE TABLE tbl (i BIGINT, s VARCHAR)`)
+	require.NoError(t, err)
+
+	insert, err := client.Prepare(ctx, `INSERT INTO tbl VALUES (?, ?)`)
+	require.NoError(t, err)
+	require.Equal(t, []arrow.Field{{Name: "1", Type: arrow.PrimitiveTypes.Int64, Nullable: true},
+		{Name: "2", Type: arrow.BinaryTypes.String, Nullable: true}}, insert.ParameterSchema().Fields())
+	params := arrow.NewSchema([]arrow.Field{{Name: "i", Type: arrow.PrimitiveTypes.Int64},
+		{Name: "s", Type: arrow.BinaryTypes.String, Nullable: true}}, nil)
+	builder := array.NewRecordBuilder(memory.DefaultAllocator, params)
+	defer builder.Release()
+	builder.Field(0).(*array.Int64Builder).AppendValues([]int64{1, 2, 3}, nil)
+	builder.Field(1).(*array.StringBuilder).AppendValues([]string{"a", "", "c"}, []bool{true, false, true})
+	rec := builder.NewRecord()
+	defer rec.Release()
+	insert.SetParameters(rec)
+	n, err := insert.ExecuteUpdate(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(3), n)
+	require.NoError(t, insert.Close(ctx))
+
+	query, err := client.Prepare(ctx, `SELECT i FROM tbl WHERE i >= ? AND s IS NOT NULL ORDER BY i`)
+	require.NoError(t, err)
+	defer query.Close(ctx)
+	builder = array.NewRecordBuilder(memory.DefaultAllocator,
+		arrow.NewSchema([]arrow.Field{{Name: "min", Type: arrow.PrimitiveTypes.Int64}}, nil))
+	defer builder.Release()
+	builder.Field(0).(*array.Int64Builder).Append(2)
+	rec = builder.NewRecord()
+	defer rec.Release()
+	query.SetParameters(rec)
+	info, err := query.Execute(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []int64{3}, readInt64s(t, client, info))
+
+	_, err = client.Prepare(ctx, `SELECT * FROM missing`)
+	require.Equal(t, codes.NotFound, status.Code(err))
+
+	// The parameter accepts any value, as DuckDB cannot infer its type.
+	anyParam, err := client.Prepare(ctx, `SELECT ?`)
+	require.NoError(t, err)
+	defer anyParam.Close(ctx)
+	require.Equal(t, arrow.Null, anyParam.ParameterSchema().Field(0).Type)
+}
+
+func TestPreparedStatementIdleTimeout(t *testing.T) {
+	t.Parallel()
+	client, server := openClient(t)
+	server.PreparedStatementIdleTimeout = 10 * time.Millisecond
+	ctx := context.Background()
+
+	idle, err := client.Prepare(ctx, `SELECT 1`)
+	require.NoError(t, err)
+	time.Sleep(20 * time.Millisecond)
+
+	// Preparing another statement closes the idle statement.
+	stmt, err := client.Prepare(ctx, `SELECT 2`)
+	require.NoError(t, err)
+	defer stmt.Close(ctx)
+	_, err = idle.Execute(ctx)
+	require.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestSqlInfo(t *testing.T) {
+	t.Parallel()
+	client, _ := openClient(t)
+	ctx := context.Background()
+
+	info, err := client.GetSqlInfo(ctx, []flightsql.SqlInfo{flightsql.SqlInfoFlightSqlServerName})
+	require.NoError(t, err)
+	rdr, err := client.DoGet(ctx, info.Endpoint[0].Ticket)
+	require.NoError(t, err)
+	defer rdr.Release()
+	require.True(t, rdr.Next())
+	values := rdr.Record().Column(1).(*array.DenseUnion)
+	require.Equal(t, serverName, values.Field(values.ChildID(0)).(*array.String).Value(int(values.ValueOffset(0))))
+}
@@ -0,0 +1,168 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FTSIndexOptions configures the full-text search index of CreateFTSIndex. The zero value uses DuckDB's defaults.
+type FTSIndexOptions struct {
+	// Stemmer is the stemmer of the words, e.g., "english", or "none". It defaults to "porter".
+	Stemmer string
+	// Stopwords is the table of the words, which the index ignores, or "none". It defaults to "english",
+	// i.e., 571 English stopwords.
+	Stopwords string
+	// Ignore is the regular expression of the characters, which the index ignores. It defaults to
+	// `(\\.|[^a-z])+`, i.e., to ignoring all escaped and non-alphabetic lowercase characters.
+	Ignore string
+	// KeepAccents keeps the accents of the words, e.g., é, instead of removing them.
+	KeepAccents bool
+	// KeepCase keeps the case of the words, instead of converting them to lowercase.
+	KeepCase bool
+	// Overwrite replaces an existing index of the table.
+	Overwrite bool
+}
+
+// FTSSearchOptions configures the search of SearchFTS. The zero value uses DuckDB's defaults.
+type FTSSearchOptions struct {
+	// Fields are the indexed columns to search. It defaults to all indexed columns.
+	Fields []string
+	// K is the k1 parameter of Okapi BM25, if set. It defaults to 1.2.
+	K *float64
+	// B is the b parameter of Okapi BM25, if set. It defaults to 0.75.
+	B *float64
+	// Conjunctive only matches the rows containing all words of the search.
+	Conjunctive bool
+	// Limit is the maximum number of rows, if positive.
+	Limit int
+}
+
+// CreateFTSIndex creates the full-text search index of the DuckDB fts extension on the columns of the table, e.g.,
+// "docs", or "archive.docs" for a table of another schema. The id column must uniquely identify the rows. If columns
+// is empty, then all VARCHAR columns are indexed. The index is a snapshot of the table, i.e., it must be created
+// again, after changing the table. The fts extension must be loaded, or autoloaded, see WithExtensions.
+func CreateFTSIndex(ctx context.Context, db Execer, table, idColumn string, columns []string, opts FTSIndexOptions) error {
+	query, err := createFTSIndexSQL(table, idColumn, columns, opts)
+	if err != nil {
+		return getError(errFTSIndex, err)
+	}
+	if _, err = db.ExecContext(ctx, query); err != nil {
+		return getError(errFTSIndex, err)
+	}
+	return nil
+}
+
+// DropFTSIndex drops the full-text search index of the table, see CreateFTSIndex.
+func DropFTSIndex(ctx context.Context, db Execer, table string) error {
+	if table == "" {
+		return getError(errFTSIndex, errors.New("empty table"))
+	}
+	if _, err := db.ExecContext(ctx, "PRAGMA drop_fts_index("+quoteString(table)+")"); err != nil {
+		return getError(errFTSIndex, err)
+	}
+	return nil
+}
+
+// SearchFTS returns the rows of the table, which match the search of the full-text search index of the table, see
+// CreateFTSIndex. The rows have the columns of the table, and their Okapi BM25 score of the search in the last
+// column, named score, and they are ordered by their descending score.
+func SearchFTS(ctx context.Context, db Queryer, table, idColumn, search string, opts FTSSearchOptions) (
+	*sql.Rows, error,
+) {
+	query, err := searchFTSSQL(table, idColumn, opts)
+	if err != nil {
+		return nil, getError(errFTSSearch, err)
+	}
+	rows, err := db.QueryContext(ctx, query, search)
+	if err != nil {
+		return nil, getError(errFTSSearch, err)
+	}
+	return rows, nil
+}
+
+func createFTSIndexSQL(table, idColumn string, columns []string, opts FTSIndexOptions) (string, error) {
+	if table == "" {
+		return "", errors.New("empty table")
+	}
+	if idColumn == "" {
+		return "", errors.New("empty id column")
+	}
+
+	args := []string{quoteString(table), quoteString(idColumn)}
+	if len(columns) == 0 {
+		args = append(args, "'*'")
+	}
+	for _, column := range columns {
+		if column == "" {
+			return "", errors.New("empty column")
+		}
+		args = append(args, quoteString(column))
+	}
+
+	if opts.Stemmer != "" {
+		args = append(args, "stemmer = "+quoteString(opts.Stemmer))
+	}
+	if opts.Stopwords != "" {
+		args = append(args, "stopwords = "+quoteString(opts.Stopwords))
+	}
+	if opts.Ignore != "" {
+		args = append(args, "ignore = "+quoteString(opts.Ignore))
+	}
+	if opts.KeepAccents {
+		args = append(args, "strip_accents = 0")
+	}
+	if opts.KeepCase {
+		args = append(args, "lower = 0")
+	}
+	if opts.Overwrite {
+		args = append(args, "overwrite = 1")
+	}
+	return "PRAGMA create_fts_index(" + strings.Join(args, ", ") + ")", nil
+}
+
+func searchFTSSQL(table, idColumn string, opts FTSSearchOptions) (string, error) {
+	if table == "" {
+		return "", errors.New("empty table")
+	}
+	if idColumn == "" {
+		return "", errors.New("empty id column")
+	}
+	if opts.K != nil && *opts.K < 0 {
+		return "", fmt.Errorf("invalid BM25 parameter: k = %g", *opts.K)
+	}
+	if opts.B != nil && (*opts.B < 0 || *opts.B > 1) {
+		return "", fmt.Errorf("invalid BM25 parameter: b = %g", *opts.B)
+	}
+
+	// The fts extension creates the index in the schema fts_<schema>_<table>.
+	schema, name, qualified := strings.Cut(table, ".")
+	if !qualified {
+		schema, name = "main", table
+	}
+	indexSchema := quoteIdentifier("fts_" + schema + "_" + name)
+
+	args := []string{quoteIdentifier(idColumn), "?"}
+	if len(opts.Fields) != 0 {
+		args = append(args, "fields := "+quoteString(strings.Join(opts.Fields, ",")))
+	}
+	if opts.K != nil {
+		args = append(args, "k := "+strconv.FormatFloat(*opts.K, 'g', -1, 64))
+	}
+	if opts.B != nil {
+		args = append(args, "b := "+strconv.FormatFloat(*opts.B, 'g', -1, 64))
+	}
+	if opts.Conjunctive {
+		args = append(args, "conjunctive := 1")
+	}
+
+	query := "SELECT * FROM (SELECT *, " + indexSchema + ".match_bm25(" + strings.Join(args, ", ") + ") AS score FROM " +
+		quoteIdentifier(schema) + "." + quoteIdentifier(name) + ") AS search WHERE score IS NOT NULL ORDER BY score DESC"
+	if opts.Limit > 0 {
+		query += " LIMIT " + strconv.Itoa(opts.Limit)
+	}
+	return query, nil
+}
@@ -0,0 +1,100 @@
+package duckdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateFTSIndexSQL(t *testing.T) {
+	t.Parallel()
+
+	query, err := createFTSIndexSQL("docs", "id", nil, FTSIndexOptions{})
+	require.NoError(t, err)
+	require.Equal(t, `PRAGMA create_fts_index('docs', 'id', '*')`, query)
+
+	query, err = createFTSIndexSQL("archive.docs", "id", []string{"title", "it's"}, FTSIndexOptions{
+		Stemmer:     "english",
+		Stopwords:   "none",
+		Ignore:      `(\\.|[^a-z0-9])+`,
+		KeepAccents: true,
+		KeepCase:    true,
+		Overwrite:   true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, `PRAGMA create_fts_index('archive.docs', 'id', 'title', 'it''s', stemmer = 'english', `+
+		`stopwords = 'none', ignore = '(\\.|[^a-z0-9])+', strip_accents = 0, lower = 0, overwrite = 1)`, query)
+
+	_, err = createFTSIndexSQL("", "id", nil, FTSIndexOptions{})
+	require.ErrorContains(t, err, "empty table")
+	_, err = createFTSIndexSQL("docs", "", nil, FTSIndexOptions{})
+	require.ErrorContains(t, err, "empty id column")
+	_, err = createFTSIndexSQL("docs", "id", []string{""}, FTSIndexOptions{})
+	require.ErrorContains(t, err, "empty column")
+
+	err = CreateFTSIndex(context.Background(), nil, "", "id", nil, FTSIndexOptions{})
+	testError(t, err, errFTSIndex.Error(), "empty table")
+	err = DropFTSIndex(context.Background(), nil, "")
+	testError(t, err, errFTSIndex.Error(), "empty table")
+}
+
+func TestSearchFTS(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	// The fts extension is not available offline, so a macro in the schema of the index of the table
+	// looks up the scores of the rows.
+	_, err := db.Exec(`CREATE TABLE docs (id INTEGER, title VARCHAR);
+		INSERT INTO docs VALUES (1, 'duck'), (2, 'goose'), (3, 'duck duck'), (4, 'duck duck duck');
+		CREATE SCHEMA fts_main_docs;
+		CREATE TABLE fts_main_docs.scores (doc INTEGER, score DOUBLE);
+		INSERT INTO fts_main_docs.scores VALUES (1, 0.5), (3, 1.0), (4, 1.5);
+		CREATE MACRO fts_main_docs.match_bm25(docname, query_string, fields := NULL, k := 1.2, b := 0.75,
+			conjunctive := 0) AS (SELECT score FROM fts_main_docs.scores WHERE doc = docname)`)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	search := func(opts FTSSearchOptions) []int {
+		rows, err := SearchFTS(ctx, db, "docs", "id", "duck", opts)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		columns, err := rows.Columns()
+		require.NoError(t, err)
+		require.Equal(t, []string{"id", "title", "score"}, columns)
+
+		var ids []int
+		for rows.Next() {
+			var id int
+			var title string
+			var score float64
+			require.NoError(t, rows.Scan(&id, &title, &score))
+			ids = append(ids, id)
+		}
+		require.NoError(t, rows.Err())
+		return ids
+	}
+
+	k, b, noLengthNormalization, invalidB := 1.5, 0.5, 0.0, 2.0
+	require.Equal(t, []int{4, 3, 1}, search(FTSSearchOptions{}))
+	require.Equal(t, []int{4, 3}, search(FTSSearchOptions{Fields: []string{"title"}, K: &k, B: &b, Conjunctive: true,
+		Limit: 2}))
+	require.Equal(t, []int{4, 3, 1}, search(FTSSearchOptions{B: &noLengthNormalization}))
+
+	query, err := searchFTSSQL("archive.docs", "id", FTSSearchOptions{Fields: []string{"title", "body"}, K: &k})
+	require.NoError(t, err)
+	require.Equal(t, `SELECT * FROM (SELECT *, "fts_archive_docs".match_bm25("id", ?, fields := 'title,body', k := 1.5) `+
+		`AS score FROM "archive"."docs") AS search WHERE score IS NOT NULL ORDER BY score DESC`, query)
+
+	// A zero b is passed, instead of falling back to the default.
+	query, err = searchFTSSQL("docs", "id", FTSSearchOptions{B: &noLengthNormalization})
+	require.NoError(t, err)
+	require.Contains(t, query, `match_bm25("id", ?, b := 0)`)
+
+	_, err = SearchFTS(ctx, db, "docs", "id", "duck", FTSSearchOptions{B: &invalidB})
+	testError(t, err, errFTSSearch.Error(), "invalid BM25 parameter")
+	_, err = SearchFTS(ctx, db, "missing", "id", "duck", FTSSearchOptions{})
+	testError(t, err, errFTSSearch.Error(), "missing")
+}
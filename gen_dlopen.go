@@ -0,0 +1,131 @@
+//go:build ignore
+
+// gen_dlopen generates dlopen.c, which implements the functions of duckdb.h by forwarding them to the DuckDB library
+// loaded at runtime, see LoadLibrary. Run it with go generate after updating duckdb.h.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"os"
+	"regexp"
+	"strings"
+)
+
+// prototypeRe matches the prototype of a function of the C API, whose declaration may span several lines.
+var prototypeRe = regexp.MustCompile(`(?ms)^DUCKDB_API\s+([^;]*?)\b(duckdb_\w+)\s*\(([^;]*)\);`)
+
+// paramNameRe matches the name of a parameter at the end of its declaration.
+var paramNameRe = regexp.MustCompile(`(\w+)\s*$`)
+
+const header = `//go:build duckdb_dlopen
+
+// Code generated by gen_dlopen.go from duckdb.h; DO NOT EDIT.
+
+#include <dlfcn.h>
+#include <pthread.h>
+#include <stdio.h>
+#include <stdlib.h>
+#include <duckdb.h>
+
+#if defined(__APPLE__)
+#define DUCKDB_GO_DEFAULT_LIBRARY "libduckdb.dylib"
+#else
+#define DUCKDB_GO_DEFAULT_LIBRARY "libduckdb.so"
+#endif
+
+static void *duckdb_go_handle;
+static pthread_mutex_t duckdb_go_mutex = PTHREAD_MUTEX_INITIALIZER;
+
+static const char *duckdb_go_load_locked(const char *path) {
+	duckdb_go_handle = dlopen(path, RTLD_NOW | RTLD_LOCAL);
+	return duckdb_go_handle ? NULL : dlerror();
+}
+
+// duckdb_go_load_library loads the library at the path. It returns 1, if a library is already loaded,
+// and -1 with the error of dlopen, if it fails.
+int duckdb_go_load_library(const char *path, const char **err) {
+	pthread_mutex_lock(&duckdb_go_mutex);
+	int result = 0;
+	if (duckdb_go_handle) {
+		result = 1;
+	} else if ((*err = duckdb_go_load_locked(path))) {
+		result = -1;
+	}
+	pthread_mutex_unlock(&duckdb_go_mutex);
+	return result;
+}
+
+// duckdb_go_symbol returns the function of the loaded library. It loads the library of the DUCKDB_LIBRARY_PATH
+// environment variable, or the default library of the dynamic linker, if none is loaded. As the functions of the
+// C API cannot report an error, it aborts, if loading the library, or looking up the function fails.
+static void *duckdb_go_symbol(const char *name) {
+	pthread_mutex_lock(&duckdb_go_mutex);
+	if (!duckdb_go_handle) {
+		const char *path = getenv("DUCKDB_LIBRARY_PATH");
+		const char *err = duckdb_go_load_locked(path && *path ? path : DUCKDB_GO_DEFAULT_LIBRARY);
+		if (err) {
+			fprintf(stderr, "go-duckdb: could not load the DuckDB library: %s\n", err);
+			abort();
+		}
+	}
+	void *fn = dlsym(duckdb_go_handle, name);
+	pthread_mutex_unlock(&duckdb_go_mutex);
+	if (!fn) {
+		fprintf(stderr, "go-duckdb: the DuckDB library does not provide %s\n", name);
+		abort();
+	}
+	return fn;
+}
+`
+
+func main() {
+	src, err := os.ReadFile("duckdb.h")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(header)
+	matches := prototypeRe.FindAllStringSubmatch(string(src), -1)
+	if len(matches) == 0 {
+		log.Fatal("no functions in duckdb.h")
+	}
+	for _, m := range matches {
+		ret, name, params := normalize(m[1]), m[2], normalize(m[3])
+		var args []string
+		if params != "" && params != "void" {
+			for _, param := range strings.Split(params, ",") {
+				arg := paramNameRe.FindString(strings.TrimSpace(param))
+				if arg == "" {
+					log.Fatalf("parameter without name in %s: %s", name, param)
+				}
+				args = append(args, arg)
+			}
+		}
+
+		call := fmt.Sprintf("fn(%s)", strings.Join(args, ", "))
+		if ret != "void" {
+			call = "return " + call
+		}
+		fmt.Fprintf(&buf, `
+typedef %s (*%s_fn)(%s);
+%s %s(%s) {
+	static %s_fn fn;
+	if (!fn) {
+		fn = (%s_fn)duckdb_go_symbol("%s");
+	}
+	%s;
+}
+`, ret, name, params, ret, name, params, name, name, name, call)
+	}
+
+	if err = os.WriteFile("dlopen.c", buf.Bytes(), 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func normalize(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
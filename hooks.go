@@ -0,0 +1,125 @@
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"time"
+)
+
+// QueryHook observes the queries executed on the connections of a Connector, e.g., to log slow queries.
+// Its methods are called synchronously by the goroutine executing the query, so they must return quickly.
+// They are called concurrently for queries on different connections.
+type QueryHook interface {
+	// OnQueryStart is called before a query executes. The Duration, Rows, and Err of the event are not set.
+	OnQueryStart(ctx context.Context, event QueryEvent)
+	// OnQueryEnd is called after a query executed, or failed.
+	OnQueryEnd(ctx context.Context, event QueryEvent)
+}
+
+// QueryKind is the method of the connection or statement, which executes or prepares a query.
+type QueryKind int
+
+const (
+	// QueryKindExec is a query executed with ExecContext.
+	QueryKindExec QueryKind = iota
+	// QueryKindQuery is a query executed with QueryContext.
+	QueryKindQuery
+	// QueryKindPrepare is a query prepared with Prepare.
+	QueryKindPrepare
+)
+
+// QueryEvent describes a query observed by a QueryHook.
+type QueryEvent struct {
+	// Kind is the method executing or preparing the query.
+	Kind QueryKind
+	// ConnectionID identifies the connection executing the query among all connections of the Connector.
+	ConnectionID uint64
+	// Query is the query text. For a multi-statement query, it contains all statements.
+	Query string
+	// Args are the arguments of the query.
+	Args []driver.NamedValue
+	// Started is the time at which the driver started executing the query.
+	Started time.Time
+	// Duration is the time it took to execute the query.
+	// For a streaming result, it is the time until the first chunk of the result was ready.
+	Duration time.Duration
+	// Rows is the number of affected rows of a statement executed with ExecContext,
+	// or the number of rows of a materialized result of QueryContext. It is -1 for a streaming result,
+	// whose number of rows is not known before reading it, and for a failed query.
+	Rows int64
+	// Chunks is the number of chunks of a materialized result of QueryContext, see ReadChunks.
+	// It is -1 for a streaming result, and for a failed query, and 0 for other queries.
+	Chunks int64
+	// Err is the error of a failed query. The error of a streaming result may surface when reading its rows instead.
+	Err error
+}
+
+// WithQueryHook calls the hook before and after each query executed with ExecContext or QueryContext
+// on the connections of the Connector, including the statements prepared with Prepare, and before and after
+// preparing a query with Prepare.
+// The hooks of several WithQueryHook options are called in order.
+// Queries containing credentials, e.g., of CreateSecret, are not observed.
+func WithQueryHook(hook QueryHook) ConnectorOption {
+	return func(c *connectorConfig) error {
+		if hook == nil {
+			return errors.New("nil query hook")
+		}
+		c.queryHooks = append(c.queryHooks, hook)
+		return nil
+	}
+}
+
+// startHooks calls the OnQueryStart method of the query hooks, and returns a function calling their OnQueryEnd method.
+// The end function is called with the number of affected rows, or the rows and chunks of the result,
+// and the error of the query.
+func (c *conn) startHooks(ctx context.Context, kind QueryKind, query string, args []driver.NamedValue) (
+	end func(rows int64, chunks int64, err error),
+) {
+	if c.config == nil || len(c.config.queryHooks) == 0 {
+		return func(int64, int64, error) {}
+	}
+
+	event := QueryEvent{Kind: kind, ConnectionID: c.id, Query: query, Args: args, Started: time.Now(), Rows: -1, Chunks: -1}
+	for _, hook := range c.config.queryHooks {
+		hook.OnQueryStart(ctx, event)
+	}
+	return func(rows int64, chunks int64, err error) {
+		event.Duration = time.Since(event.Started)
+		if err == nil {
+			event.Rows = rows
+			event.Chunks = chunks
+		}
+		event.Err = err
+		for _, hook := range c.config.queryHooks {
+			hook.OnQueryEnd(ctx, event)
+		}
+	}
+}
+
+// resultRows returns the number of affected rows of the result of ExecContext, or -1, if it failed.
+func resultRows(res driver.Result) int64 {
+	if res == nil {
+		return -1
+	}
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return -1
+	}
+	return affected
+}
+
+// rowCount returns the number of rows and chunks of the materialized result of QueryContext,
+// or -1, if it is streaming.
+func rowCount(driverRows driver.Rows) (int64, int64) {
+	r, ok := driverRows.(*rows)
+	if !ok || r.streaming {
+		return -1, -1
+	}
+	return int64(C.duckdb_row_count(&r.res)), int64(C.duckdb_result_chunk_count(r.res))
+}
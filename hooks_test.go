@@ -0,0 +1,124 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recordingHook records the events of the queries.
+type recordingHook struct {
+	mu     sync.Mutex
+	starts []QueryEvent
+	ends   []QueryEvent
+}
+
+func (h *recordingHook) OnQueryStart(_ context.Context, event QueryEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.starts = append(h.starts, event)
+}
+
+func (h *recordingHook) OnQueryEnd(_ context.Context, event QueryEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.ends = append(h.ends, event)
+}
+
+// last returns the last start and end events, and resets the recorded events.
+func (h *recordingHook) last(t *testing.T) (QueryEvent, QueryEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	require.NotEmpty(t, h.starts)
+	require.Len(t, h.ends, len(h.starts))
+	start, end := h.starts[len(h.starts)-1], h.ends[len(h.ends)-1]
+	h.starts, h.ends = nil, nil
+	return start, end
+}
+
+func TestQueryHook(t *testing.T) {
+	t.Parallel()
+
+	hook := &recordingHook{}
+	connector, err := NewConnector("", nil, WithQueryHook(hook))
+	require.NoError(t, err)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	t.Run("exec", func(t *testing.T) {
+		const query = `CREATE TABLE hook_tbl (i INTEGER); INSERT INTO hook_tbl SELECT * FROM range(?)`
+		_, err := db.Exec(query, 5)
+		require.NoError(t, err)
+
+		start, end := hook.last(t)
+		require.Equal(t, QueryKindExec, start.Kind)
+		require.Equal(t, query, start.Query)
+		require.Equal(t, 5, start.Args[0].Value)
+		require.Zero(t, start.Duration)
+		require.Equal(t, start.Started, end.Started)
+		require.Equal(t, start.ConnectionID, end.ConnectionID)
+		require.Positive(t, end.Duration)
+		require.Equal(t, int64(5), end.Rows)
+		require.NoError(t, end.Err)
+	})
+
+	t.Run("query", func(t *testing.T) {
+		rows, err := db.Query(`SELECT * FROM hook_tbl WHERE i > ?`, 1)
+		require.NoError(t, err)
+		require.NoError(t, rows.Close())
+
+		_, end := hook.last(t)
+		require.Equal(t, QueryKindQuery, end.Kind)
+		require.Equal(t, int64(3), end.Rows)
+		require.Equal(t, int64(1), end.Chunks)
+	})
+
+	t.Run("streaming query", func(t *testing.T) {
+		rows, err := db.QueryContext(ContextWithFetchMode(context.Background(), FetchStreaming), `SELECT * FROM hook_tbl`)
+		require.NoError(t, err)
+		require.NoError(t, rows.Close())
+
+		_, end := hook.last(t)
+		require.Equal(t, int64(-1), end.Rows)
+		require.Equal(t, int64(-1), end.Chunks)
+	})
+
+	t.Run("prepared statement", func(t *testing.T) {
+		con, err := db.Conn(context.Background())
+		require.NoError(t, err)
+		defer con.Close()
+		stmt, err := con.PrepareContext(context.Background(), `DELETE FROM hook_tbl WHERE i = ?`)
+		require.NoError(t, err)
+		defer stmt.Close()
+
+		start, end := hook.last(t)
+		require.Equal(t, QueryKindPrepare, start.Kind)
+		require.Equal(t, `DELETE FROM hook_tbl WHERE i = ?`, end.Query)
+		require.NoError(t, end.Err)
+
+		_, err = stmt.Exec(0)
+		require.NoError(t, err)
+
+		start, end = hook.last(t)
+		require.Equal(t, QueryKindExec, start.Kind)
+		require.Equal(t, `DELETE FROM hook_tbl WHERE i = ?`, start.Query)
+		require.Equal(t, int64(1), end.Rows)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := db.Exec(`SELECT * FROM missing`)
+		require.Error(t, err)
+
+		_, end := hook.last(t)
+		require.Equal(t, err.Error(), end.Err.Error())
+		require.Equal(t, int64(-1), end.Rows)
+	})
+
+	t.Run("nil hook", func(t *testing.T) {
+		_, err := NewConnector("", nil, WithQueryHook(nil))
+		testError(t, err, errInvalidOption.Error(), "nil query hook")
+	})
+}
@@ -0,0 +1,120 @@
+package duckdb
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// S3URLStyle is the style of the URLs of S3 requests, see HTTPFSOptions.
+type S3URLStyle string
+
+const (
+	// S3URLStyleVirtualHost addresses a bucket by its host, e.g., https://bucket.s3.amazonaws.com/key.
+	S3URLStyleVirtualHost S3URLStyle = "vhost"
+	// S3URLStylePath addresses a bucket by the path, e.g., https://minio:9000/bucket/key, which most
+	// S3-compatible object stores require.
+	S3URLStylePath S3URLStyle = "path"
+)
+
+// HTTPFSOptions configures the httpfs extension, which reads and writes files over HTTP(S) and S3, e.g.,
+// s3:// Parquet files, see WithHTTPFS. The zero value of a field keeps the default of DuckDB.
+// The credentials of S3 are not options, see CreateSecret.
+type HTTPFSOptions struct {
+	// S3Endpoint is the host, and optional port, of S3 requests, e.g., "minio:9000". It defaults to s3.amazonaws.com.
+	S3Endpoint string
+	// S3Region is the region of S3 requests, e.g., "eu-west-1". It defaults to us-east-1.
+	S3Region string
+	// S3URLStyle is the style of the URLs of S3 requests. It defaults to S3URLStyleVirtualHost.
+	S3URLStyle S3URLStyle
+	// S3DisableSSL sends S3 requests over HTTP instead of HTTPS, e.g., to a local object store.
+	S3DisableSSL bool
+	// HTTPRetries is the number of retries of a failing HTTP request. It defaults to 3.
+	HTTPRetries int
+	// HTTPTimeout is the timeout of reading, writing, connecting, and retrying an HTTP request.
+	// DuckDB v0.10 has a granularity of milliseconds. It defaults to 30 seconds.
+	HTTPTimeout time.Duration
+	// HTTPProxy is the URL of the proxy of HTTP requests, e.g., "http://proxy:3128". The user info of the URL
+	// authenticates at the proxy.
+	HTTPProxy string
+}
+
+// WithHTTPFS installs and loads the httpfs extension on each new connection, like WithExtensions,
+// and sets the options of the extension on the connection. Invalid options fail NewConnector.
+func WithHTTPFS(opts HTTPFSOptions) ConnectorOption {
+	return func(c *connectorConfig) error {
+		settings, err := httpfsSettings(opts)
+		if err != nil {
+			return err
+		}
+		if !slices.Contains(c.extensions, "httpfs") {
+			c.extensions = append(c.extensions, "httpfs")
+		}
+		for _, setting := range settings {
+			c.connInitQueries = append(c.connInitQueries, "SET "+setting[0]+" = "+setting[1])
+		}
+		return nil
+	}
+}
+
+// httpfsSettings returns the names and values of the settings of the options, see WithHTTPFS.
+func httpfsSettings(opts HTTPFSOptions) ([][2]string, error) {
+	var settings [][2]string
+	set := func(name, value string) {
+		settings = append(settings, [2]string{name, value})
+	}
+
+	if opts.S3Endpoint != "" {
+		if strings.Contains(opts.S3Endpoint, "://") || strings.Contains(opts.S3Endpoint, "/") {
+			return nil, fmt.Errorf("invalid S3 endpoint %q: must be a host without scheme or path", opts.S3Endpoint)
+		}
+		set("s3_endpoint", quoteString(opts.S3Endpoint))
+	}
+	if opts.S3Region != "" {
+		set("s3_region", quoteString(opts.S3Region))
+	}
+	switch opts.S3URLStyle {
+	case "":
+	case S3URLStyleVirtualHost, S3URLStylePath:
+		set("s3_url_style", quoteString(string(opts.S3URLStyle)))
+	default:
+		return nil, fmt.Errorf("invalid S3 URL style %q", opts.S3URLStyle)
+	}
+	if opts.S3DisableSSL {
+		set("s3_use_ssl", "false")
+	}
+
+	if opts.HTTPRetries < 0 {
+		return nil, fmt.Errorf("invalid HTTP retries: %d", opts.HTTPRetries)
+	} else if opts.HTTPRetries > 0 {
+		set("http_retries", strconv.Itoa(opts.HTTPRetries))
+	}
+	if opts.HTTPTimeout < 0 || (opts.HTTPTimeout > 0 && opts.HTTPTimeout < time.Millisecond) {
+		return nil, fmt.Errorf("invalid HTTP timeout: %s", opts.HTTPTimeout)
+	} else if opts.HTTPTimeout > 0 {
+		set("http_timeout", strconv.FormatInt(opts.HTTPTimeout.Milliseconds(), 10))
+	}
+
+	if opts.HTTPProxy != "" {
+		proxy, err := url.Parse(opts.HTTPProxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid HTTP proxy: %w", err)
+		}
+		if proxy.Host == "" {
+			return nil, errors.New("invalid HTTP proxy: missing host")
+		}
+		// DuckDB expects the proxy without its credentials.
+		set("http_proxy", quoteString(proxy.Host))
+		if proxy.User != nil {
+			set("http_proxy_username", quoteString(proxy.User.Username()))
+			if password, ok := proxy.User.Password(); ok {
+				set("http_proxy_password", quoteString(password))
+			}
+		}
+	}
+	return settings, nil
+}
@@ -0,0 +1,98 @@
+package duckdb
+
+import (
+	"math"
+	"time"
+)
+
+// InfinityMode defines how the infinite DATE and TIMESTAMP values 'infinity' and '-infinity', e.g., of data
+// imported from Postgres, and the NaN and infinite FLOAT and DOUBLE values scan, see WithInfinityMode.
+type InfinityMode int
+
+const (
+	// InfinityAsSentinel scans infinite dates and timestamps as PositiveInfinityTime and NegativeInfinityTime,
+	// or as the Date of their day with WithCivilDates. NaN and infinite floats scan as math.NaN and math.Inf.
+	// This is the default.
+	InfinityAsSentinel InfinityMode = iota
+	// InfinityAsNull scans infinite dates and timestamps, and NaN and infinite floats as NULL, e.g.,
+	// into an invalid sql.NullTime.
+	InfinityAsNull
+	// InfinityAsError fails scanning infinite dates and timestamps, and NaN and infinite floats with an error
+	// matching ErrNonFiniteValue.
+	InfinityAsError
+)
+
+// The sentinel values of infinite dates and timestamps, see InfinityAsSentinel. They are the instants of
+// the TIMESTAMP values 'infinity' and '-infinity' in UTC, and lie outside the range of finite DuckDB timestamps.
+// Binding them, e.g., to a TIMESTAMP or DATE parameter, binds an infinite value.
+var (
+	PositiveInfinityTime = time.UnixMicro(math.MaxInt64).UTC()
+	NegativeInfinityTime = time.UnixMicro(-math.MaxInt64).UTC()
+)
+
+// The values of 'infinity' and '-infinity' in the vectors of DuckDB. All precisions of TIMESTAMP store them as
+// the extremes of int64, and a DATE as the extremes of its int32 days.
+const (
+	infinityTimestamp = math.MaxInt64
+	infinityDate      = math.MaxInt32
+)
+
+func (c *connectorConfig) infinityMode() InfinityMode {
+	if c == nil {
+		return InfinityAsSentinel
+	}
+	return c.infinity
+}
+
+// isInfiniteTimestamp returns true, if the value of a TIMESTAMP of any precision is infinite.
+func isInfiniteTimestamp(v int64) bool {
+	return v == infinityTimestamp || v == -infinityTimestamp
+}
+
+// isInfiniteDate returns true, if the days of a DATE are infinite.
+func isInfiniteDate(days int32) bool {
+	return days == infinityDate || days == -infinityDate
+}
+
+// infiniteTime returns the scanned value of an infinite date or timestamp.
+func (c *connectorConfig) infiniteTime(positive bool, civilDate bool) (any, error) {
+	switch c.infinityMode() {
+	case InfinityAsNull:
+		return nil, nil
+	case InfinityAsError:
+		return nil, getError(ErrNonFiniteValue, nil)
+	}
+	ts := NegativeInfinityTime
+	if positive {
+		ts = PositiveInfinityTime
+	}
+	if civilDate {
+		return DateOf(ts), nil
+	}
+	return ts, nil
+}
+
+// nonFiniteFloat returns the scanned value of v, which is NaN or infinite, if v is not finite.
+func (c *connectorConfig) nonFiniteFloat(v any, f float64) (any, error) {
+	if !math.IsNaN(f) && !math.IsInf(f, 0) {
+		return v, nil
+	}
+	switch c.infinityMode() {
+	case InfinityAsNull:
+		return nil, nil
+	case InfinityAsError:
+		return nil, getError(ErrNonFiniteValue, nil)
+	}
+	return v, nil
+}
+
+// infiniteTimeMicros returns the microseconds of an infinite TIMESTAMP, if v is a sentinel of InfinityAsSentinel.
+func infiniteTimeMicros(v time.Time) (int64, bool) {
+	switch {
+	case v.Equal(PositiveInfinityTime):
+		return infinityTimestamp, true
+	case v.Equal(NegativeInfinityTime):
+		return -infinityTimestamp, true
+	}
+	return 0, false
+}
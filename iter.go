@@ -0,0 +1,57 @@
+//go:build go1.23
+
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"iter"
+	"reflect"
+	"time"
+)
+
+// Query executes the query with a streaming result, see FetchStreaming, and returns an iterator over its rows,
+// e.g., for row, err := range Query[T](ctx, db, query, args...). A struct T scans each row like ScanStruct.
+// Any other T scans the single column of each row, e.g., Query[int64] or Query[time.Time].
+// The iterator yields an error at most once, e.g., of executing the query, after which it stops.
+// Breaking out of the loop closes the rows, which interrupts the query.
+// So a large result is consumed chunk by chunk, without materializing it as a []T.
+func Query[T any](ctx context.Context, db Queryer, query string, args ...any) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		var zero T
+		rows, err := db.QueryContext(ContextWithFetchMode(ctx, FetchStreaming), query, args...)
+		if err != nil {
+			yield(zero, err)
+			return
+		}
+		defer rows.Close()
+
+		scan := scanRowFunc[T]()
+		for rows.Next() {
+			var row T
+			if err = scan(rows, &row); err != nil {
+				yield(zero, err)
+				return
+			}
+			if !yield(row, nil) {
+				return
+			}
+		}
+		if err = rows.Err(); err != nil {
+			yield(zero, err)
+		}
+	}
+}
+
+// scanRowFunc returns the function scanning a row into a T, i.e., into the fields of a struct, or into the T itself.
+func scanRowFunc[T any]() func(rows *sql.Rows, dest *T) error {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() == reflect.Struct && t != reflect.TypeOf(time.Time{}) && !reflect.PointerTo(t).Implements(scannerType) {
+		return func(rows *sql.Rows, dest *T) error {
+			return ScanStruct(rows, dest)
+		}
+	}
+	return func(rows *sql.Rows, dest *T) error {
+		return rows.Scan(scanDest(reflect.ValueOf(dest).Elem()))
+	}
+}
@@ -0,0 +1,53 @@
+//go:build go1.23
+
+package duckdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryIterator(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	type item struct {
+		ID   int64
+		Tags []string
+	}
+	var items []item
+	for row, err := range Query[item](ctx, db, `SELECT range AS id, [range::VARCHAR] AS tags FROM range(?)`, 3000) {
+		require.NoError(t, err)
+		items = append(items, row)
+	}
+	require.Len(t, items, 3000)
+	require.Equal(t, item{ID: 2999, Tags: []string{"2999"}}, items[2999])
+
+	// Breaking out of the loop closes the rows of a large result.
+	var sum int64
+	for i, err := range Query[int64](ctx, db, `SELECT range FROM range(100000000)`) {
+		require.NoError(t, err)
+		if i == 10 {
+			break
+		}
+		sum += i
+	}
+	require.Equal(t, int64(45), sum)
+
+	var errs []error
+	for _, err := range Query[int64](ctx, db, `SELECT * FROM missing`) {
+		errs = append(errs, err)
+	}
+	require.Len(t, errs, 1)
+	require.ErrorContains(t, errs[0], "Catalog Error")
+
+	errs = nil
+	for _, err := range Query[int64](ctx, db, `SELECT 'x'`) {
+		errs = append(errs, err)
+	}
+	require.Len(t, errs, 1)
+}
@@ -0,0 +1,127 @@
+package duckdb
+
+/*
+#include <stdlib.h>
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"context"
+	"time"
+	"unsafe"
+)
+
+type queryLimitsKey struct{}
+
+// queryLimits are the resource limits of the statements executed with a context, see ContextWithMemoryLimit
+// and ContextWithQueryTimeout.
+type queryLimits struct {
+	memoryLimit string
+	timeout     time.Duration
+}
+
+func contextQueryLimits(ctx context.Context) queryLimits {
+	limits, _ := ctx.Value(queryLimitsKey{}).(queryLimits)
+	return limits
+}
+
+// ContextWithMemoryLimit returns a copy of ctx, which limits the memory of the statements executed with it to
+// the size, e.g., "2GB", so that a single heavy query spills to disk, or fails, instead of exhausting the memory of
+// a shared service. As DuckDB limits the memory of the entire database, the driver lowers its memory_limit while
+// the statement executes, and restores it afterwards, i.e., concurrent queries share the lowered limit.
+// Statements with a memory limit execute one at a time on the connections of a Connector.
+// The limit applies to statements executed with ExecContext and QueryContext, until their result is materialized,
+// or until the rows of a streaming result are closed. If restoring the previous limit fails, the driver logs the
+// error, see WithLogger.
+func ContextWithMemoryLimit(ctx context.Context, size string) context.Context {
+	limits := contextQueryLimits(ctx)
+	limits.memoryLimit = size
+	return context.WithValue(ctx, queryLimitsKey{}, limits)
+}
+
+// ContextWithQueryTimeout returns a copy of ctx, which interrupts each statement executed with it, if it does not
+// complete within the timeout, and fails it with context.DeadlineExceeded. Unlike context.WithTimeout, the timeout
+// starts with each statement, e.g., each query of a transaction, and does not apply to reading the rows of a result.
+func ContextWithQueryTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	limits := contextQueryLimits(ctx)
+	limits.timeout = timeout
+	return context.WithValue(ctx, queryLimitsKey{}, limits)
+}
+
+// withQueryTimeout returns a copy of ctx, which is done after the timeout of ContextWithQueryTimeout, if any.
+func withQueryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if timeout := contextQueryLimits(ctx).timeout; timeout > 0 {
+		return context.WithTimeout(ctx, timeout)
+	}
+	return ctx, func() {}
+}
+
+// limitMemory lowers the memory limit of the database to the limit of ContextWithMemoryLimit, if any,
+// until calling restore. It must be called while holding the lock of the connection.
+func (c *conn) limitMemory(ctx context.Context) (restore func(), err error) {
+	size := contextQueryLimits(ctx).memoryLimit
+	if size == "" {
+		return func() {}, nil
+	}
+	if _, err = parseByteSize(size); err != nil {
+		return nil, getError(errQueryLimit, err)
+	}
+
+	if c.memoryLimitSem != nil {
+		select {
+		case c.memoryLimitSem <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	release := func() {
+		if c.memoryLimitSem != nil {
+			<-c.memoryLimitSem
+		}
+	}
+
+	prev, err := c.rawQueryString(`SELECT current_setting('memory_limit')`)
+	if err == nil {
+		err = c.rawExec(`SET memory_limit = ` + quoteString(size))
+	}
+	if err != nil {
+		release()
+		return nil, getError(errQueryLimit, err)
+	}
+	return func() {
+		// DuckDB accepts the formatted value of the setting.
+		if err := c.rawExec(`SET memory_limit = ` + quoteString(prev)); err != nil {
+			c.logError("duckdb could not restore the memory limit", err)
+		}
+		release()
+	}, nil
+}
+
+// rawExec executes the query without tracking it as an active query. It must be called while holding the lock of the
+// connection.
+func (c *conn) rawExec(query string) error {
+	_, err := c.rawQueryString(query)
+	return err
+}
+
+// rawQueryString returns the string of the first column of the first row of the query, or an empty string, if it
+// returns no rows. It must be called while holding the lock of the connection.
+func (c *conn) rawQueryString(query string) (string, error) {
+	cQuery := C.CString(query)
+	defer C.free(unsafe.Pointer(cQuery))
+
+	var res C.duckdb_result
+	state := C.duckdb_query(c.duckdbCon, cQuery, &res)
+	defer C.duckdb_destroy_result(&res)
+	if state == C.DuckDBError {
+		return "", newError(C.GoString(C.duckdb_result_error(&res)))
+	}
+	if C.duckdb_row_count(&res) == 0 || C.duckdb_column_count(&res) == 0 {
+		return "", nil
+	}
+
+	value := C.duckdb_value_varchar(&res, 0, 0)
+	defer C.duckdb_free(unsafe.Pointer(value))
+	return C.GoString(value), nil
+}
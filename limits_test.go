@@ -0,0 +1,72 @@
+package duckdb
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryLimits(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+	ctx := context.Background()
+
+	// Queries exceeding their memory limit spill to the temporary directory, instead of .tmp of the working directory.
+	_, err := db.Exec(`SET memory_limit = '2GiB'; SET temp_directory = '` + t.TempDir() + `'`)
+	require.NoError(t, err)
+
+	t.Run("memory limit", func(t *testing.T) {
+		limitCtx := ContextWithMemoryLimit(ctx, "1GiB")
+		var limit string
+		require.NoError(t, db.QueryRowContext(limitCtx, `SELECT value FROM duckdb_settings() WHERE name = 'memory_limit'`).Scan(&limit))
+		require.Equal(t, "1.0 GiB", limit)
+		require.NoError(t, db.QueryRowContext(ctx, `SELECT value FROM duckdb_settings() WHERE name = 'memory_limit'`).Scan(&limit))
+		require.Equal(t, "2.0 GiB", limit)
+
+		_, err := db.ExecContext(ContextWithMemoryLimit(ctx, "8MB"),
+			`CREATE TABLE heavy AS SELECT list(range::VARCHAR) AS l FROM range(10000000)`)
+		require.ErrorContains(t, err, "Out of Memory Error")
+		require.NoError(t, db.QueryRowContext(ctx, `SELECT value FROM duckdb_settings() WHERE name = 'memory_limit'`).Scan(&limit))
+		require.Equal(t, "2.0 GiB", limit)
+
+		_, err = db.ExecContext(ContextWithMemoryLimit(ctx, "lots"), `SELECT 1`)
+		require.ErrorIs(t, err, errQueryLimit)
+	})
+
+	t.Run("streaming memory limit", func(t *testing.T) {
+		limitCtx := ContextWithFetchMode(ContextWithMemoryLimit(ctx, "1GiB"), FetchStreaming)
+		rows, err := db.QueryContext(limitCtx, `SELECT range FROM range(10000000)`)
+		require.NoError(t, err)
+		require.True(t, rows.Next())
+
+		// The limit applies until the rows are closed.
+		var limit string
+		require.NoError(t, db.QueryRowContext(ctx, `SELECT current_setting('memory_limit')`).Scan(&limit))
+		require.Equal(t, "1.0 GiB", limit)
+		require.NoError(t, rows.Close())
+		require.NoError(t, db.QueryRowContext(ctx, `SELECT current_setting('memory_limit')`).Scan(&limit))
+		require.Equal(t, "2.0 GiB", limit)
+	})
+
+	t.Run("timeout", func(t *testing.T) {
+		timeoutCtx := ContextWithQueryTimeout(ctx, 50*time.Millisecond)
+		_, err := db.ExecContext(timeoutCtx, `SELECT count(*) FROM range(100000000) a, range(100000000) b`)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+
+		// The timeout starts with each statement.
+		time.Sleep(100 * time.Millisecond)
+		var one int
+		require.NoError(t, db.QueryRowContext(timeoutCtx, `SELECT 1`).Scan(&one))
+		require.Equal(t, 1, one)
+	})
+
+	t.Run("both", func(t *testing.T) {
+		limitCtx := ContextWithQueryTimeout(ContextWithMemoryLimit(ctx, "1GiB"), time.Minute)
+		var limit string
+		require.NoError(t, db.QueryRowContext(limitCtx, `SELECT value FROM duckdb_settings() WHERE name = 'memory_limit'`).Scan(&limit))
+		require.Equal(t, "1.0 GiB", limit)
+	})
+}
@@ -0,0 +1,107 @@
+package duckdb
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"runtime"
+)
+
+// WithLogger logs the diagnostics of the connections of the Connector to the logger, so that they end up in the
+// structured logs of the application: each failed query at slog.LevelWarn, or at slog.LevelError, if DuckDB reports
+// a fatal or internal error, each executed query at slog.LevelDebug, and the errors of cleanups, which the driver
+// cannot return, e.g., of restoring the memory limit of ContextWithMemoryLimit. Queries interrupted because their
+// context is done are logged at slog.LevelDebug, too.
+// DuckDB v0.10 has no internal logging, which the driver could capture, so only the diagnostics observed by the
+// driver are logged. Like WithQueryHook, it does not log queries containing credentials.
+func WithLogger(logger *slog.Logger) ConnectorOption {
+	return func(c *connectorConfig) error {
+		if logger == nil {
+			return errors.New("nil logger")
+		}
+		c.logger = logger
+		c.queryHooks = append(c.queryHooks, loggerHook{logger: logger})
+		return nil
+	}
+}
+
+// loggerHook is the QueryHook of WithLogger.
+type loggerHook struct {
+	logger *slog.Logger
+}
+
+func (loggerHook) OnQueryStart(context.Context, QueryEvent) {}
+
+func (h loggerHook) OnQueryEnd(ctx context.Context, event QueryEvent) {
+	level := slog.LevelDebug
+	msg := "duckdb query"
+	var duckdbErr *Error
+	switch {
+	case event.Err == nil:
+	case ctx.Err() != nil:
+		msg = "duckdb query interrupted"
+	case errors.As(event.Err, &duckdbErr) &&
+		(duckdbErr.Type == ErrorTypeFatal || duckdbErr.Type == ErrorTypeInternal):
+		level = slog.LevelError
+		msg = "duckdb query failed"
+	default:
+		level = slog.LevelWarn
+		msg = "duckdb query failed"
+	}
+	if !h.logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.Uint64("connection_id", event.ConnectionID),
+		slog.String("query", event.Query),
+		slog.Duration("duration", event.Duration),
+	}
+	if event.Err != nil {
+		attrs = append(attrs, slog.Any("error", event.Err))
+		if duckdbErr != nil {
+			attrs = append(attrs, slog.Int("error_type", int(duckdbErr.Type)))
+		}
+	} else if event.Kind != QueryKindPrepare {
+		attrs = append(attrs, slog.Int64("rows", event.Rows))
+	}
+	h.logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// leakCheck logs a warning at slog.LevelWarn, if the statement or rows owning it are garbage collected without
+// being closed, see WithLogger. It is an object of its own, as the finalizer of an object referencing itself,
+// e.g., of rows, whose scanners reference their string arena, may never run.
+type leakCheck struct {
+	c     *conn
+	msg   string
+	query string
+}
+
+// newLeakCheck returns the leak check of a statement or rows of the query, or nil, if the Connector does not log.
+func newLeakCheck(c *conn, msg string, query string) *leakCheck {
+	if c.config == nil || c.config.logger == nil {
+		return nil
+	}
+	l := &leakCheck{c: c, msg: msg, query: query}
+	runtime.SetFinalizer(l, func(l *leakCheck) {
+		l.c.config.logger.LogAttrs(context.Background(), slog.LevelWarn, l.msg,
+			slog.Uint64("connection_id", l.c.id), slog.String("query", l.query))
+	})
+	return l
+}
+
+// close stops the leak check, after its owner was closed.
+func (l *leakCheck) close() {
+	if l != nil {
+		runtime.SetFinalizer(l, nil)
+	}
+}
+
+// logError logs an error of the connection, which the driver cannot return, at slog.LevelError, see WithLogger.
+func (c *conn) logError(msg string, err error) {
+	if c.config == nil || c.config.logger == nil || err == nil {
+		return
+	}
+	c.config.logger.LogAttrs(context.Background(), slog.LevelError, msg,
+		slog.Uint64("connection_id", c.id), slog.Any("error", err))
+}
@@ -0,0 +1,132 @@
+package duckdb
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"runtime"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithLogger(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	connector, err := NewConnector("", nil, WithLogger(logger))
+	require.NoError(t, err)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	records := func() []map[string]any {
+		var records []map[string]any
+		dec := json.NewDecoder(&buf)
+		for dec.More() {
+			var record map[string]any
+			require.NoError(t, dec.Decode(&record))
+			records = append(records, record)
+		}
+		return records
+	}
+
+	_, err = db.Exec(`CREATE TABLE tbl AS SELECT range AS i FROM range(3)`)
+	require.NoError(t, err)
+	logged := records()
+	require.Len(t, logged, 1)
+	require.Equal(t, "DEBUG", logged[0]["level"])
+	require.Equal(t, "duckdb query", logged[0]["msg"])
+	require.Equal(t, `CREATE TABLE tbl AS SELECT range AS i FROM range(3)`, logged[0]["query"])
+	require.Equal(t, 3.0, logged[0]["rows"])
+
+	_, err = db.Exec(`SELECT * FROM missing`)
+	require.Error(t, err)
+	logged = records()
+	require.Len(t, logged, 1)
+	require.Equal(t, "WARN", logged[0]["level"])
+	require.Equal(t, "duckdb query failed", logged[0]["msg"])
+	require.Contains(t, logged[0]["error"], "Catalog Error")
+	require.Equal(t, float64(ErrorTypeCatalog), logged[0]["error_type"])
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	_, err = db.ExecContext(ctx, `SELECT count(*) FROM range(100000000) a, range(100000000) b`)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	logged = records()
+	require.Len(t, logged, 1)
+	require.Equal(t, "DEBUG", logged[0]["level"])
+	require.Equal(t, "duckdb query interrupted", logged[0]["msg"])
+
+	// The level of the logger filters the queries.
+	buf.Reset()
+	connector, err = NewConnector("", nil, WithLogger(slog.New(slog.NewJSONHandler(&buf, nil))))
+	require.NoError(t, err)
+	db2 := sql.OpenDB(connector)
+	defer db2.Close()
+	_, err = db2.Exec(`SELECT 1`)
+	require.NoError(t, err)
+	require.Empty(t, records())
+
+	_, err = NewConnector("", nil, WithLogger(nil))
+	testError(t, err, errInvalidOption.Error(), "nil logger")
+}
+
+// syncBuffer is a buffer, which is safe for concurrent use, e.g., by finalizers.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestLeakWarnings(t *testing.T) {
+	t.Parallel()
+
+	var out syncBuffer
+	connector, err := NewConnector("", nil, WithLogger(slog.New(slog.NewTextHandler(&out, nil))))
+	require.NoError(t, err)
+	defer connector.Close()
+
+	ctx := context.Background()
+	driverConn, err := connector.Connect(ctx)
+	require.NoError(t, err)
+	defer driverConn.Close()
+	con := driverConn.(*conn)
+
+	func() {
+		_, err := con.PrepareContext(ctx, `SELECT 42 AS leaked_stmt`)
+		require.NoError(t, err)
+		_, err = con.QueryContext(ctx, `SELECT 43 AS leaked_rows`, nil)
+		require.NoError(t, err)
+		s, err := con.PrepareContext(ctx, `SELECT 44 AS closed_stmt`)
+		require.NoError(t, err)
+		require.NoError(t, s.Close())
+	}()
+
+	require.Eventually(t, func() bool {
+		runtime.GC()
+		logged := out.String()
+		return strings.Contains(logged, `msg="duckdb statement garbage collected without Close" connection_id=`) &&
+			strings.Contains(logged, "leaked_stmt") &&
+			strings.Contains(logged, `msg="duckdb rows garbage collected without Close"`) &&
+			strings.Contains(logged, "leaked_rows")
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NotContains(t, out.String(), "closed_stmt")
+	require.NotContains(t, out.String(), "level=ERROR")
+}
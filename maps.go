@@ -0,0 +1,454 @@
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math/big"
+	"time"
+	"unsafe"
+)
+
+// QueryMaps executes the query and returns each row of its result as a map from the column names to the values.
+// The values have the same Go types as when scanning them into an any, e.g., int32 for INTEGER, []any for LIST,
+// and Map for MAP values. If several columns have the same name, then the map contains the value of the last one.
+// Arguments are bound to the last statement of the query.
+//
+// Unlike scanning database/sql rows, QueryMaps resolves the conversion of each column, including its nested types,
+// once before reading any rows. It resolves the data of each vector once per chunk, so converting a value requires
+// neither a type switch nor a cgo call for most types.
+func QueryMaps(ctx context.Context, driverConn driver.Conn, query string, args ...any) ([]map[string]any, error) {
+	con, ok := driverConn.(*conn)
+	if !ok {
+		return nil, getError(errInvalidCon, nil)
+	}
+	if con.closed {
+		return nil, getError(errClosedCon, nil)
+	}
+
+	driverRows, err := con.QueryContext(ctx, query, anyArgsToNamedArgs(args))
+	if err != nil {
+		return nil, err
+	}
+	r := driverRows.(*rows)
+	defer r.Close()
+
+	scanners := make([]*vectorScanner, len(r.columns))
+	for i := range scanners {
+		logicalType := C.duckdb_column_logical_type(&r.res, C.idx_t(i))
+		scanners[i] = newVectorScanner(r.config, logicalType, &r.strings)
+		C.duckdb_destroy_logical_type(&logicalType)
+	}
+
+	maps := []map[string]any{}
+	for {
+		chunk, err := r.nextChunk()
+		if err != nil {
+			return nil, err
+		}
+		if chunk == nil {
+			break
+		}
+
+		maps, err = appendChunkMaps(maps, chunk, r.columns, scanners)
+		C.duckdb_destroy_data_chunk(&chunk)
+		if err != nil {
+			return nil, getError(errQueryMaps, err)
+		}
+	}
+	return maps, nil
+}
+
+// ScanMap scans the current row of the rows into a map from the column names to the values, e.g., for a generic
+// JSON API. Like the values of QueryMaps, the values have the same Go types as when scanning them into an any,
+// including nested values. Unlike QueryMaps, it scans the rows of any query of database/sql, e.g., of a sql.Tx.
+func ScanMap(rows *sql.Rows) (map[string]any, error) {
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+	values := make([]any, len(columns))
+	dest := make([]any, len(columns))
+	for i := range values {
+		dest[i] = &values[i]
+	}
+	if err = rows.Scan(dest...); err != nil {
+		return nil, err
+	}
+
+	m := make(map[string]any, len(columns))
+	for i, column := range columns {
+		m[column] = values[i]
+	}
+	return m, nil
+}
+
+// QueryRowsAsMaps executes the query on the database, e.g., a sql.DB, sql.Conn, or sql.Tx, and returns each row
+// of its result as a map, see ScanMap. On a driver connection, QueryMaps converts the values faster.
+func QueryRowsAsMaps(ctx context.Context, db Queryer, query string, args ...any) ([]map[string]any, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	maps := []map[string]any{}
+	for rows.Next() {
+		m, err := ScanMap(rows)
+		if err != nil {
+			return nil, getError(errQueryMaps, err)
+		}
+		maps = append(maps, m)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+	return maps, nil
+}
+
+func appendChunkMaps(maps []map[string]any, chunk C.duckdb_data_chunk, columns []string, scanners []*vectorScanner) (
+	[]map[string]any, error,
+) {
+	for colIdx, s := range scanners {
+		s.setVector(C.duckdb_data_chunk_get_vector(chunk, C.idx_t(colIdx)))
+	}
+
+	rowCount := C.duckdb_data_chunk_get_size(chunk)
+	for rowIdx := C.idx_t(0); rowIdx < rowCount; rowIdx++ {
+		row := make(map[string]any, len(columns))
+		for colIdx, s := range scanners {
+			value, err := s.value(rowIdx)
+			if err != nil {
+				return nil, columnError(err, colIdx+1)
+			}
+			row[columns[colIdx]] = value
+		}
+		maps = append(maps, row)
+	}
+	return maps, nil
+}
+
+// vectorScanner converts the values of a vector. It resolves the logical type of the vector once,
+// and the data of the vector once per chunk, see setVector.
+type vectorScanner struct {
+	typeID C.duckdb_type
+	// convert converts the value of a valid row.
+	convert func(s *vectorScanner, rowIdx C.idx_t) (any, error)
+	// The scanners of the child vectors of a LIST, ARRAY, or STRUCT, or of the keys and values of a MAP.
+	children []*vectorScanner
+	// The field names of a STRUCT.
+	names []string
+	// The size of an ARRAY.
+	arraySize C.idx_t
+	// strings holds the converted VARCHAR values.
+	strings *stringArena
+	// If borrowBytes is set, then BLOB and JSON values point into the vector, instead of copying them.
+	// They are only valid until the chunk is destroyed, see rows.Next.
+	borrowBytes bool
+
+	vector   C.duckdb_vector
+	data     unsafe.Pointer
+	validity *C.uint64_t
+}
+
+// newVectorScanner returns the scanner of vectors of the logical type, which converts VARCHAR values into strings
+// of the arena.
+func newVectorScanner(config *connectorConfig, lt C.duckdb_logical_type, strings *stringArena) *vectorScanner {
+	s := &vectorScanner{typeID: C.duckdb_get_type_id(lt), strings: strings}
+
+	switch s.typeID {
+	case C.DUCKDB_TYPE_INVALID:
+		s.convert = func(*vectorScanner, C.idx_t) (any, error) { return nil, errInvalidType }
+	case C.DUCKDB_TYPE_BOOLEAN:
+		s.convert = convertPrimitive[bool]
+	case C.DUCKDB_TYPE_TINYINT:
+		s.convert = convertPrimitive[int8]
+	case C.DUCKDB_TYPE_SMALLINT:
+		s.convert = convertPrimitive[int16]
+	case C.DUCKDB_TYPE_INTEGER:
+		s.convert = convertPrimitive[int32]
+	case C.DUCKDB_TYPE_BIGINT:
+		s.convert = convertPrimitive[int64]
+	case C.DUCKDB_TYPE_UTINYINT:
+		s.convert = convertPrimitive[uint8]
+	case C.DUCKDB_TYPE_USMALLINT:
+		s.convert = convertPrimitive[uint16]
+	case C.DUCKDB_TYPE_UINTEGER:
+		s.convert = convertPrimitive[uint32]
+	case C.DUCKDB_TYPE_UBIGINT:
+		s.convert = convertPrimitive[uint64]
+	case C.DUCKDB_TYPE_FLOAT:
+		s.convert = floatConverter[float32](config)
+	case C.DUCKDB_TYPE_DOUBLE:
+		s.convert = floatConverter[float64](config)
+	case C.DUCKDB_TYPE_VARCHAR:
+		if isJSONType(lt) {
+			// Like scan, JSON values convert to bytes.
+			s.convert = convertBytes
+			break
+		}
+		s.convert = func(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+			return s.strings.string(stringAt(s.data, rowIdx)), nil
+		}
+	case C.DUCKDB_TYPE_BLOB:
+		s.convert = convertBytes
+	case C.DUCKDB_TYPE_TIMESTAMP:
+		loc := config.timestampLocation()
+		s.convert = func(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+			micros := int64(vectorData[C.duckdb_timestamp](s, rowIdx).micros)
+			if isInfiniteTimestamp(micros) {
+				return config.infiniteTime(micros > 0, false)
+			}
+			return microsToTimestamp(micros, loc), nil
+		}
+	case C.DUCKDB_TYPE_TIMESTAMP_TZ:
+		loc := config.timestampTZLocation()
+		s.convert = func(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+			micros := int64(vectorData[C.duckdb_timestamp](s, rowIdx).micros)
+			if isInfiniteTimestamp(micros) {
+				return config.infiniteTime(micros > 0, false)
+			}
+			return time.UnixMicro(micros).In(loc), nil
+		}
+	case C.DUCKDB_TYPE_DECIMAL:
+		s.convert = decimalConverter(lt)
+	case C.DUCKDB_TYPE_ENUM:
+		s.convert = enumConverter(lt)
+	case C.DUCKDB_TYPE_LIST:
+		child := C.duckdb_list_type_child_type(lt)
+		s.children = []*vectorScanner{newVectorScanner(config, child, strings)}
+		C.duckdb_destroy_logical_type(&child)
+		s.convert = convertList
+	case C.DUCKDB_TYPE_ARRAY:
+		child := C.duckdb_array_type_child_type(lt)
+		s.children = []*vectorScanner{newVectorScanner(config, child, strings)}
+		C.duckdb_destroy_logical_type(&child)
+		s.arraySize = C.duckdb_array_type_array_size(lt)
+		s.convert = convertArray
+	case C.DUCKDB_TYPE_STRUCT:
+		count := C.duckdb_struct_type_child_count(lt)
+		for i := C.idx_t(0); i < count; i++ {
+			name := C.duckdb_struct_type_child_name(lt, i)
+			s.names = append(s.names, C.GoString(name))
+			C.duckdb_free(unsafe.Pointer(name))
+
+			child := C.duckdb_struct_type_child_type(lt, i)
+			s.children = append(s.children, newVectorScanner(config, child, strings))
+			C.duckdb_destroy_logical_type(&child)
+		}
+		s.convert = convertStruct
+	case C.DUCKDB_TYPE_MAP:
+		key := C.duckdb_map_type_key_type(lt)
+		value := C.duckdb_map_type_value_type(lt)
+		s.children = []*vectorScanner{newVectorScanner(config, key, strings), newVectorScanner(config, value, strings)}
+		C.duckdb_destroy_logical_type(&key)
+		C.duckdb_destroy_logical_type(&value)
+		s.convert = convertMap
+	default:
+		// The remaining types are rare, so they fall back to the conversion of scan.
+		s.convert = func(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+			return scan(config, s.vector, rowIdx)
+		}
+	}
+	return s
+}
+
+// setVector sets the vector of the current chunk, and the vectors of its children.
+func (s *vectorScanner) setVector(vector C.duckdb_vector) {
+	s.vector = vector
+	s.data = C.duckdb_vector_get_data(vector)
+	s.validity = C.duckdb_vector_get_validity(vector)
+
+	switch s.typeID {
+	case C.DUCKDB_TYPE_LIST:
+		s.children[0].setVector(C.duckdb_list_vector_get_child(vector))
+	case C.DUCKDB_TYPE_ARRAY:
+		s.children[0].setVector(C.duckdb_array_vector_get_child(vector))
+	case C.DUCKDB_TYPE_STRUCT:
+		for i, child := range s.children {
+			child.setVector(C.duckdb_struct_vector_get_child(vector, C.idx_t(i)))
+		}
+	case C.DUCKDB_TYPE_MAP:
+		// A MAP is a LIST of STRUCTs with a key and a value field.
+		entries := C.duckdb_list_vector_get_child(vector)
+		s.children[0].setVector(C.duckdb_struct_vector_get_child(entries, 0))
+		s.children[1].setVector(C.duckdb_struct_vector_get_child(entries, 1))
+	}
+}
+
+// value returns the value of the row, or nil, if it is NULL.
+func (s *vectorScanner) value(rowIdx C.idx_t) (any, error) {
+	if s.validity != nil {
+		mask := *(*uint64)(unsafe.Add(unsafe.Pointer(s.validity), rowIdx/64*8))
+		if mask&(1<<(rowIdx%64)) == 0 {
+			return nil, nil
+		}
+	}
+	return s.convert(s, rowIdx)
+}
+
+func vectorData[T any](s *vectorScanner, rowIdx C.idx_t) T {
+	return (*[1 << 31]T)(s.data)[rowIdx]
+}
+
+func convertPrimitive[T any](s *vectorScanner, rowIdx C.idx_t) (any, error) {
+	return vectorData[T](s, rowIdx), nil
+}
+
+// floatConverter returns the conversion of FLOAT or DOUBLE values, which checks for NaN and infinite values,
+// unless they scan as they are, see WithInfinityMode.
+func floatConverter[T float32 | float64](config *connectorConfig) func(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+	if config.infinityMode() == InfinityAsSentinel {
+		return convertPrimitive[T]
+	}
+	return func(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+		v := vectorData[T](s, rowIdx)
+		return config.nonFiniteFloat(v, float64(v))
+	}
+}
+
+func convertBytes(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+	b := stringAt(s.data, rowIdx)
+	if s.borrowBytes {
+		return b, nil
+	}
+	return bytes.Clone(b), nil
+}
+
+func convertList(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+	entry := vectorData[duckdb_list_entry_t](s, rowIdx)
+	return s.children[0].values(C.idx_t(entry.offset), C.idx_t(entry.length))
+}
+
+func convertArray(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+	// The elements of all arrays are stored consecutively in the child vector.
+	return s.children[0].values(rowIdx*s.arraySize, s.arraySize)
+}
+
+// values returns the values of length rows, starting at the row offset.
+func (s *vectorScanner) values(offset C.idx_t, length C.idx_t) ([]any, error) {
+	values := make([]any, length)
+	for i := range values {
+		value, err := s.value(offset + C.idx_t(i))
+		if err != nil {
+			return nil, err
+		}
+		values[i] = value
+	}
+	return values, nil
+}
+
+func convertStruct(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+	values := make(map[string]any, len(s.children))
+	for i, child := range s.children {
+		value, err := child.value(rowIdx)
+		if err != nil {
+			return nil, err
+		}
+		values[s.names[i]] = value
+	}
+	return values, nil
+}
+
+func convertMap(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+	entry := vectorData[duckdb_list_entry_t](s, rowIdx)
+	keys, values := s.children[0], s.children[1]
+
+	// Go maps only support comparable key types, see scanMap.
+	if entry.length > 0 {
+		switch keys.typeID {
+		case C.DUCKDB_TYPE_BLOB, C.DUCKDB_TYPE_UUID, C.DUCKDB_TYPE_LIST, C.DUCKDB_TYPE_ARRAY, C.DUCKDB_TYPE_STRUCT,
+			C.DUCKDB_TYPE_MAP:
+			return nil, errUnsupportedMapKeyType
+		}
+	}
+
+	m := make(Map, entry.length)
+	for i := C.idx_t(entry.offset); i < C.idx_t(entry.offset+entry.length); i++ {
+		key, err := keys.value(i)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := key.([]byte); ok {
+			// JSON keys convert to bytes.
+			return nil, errUnsupportedMapKeyType
+		}
+		value, err := values.value(i)
+		if err != nil {
+			return nil, err
+		}
+		m[key] = value
+	}
+	return m, nil
+}
+
+func decimalConverter(lt C.duckdb_logical_type) func(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+	width := uint8(C.duckdb_decimal_width(lt))
+	scale := uint8(C.duckdb_decimal_scale(lt))
+	decimal := func(value *big.Int) (any, error) {
+		return Decimal{Width: width, Scale: scale, Value: value}, nil
+	}
+
+	switch C.duckdb_decimal_internal_type(lt) {
+	case C.DUCKDB_TYPE_SMALLINT:
+		return func(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+			return decimal(big.NewInt(int64(vectorData[int16](s, rowIdx))))
+		}
+	case C.DUCKDB_TYPE_INTEGER:
+		return func(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+			return decimal(big.NewInt(int64(vectorData[int32](s, rowIdx))))
+		}
+	case C.DUCKDB_TYPE_BIGINT:
+		return func(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+			return decimal(big.NewInt(vectorData[int64](s, rowIdx)))
+		}
+	case C.DUCKDB_TYPE_HUGEINT:
+		return func(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+			value := hugeIntToNative(vectorData[C.duckdb_hugeint](s, rowIdx))
+			if value == nil {
+				return nil, fmt.Errorf("unable to convert hugeint to native type")
+			}
+			return decimal(value)
+		}
+	default:
+		return func(*vectorScanner, C.idx_t) (any, error) { return nil, errInvalidType }
+	}
+}
+
+// enumLabels returns the labels of the ENUM type, which its values index.
+func enumLabels(lt C.duckdb_logical_type) []string {
+	labels := make([]string, C.duckdb_enum_dictionary_size(lt))
+	for i := range labels {
+		value := C.duckdb_enum_dictionary_value(lt, C.idx_t(i))
+		labels[i] = C.GoString(value)
+		C.duckdb_free(unsafe.Pointer(value))
+	}
+	return labels
+}
+
+func enumConverter(lt C.duckdb_logical_type) func(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+	dictionary := enumLabels(lt)
+	switch C.duckdb_enum_internal_type(lt) {
+	case C.DUCKDB_TYPE_UTINYINT:
+		return func(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+			return dictionary[vectorData[uint8](s, rowIdx)], nil
+		}
+	case C.DUCKDB_TYPE_USMALLINT:
+		return func(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+			return dictionary[vectorData[uint16](s, rowIdx)], nil
+		}
+	case C.DUCKDB_TYPE_UINTEGER:
+		return func(s *vectorScanner, rowIdx C.idx_t) (any, error) {
+			return dictionary[vectorData[uint32](s, rowIdx)], nil
+		}
+	default:
+		return func(*vectorScanner, C.idx_t) (any, error) { return nil, errInvalidType }
+	}
+}
@@ -0,0 +1,126 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// queryMapsNaive scans the rows of the query with database/sql, which converts each value with the type switch of scan.
+func queryMapsNaive(t testing.TB, db *sql.DB, query string) []map[string]any {
+	maps, err := QueryRowsAsMaps(context.Background(), db, query)
+	require.NoError(t, err)
+	return maps
+}
+
+func queryMaps(t testing.TB, db *sql.DB, query string, args ...any) ([]map[string]any, error) {
+	con, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	var maps []map[string]any
+	err = con.Raw(func(driverConn any) error {
+		maps, err = QueryMaps(context.Background(), driverConn.(driver.Conn), query, args...)
+		return err
+	})
+	return maps, err
+}
+
+func TestQueryMaps(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy')`)
+	require.NoError(t, err)
+
+	queries := map[string]string{
+		"primitives": `SELECT (range % 2)::BOOLEAN AS b, (range % 100)::TINYINT AS i8, range::SMALLINT AS i16, range::INTEGER AS i32,
+			range AS i64, (range % 200)::UTINYINT AS u8, range::USMALLINT AS u16, range::UINTEGER AS u32, range::UBIGINT AS u64,
+			range::FLOAT AS f32, range::DOUBLE AS f64, range::VARCHAR || 'a long string value' AS s,
+			CASE WHEN range % 3 = 0 THEN NULL ELSE range END AS n
+			FROM range(5000)`,
+		"temporal": `SELECT TIMESTAMP '2024-01-02 03:04:05' + INTERVAL (range) SECOND AS ts,
+			(TIMESTAMP '2024-01-02 03:04:05' + INTERVAL (range) SECOND)::TIMESTAMPTZ AS tstz,
+			DATE '2024-01-01' + range::INTEGER AS d, TIME '01:02:03' AS t, INTERVAL (range) DAY AS i,
+			'2024-01-02 03:04:05.123'::TIMESTAMP_MS AS ms, '2024-01-02 03:04:05'::TIMESTAMP_S AS sec,
+			'2024-01-02 03:04:05.123456789'::TIMESTAMP_NS AS ns
+			FROM range(10)`,
+		"others": `SELECT range::DECIMAL(4, 1) AS d4, range::DECIMAL(9, 2) AS d9, range::DECIMAL(18, 3) AS d18,
+			range::DECIMAL(38, 10) AS d38, range::HUGEINT AS h, 'ok'::mood AS e, 'abc'::BLOB AS bl,
+			'b1f2ac40-8f62-4b5f-9d50-6f1e2c9e3a10'::UUID AS u
+			FROM range(10)`,
+		"nested": `SELECT [range, NULL] AS l, {'a': range, 'b': [range::VARCHAR]} AS st,
+			MAP {range: [range::DOUBLE]} AS m, [range, range + 1]::BIGINT[2] AS a,
+			[MAP {'k': {'x': range}}, NULL] AS lm,
+			CASE WHEN range % 2 = 0 THEN NULL ELSE {'a': NULL, 'b': []::VARCHAR[]} END AS ns
+			FROM range(3000)`,
+		"empty": `SELECT 1 AS a WHERE false`,
+	}
+
+	for name, query := range queries {
+		t.Run(name, func(t *testing.T) {
+			maps, err := queryMaps(t, db, query)
+			require.NoError(t, err)
+			require.Equal(t, queryMapsNaive(t, db, query), maps)
+		})
+	}
+
+	t.Run("arguments", func(t *testing.T) {
+		maps, err := queryMaps(t, db, `SELECT ? AS a, ?::INTEGER AS b`, "x", 2)
+		require.NoError(t, err)
+		require.Equal(t, []map[string]any{{"a": "x", "b": int32(2)}}, maps)
+	})
+
+	t.Run("unsupported map key", func(t *testing.T) {
+		_, err := queryMaps(t, db, `SELECT MAP {[1]: 1}`)
+		testError(t, err, errQueryMaps.Error(), errUnsupportedMapKeyType.Error())
+	})
+
+	t.Run("invalid connection", func(t *testing.T) {
+		_, err := QueryMaps(context.Background(), nil, `SELECT 1`)
+		testError(t, err, errInvalidCon.Error())
+	})
+
+	t.Run("scan map", func(t *testing.T) {
+		maps, err := QueryRowsAsMaps(context.Background(), db, `SELECT ? AS a, [1, 2] AS l, {'x': 'y'} AS s`, "x")
+		require.NoError(t, err)
+		require.Equal(t, []map[string]any{{"a": "x", "l": []any{int32(1), int32(2)}, "s": map[string]any{"x": "y"}}}, maps)
+
+		rows, err := db.Query(`SELECT 1 AS a`)
+		require.NoError(t, err)
+		defer rows.Close()
+		require.True(t, rows.Next())
+		m, err := ScanMap(rows)
+		require.NoError(t, err)
+		require.Equal(t, map[string]any{"a": int32(1)}, m)
+
+		_, err = QueryRowsAsMaps(context.Background(), db, `SELECT * FROM missing`)
+		require.ErrorContains(t, err, "Catalog Error")
+	})
+}
+
+func BenchmarkQueryMaps(b *testing.B) {
+	db := openDB(b)
+	defer db.Close()
+
+	const query = `SELECT range AS i, range::DOUBLE AS f, range::VARCHAR AS s, [range, range + 1] AS l,
+		{'a': range, 'b': range::VARCHAR} AS st, CASE WHEN range % 2 = 0 THEN NULL ELSE range END AS n
+		FROM range(100000)`
+
+	b.Run("naive", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			queryMapsNaive(b, db, query)
+		}
+	})
+
+	b.Run("precomputed", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			_, err := queryMaps(b, db, query)
+			require.NoError(b, err)
+		}
+	})
+}
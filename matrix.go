@@ -0,0 +1,188 @@
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"math"
+	"reflect"
+	"unsafe"
+)
+
+// MatrixOptions configures QueryMatrixWithOptions.
+type MatrixOptions struct {
+	// NullAsNaN maps NULL values to math.NaN(). By default, a NULL value returns an error.
+	NullAsNaN bool
+}
+
+// QueryMatrix executes the query and returns its all-numeric result as a dense, row-major 2D slice.
+// It returns an error, if any column is not numeric, or if the result contains a NULL value.
+// Arguments are bound to the last statement of the query.
+func QueryMatrix(ctx context.Context, driverConn driver.Conn, query string, args ...any) ([][]float64, error) {
+	return QueryMatrixWithOptions(ctx, driverConn, MatrixOptions{}, query, args...)
+}
+
+// QueryMatrixWithOptions is like QueryMatrix, but it allows configuring the conversion with MatrixOptions.
+func QueryMatrixWithOptions(ctx context.Context, driverConn driver.Conn, opts MatrixOptions, query string,
+	args ...any,
+) ([][]float64, error) {
+	con, ok := driverConn.(*conn)
+	if !ok {
+		return nil, getError(errInvalidCon, nil)
+	}
+	if con.closed {
+		return nil, getError(errClosedCon, nil)
+	}
+
+	driverRows, err := con.QueryContext(ctx, query, anyArgsToNamedArgs(args))
+	if err != nil {
+		return nil, err
+	}
+	r := driverRows.(*rows)
+	defer r.Close()
+
+	// Get the conversion function of each column before fetching any data.
+	colCount := len(r.columns)
+	valueFns := make([]fnMatrixValue, colCount)
+	for i := 0; i < colCount; i++ {
+		logicalType := C.duckdb_column_logical_type(&r.res, C.idx_t(i))
+		valueFns[i] = matrixValueFn(logicalType)
+		C.duckdb_destroy_logical_type(&logicalType)
+
+		if valueFns[i] == nil {
+			name := r.ColumnTypeDatabaseTypeName(i)
+			return nil, getError(errQueryMatrix, columnError(castError(name, reflect.Float64.String()), i+1))
+		}
+	}
+
+	// Preallocate a single backing slice for all rows, if their number is known.
+	var data []float64
+	if !r.streaming {
+		data = make([]float64, 0, int(C.duckdb_row_count(&r.res))*colCount)
+	}
+
+	for {
+		chunk, err := r.nextChunk()
+		if err != nil {
+			return nil, err
+		}
+		if chunk == nil {
+			break
+		}
+		data, err = appendMatrixChunk(data, chunk, valueFns, opts)
+		C.duckdb_destroy_data_chunk(&chunk)
+		if err != nil {
+			return nil, getError(errQueryMatrix, err)
+		}
+	}
+
+	matrix := make([][]float64, len(data)/max(colCount, 1))
+	for i := range matrix {
+		matrix[i] = data[i*colCount : (i+1)*colCount : (i+1)*colCount]
+	}
+	return matrix, nil
+}
+
+// fnMatrixValue reads the value at rowIdx from the data of a vector, and converts it to a float64.
+type fnMatrixValue func(ptr unsafe.Pointer, rowIdx C.idx_t) float64
+
+func appendMatrixChunk(data []float64, chunk C.duckdb_data_chunk, valueFns []fnMatrixValue,
+	opts MatrixOptions,
+) ([]float64, error) {
+	colCount := len(valueFns)
+	size := int(C.duckdb_data_chunk_get_size(chunk))
+
+	// Extend the slice by the rows of this chunk, so that we can write each column separately.
+	offset := len(data)
+	data = append(data, make([]float64, size*colCount)...)
+
+	for colIdx := 0; colIdx < colCount; colIdx++ {
+		vector := C.duckdb_data_chunk_get_vector(chunk, C.idx_t(colIdx))
+		ptr := C.duckdb_vector_get_data(vector)
+		validity := C.duckdb_vector_get_validity(vector)
+
+		for rowIdx := 0; rowIdx < size; rowIdx++ {
+			idx := offset + rowIdx*colCount + colIdx
+			if !C.duckdb_validity_row_is_valid(validity, C.idx_t(rowIdx)) {
+				if !opts.NullAsNaN {
+					return nil, columnError(errUnexpectedNull, colIdx+1)
+				}
+				data[idx] = math.NaN()
+				continue
+			}
+			data[idx] = valueFns[colIdx](ptr, C.idx_t(rowIdx))
+		}
+	}
+	return data, nil
+}
+
+// matrixValueFn returns the conversion function for a logical type, or nil, if the type is not numeric.
+func matrixValueFn(logicalType C.duckdb_logical_type) fnMatrixValue {
+	switch C.duckdb_get_type_id(logicalType) {
+	case C.DUCKDB_TYPE_TINYINT:
+		return matrixValue[int8]
+	case C.DUCKDB_TYPE_SMALLINT:
+		return matrixValue[int16]
+	case C.DUCKDB_TYPE_INTEGER:
+		return matrixValue[int32]
+	case C.DUCKDB_TYPE_BIGINT:
+		return matrixValue[int64]
+	case C.DUCKDB_TYPE_UTINYINT:
+		return matrixValue[uint8]
+	case C.DUCKDB_TYPE_USMALLINT:
+		return matrixValue[uint16]
+	case C.DUCKDB_TYPE_UINTEGER:
+		return matrixValue[uint32]
+	case C.DUCKDB_TYPE_UBIGINT:
+		return matrixValue[uint64]
+	case C.DUCKDB_TYPE_FLOAT:
+		return matrixValue[float32]
+	case C.DUCKDB_TYPE_DOUBLE:
+		return matrixValue[float64]
+	case C.DUCKDB_TYPE_HUGEINT:
+		return matrixHugeInt
+	case C.DUCKDB_TYPE_UHUGEINT:
+		return matrixUHugeInt
+	case C.DUCKDB_TYPE_DECIMAL:
+		return matrixDecimalFn(logicalType)
+	}
+	return nil
+}
+
+func matrixValue[T numericType](ptr unsafe.Pointer, rowIdx C.idx_t) float64 {
+	return float64((*[1 << 31]T)(ptr)[rowIdx])
+}
+
+func matrixHugeInt(ptr unsafe.Pointer, rowIdx C.idx_t) float64 {
+	return float64(C.duckdb_hugeint_to_double((*[1 << 31]C.duckdb_hugeint)(ptr)[rowIdx]))
+}
+
+func matrixUHugeInt(ptr unsafe.Pointer, rowIdx C.idx_t) float64 {
+	return float64(C.duckdb_uhugeint_to_double((*[1 << 31]C.duckdb_uhugeint)(ptr)[rowIdx]))
+}
+
+func matrixDecimalFn(logicalType C.duckdb_logical_type) fnMatrixValue {
+	factor := math.Pow10(int(C.duckdb_decimal_scale(logicalType)))
+
+	var internalFn fnMatrixValue
+	switch C.duckdb_decimal_internal_type(logicalType) {
+	case C.DUCKDB_TYPE_SMALLINT:
+		internalFn = matrixValue[int16]
+	case C.DUCKDB_TYPE_INTEGER:
+		internalFn = matrixValue[int32]
+	case C.DUCKDB_TYPE_BIGINT:
+		internalFn = matrixValue[int64]
+	case C.DUCKDB_TYPE_HUGEINT:
+		internalFn = matrixHugeInt
+	default:
+		return nil
+	}
+
+	return func(ptr unsafe.Pointer, rowIdx C.idx_t) float64 {
+		return internalFn(ptr, rowIdx) / factor
+	}
+}
@@ -0,0 +1,65 @@
+package duckdb
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryMatrix(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	con, err := c.Connect(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	t.Run("numeric columns", func(t *testing.T) {
+		// Exceed the vector size to fetch multiple chunks.
+		matrix, err := QueryMatrix(context.Background(), con, `SELECT (i % 100)::TINYINT, i, i::UBIGINT, i / 4, i::FLOAT,
+			i::DECIMAL(10, 2) / 100, i::DECIMAL(30, 3) / 1000, i::HUGEINT FROM range(?) t(i)`, 5000)
+		require.NoError(t, err)
+		require.Len(t, matrix, 5000)
+
+		for i, row := range matrix {
+			require.Len(t, row, 8)
+			f := float64(i)
+			require.Equal(t, []float64{float64(i % 100), f, f, f / 4, f}, row[:5])
+			require.InDelta(t, f/100, row[5], 1e-9)
+			require.InDelta(t, f/1000, row[6], 1e-9)
+			require.Equal(t, f, row[7])
+		}
+	})
+
+	t.Run("empty result", func(t *testing.T) {
+		matrix, err := QueryMatrix(context.Background(), con, `SELECT i FROM range(10) t(i) WHERE i > 10`)
+		require.NoError(t, err)
+		require.Empty(t, matrix)
+	})
+
+	t.Run("NULL values", func(t *testing.T) {
+		_, err := QueryMatrix(context.Background(), con, `SELECT 1, NULL::INTEGER`)
+		testError(t, err, errQueryMatrix.Error(), errUnexpectedNull.Error(), columnErrMsg)
+
+		matrix, err := QueryMatrixWithOptions(context.Background(), con, MatrixOptions{NullAsNaN: true},
+			`SELECT 1, NULL::INTEGER`)
+		require.NoError(t, err)
+		require.Equal(t, float64(1), matrix[0][0])
+		require.True(t, math.IsNaN(matrix[0][1]))
+	})
+
+	t.Run("non-numeric column", func(t *testing.T) {
+		_, err := QueryMatrix(context.Background(), con, `SELECT 1, 'hello'`)
+		testError(t, err, errQueryMatrix.Error(), castErrMsg, "VARCHAR", columnErrMsg)
+	})
+
+	t.Run("invalid connection", func(t *testing.T) {
+		_, err := QueryMatrix(context.Background(), nil, `SELECT 1`)
+		testError(t, err, errInvalidCon.Error())
+	})
+}
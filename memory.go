@@ -0,0 +1,235 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"time"
+)
+
+// MemoryStats is the memory and temporary storage usage of a database.
+type MemoryStats struct {
+	// UsageBytes is the memory usage of the buffer manager. If HasTags is set, then it is the exact
+	// sum of the usage of all tags. Otherwise, it is parsed from PRAGMA database_size, which
+	// rounds it to one decimal of its unit, e.g., 9.5 MiB.
+	UsageBytes int64
+	// LimitBytes is the memory_limit, rounded like the fallback of UsageBytes. It is zero, if the memory is unlimited.
+	LimitBytes int64
+	// TemporaryDirectory is the directory to which DuckDB spills data exceeding the memory limit.
+	TemporaryDirectory string
+	// Tags contains the usage of each memory tag, e.g., BASE_TABLE or HASH_TABLE, if HasTags is set.
+	Tags []MemoryTagUsage
+	// HasTags is set, if DuckDB provides the usage of each tag through duckdb_memory().
+	HasTags bool
+	// TemporaryFiles contains the files of the temporary directory, if HasTemporaryFiles is set.
+	TemporaryFiles []TemporaryFile
+	// HasTemporaryFiles is set, if DuckDB lists its temporary files through duckdb_temporary_files().
+	HasTemporaryFiles bool
+}
+
+// MemoryTagUsage is the memory and temporary storage usage of a memory tag.
+type MemoryTagUsage struct {
+	Tag                   string
+	MemoryUsageBytes      int64
+	TemporaryStorageBytes int64
+}
+
+// TemporaryFile is a file to which DuckDB spilled data.
+type TemporaryFile struct {
+	Path      string
+	SizeBytes int64
+}
+
+// TemporaryStorageBytes returns the total size of the temporary files.
+func (s MemoryStats) TemporaryStorageBytes() int64 {
+	var total int64
+	for _, file := range s.TemporaryFiles {
+		total += file.SizeBytes
+	}
+	return total
+}
+
+// MemoryUsage returns the memory usage and limit of the database, and its usage of temporary storage.
+// DuckDB versions without duckdb_memory() or duckdb_temporary_files() return partial statistics,
+// see HasTags and HasTemporaryFiles.
+func MemoryUsage(ctx context.Context, db Queryer) (MemoryStats, error) {
+	var stats MemoryStats
+	var usage, limit string
+	err := queryRow(ctx, db, `SELECT memory_usage, memory_limit, current_setting('temp_directory')
+		FROM pragma_database_size() LIMIT 1`, &usage, &limit, &stats.TemporaryDirectory)
+	if err != nil {
+		return stats, err
+	}
+
+	usageBytes, err := parseByteSize(usage)
+	if err != nil {
+		return stats, getError(errMemoryUsage, err)
+	}
+	stats.UsageBytes = int64(usageBytes)
+	if limit != "Unlimited" {
+		limitBytes, err := parseByteSize(limit)
+		if err != nil {
+			return stats, getError(errMemoryUsage, err)
+		}
+		stats.LimitBytes = int64(limitBytes)
+	}
+
+	stats.Tags, stats.HasTags, err = queryOptional(ctx, db,
+		`SELECT tag, memory_usage_bytes, temporary_storage_bytes FROM duckdb_memory()`,
+		func(rows *sql.Rows) (MemoryTagUsage, error) {
+			var tag MemoryTagUsage
+			return tag, rows.Scan(&tag.Tag, &tag.MemoryUsageBytes, &tag.TemporaryStorageBytes)
+		})
+	if err != nil {
+		return stats, err
+	}
+	if stats.HasTags {
+		stats.UsageBytes = 0
+		for _, tag := range stats.Tags {
+			stats.UsageBytes += tag.MemoryUsageBytes
+		}
+	}
+
+	stats.TemporaryFiles, stats.HasTemporaryFiles, err = queryOptional(ctx, db,
+		`SELECT path, size FROM duckdb_temporary_files() ORDER BY path`,
+		func(rows *sql.Rows) (TemporaryFile, error) {
+			var file TemporaryFile
+			return file, rows.Scan(&file.Path, &file.SizeBytes)
+		})
+	return stats, err
+}
+
+// queryRow scans the first row of the query into dest.
+func queryRow(ctx context.Context, db Queryer, query string, dest ...any) error {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return err
+		}
+		return sql.ErrNoRows
+	}
+	if err = rows.Scan(dest...); err != nil {
+		return err
+	}
+	return rows.Close()
+}
+
+// queryOptional scans all rows of a query of a function, which may not exist in the DuckDB version.
+// If the function does not exist, then it returns false, and no error.
+func queryOptional[T any](ctx context.Context, db Queryer, query string, scan func(rows *sql.Rows) (T, error)) (
+	[]T, bool, error,
+) {
+	rows, err := db.QueryContext(ctx, query)
+	if err != nil {
+		if ctx.Err() == nil && strings.Contains(err.Error(), "Catalog Error") {
+			return nil, false, nil
+		}
+		return nil, false, err
+	}
+	defer rows.Close()
+
+	values := []T{}
+	for rows.Next() {
+		value, err := scan(rows)
+		if err != nil {
+			return nil, false, err
+		}
+		values = append(values, value)
+	}
+	return values, true, rows.Err()
+}
+
+// QueryMemoryUsage is the memory usage of the database, while a query executes, see ContextWithMemoryUsage.
+// DuckDB does not account memory to queries, so it includes the memory of concurrent queries and of the data of
+// the database, e.g., PeakUsageBytes - StartUsageBytes approximates the memory of a single query.
+type QueryMemoryUsage struct {
+	// UsageBytes is the memory usage of the buffer manager of the database.
+	UsageBytes int64
+	// StartUsageBytes is the memory usage, when the query started.
+	StartUsageBytes int64
+	// PeakUsageBytes is the peak of the sampled memory usage since the query started.
+	PeakUsageBytes int64
+	// LimitBytes is the memory_limit of the database, rounded to one decimal of its unit. It is zero, if the memory
+	// is unlimited.
+	LimitBytes int64
+	// Done is set for the last sample, after the query finished.
+	Done bool
+}
+
+type memoryUsageKey struct{}
+
+// memoryUsageReporter reports the memory usage of the database during the queries of a context.
+type memoryUsageReporter struct {
+	interval time.Duration
+	fn       func(QueryMemoryUsage)
+}
+
+// ContextWithMemoryUsage returns a copy of ctx, which reports the memory usage of the database, while the queries
+// executed with it execute, e.g., to alert on a memory budget, or to cancel a query exceeding it.
+// The driver samples the memory usage, when a query starts, every interval, which defaults to 100ms, and after
+// it finished, and calls fn with each sample after the first. It samples the memory usage on a separate connection,
+// so it only reports the queries of the connections of a Connector. Like ContextWithProgress, it reports a streaming
+// result until its first chunk is ready. The samples also update the peak memory usage of Connector.Stats.
+func ContextWithMemoryUsage(ctx context.Context, interval time.Duration, fn func(QueryMemoryUsage)) context.Context {
+	if interval <= 0 {
+		interval = defaultProgressInterval
+	}
+	return context.WithValue(ctx, memoryUsageKey{}, &memoryUsageReporter{interval: interval, fn: fn})
+}
+
+// reportMemoryUsage reports the memory usage of the database to the memory usage reporter of the context, if any,
+// until calling stop, which reports the last sample.
+func (c *conn) reportMemoryUsage(ctx context.Context) (stop func()) {
+	reporter, _ := ctx.Value(memoryUsageKey{}).(*memoryUsageReporter)
+	if reporter == nil || reporter.fn == nil || c.connector == nil {
+		return func() {}
+	}
+
+	var usage QueryMemoryUsage
+	sample := func() bool {
+		// The memory usage of a sample does not depend on the context of the query, which may be done.
+		current, limit, err := c.connector.sampleMemory(context.Background())
+		if err != nil {
+			return false
+		}
+		usage.UsageBytes = current
+		usage.LimitBytes = limit
+		usage.PeakUsageBytes = max(usage.PeakUsageBytes, current)
+		return true
+	}
+	if sample() {
+		usage.StartUsageBytes = usage.UsageBytes
+	}
+
+	mainDoneCh := make(chan struct{})
+	bgDoneCh := make(chan struct{})
+	go func() {
+		defer close(bgDoneCh)
+		ticker := time.NewTicker(reporter.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				if sample() {
+					reporter.fn(usage)
+				}
+			case <-mainDoneCh:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(mainDoneCh)
+		<-bgDoneCh
+		if sample() {
+			usage.Done = true
+			reporter.fn(usage)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMemoryUsage(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	dir := t.TempDir()
+	_, err := db.Exec(`SET memory_limit = '512MiB'; SET temp_directory = '` + dir + `'`)
+	require.NoError(t, err)
+
+	stats, err := MemoryUsage(context.Background(), db)
+	require.NoError(t, err)
+	require.Equal(t, int64(512<<20), stats.LimitBytes)
+	require.Equal(t, dir, stats.TemporaryDirectory)
+	require.True(t, stats.HasTags)
+	require.True(t, stats.HasTemporaryFiles)
+	require.Empty(t, stats.TemporaryFiles)
+	require.Zero(t, stats.TemporaryStorageBytes())
+
+	var tags []string
+	for _, tag := range stats.Tags {
+		tags = append(tags, tag.Tag)
+	}
+	require.Contains(t, tags, "BASE_TABLE")
+
+	t.Run("unlimited", func(t *testing.T) {
+		_, err := db.Exec(`SET memory_limit = '-1'`)
+		require.NoError(t, err)
+		stats, err := MemoryUsage(context.Background(), db)
+		require.NoError(t, err)
+		require.Zero(t, stats.LimitBytes)
+	})
+
+	t.Run("spilling", func(t *testing.T) {
+		_, err := db.Exec(`SET memory_limit = '10MB'`)
+		require.NoError(t, err)
+		_, err = db.Exec(`CREATE TABLE tbl AS SELECT range AS i, range::VARCHAR || 'padding' AS s FROM range(1000000)`)
+		require.NoError(t, err)
+
+		stats, err := MemoryUsage(context.Background(), db)
+		require.NoError(t, err)
+		// PRAGMA database_size rounds the limit to 9.5 MiB.
+		require.InDelta(t, 10_000_000, stats.LimitBytes, 0.1*(1<<20))
+		require.Positive(t, stats.UsageBytes)
+		require.LessOrEqual(t, stats.UsageBytes, int64(10_000_000))
+		require.NotEmpty(t, stats.TemporaryFiles)
+		require.Positive(t, stats.TemporaryStorageBytes())
+
+		var spilled int64
+		for _, tag := range stats.Tags {
+			spilled += tag.TemporaryStorageBytes
+		}
+		require.Positive(t, spilled)
+	})
+}
+
+func TestContextWithMemoryUsage(t *testing.T) {
+	t.Parallel()
+	connector, err := NewConnector("", nil, WithMemoryLimit("1GiB"), WithTempDirectory(t.TempDir()))
+	require.NoError(t, err)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	var samples []QueryMemoryUsage
+	ctx := ContextWithMemoryUsage(context.Background(), time.Millisecond, func(usage QueryMemoryUsage) {
+		samples = append(samples, usage)
+	})
+	_, err = db.ExecContext(ctx, `CREATE TABLE strs AS SELECT range::VARCHAR AS s FROM range(5000000)`)
+	require.NoError(t, err)
+
+	require.NotEmpty(t, samples)
+	last := samples[len(samples)-1]
+	require.True(t, last.Done)
+	require.Equal(t, int64(1<<30), last.LimitBytes)
+	require.Positive(t, last.UsageBytes)
+	require.Greater(t, last.UsageBytes, last.StartUsageBytes)
+	for _, usage := range samples {
+		require.GreaterOrEqual(t, last.PeakUsageBytes, usage.UsageBytes)
+	}
+
+	// Queries without a memory usage reporter are not sampled.
+	samples = nil
+	_, err = db.Exec(`SELECT 1`)
+	require.NoError(t, err)
+	require.Empty(t, samples)
+
+	stats, err := connector.Stats(context.Background())
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, stats.PeakMemoryUsageBytes, last.PeakUsageBytes)
+	require.Equal(t, int64(1<<30), stats.MemoryLimitBytes)
+}
@@ -0,0 +1,59 @@
+package duckdb
+
+import (
+	"errors"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// motherDuckPrefixes are the prefixes of the DSN of a MotherDuck database, e.g., md:my_db.
+var motherDuckPrefixes = []string{"md:", "motherduck:"}
+
+// motherDuckTokenOption is the configuration option of the token authenticating with MotherDuck.
+const motherDuckTokenOption = "motherduck_token"
+
+// motherDuckTokenEnvs are the environment variables, from which a connector to MotherDuck reads the token,
+// if neither the DSN nor WithMotherDuckToken set it.
+var motherDuckTokenEnvs = []string{"motherduck_token", "MOTHERDUCK_TOKEN"}
+
+// isMotherDuckPath returns true, if the path of a DSN is a MotherDuck database, which is not a local file.
+func isMotherDuckPath(path string) bool {
+	for _, prefix := range motherDuckPrefixes {
+		if strings.HasPrefix(strings.ToLower(path), prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// WithMotherDuckToken sets the token authenticating with MotherDuck, for a DSN of a MotherDuck database,
+// e.g., md:my_db. The token defaults to the motherduck_token, or MOTHERDUCK_TOKEN environment variable.
+// Unlike a motherduck_token parameter of the DSN, the token does not have to be URL-encoded.
+// DuckDB installs and loads the motherduck extension, when opening a MotherDuck database.
+func WithMotherDuckToken(token string) ConnectorOption {
+	return func(c *connectorConfig) error {
+		if token == "" {
+			return errors.New("empty MotherDuck token")
+		}
+		c.config[motherDuckTokenOption] = token
+		return nil
+	}
+}
+
+// setMotherDuckToken sets the token of a MotherDuck database from the environment,
+// unless the DSN or the connector options set it.
+func setMotherDuckToken(path string, parsedDSN *url.URL, connConfig *connectorConfig) {
+	if !isMotherDuckPath(path) || parsedDSN.Query().Has(motherDuckTokenOption) {
+		return
+	}
+	if _, ok := connConfig.config[motherDuckTokenOption]; ok {
+		return
+	}
+	for _, env := range motherDuckTokenEnvs {
+		if token := os.Getenv(env); token != "" {
+			connConfig.config[motherDuckTokenOption] = token
+			return
+		}
+	}
+}
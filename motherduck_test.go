@@ -0,0 +1,37 @@
+package duckdb
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestMotherDuckToken(t *testing.T) {
+	t.Setenv("motherduck_token", "")
+	t.Setenv("MOTHERDUCK_TOKEN", "env-token")
+
+	token := func(dsn string, opts ...ConnectorOption) string {
+		path := getConnString(dsn)
+		parsedDSN, err := url.Parse(dsn)
+		require.NoError(t, err)
+		connConfig, err := newConnectorConfig(opts)
+		require.NoError(t, err)
+		setMotherDuckToken(path, parsedDSN, connConfig)
+		return connConfig.config[motherDuckTokenOption]
+	}
+
+	require.True(t, isMotherDuckPath("md:"))
+	require.True(t, isMotherDuckPath("MotherDuck:my_db"))
+	require.False(t, isMotherDuckPath("/path/to/md:db"))
+	require.False(t, isMotherDuckPath(":memory:"))
+
+	require.Equal(t, "env-token", token("md:my_db"))
+	require.Equal(t, "env-token", token("motherduck:?threads=4"))
+	require.Equal(t, "option-token", token("md:my_db", WithMotherDuckToken("option-token")))
+	require.Empty(t, token("md:my_db?motherduck_token=dsn-token"))
+	require.Empty(t, token("/path/to/local.db"))
+
+	_, err := NewConnector("md:my_db", nil, WithMotherDuckToken(""))
+	testError(t, err, errInvalidOption.Error(), "empty MotherDuck token")
+}
@@ -0,0 +1,168 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// ExecNamedStruct executes a query with named parameters, e.g., $id, and binds each of them to
+// the field of arg with the matching name. The arg must be a struct or a pointer to a struct.
+// The fields match the parameters like a StructScanner matches the columns, i.e., by their `db` tag,
+// or by their field name, case-insensitively.
+// Fields of pointer or sql.Null types bind NULL, if they are nil or invalid.
+// It returns an error listing all parameters without a matching field.
+func ExecNamedStruct(ctx context.Context, db Execer, query string, arg any) (sql.Result, error) {
+	args, err := namedStructArgs(query, arg)
+	if err != nil {
+		return nil, err
+	}
+	return db.ExecContext(ctx, query, args...)
+}
+
+// namedStructArgs returns the sql.NamedArg of each named parameter of the query.
+func namedStructArgs(query string, arg any) ([]any, error) {
+	v := reflect.ValueOf(arg)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return nil, getError(errBindStruct, castError(fmt.Sprintf("%T", arg), "struct or pointer to struct"))
+	}
+
+	names := namedParameters(query)
+	indexes, err := StructScanner{}.fieldIndexes(v.Type(), names)
+	if err != nil {
+		// Report all parameters without a matching field.
+		var missing []string
+		fields := structFields(v.Type())
+		for _, name := range names {
+			if !hasMatchingField(fields, name) {
+				missing = append(missing, "$"+name)
+			}
+		}
+		return nil, getError(errBindStruct, fmt.Errorf("%s: %s", missingParamErrMsg, strings.Join(missing, ", ")))
+	}
+
+	args := make([]any, len(names))
+	for i, index := range indexes {
+		args[i] = sql.Named(names[i], v.FieldByIndex(index).Interface())
+	}
+	return args, nil
+}
+
+func hasMatchingField(fields []structField, name string) bool {
+	for i := range fields {
+		if (StructScanner{}).match(&fields[i], name) != matchNone {
+			return true
+		}
+	}
+	return false
+}
+
+// namedParameters returns the distinct names of the named parameters of the query, in their order of appearance.
+// It skips string literals, quoted identifiers, comments, and positional parameters, e.g., $1.
+func namedParameters(query string) []string {
+	var names []string
+	seen := make(map[string]struct{})
+	for _, p := range queryPlaceholders([]rune(query)) {
+		if p.name == "" || unicode.IsDigit([]rune(p.name)[0]) {
+			continue
+		}
+		if _, ok := seen[p.name]; !ok {
+			seen[p.name] = struct{}{}
+			names = append(names, p.name)
+		}
+	}
+	return names
+}
+
+// placeholder is a parameter of a query, spanning the runes [start, end).
+// The name of a ? parameter is empty, and the name of a $1 parameter is its position.
+type placeholder struct {
+	start, end int
+	name       string
+}
+
+// queryPlaceholders returns the parameters of the query, in their order of appearance.
+// It skips string literals, quoted identifiers, and comments.
+func queryPlaceholders(runes []rune) []placeholder {
+	var params []placeholder
+	for i := 0; i < len(runes); i++ {
+		switch r := runes[i]; {
+		case r == '\'' || r == '"':
+			i = skipQuoted(runes, i, r)
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			i = indexRunes(runes, i+2, []rune("*/")) + 1
+		case r == '?':
+			params = append(params, placeholder{start: i, end: i + 1})
+		case r == '$':
+			start := i + 1
+			end := start
+			if end < len(runes) && unicode.IsDigit(runes[end]) {
+				for end < len(runes) && unicode.IsDigit(runes[end]) {
+					end++
+				}
+			} else {
+				for end < len(runes) && isIdentifierRune(runes[end], end == start) {
+					end++
+				}
+			}
+
+			// $tag$ and $$ open a dollar-quoted string, which ends at the next occurrence of the tag.
+			if end < len(runes) && runes[end] == '$' {
+				tag := runes[i : end+1]
+				i = indexRunes(runes, end+1, tag) + len(tag) - 1
+				continue
+			}
+
+			i = end - 1
+			if end > start {
+				params = append(params, placeholder{start: start - 1, end: end, name: string(runes[start:end])})
+			}
+		}
+	}
+	return params
+}
+
+// indexRunes returns the index of the first occurrence of token in runes at or after index from,
+// or len(runes), if there is none.
+func indexRunes(runes []rune, from int, token []rune) int {
+	for i := from; i+len(token) <= len(runes); i++ {
+		if string(runes[i:i+len(token)]) == string(token) {
+			return i
+		}
+	}
+	return len(runes)
+}
+
+// skipQuoted returns the index of the quote closing the quoted part starting at index start.
+// Doubled quotes are escaped quotes.
+func skipQuoted(runes []rune, start int, quote rune) int {
+	for i := start + 1; i < len(runes); i++ {
+		if runes[i] != quote {
+			continue
+		}
+		if i+1 < len(runes) && runes[i+1] == quote {
+			i++
+			continue
+		}
+		return i
+	}
+	return len(runes)
+}
+
+// isIdentifierRune returns true, if r can be part of a parameter name. A name cannot start with a digit.
+func isIdentifierRune(r rune, first bool) bool {
+	if r == '_' || unicode.IsLetter(r) {
+		return true
+	}
+	return !first && unicode.IsDigit(r)
+}
@@ -0,0 +1,98 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestExecNamedStruct(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE users (id INTEGER, name VARCHAR, email VARCHAR, age INTEGER, score DOUBLE)`)
+	require.NoError(t, err)
+
+	type Audit struct {
+		Score sql.NullFloat64
+	}
+	type user struct {
+		Audit
+		ID    int            `db:"id"`
+		Name  string         `db:"name"`
+		Email *string        `db:"email"`
+		Age   sql.NullInt32  `db:"age"`
+		Note  sql.NullString `db:"-"`
+	}
+
+	const insert = `INSERT INTO users VALUES ($id, $name, $email, $age, $score)`
+	email := "ada@example.com"
+	res, err := ExecNamedStruct(context.Background(), db, insert, user{
+		ID: 1, Name: "ada", Email: &email, Age: sql.NullInt32{Int32: 36, Valid: true},
+		Audit: Audit{Score: sql.NullFloat64{Float64: 9.5, Valid: true}},
+	})
+	require.NoError(t, err)
+	affected, err := res.RowsAffected()
+	require.NoError(t, err)
+	require.Equal(t, int64(1), affected)
+
+	// Nil pointers and invalid Null types bind NULL.
+	_, err = ExecNamedStruct(context.Background(), db, insert, &user{ID: 2, Name: "bob"})
+	require.NoError(t, err)
+
+	// Parameters may occur several times, and in any order.
+	_, err = ExecNamedStruct(context.Background(), db,
+		`UPDATE users SET name = $name || '-' || $id WHERE id = $id`, struct{ ID, Name any }{2, "bobby"})
+	require.NoError(t, err)
+
+	rows, err := db.Query(`SELECT id, name, email, age, score FROM users ORDER BY id`)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	var got []user
+	for rows.Next() {
+		var u user
+		require.NoError(t, ScanStruct(rows, &u))
+		got = append(got, u)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []user{
+		{
+			ID: 1, Name: "ada", Email: &email, Age: sql.NullInt32{Int32: 36, Valid: true},
+			Audit: Audit{Score: sql.NullFloat64{Float64: 9.5, Valid: true}},
+		},
+		{ID: 2, Name: "bobby-2"},
+	}, got)
+
+	t.Run("missing fields", func(t *testing.T) {
+		_, err := ExecNamedStruct(context.Background(), db, `SELECT $id, $foo, $note, $bar`, user{})
+		testError(t, err, errBindStruct.Error(), missingParamErrMsg, "$foo, $note, $bar")
+	})
+
+	t.Run("invalid argument", func(t *testing.T) {
+		_, err := ExecNamedStruct(context.Background(), db, insert, 1)
+		testError(t, err, errBindStruct.Error(), castErrMsg)
+		_, err = ExecNamedStruct(context.Background(), db, insert, (*user)(nil))
+		testError(t, err, errBindStruct.Error(), castErrMsg)
+	})
+}
+
+func TestNamedParametersOfQuery(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string][]string{
+		`SELECT 1`:                            nil,
+		`SELECT $a, $b + $a`:                  {"a", "b"},
+		`SELECT $1, ?, $a_1`:                  {"a_1"},
+		`SELECT '$a', "$b", 'it''s $c', $d`:   {"d"},
+		"SELECT $x -- $y\n, $z /* $w */, $v":  {"x", "z", "v"},
+		`SELECT $$ $a $$, $tag$ $b $tag$, $c`: {"c"},
+		`SELECT $a::INTEGER`:                  {"a"},
+	}
+	for query, expected := range tests {
+		require.Equal(t, expected, namedParameters(query), query)
+	}
+}
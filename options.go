@@ -0,0 +1,491 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ConnectorOption configures a Connector. Options are applied in order by NewConnector,
+// after the configuration options of the DSN. Thus, an option overrides a DSN option of the same name.
+type ConnectorOption func(c *connectorConfig) error
+
+// connectorConfig collects the settings of all ConnectorOptions.
+type connectorConfig struct {
+	// DuckDB configuration options applied when opening the database.
+	config map[string]string
+	// True, if a failed query attaches its EXPLAIN output to the returned Error.
+	explainOnError bool
+	// The location of the wall clock time of TIMESTAMP values.
+	timestampLoc *time.Location
+	// The location of scanned TIMESTAMPTZ values.
+	timestampTZLoc *time.Location
+	// The location of the midnight of scanned DATE values.
+	dateLoc *time.Location
+	// True, if DATE values scan as a Date instead of a time.Time.
+	civilDates bool
+	// The scanning of infinite dates and timestamps, and of NaN and infinite floats.
+	infinity InfinityMode
+	// The extensions installed and loaded on each new connection.
+	extensions []string
+	// True, if queries stream their results instead of materializing them.
+	streamingResults bool
+	// The maximum number of retries of a single statement after a transaction conflict.
+	conflictRetries int
+	// The backoff before the first retry, which doubles with each retry.
+	conflictBackoff time.Duration
+	// The functions called on each new connection, after the connInitFn of NewConnector.
+	connInitFns []func(execer driver.ExecerContext) error
+	// The queries executed once after opening the database.
+	bootQueries []string
+	// The queries executed on each new connection, after the connection init functions.
+	connInitQueries []string
+	// True, if Connector.Close checkpoints the database before closing it.
+	checkpointOnClose bool
+	// The maximum number of cached prepared statements of each connection, or 0, if the cache is disabled.
+	stmtCacheSize int
+	// The hooks observing the queries of the connections, see WithQueryHook.
+	queryHooks []QueryHook
+	// The converters of the arguments of custom Go types, see WithParamConverter.
+	paramConverters []paramConverter
+	// The logger of the diagnostics of the connections, see WithLogger.
+	logger *slog.Logger
+}
+
+// defaultConflictBackoff is the default backoff before the first retry after a transaction conflict.
+const defaultConflictBackoff = 10 * time.Millisecond
+
+func (c *connectorConfig) conflictBackoffDuration() time.Duration {
+	if c == nil || c.conflictBackoff <= 0 {
+		return defaultConflictBackoff
+	}
+	return c.conflictBackoff
+}
+
+func (c *connectorConfig) timestampLocation() *time.Location {
+	if c == nil || c.timestampLoc == nil {
+		return time.UTC
+	}
+	return c.timestampLoc
+}
+
+func (c *connectorConfig) timestampTZLocation() *time.Location {
+	if c == nil || c.timestampTZLoc == nil {
+		return time.UTC
+	}
+	return c.timestampTZLoc
+}
+
+func (c *connectorConfig) dateLocation() *time.Location {
+	if c == nil || c.dateLoc == nil {
+		return time.UTC
+	}
+	return c.dateLoc
+}
+
+func newConnectorConfig(opts []ConnectorOption) (*connectorConfig, error) {
+	c := &connectorConfig{config: map[string]string{}}
+	for _, opt := range opts {
+		if opt == nil {
+			continue
+		}
+		if err := opt(c); err != nil {
+			return nil, getError(errInvalidOption, err)
+		}
+	}
+	return c, nil
+}
+
+// WithConfig sets the DuckDB configuration options, e.g., {"threads": "4"}, like the options of the DSN.
+// Unlike DSN options, the values do not have to be URL-encoded.
+func WithConfig(config map[string]string) ConnectorOption {
+	return func(c *connectorConfig) error {
+		for name, value := range config {
+			c.config[name] = value
+		}
+		return nil
+	}
+}
+
+// WithReadOnly opens the database in read-only mode, i.e., it sets the access_mode to READ_ONLY.
+// Several processes can open the same database file in read-only mode, but none can open it for writing.
+// Statements writing to the database fail with an error matching ErrReadOnly.
+// An in-memory database cannot be opened in read-only mode.
+func WithReadOnly() ConnectorOption {
+	return func(c *connectorConfig) error {
+		c.config["access_mode"] = "READ_ONLY"
+		return nil
+	}
+}
+
+// WithConnInitFn calls fn on each new connection, e.g., to set the search_path. The functions of several
+// WithConnInitFn options are called in order, after the connInitFn of NewConnector, if any.
+// A connection whose initialization fails is closed.
+func WithConnInitFn(fn func(execer driver.ExecerContext) error) ConnectorOption {
+	return func(c *connectorConfig) error {
+		if fn == nil {
+			return errors.New("nil connection init function")
+		}
+		c.connInitFns = append(c.connInitFns, fn)
+		return nil
+	}
+}
+
+// WithBootQueries executes the queries once after opening the database, e.g., to attach other databases
+// or to create macros. Connection-local settings, e.g., the search_path, must be set by WithConnInitFn instead.
+// NewConnector fails and closes the database, if a query fails.
+func WithBootQueries(queries ...string) ConnectorOption {
+	return func(c *connectorConfig) error {
+		c.bootQueries = append(c.bootQueries, queries...)
+		return nil
+	}
+}
+
+// initQueryParam is the DSN parameter of a query executed on each new connection, see WithConnInitQueries.
+// It can occur several times, e.g., ?init_query=LOAD%20httpfs&init_query=SET%20s3_region%3D%27eu-west-1%27.
+const initQueryParam = "init_query"
+
+// WithConnInitQueries executes the queries on each new connection, before database/sql uses it, e.g.,
+// "LOAD httpfs" or "SET s3_region = 'eu-west-1'". They execute after the connection init functions, and after
+// the init_query parameters of the DSN, in order. A query may contain several statements.
+// A connection whose initialization fails is closed.
+func WithConnInitQueries(queries ...string) ConnectorOption {
+	return func(c *connectorConfig) error {
+		c.connInitQueries = append(c.connInitQueries, queries...)
+		return nil
+	}
+}
+
+// dsnInitQueries removes the init_query parameters from the DSN, and returns their queries, see WithConnInitQueries.
+func dsnInitQueries(parsedDSN *url.URL) []string {
+	query := parsedDSN.Query()
+	queries, ok := query[initQueryParam]
+	if !ok {
+		return nil
+	}
+	query.Del(initQueryParam)
+	parsedDSN.RawQuery = query.Encode()
+	return queries
+}
+
+// WithCheckpointThreshold sets the WAL size at which DuckDB automatically checkpoints the database,
+// e.g., "16MB" or "1GiB". DuckDB syncs the WAL to disk on every commit, and it does not expose
+// a setting to disable this. Thus, committed transactions are durable regardless of this threshold.
+// A higher threshold trades faster bulk writes for a larger WAL,
+// longer recovery after a crash, and more data being rewritten when the checkpoint eventually runs.
+// A lower threshold keeps the WAL short at the cost of more frequent checkpoints.
+func WithCheckpointThreshold(size string) ConnectorOption {
+	return withByteSizeConfig("checkpoint_threshold", size)
+}
+
+// WithWALAutocheckpoint is an alias of WithCheckpointThreshold, named after DuckDB's
+// wal_autocheckpoint setting. The same durability tradeoffs apply. Both options set checkpoint_threshold,
+// so that the last of them applies.
+func WithWALAutocheckpoint(size string) ConnectorOption {
+	return WithCheckpointThreshold(size)
+}
+
+// WithCheckpointOnClose checkpoints the database, when closing the connector, see Checkpoint.
+// DuckDB also checkpoints when closing the database, but ignores a failing checkpoint, whereas
+// Connector.Close returns its error, after closing the database nonetheless.
+func WithCheckpointOnClose() ConnectorOption {
+	return func(c *connectorConfig) error {
+		c.checkpointOnClose = true
+		return nil
+	}
+}
+
+// WithThreads sets the total number of threads used by DuckDB, which defaults to the number of CPU cores.
+// In containers, DuckDB may detect the cores of the host instead of the CPU limit of the container.
+// Use ThreadInfo to verify the effective number of threads.
+func WithThreads(threads int) ConnectorOption {
+	return func(c *connectorConfig) error {
+		if threads < 1 {
+			return fmt.Errorf("invalid number of threads: %d", threads)
+		}
+		c.config["threads"] = strconv.Itoa(threads)
+		return nil
+	}
+}
+
+// WithExternalThreads sets how many of the threads of WithThreads are not part of DuckDB's thread pool, which
+// defaults to 1, i.e., the thread of the caller of a query. The goroutines running a TaskExecutor take the place
+// of the other external threads. For example, WithThreads(4) and WithExternalThreads(4) start no threads in DuckDB,
+// so that only the callers of queries, and the goroutines running task executors, execute the queries.
+func WithExternalThreads(threads int) ConnectorOption {
+	return func(c *connectorConfig) error {
+		if threads < 1 {
+			return fmt.Errorf("invalid number of external threads: %d", threads)
+		}
+		c.config["external_threads"] = strconv.Itoa(threads)
+		return nil
+	}
+}
+
+// WithMemoryLimit sets the maximum memory of the database, e.g., "4GB" or "512MiB", which defaults to 80% of
+// the physical memory. Operators exceeding it spill to the temporary directory, see WithTempDirectory.
+// Like WithThreads, it helps to respect the memory limit of a container, which DuckDB may not detect.
+func WithMemoryLimit(size string) ConnectorOption {
+	return withByteSizeConfig("memory_limit", size)
+}
+
+// WithTempDirectory sets the directory, to which DuckDB spills data exceeding the memory limit.
+// It defaults to the .tmp directory next to the database file, or to none for an in-memory database.
+// An empty directory disables spilling, so that queries exceeding the memory limit fail.
+func WithTempDirectory(dir string) ConnectorOption {
+	return func(c *connectorConfig) error {
+		if dir != "" {
+			if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+				return fmt.Errorf("temp_directory: not a directory: %q", dir)
+			}
+		}
+		c.config["temp_directory"] = dir
+		return nil
+	}
+}
+
+// WithMaxTempDirectorySize sets the maximum size of the data spilled to the temporary directory, e.g., "10GB",
+// which defaults to 90% of the available disk space. It fails, if the linked DuckDB library does not support
+// the setting, see FeatureMaxTempDirectorySize.
+func WithMaxTempDirectorySize(size string) ConnectorOption {
+	return func(c *connectorConfig) error {
+		if !SupportsFeature(FeatureMaxTempDirectorySize) {
+			return fmt.Errorf("max_temp_directory_size: unsupported by DuckDB %s", LibraryVersion())
+		}
+		return withByteSizeConfig("max_temp_directory_size", size)(c)
+	}
+}
+
+// WithExplainOnError attaches the EXPLAIN output of a failed query to the Detail of the returned Error.
+// This helps to diagnose errors in complex (generated) queries. If DuckDB cannot explain the query,
+// e.g., due to a binder error or because the query has parameters, then the Detail contains the failed query instead.
+// Only single-statement queries are explained. Explaining costs an extra round trip for each failed query.
+func WithExplainOnError() ConnectorOption {
+	return func(c *connectorConfig) error {
+		c.explainOnError = true
+		return nil
+	}
+}
+
+// WithTimestampLocation sets the location of TIMESTAMP (without time zone) values, which defaults to UTC.
+// This also applies to TIMESTAMP_S, TIMESTAMP_MS, and TIMESTAMP_NS values.
+// A TIMESTAMP stores a wall clock time without any time zone. Scanning a TIMESTAMP returns a time.Time with
+// this wall clock time in loc, i.e., the location is attached without shifting the stored time.
+// For example, with loc set to Europe/Berlin, '2024-01-01 12:00:00' scans as 12:00 CET, which is 11:00 UTC.
+// Conversely, binding a time.Time to a TIMESTAMP parameter stores its wall clock time in loc.
+//
+// This differs from a TIMESTAMPTZ, which stores an instant. TIMESTAMPTZ values are not affected by this option,
+// see WithTimestampTZLocation.
+func WithTimestampLocation(loc *time.Location) ConnectorOption {
+	return func(c *connectorConfig) error {
+		if loc == nil {
+			return errors.New("nil timestamp location")
+		}
+		c.timestampLoc = loc
+		return nil
+	}
+}
+
+// WithTimestampTZLocation sets the location of scanned TIMESTAMPTZ values, which defaults to UTC.
+// A TIMESTAMPTZ stores an instant, so the location does not change the scanned instant, but only its
+// presentation, e.g., '2024-01-01 12:00:00+00' scans as 13:00 CET with loc set to Europe/Berlin.
+// Binding a time.Time to a TIMESTAMPTZ parameter always stores its instant.
+func WithTimestampTZLocation(loc *time.Location) ConnectorOption {
+	return func(c *connectorConfig) error {
+		if loc == nil {
+			return errors.New("nil timestamp with time zone location")
+		}
+		c.timestampTZLoc = loc
+		return nil
+	}
+}
+
+// WithTimeLocation sets the location of scanned DATE and TIMESTAMP values, which defaults to UTC, i.e.,
+// the location of TIMESTAMP values, see WithTimestampLocation, of TIMESTAMPTZ values, see WithTimestampTZLocation,
+// and of DATE values, which scan as a time.Time at the start of their day in loc. It does not affect TIME values,
+// which scan in UTC, and TIMETZ values, which scan in their offset.
+// Conversely, binding a time.Time to a DATE parameter stores its date in loc.
+// An application working in a single time zone sets it once with this option.
+// Later WithTimestampLocation and WithTimestampTZLocation options override the location of their types.
+func WithTimeLocation(loc *time.Location) ConnectorOption {
+	return func(c *connectorConfig) error {
+		if loc == nil {
+			return errors.New("nil time location")
+		}
+		c.timestampLoc = loc
+		c.timestampTZLoc = loc
+		c.dateLoc = loc
+		return nil
+	}
+}
+
+// WithCivilDates scans DATE values as a Date, instead of as a time.Time at the start of their day.
+// A DATE has no time zone, so a Date avoids attaching a time of day and a location to it.
+func WithCivilDates() ConnectorOption {
+	return func(c *connectorConfig) error {
+		c.civilDates = true
+		return nil
+	}
+}
+
+// WithInfinityMode sets how the infinite DATE and TIMESTAMP values 'infinity' and '-infinity', and the NaN and
+// infinite FLOAT and DOUBLE values scan, which defaults to InfinityAsSentinel. Otherwise, an infinite timestamp
+// would scan as an arbitrary time.Time far in the future or past. The mode applies to nested values, too.
+func WithInfinityMode(mode InfinityMode) ConnectorOption {
+	return func(c *connectorConfig) error {
+		if mode < InfinityAsSentinel || mode > InfinityAsError {
+			return fmt.Errorf("invalid infinity mode: %d", mode)
+		}
+		c.infinity = mode
+		return nil
+	}
+}
+
+// WithStreamingResults streams the results of queries chunk by chunk, instead of materializing them
+// before QueryContext returns. Thus, the first rows are available before the query finishes, and only
+// the current chunk is held in memory. Closing the rows before reading all of them interrupts the query,
+// so an abandoned query stops without computing the rest of its result.
+//
+// The connection is busy until the rows are closed. Errors occurring during the execution of a streaming
+// query surface when fetching the rows, i.e., from Next instead of QueryContext.
+// Cancelling the context of a streaming query interrupts it during any fetch of a chunk, i.e., before its first
+// chunk is ready, and while Next fetches a later chunk, which then fails with the error of the context.
+//
+// WithStreamingResults sets the default of all queries. A query can override it with ContextWithFetchMode.
+func WithStreamingResults() ConnectorOption {
+	return func(c *connectorConfig) error {
+		c.streamingResults = true
+		return nil
+	}
+}
+
+// WithConflictRetry retries a statement up to maxRetries times, if it fails due to a conflict with
+// a concurrent transaction, e.g., because both update the same row. The backoff before the first retry
+// defaults to 10ms, if zero, and doubles with each retry.
+//
+// Only single statements executed outside of a transaction (BeginTx) retry, as DuckDB auto-commits them
+// and rolls them back as a whole on a conflict. The statements of a multi-statement query, e.g.,
+// "UPDATE ...; UPDATE ...", never retry, as the statements before the conflict have already been committed.
+// The failed attempt has no effect, but the retry observes the changes of the conflicting transaction.
+// Statements executing BEGIN TRANSACTION themselves must not be combined with this option.
+// Statements with an io.Reader argument never retry, as the first attempt consumes the reader.
+func WithConflictRetry(maxRetries int, backoff time.Duration) ConnectorOption {
+	return func(c *connectorConfig) error {
+		if maxRetries < 0 {
+			return fmt.Errorf("invalid number of conflict retries: %d", maxRetries)
+		}
+		c.conflictRetries = maxRetries
+		c.conflictBackoff = backoff
+		return nil
+	}
+}
+
+// WithStatementCache caches the prepared statements of up to size single-statement queries on each connection,
+// keyed by their query text. Executing a cached query through ExecContext, QueryContext, or the Arrow interface,
+// e.g., WriteArrowIPC, binds the arguments to its cached statement instead of preparing the query again. The least
+// recently used statement is evicted, if the cache is full. While the rows of a cached statement are open, executing
+// the same query on the connection prepares it again. Multi-statement queries and statements prepared with Prepare
+// are not cached.
+// DuckDB rebinds a cached statement, if the schema of its tables changes.
+func WithStatementCache(size int) ConnectorOption {
+	return func(c *connectorConfig) error {
+		if size < 0 {
+			return fmt.Errorf("invalid statement cache size: %d", size)
+		}
+		c.stmtCacheSize = size
+		return nil
+	}
+}
+
+// FetchMode selects whether a query materializes or streams its result.
+type FetchMode int
+
+const (
+	// FetchDefault uses the default fetch mode of the Connector, see WithStreamingResults.
+	FetchDefault FetchMode = iota
+	// FetchMaterialized computes the entire result before QueryContext returns.
+	// It has the lowest latency for small results, and keeps the connection available while reading the rows.
+	FetchMaterialized
+	// FetchStreaming computes the result chunk by chunk while reading the rows.
+	// It returns the first rows of large results sooner, and holds only the current chunk in memory.
+	FetchStreaming
+)
+
+type fetchModeKey struct{}
+
+// ContextWithFetchMode returns a copy of ctx, which sets the fetch mode of the queries executed with it.
+// For example, db.QueryContext(ContextWithFetchMode(ctx, FetchStreaming), query) streams the result of the query,
+// regardless of the default of the Connector. Statements executed with ExecContext are always materialized.
+func ContextWithFetchMode(ctx context.Context, mode FetchMode) context.Context {
+	return context.WithValue(ctx, fetchModeKey{}, mode)
+}
+
+// streamingContext returns true, if a query executed with ctx streams its result.
+func (c *connectorConfig) streamingContext(ctx context.Context) bool {
+	switch mode, _ := ctx.Value(fetchModeKey{}).(FetchMode); mode {
+	case FetchMaterialized:
+		return false
+	case FetchStreaming:
+		return true
+	default:
+		return c != nil && c.streamingResults
+	}
+}
+
+func withByteSizeConfig(name string, size string) ConnectorOption {
+	return func(c *connectorConfig) error {
+		if _, err := parseByteSize(size); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		c.config[name] = size
+		return nil
+	}
+}
+
+// byteUnits contains the size units accepted by DuckDB, e.g., in the memory_limit setting.
+var byteUnits = map[string]uint64{
+	"":      1,
+	"b":     1,
+	"byte":  1,
+	"bytes": 1,
+	"kb":    1000,
+	"mb":    1000 * 1000,
+	"gb":    1000 * 1000 * 1000,
+	"tb":    1000 * 1000 * 1000 * 1000,
+	"pb":    1000 * 1000 * 1000 * 1000 * 1000,
+	"kib":   1 << 10,
+	"mib":   1 << 20,
+	"gib":   1 << 30,
+	"tib":   1 << 40,
+	"pib":   1 << 50,
+}
+
+// parseByteSize parses a size string like "16MB" or "1.5 GiB" into its number of bytes.
+func parseByteSize(size string) (uint64, error) {
+	s := strings.TrimSpace(size)
+	idx := strings.IndexFunc(s, func(r rune) bool {
+		return (r < '0' || r > '9') && r != '.'
+	})
+	if idx < 0 {
+		idx = len(s)
+	}
+
+	number, unit := s[:idx], strings.ToLower(strings.TrimSpace(s[idx:]))
+	factor, ok := byteUnits[unit]
+	if number == "" || !ok {
+		return 0, fmt.Errorf("%s: %q", byteSizeErrMsg, size)
+	}
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %q", byteSizeErrMsg, size)
+	}
+	return uint64(value * float64(factor)), nil
+}
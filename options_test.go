@@ -0,0 +1,276 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDurabilityOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("checkpoint threshold", func(t *testing.T) {
+		connector, err := NewConnector("", nil, WithCheckpointThreshold("1GiB"))
+		require.NoError(t, err)
+		db := sql.OpenDB(connector)
+		defer db.Close()
+
+		var threshold string
+		require.NoError(t, db.QueryRow("SELECT current_setting('checkpoint_threshold')").Scan(&threshold))
+		require.Equal(t, "1.0 GiB", threshold)
+	})
+
+	t.Run("option overrides DSN", func(t *testing.T) {
+		connector, err := NewConnector("?wal_autocheckpoint=1GiB", nil, WithWALAutocheckpoint("2 GiB"))
+		require.NoError(t, err)
+		db := sql.OpenDB(connector)
+		defer db.Close()
+
+		var threshold string
+		require.NoError(t, db.QueryRow("SELECT current_setting('wal_autocheckpoint')").Scan(&threshold))
+		require.Equal(t, "2.0 GiB", threshold)
+	})
+
+	t.Run("last alias applies", func(t *testing.T) {
+		connector, err := NewConnector("", nil, WithCheckpointThreshold("1GiB"), WithWALAutocheckpoint("2GiB"),
+			WithCheckpointThreshold("3GiB"))
+		require.NoError(t, err)
+		db := sql.OpenDB(connector)
+		defer db.Close()
+
+		var threshold string
+		require.NoError(t, db.QueryRow("SELECT current_setting('checkpoint_threshold')").Scan(&threshold))
+		require.Equal(t, "3.0 GiB", threshold)
+	})
+}
+
+func TestResourceLimitOptions(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	connector, err := NewConnector("", nil, WithMemoryLimit("512MiB"), WithThreads(2), WithTempDirectory(dir))
+	require.NoError(t, err)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	var memoryLimit, tempDir string
+	var threads int64
+	require.NoError(t, db.QueryRow(`SELECT current_setting('memory_limit'), current_setting('threads'),
+		current_setting('temp_directory')`).Scan(&memoryLimit, &threads, &tempDir))
+	require.Equal(t, "512.0 MiB", memoryLimit)
+	require.Equal(t, int64(2), threads)
+	require.Equal(t, dir, tempDir)
+
+	_, err = NewConnector("", nil, WithMemoryLimit("lots"))
+	testError(t, err, errInvalidOption.Error(), "memory_limit")
+
+	if SupportsFeature(FeatureMaxTempDirectorySize) {
+		connector, err = NewConnector("", nil, WithMaxTempDirectorySize("2GiB"))
+		require.NoError(t, err)
+		require.NoError(t, connector.Close())
+		_, err = NewConnector("", nil, WithMaxTempDirectorySize("-1GB"))
+	} else {
+		_, err = NewConnector("", nil, WithMaxTempDirectorySize("2GiB"))
+	}
+	testError(t, err, errInvalidOption.Error(), "max_temp_directory_size")
+
+	file := filepath.Join(dir, "file")
+	require.NoError(t, os.WriteFile(file, nil, 0o600))
+	_, err = NewConnector("", nil, WithTempDirectory(file))
+	testError(t, err, errInvalidOption.Error(), "not a directory")
+}
+
+func TestConfigOptions(t *testing.T) {
+	t.Parallel()
+
+	t.Run("config", func(t *testing.T) {
+		connector, err := NewConnector("?threads=2", nil, WithConfig(map[string]string{
+			"threads":      "3",
+			"null_order":   "nulls_first",
+			"memory_limit": "1GB",
+		}))
+		require.NoError(t, err)
+		db := sql.OpenDB(connector)
+		defer db.Close()
+
+		var threads int64
+		var nullOrder string
+		require.NoError(t, db.QueryRow(`SELECT current_setting('threads'), current_setting('null_order')`).
+			Scan(&threads, &nullOrder))
+		require.Equal(t, int64(3), threads)
+		require.Equal(t, "nulls_first", nullOrder)
+	})
+
+	t.Run("read-only and boot queries", func(t *testing.T) {
+		dir := t.TempDir()
+		path := filepath.Join(dir, "test.db")
+		connector, err := NewConnector(path, nil, WithBootQueries(
+			`CREATE TABLE tbl (i INTEGER)`,
+			`INSERT INTO tbl VALUES (42)`,
+		))
+		require.NoError(t, err)
+		require.NoError(t, connector.Close())
+
+		other := filepath.Join(dir, "other.db")
+		connector, err = NewConnector(other, nil, WithBootQueries(`CREATE TABLE t (j INTEGER)`))
+		require.NoError(t, err)
+		require.NoError(t, connector.Close())
+
+		connector, err = NewConnector(path, nil, WithReadOnly(),
+			WithBootQueries(`ATTACH `+quoteString(other)+` AS other (READ_ONLY)`))
+		require.NoError(t, err)
+		db := sql.OpenDB(connector)
+		defer db.Close()
+
+		var i int32
+		require.NoError(t, db.QueryRow(`SELECT i FROM tbl`).Scan(&i))
+		require.Equal(t, int32(42), i)
+		var count int64
+		require.NoError(t, db.QueryRow(`SELECT count(*) FROM other.t`).Scan(&count))
+		require.Zero(t, count)
+
+		for _, query := range []string{`INSERT INTO tbl VALUES (1)`, `CREATE TABLE t2 (i INTEGER)`, `DROP TABLE tbl`} {
+			_, err = db.Exec(query)
+			require.ErrorIs(t, err, ErrReadOnly, query)
+			var duckdbErr *Error
+			require.ErrorAs(t, err, &duckdbErr)
+		}
+
+		// Temporary tables remain writable.
+		_, err = db.Exec(`CREATE TEMP TABLE tmp (i INTEGER); INSERT INTO tmp VALUES (1)`)
+		require.NoError(t, err)
+		_, err = db.Exec(`SELECT * FROM missing`)
+		require.NotErrorIs(t, err, ErrReadOnly)
+
+		// Other connectors can open the same file in read-only mode.
+		second, err := NewConnector(path, nil, WithReadOnly())
+		require.NoError(t, err)
+		otherDB := sql.OpenDB(second)
+		defer otherDB.Close()
+		require.NoError(t, otherDB.QueryRow(`SELECT i FROM tbl`).Scan(&i))
+	})
+
+	t.Run("failing boot query", func(t *testing.T) {
+		_, err := NewConnector("", nil, WithBootQueries(`SELECT * FROM missing`))
+		testError(t, err, errBootQuery.Error(), "missing")
+	})
+
+	t.Run("connection init functions", func(t *testing.T) {
+		var calls []string
+		initFn := func(name string) func(execer driver.ExecerContext) error {
+			return func(execer driver.ExecerContext) error {
+				calls = append(calls, name)
+				_, err := execer.ExecContext(context.Background(), `SET perfect_ht_threshold = 13`, nil)
+				return err
+			}
+		}
+		connector, err := NewConnector("", initFn("param"), WithConnInitFn(initFn("first")), WithConnInitFn(initFn("second")))
+		require.NoError(t, err)
+		db := sql.OpenDB(connector)
+		defer db.Close()
+
+		var threshold int64
+		require.NoError(t, db.QueryRow(`SELECT current_setting('perfect_ht_threshold')`).Scan(&threshold))
+		require.Equal(t, int64(13), threshold)
+		require.Equal(t, []string{"param", "first", "second"}, calls)
+
+		connector, err = NewConnector("", nil, WithConnInitFn(func(driver.ExecerContext) error {
+			return errors.New("init failed")
+		}))
+		require.NoError(t, err)
+		defer connector.Close()
+		_, err = connector.Connect(context.Background())
+		require.EqualError(t, err, "init failed")
+
+		_, err = NewConnector("", nil, WithConnInitFn(nil))
+		testError(t, err, errInvalidOption.Error(), "nil connection init function")
+	})
+
+	t.Run("connection init queries", func(t *testing.T) {
+		dsn := "?threads=2&init_query=" + url.QueryEscape(`SET perfect_ht_threshold = 13`)
+		connector, err := NewConnector(dsn, nil, WithConnInitQueries(
+			`SET perfect_ht_threshold = 14; CREATE TEMP TABLE init_tbl (i INTEGER)`,
+			`INSERT INTO init_tbl VALUES (current_setting('perfect_ht_threshold'))`,
+		))
+		require.NoError(t, err)
+		db := sql.OpenDB(connector)
+		defer db.Close()
+		db.SetMaxOpenConns(2)
+
+		// Each connection executes the queries in order, after the queries of the DSN.
+		ctx := context.Background()
+		for i := 0; i < 2; i++ {
+			con, err := db.Conn(ctx)
+			require.NoError(t, err)
+			defer con.Close()
+			var threads, threshold, value int64
+			require.NoError(t, con.QueryRowContext(ctx, `SELECT current_setting('threads'),
+				current_setting('perfect_ht_threshold'), (SELECT i FROM init_tbl)`).Scan(&threads, &threshold, &value))
+			require.Equal(t, int64(2), threads)
+			require.Equal(t, int64(14), threshold)
+			require.Equal(t, int64(14), value)
+		}
+
+		connector, err = NewConnector("", nil, WithConnInitQueries(`SELECT * FROM missing`))
+		require.NoError(t, err)
+		defer connector.Close()
+		_, err = connector.Connect(context.Background())
+		testError(t, err, errConnInitQuery.Error(), "missing")
+	})
+}
+
+func TestParseByteSize(t *testing.T) {
+	t.Parallel()
+
+	valid := map[string]uint64{
+		"1024":     1024,
+		"16MB":     16 * 1000 * 1000,
+		"1.5 GiB":  3 << 29,
+		"10 bytes": 10,
+		" 2kib ":   2048,
+	}
+	for size, expected := range valid {
+		actual, err := parseByteSize(size)
+		require.NoError(t, err, size)
+		require.Equal(t, expected, actual, size)
+	}
+
+	for _, size := range []string{"", "GB", "16 MBs", "1.2.3 KB", "-1GB"} {
+		_, err := parseByteSize(size)
+		require.ErrorContains(t, err, byteSizeErrMsg, size)
+	}
+}
+
+func TestConfigFlags(t *testing.T) {
+	t.Parallel()
+
+	flags := ConfigFlags()
+	require.NotEmpty(t, flags)
+	names := make(map[string]string)
+	for i, flag := range flags {
+		require.NotEmpty(t, flag.Name)
+		if i > 0 {
+			require.Less(t, flags[i-1].Name, flag.Name)
+		}
+		names[flag.Name] = flag.Description
+	}
+	require.Contains(t, names, "threads")
+	require.Contains(t, names, "access_mode")
+	require.NotEmpty(t, names["memory_limit"])
+
+	// The returned slice is a copy.
+	flags[0].Name = "changed"
+	require.NotEqual(t, "changed", ConfigFlags()[0].Name)
+
+	require.True(t, isConfigFlag("THREADS"))
+	require.False(t, isConfigFlag("thread"))
+	require.Equal(t, 1, editDistance("thread", "threads"))
+	require.Equal(t, 3, editDistance("kitten", "sitting"))
+}
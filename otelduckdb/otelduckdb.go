@@ -0,0 +1,133 @@
+// Package otelduckdb creates OpenTelemetry spans for the queries of a DuckDB Connector.
+// It is a separate module, so that the driver does not depend on OpenTelemetry.
+package otelduckdb
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"github.com/marcboeker/go-duckdb"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName is the name of the tracer of the spans.
+const instrumentationName = "github.com/marcboeker/go-duckdb/otelduckdb"
+
+// The attributes of the spans, in addition to the semantic conventions of database clients.
+const (
+	// RowsKey is the number of affected rows of ExecContext, or of rows of a materialized result of QueryContext.
+	RowsKey = attribute.Key("duckdb.rows")
+	// ChunksKey is the number of chunks of a materialized result of QueryContext.
+	ChunksKey = attribute.Key("duckdb.chunks")
+	// StreamingKey is set, if QueryContext returned a streaming result.
+	StreamingKey = attribute.Key("duckdb.streaming")
+	// InterruptedKey is set, if the query was interrupted, because its context was canceled or timed out.
+	InterruptedKey = attribute.Key("duckdb.interrupted")
+	// ConnectionIDKey identifies the connection executing the query among all connections of the Connector.
+	ConnectionIDKey = attribute.Key("duckdb.connection_id")
+	// ErrorTypeKey is the type of a DuckDB error, see duckdb.ErrorType.
+	ErrorTypeKey = attribute.Key("duckdb.error_type")
+)
+
+// Option configures the spans of WithTracing.
+type Option func(c *config)
+
+type config struct {
+	tracerProvider trace.TracerProvider
+	omitQuery      bool
+}
+
+// WithTracerProvider sets the provider of the tracer. It defaults to the global provider of otel.
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(c *config) {
+		c.tracerProvider = provider
+	}
+}
+
+// WithoutQuery omits the query text from the spans, e.g., if queries contain sensitive literals.
+func WithoutQuery() Option {
+	return func(c *config) {
+		c.omitQuery = true
+	}
+}
+
+// WithTracing creates a span for each query executed with ExecContext or QueryContext,
+// and for each query prepared with Prepare, on the connections of the Connector.
+// The span of a query is a child of the span of its context. The span of a prepared query has no parent,
+// as database/sql does not pass the context of PrepareContext to the driver.
+func WithTracing(opts ...Option) duckdb.ConnectorOption {
+	return duckdb.WithQueryHook(NewQueryHook(opts...))
+}
+
+// NewQueryHook returns the duckdb.QueryHook of WithTracing.
+func NewQueryHook(opts ...Option) duckdb.QueryHook {
+	c := config{tracerProvider: otel.GetTracerProvider()}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return &hook{config: c, tracer: c.tracerProvider.Tracer(instrumentationName)}
+}
+
+type hook struct {
+	config config
+	tracer trace.Tracer
+	// spans are the spans of the queries in flight, by the ID of their connection.
+	// A connection executes its queries one after another, and the hooks are called by the executing goroutine.
+	spans sync.Map
+}
+
+var spanNames = map[duckdb.QueryKind]string{
+	duckdb.QueryKindExec:    "duckdb.exec",
+	duckdb.QueryKindQuery:   "duckdb.query",
+	duckdb.QueryKindPrepare: "duckdb.prepare",
+}
+
+func (h *hook) OnQueryStart(ctx context.Context, event duckdb.QueryEvent) {
+	attrs := []attribute.KeyValue{
+		semconv.DBSystemKey.String("duckdb"),
+		ConnectionIDKey.Int64(int64(event.ConnectionID)),
+	}
+	if !h.config.omitQuery {
+		attrs = append(attrs, semconv.DBStatement(event.Query))
+	}
+	_, span := h.tracer.Start(ctx, spanNames[event.Kind], trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithTimestamp(event.Started), trace.WithAttributes(attrs...))
+	h.spans.Store(event.ConnectionID, span)
+}
+
+func (h *hook) OnQueryEnd(_ context.Context, event duckdb.QueryEvent) {
+	value, ok := h.spans.LoadAndDelete(event.ConnectionID)
+	if !ok {
+		return
+	}
+	span := value.(trace.Span)
+	defer span.End(trace.WithTimestamp(event.Started.Add(event.Duration)))
+
+	if event.Err != nil {
+		span.RecordError(event.Err)
+		span.SetStatus(codes.Error, event.Err.Error())
+		interrupted := errors.Is(event.Err, context.Canceled) || errors.Is(event.Err, context.DeadlineExceeded)
+		span.SetAttributes(InterruptedKey.Bool(interrupted))
+		var duckdbErr *duckdb.Error
+		if errors.As(event.Err, &duckdbErr) {
+			span.SetAttributes(ErrorTypeKey.Int(int(duckdbErr.Type)))
+		}
+		return
+	}
+
+	switch event.Kind {
+	case duckdb.QueryKindExec:
+		span.SetAttributes(RowsKey.Int64(event.Rows))
+	case duckdb.QueryKindQuery:
+		streaming := event.Rows < 0
+		span.SetAttributes(StreamingKey.Bool(streaming))
+		if !streaming {
+			span.SetAttributes(RowsKey.Int64(event.Rows), ChunksKey.Int64(event.Chunks))
+		}
+	}
+}
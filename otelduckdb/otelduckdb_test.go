@@ -0,0 +1,112 @@
+package otelduckdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/marcboeker/go-duckdb"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+)
+
+func TestWithTracing(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	connector, err := duckdb.NewConnector("", nil, WithTracing(WithTracerProvider(provider)))
+	require.NoError(t, err)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	// lastSpan returns the attributes of the last ended span.
+	lastSpan := func(t *testing.T) (sdktrace.ReadOnlySpan, map[attribute.Key]attribute.Value) {
+		spans := recorder.Ended()
+		require.NotEmpty(t, spans)
+		span := spans[len(spans)-1]
+		attrs := make(map[attribute.Key]attribute.Value)
+		for _, attr := range span.Attributes() {
+			attrs[attr.Key] = attr.Value
+		}
+		return span, attrs
+	}
+
+	ctx := context.Background()
+	parentCtx, parent := provider.Tracer("test").Start(ctx, "parent")
+	_, err = db.ExecContext(parentCtx, `CREATE TABLE otel_tbl AS SELECT * FROM range(3000) t(i)`)
+	require.NoError(t, err)
+	parent.End()
+
+	// The parent span ends after the span of the query.
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	span := spans[0]
+	require.Equal(t, "duckdb.exec", span.Name())
+	require.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	attrs := make(map[attribute.Key]attribute.Value)
+	for _, attr := range span.Attributes() {
+		attrs[attr.Key] = attr.Value
+	}
+	require.Equal(t, "duckdb", attrs[semconv.DBSystemKey].AsString())
+	require.Equal(t, `CREATE TABLE otel_tbl AS SELECT * FROM range(3000) t(i)`, attrs[semconv.DBStatementKey].AsString())
+	require.Equal(t, int64(3000), attrs[RowsKey].AsInt64())
+
+	rows, err := db.QueryContext(ctx, `SELECT * FROM otel_tbl`)
+	require.NoError(t, err)
+	require.NoError(t, rows.Close())
+	span, attrs = lastSpan(t)
+	require.Equal(t, "duckdb.query", span.Name())
+	require.False(t, attrs[StreamingKey].AsBool())
+	require.Equal(t, int64(3000), attrs[RowsKey].AsInt64())
+	require.Equal(t, int64(2), attrs[ChunksKey].AsInt64())
+
+	rows, err = db.QueryContext(duckdb.ContextWithFetchMode(ctx, duckdb.FetchStreaming), `SELECT * FROM otel_tbl`)
+	require.NoError(t, err)
+	require.NoError(t, rows.Close())
+	_, attrs = lastSpan(t)
+	require.True(t, attrs[StreamingKey].AsBool())
+	require.NotContains(t, attrs, RowsKey)
+
+	_, err = db.ExecContext(ctx, `SELECT * FROM missing`)
+	require.Error(t, err)
+	span, attrs = lastSpan(t)
+	require.Equal(t, codes.Error, span.Status().Code)
+	require.False(t, attrs[InterruptedKey].AsBool())
+	require.Equal(t, int64(duckdb.ErrorTypeCatalog), attrs[ErrorTypeKey].AsInt64())
+
+	timeoutCtx, cancel := context.WithTimeout(ctx, 100*time.Millisecond)
+	defer cancel()
+	_, err = db.ExecContext(timeoutCtx, `SELECT count(*) FROM range(100000000) t1, range(1000000) t2`)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	_, attrs = lastSpan(t)
+	require.True(t, attrs[InterruptedKey].AsBool())
+
+	stmt, err := db.PrepareContext(ctx, `SELECT * FROM otel_tbl WHERE i = ?`)
+	require.NoError(t, err)
+	defer stmt.Close()
+	span, _ = lastSpan(t)
+	require.Equal(t, "duckdb.prepare", span.Name())
+}
+
+func TestWithoutQuery(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder))
+
+	connector, err := duckdb.NewConnector("", nil, WithTracing(WithTracerProvider(provider), WithoutQuery()))
+	require.NoError(t, err)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	_, err = db.Exec(`SELECT 'secret'`)
+	require.NoError(t, err)
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	for _, attr := range spans[0].Attributes() {
+		require.NotEqual(t, semconv.DBStatementKey, attr.Key)
+	}
+}
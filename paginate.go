@@ -0,0 +1,201 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+// Queryer executes queries. It is implemented by *sql.DB, *sql.Conn, and *sql.Tx.
+type Queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+// OrderKey is a column of the ORDER BY key of a keyset pagination.
+type OrderKey struct {
+	// Column is the (unquoted) name of a column of the base query.
+	Column string
+	// Desc orders the column in descending order.
+	Desc bool
+}
+
+// Keyset describes a keyset (or seek) pagination over the result of a base query.
+//
+// The ORDER BY key must be stable and unique: the combination of all key columns must identify each row,
+// otherwise rows with the same key can be skipped or repeated at the boundary of a page.
+// Add a unique column, e.g., the primary key, as the last key column to break ties.
+// NULL values are ordered last, regardless of the direction of the column.
+type Keyset struct {
+	// Query is the base query. It must use ? placeholders for its parameters,
+	// and it must not rely on its own ORDER BY or LIMIT clause, as the pagination wraps it into a subquery.
+	Query string
+	// Keys is the ORDER BY key of the pagination.
+	Keys []OrderKey
+	// Limit is the maximum number of rows of a page.
+	Limit int
+	// Scanner matches the result columns to the fields of the scanned structs.
+	Scanner StructScanner
+}
+
+// Cursor contains the key values of the last row of a page.
+// A nil Cursor refers to the first page.
+type Cursor []any
+
+// SQL returns the parameterized query, and its arguments, which fetches the page after the cursor.
+// The arguments of the base query must be passed as args.
+func (k Keyset) SQL(cursor Cursor, args ...any) (string, []any, error) {
+	if len(k.Keys) == 0 || k.Limit <= 0 {
+		return "", nil, getError(errPaginate, fmt.Errorf("the keyset requires at least one key and a positive limit"))
+	}
+	if cursor != nil && len(cursor) != len(k.Keys) {
+		return "", nil, getError(errPaginate, columnCountError(len(cursor), len(k.Keys)))
+	}
+
+	var b strings.Builder
+	b.WriteString("SELECT * FROM (")
+	b.WriteString(k.Query)
+	b.WriteString(") AS page")
+
+	queryArgs := append([]any{}, args...)
+	if cursor != nil {
+		cond, condArgs, err := k.seekCondition(cursor)
+		if err != nil {
+			return "", nil, getError(errPaginate, err)
+		}
+		b.WriteString(" WHERE ")
+		b.WriteString(cond)
+		queryArgs = append(queryArgs, condArgs...)
+	}
+
+	b.WriteString(" ORDER BY ")
+	for i, key := range k.Keys {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(quoteIdentifier(key.Column))
+		if key.Desc {
+			b.WriteString(" DESC NULLS LAST")
+		} else {
+			b.WriteString(" ASC NULLS LAST")
+		}
+	}
+
+	// Fetch one additional row to know whether there is a next page.
+	b.WriteString(" LIMIT ?")
+	queryArgs = append(queryArgs, k.Limit+1)
+
+	return b.String(), queryArgs, nil
+}
+
+// seekCondition returns the condition selecting all rows after the cursor.
+// For the ascending keys (a, b) without NULL values, the condition is: a > ? OR (a = ? AND b > ?).
+func (k Keyset) seekCondition(cursor Cursor) (string, []any, error) {
+	var disjuncts []string
+	var args []any
+
+	var prefix []string
+	var prefixArgs []any
+	for i, key := range k.Keys {
+		value, err := driver.DefaultParameterConverter.ConvertValue(cursor[i])
+		if err != nil {
+			value = cursor[i]
+		}
+		column := quoteIdentifier(key.Column)
+
+		// NULL values are last, so there are no rows after a NULL value, except with the same NULL value.
+		if value != nil {
+			op := ">"
+			if key.Desc {
+				op = "<"
+			}
+			after := fmt.Sprintf("(%s %s ? OR %s IS NULL)", column, op, column)
+			disjuncts = append(disjuncts, strings.Join(append(append([]string{}, prefix...), after), " AND "))
+			args = append(append(args, prefixArgs...), value)
+		}
+
+		if value == nil {
+			prefix = append(prefix, column+" IS NULL")
+		} else {
+			prefix = append(prefix, column+" = ?")
+			prefixArgs = append(prefixArgs, value)
+		}
+	}
+
+	if len(disjuncts) == 0 {
+		// The cursor consists of NULL values only, i.e., it is the last row.
+		return "FALSE", nil, nil
+	}
+	return "((" + strings.Join(disjuncts, ") OR (") + "))", args, nil
+}
+
+// Paginate fetches the page after the cursor, and scans its rows into structs of type T.
+// It returns the cursor of the next page, or nil, if this is the last page.
+// The key columns must be part of the result, and match fields of T.
+func Paginate[T any](ctx context.Context, db Queryer, keyset Keyset, cursor Cursor, args ...any) ([]T, Cursor, error) {
+	query, queryArgs, err := keyset.SQL(cursor, args...)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, query, queryArgs...)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var dummy T
+	t := reflect.TypeOf(dummy)
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, nil, getError(errPaginate, castError(fmt.Sprintf("%T", dummy), reflect.Struct.String()))
+	}
+
+	// Find the fields containing the key values.
+	keyIndexes, err := keyset.Scanner.fieldIndexes(t, keyColumns(keyset.Keys))
+	if err != nil {
+		return nil, nil, getError(errPaginate, err)
+	}
+	if _, err = keyset.Scanner.fieldIndexes(t, columns); err != nil {
+		return nil, nil, getError(errPaginate, err)
+	}
+
+	page := make([]T, 0, keyset.Limit)
+	var next Cursor
+	for rows.Next() {
+		var row T
+		if err = keyset.Scanner.Scan(rows, &row); err != nil {
+			return nil, nil, err
+		}
+
+		if len(page) == keyset.Limit {
+			// This is the first row of the next page, so the last row of this page is the next cursor.
+			last := reflect.ValueOf(&page[len(page)-1]).Elem()
+			next = make(Cursor, len(keyIndexes))
+			for i, index := range keyIndexes {
+				next[i] = last.FieldByIndex(index).Interface()
+			}
+			break
+		}
+		page = append(page, row)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, nil, err
+	}
+
+	return page, next, nil
+}
+
+func keyColumns(keys []OrderKey) []string {
+	columns := make([]string, len(keys))
+	for i, key := range keys {
+		columns[i] = key.Column
+	}
+	return columns
+}
@@ -0,0 +1,112 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type pageItem struct {
+	ID       int32
+	Category sql.NullString
+	Score    float64
+}
+
+func TestPaginate(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE items AS SELECT i::INTEGER AS id,
+		CASE WHEN i % 4 = 0 THEN NULL ELSE 'c' || (i % 3) END AS category, (i % 5)::DOUBLE AS score
+		FROM range(50) t(i)`)
+	require.NoError(t, err)
+
+	paginateAll := func(t *testing.T, keyset Keyset, args ...any) []pageItem {
+		var all []pageItem
+		var cursor Cursor
+		for pages := 0; ; pages++ {
+			page, next, err := Paginate[pageItem](context.Background(), db, keyset, cursor, args...)
+			require.NoError(t, err)
+			require.LessOrEqual(t, len(page), keyset.Limit)
+			all = append(all, page...)
+			if next == nil {
+				return all
+			}
+			require.Len(t, page, keyset.Limit)
+			require.Less(t, pages, 100)
+			cursor = next
+		}
+	}
+
+	queryAll := func(t *testing.T, query string, args ...any) []pageItem {
+		rows, err := db.Query(query, args...)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var all []pageItem
+		for rows.Next() {
+			var item pageItem
+			require.NoError(t, ScanStruct(rows, &item))
+			all = append(all, item)
+		}
+		require.NoError(t, rows.Err())
+		return all
+	}
+
+	t.Run("single key", func(t *testing.T) {
+		keyset := Keyset{Query: `SELECT * FROM items`, Keys: []OrderKey{{Column: "id"}}, Limit: 7}
+		expected := queryAll(t, `SELECT * FROM items ORDER BY id`)
+		require.Equal(t, expected, paginateAll(t, keyset))
+	})
+
+	t.Run("composite key with NULL values", func(t *testing.T) {
+		for _, desc := range []bool{false, true} {
+			keyset := Keyset{
+				Query: `SELECT * FROM items WHERE score < ?`,
+				Keys:  []OrderKey{{Column: "category", Desc: desc}, {Column: "score"}, {Column: "id", Desc: desc}},
+				Limit: 4,
+			}
+			order := "ORDER BY category ASC NULLS LAST, score, id ASC"
+			if desc {
+				order = "ORDER BY category DESC NULLS LAST, score, id DESC"
+			}
+			expected := queryAll(t, `SELECT * FROM items WHERE score < ? `+order, 4)
+			require.Len(t, expected, 40)
+			require.Equal(t, expected, paginateAll(t, keyset, 4))
+		}
+	})
+
+	t.Run("exact page boundary", func(t *testing.T) {
+		keyset := Keyset{Query: `SELECT * FROM items`, Keys: []OrderKey{{Column: "id"}}, Limit: 50}
+		page, next, err := Paginate[pageItem](context.Background(), db, keyset, nil)
+		require.NoError(t, err)
+		require.Len(t, page, 50)
+		require.Nil(t, next)
+	})
+
+	t.Run("SQL", func(t *testing.T) {
+		keyset := Keyset{Query: `SELECT * FROM items`, Keys: []OrderKey{{Column: "a"}, {Column: "b", Desc: true}}, Limit: 10}
+		query, args, err := keyset.SQL(Cursor{1, nil})
+		require.NoError(t, err)
+		require.Equal(t, `SELECT * FROM (SELECT * FROM items) AS page WHERE ((("a" > ? OR "a" IS NULL))) `+
+			`ORDER BY "a" ASC NULLS LAST, "b" DESC NULLS LAST LIMIT ?`, query)
+		require.Equal(t, []any{int64(1), 11}, args)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, _, err := Keyset{Query: `SELECT * FROM items`, Limit: 10}.SQL(nil)
+		testError(t, err, errPaginate.Error())
+
+		keyset := Keyset{Query: `SELECT * FROM items`, Keys: []OrderKey{{Column: "id"}}, Limit: 10}
+		_, _, err = keyset.SQL(Cursor{1, 2})
+		testError(t, err, errPaginate.Error(), columnCountErrMsg)
+
+		_, _, err = Paginate[pageItem](context.Background(), db, Keyset{
+			Query: `SELECT id AS missing FROM items`, Keys: []OrderKey{{Column: "missing"}}, Limit: 10,
+		}, nil)
+		testError(t, err, errPaginate.Error(), missingFieldErrMsg)
+	})
+}
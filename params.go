@@ -0,0 +1,57 @@
+package duckdb
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// paramConverter converts the arguments of a Go type, see WithParamConverter.
+type paramConverter struct {
+	// typ is the type of the converted arguments. An interface type converts all arguments implementing it.
+	typ     reflect.Type
+	convert func(v any) (any, error)
+}
+
+// WithParamConverter teaches the connections of the Connector to bind arguments of the Go type T, e.g., a domain type
+// like a custom ID, enum, or geometry type, which the driver does not know. Each argument of type T is replaced
+// by the value fn returns, which binds like any other argument, e.g., an int64, a string, a Decimal, or a TypedParam.
+// If T is an interface type, fn converts all arguments implementing it. A converter takes precedence over the
+// driver's own conversion of its type, including driver.Valuer. If several converters match an argument,
+// the first registered one converts it. The converters do not convert the returned value again, except the Value
+// of a returned TypedParam.
+// An error of fn fails the query with an error wrapping it.
+func WithParamConverter[T any](fn func(v T) (any, error)) ConnectorOption {
+	return func(c *connectorConfig) error {
+		if fn == nil {
+			return errors.New("nil parameter converter")
+		}
+		c.paramConverters = append(c.paramConverters, paramConverter{
+			typ: reflect.TypeOf((*T)(nil)).Elem(),
+			convert: func(v any) (any, error) {
+				return fn(v.(T))
+			},
+		})
+		return nil
+	}
+}
+
+// convertParam returns the value of the first converter of WithParamConverter matching the argument v,
+// or v, if no converter matches it.
+func (c *connectorConfig) convertParam(v any) (any, error) {
+	if c == nil || len(c.paramConverters) == 0 || v == nil {
+		return v, nil
+	}
+	typ := reflect.TypeOf(v)
+	for _, conv := range c.paramConverters {
+		if typ != conv.typ && (conv.typ.Kind() != reflect.Interface || !typ.Implements(conv.typ)) {
+			continue
+		}
+		value, err := conv.convert(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: converting %T: %w", errCouldNotBind, v, err)
+		}
+		return value, nil
+	}
+	return v, nil
+}
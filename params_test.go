@@ -0,0 +1,84 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+type userID struct{ n int64 }
+
+type color int
+
+func (c color) String() string { return [...]string{"red", "green"}[c] }
+
+// Value is overridden by the converter of color.
+func (c color) Value() (driver.Value, error) { return int64(c), nil }
+
+type point struct{ x, y float64 }
+
+func TestWithParamConverter(t *testing.T) {
+	t.Parallel()
+
+	errBadID := errors.New("bad id")
+	c, err := NewConnector("", nil,
+		WithParamConverter(func(id userID) (any, error) {
+			if id.n < 0 {
+				return nil, errBadID
+			}
+			return id.n, nil
+		}),
+		WithParamConverter(func(s fmt.Stringer) (any, error) { return s.String(), nil }),
+		WithParamConverter(func(p point) (any, error) {
+			return TypedParam{Value: fmt.Sprintf("{'x': %g, 'y': %g}", p.x, p.y), Type: "STRUCT(x DOUBLE, y DOUBLE)"}, nil
+		}),
+	)
+	require.NoError(t, err)
+	db := sql.OpenDB(c)
+	defer db.Close()
+
+	var typeName string
+	var id int64
+	require.NoError(t, db.QueryRow(`SELECT typeof(?), ?`, userID{n: 7}, userID{n: 7}).Scan(&typeName, &id))
+	require.Equal(t, "BIGINT", typeName)
+	require.Equal(t, int64(7), id)
+
+	// Interface converters match all implementing types, and take precedence over driver.Valuer.
+	var name string
+	require.NoError(t, db.QueryRow(`SELECT ?`, color(1)).Scan(&name))
+	require.Equal(t, "green", name)
+
+	var y float64
+	require.NoError(t, db.QueryRow(`SELECT (?).y`, point{x: 1, y: 2.5}).Scan(&y))
+	require.Equal(t, 2.5, y)
+
+	// The converters also apply to the values of typed parameters and to ExecBatch.
+	require.NoError(t, db.QueryRow(`SELECT typeof(?)`, TypedParam{Value: userID{n: 1}, Type: "INTEGER"}).Scan(&typeName))
+	require.Equal(t, "INTEGER", typeName)
+
+	_, err = db.Exec(`CREATE TABLE users (id BIGINT)`)
+	require.NoError(t, err)
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+	require.NoError(t, conn.Raw(func(driverConn any) error {
+		_, err := ExecBatch(context.Background(), driverConn.(driver.Conn), `INSERT INTO users VALUES (?)`,
+			[][]any{{userID{n: 1}}, {userID{n: 2}}})
+		return err
+	}))
+	var sum int64
+	require.NoError(t, db.QueryRow(`SELECT sum(id) FROM users`).Scan(&sum))
+	require.Equal(t, int64(3), sum)
+
+	_, err = db.Exec(`SELECT ?`, userID{n: -1})
+	require.ErrorIs(t, err, errBadID)
+	require.ErrorIs(t, err, errCouldNotBind)
+
+	_, err = NewConnector("", nil, WithParamConverter[userID](nil))
+	require.ErrorIs(t, err, errInvalidOption)
+}
@@ -0,0 +1,216 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ParquetPartitionOptions configures ReadParquetPartitioned.
+type ParquetPartitionOptions struct {
+	// Columns are the columns of the result. If empty, the result contains all columns,
+	// including the partition columns, unless ExcludePartitionColumns is set.
+	Columns []string
+	// Filters selects the rows whose partition columns equal the values. The values bind as parameters.
+	// DuckDB skips the files of all other partitions.
+	Filters map[string]any
+	// ExcludePartitionColumns removes the partition columns from the result, if Columns is empty.
+	// The partition columns are discovered from the directory names, so this requires a local root path.
+	ExcludePartitionColumns bool
+}
+
+// ReadParquetPartitioned reads all Parquet files of the hive-partitioned dataset at rootPath,
+// e.g., rootPath/year=2024/region=eu/data.parquet. The result contains the partition columns,
+// e.g., year and region, whose types DuckDB infers from their values.
+// A local root path must be an existing directory. Remote paths, e.g., s3://bucket/dataset, are passed to DuckDB.
+func ReadParquetPartitioned(ctx context.Context, db Queryer, rootPath string, opts ParquetPartitionOptions) (
+	*sql.Rows, error,
+) {
+	query, args, err := parquetPartitionedSQL(rootPath, opts)
+	if err != nil {
+		return nil, getError(errReadParquet, err)
+	}
+	return db.QueryContext(ctx, query, args...)
+}
+
+func parquetPartitionedSQL(rootPath string, opts ParquetPartitionOptions) (string, []any, error) {
+	if rootPath == "" {
+		return "", nil, errors.New("empty root path")
+	}
+	remote := strings.Contains(rootPath, "://")
+	if !remote {
+		info, err := os.Stat(rootPath)
+		if err != nil {
+			return "", nil, err
+		}
+		if !info.IsDir() {
+			return "", nil, fmt.Errorf("root path is not a directory: %s", rootPath)
+		}
+	}
+
+	projection := "*"
+	if len(opts.Columns) != 0 {
+		columns := make([]string, len(opts.Columns))
+		for i, column := range opts.Columns {
+			columns[i] = quoteIdentifier(column)
+		}
+		projection = strings.Join(columns, ", ")
+	} else if opts.ExcludePartitionColumns {
+		if remote {
+			return "", nil, fmt.Errorf("cannot discover the partition columns of a remote path: %s", rootPath)
+		}
+		keys, err := partitionKeys(rootPath)
+		if err != nil {
+			return "", nil, err
+		}
+		if len(keys) != 0 {
+			for i, key := range keys {
+				keys[i] = quoteIdentifier(key)
+			}
+			projection = "* EXCLUDE (" + strings.Join(keys, ", ") + ")"
+		}
+	}
+
+	pattern := strings.TrimSuffix(rootPath, "/") + "/**/*.parquet"
+	query := fmt.Sprintf("SELECT %s FROM read_parquet(?, hive_partitioning = true)", projection)
+	args := []any{pattern}
+
+	// Sort the filters for a deterministic query.
+	keys := make([]string, 0, len(opts.Filters))
+	for key := range opts.Filters {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for i, key := range keys {
+		if i == 0 {
+			query += " WHERE "
+		} else {
+			query += " AND "
+		}
+		query += quoteIdentifier(key) + " = ?"
+		args = append(args, opts.Filters[key])
+	}
+	return query, args, nil
+}
+
+// ParquetCompression is the compression codec of the Parquet files of ExportParquet.
+type ParquetCompression string
+
+const (
+	ParquetUncompressed ParquetCompression = "uncompressed"
+	ParquetSnappy       ParquetCompression = "snappy"
+	ParquetGzip         ParquetCompression = "gzip"
+	ParquetZstd         ParquetCompression = "zstd"
+	ParquetLZ4          ParquetCompression = "lz4"
+)
+
+// ParquetOptions configures ExportParquet.
+type ParquetOptions struct {
+	// Compression is the compression codec. It defaults to DuckDB's default, i.e., snappy.
+	Compression ParquetCompression
+	// RowGroupSize is the number of rows of a row group. It defaults to DuckDB's default of 122880 rows.
+	RowGroupSize int
+	// PartitionBy are the columns of a hive-partitioned export, e.g., path/year=2024/region=eu/data_0.parquet.
+	// Then, the path is a directory, see ReadParquetPartitioned.
+	PartitionBy []string
+	// Overwrite writes a partitioned export into a directory, which is not empty. It overwrites files with the same
+	// names, but keeps all other files.
+	Overwrite bool
+}
+
+// ExportParquet writes the result of the query to the Parquet file at the path, and returns the number of rows.
+// DuckDB does not report the number of rows of a partitioned export, so it returns 0 for it.
+// The arguments are bound to the parameters of the query. To write to a writer instead of a file, see CopyTo.
+func ExportParquet(ctx context.Context, db Execer, query string, path string, opts ParquetOptions, args ...any) (
+	int64, error,
+) {
+	copySQL, err := exportParquetSQL(query, path, opts)
+	if err != nil {
+		return 0, getError(errWriteParquet, err)
+	}
+	res, err := db.ExecContext(ctx, copySQL, args...)
+	if err != nil {
+		return 0, getError(errWriteParquet, err)
+	}
+	return res.RowsAffected()
+}
+
+func exportParquetSQL(query string, path string, opts ParquetOptions) (string, error) {
+	query = strings.TrimRight(strings.TrimSpace(query), ";")
+	if query == "" {
+		return "", errors.New("empty query")
+	}
+	if path == "" {
+		return "", errors.New("empty path")
+	}
+
+	options := []string{"FORMAT PARQUET"}
+	switch opts.Compression {
+	case "":
+	case ParquetUncompressed, ParquetSnappy, ParquetGzip, ParquetZstd, ParquetLZ4:
+		options = append(options, "COMPRESSION "+string(opts.Compression))
+	default:
+		return "", fmt.Errorf("unsupported compression %q", opts.Compression)
+	}
+	if opts.RowGroupSize < 0 {
+		return "", fmt.Errorf("invalid row group size: %d", opts.RowGroupSize)
+	} else if opts.RowGroupSize > 0 {
+		options = append(options, fmt.Sprintf("ROW_GROUP_SIZE %d", opts.RowGroupSize))
+	}
+
+	if len(opts.PartitionBy) != 0 {
+		columns := make([]string, len(opts.PartitionBy))
+		for i, column := range opts.PartitionBy {
+			if column == "" {
+				return "", errors.New("empty partition column")
+			}
+			columns[i] = quoteIdentifier(column)
+		}
+		options = append(options, "PARTITION_BY ("+strings.Join(columns, ", ")+")")
+		if opts.Overwrite {
+			options = append(options, "OVERWRITE_OR_IGNORE")
+		}
+	} else if opts.Overwrite {
+		return "", errors.New("overwrite requires a partitioned export")
+	}
+
+	return "COPY (" + query + ") TO " + quoteString(path) + " (" + strings.Join(options, ", ") + ")", nil
+}
+
+// partitionKeys returns the partition keys of the first Parquet file below the root path.
+func partitionKeys(rootPath string) ([]string, error) {
+	errFound := errors.New("found")
+	var keys []string
+
+	err := filepath.WalkDir(rootPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || filepath.Ext(path) != ".parquet" {
+			return nil
+		}
+
+		rel, err := filepath.Rel(rootPath, filepath.Dir(path))
+		if err != nil {
+			return err
+		}
+		for _, dir := range strings.Split(filepath.ToSlash(rel), "/") {
+			if key, _, ok := strings.Cut(dir, "="); ok {
+				keys = append(keys, key)
+			}
+		}
+		return errFound
+	})
+
+	if err != nil && !errors.Is(err, errFound) {
+		return nil, err
+	}
+	return keys, nil
+}
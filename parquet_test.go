@@ -0,0 +1,161 @@
+package duckdb
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadParquetPartitioned(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	root := filepath.Join(t.TempDir(), "dataset")
+	_, err := db.Exec(`COPY (SELECT i AS id, 2020 + i % 3 AS year, CASE WHEN i % 2 = 0 THEN 'eu' ELSE 'us' END AS region
+		FROM range(60) t(i)) TO '` + root + `' (FORMAT PARQUET, PARTITION_BY (year, region))`)
+	require.NoError(t, err)
+
+	count := func(t *testing.T, opts ParquetPartitionOptions) (int, []string) {
+		rows, err := ReadParquetPartitioned(context.Background(), db, root, opts)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		columns, err := rows.Columns()
+		require.NoError(t, err)
+
+		n := 0
+		for rows.Next() {
+			n++
+		}
+		require.NoError(t, rows.Err())
+		return n, columns
+	}
+
+	t.Run("all partitions", func(t *testing.T) {
+		n, columns := count(t, ParquetPartitionOptions{})
+		require.Equal(t, 60, n)
+		require.ElementsMatch(t, []string{"id", "year", "region"}, columns)
+	})
+
+	t.Run("filters", func(t *testing.T) {
+		n, _ := count(t, ParquetPartitionOptions{Filters: map[string]any{"year": 2021, "region": "us"}})
+		require.Equal(t, 10, n)
+
+		var sum int
+		rows, err := ReadParquetPartitioned(context.Background(), db, root, ParquetPartitionOptions{
+			Columns: []string{"id"},
+			Filters: map[string]any{"year": 2020},
+		})
+		require.NoError(t, err)
+		defer rows.Close()
+		for rows.Next() {
+			var id int
+			require.NoError(t, rows.Scan(&id))
+			require.Equal(t, 0, id%3)
+			sum += id
+		}
+		require.NoError(t, rows.Err())
+		require.Equal(t, 570, sum)
+	})
+
+	t.Run("exclude partition columns", func(t *testing.T) {
+		n, columns := count(t, ParquetPartitionOptions{ExcludePartitionColumns: true})
+		require.Equal(t, 60, n)
+		require.Equal(t, []string{"id"}, columns)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := ReadParquetPartitioned(context.Background(), db, "", ParquetPartitionOptions{})
+		testError(t, err, errReadParquet.Error(), "empty root path")
+
+		_, err = ReadParquetPartitioned(context.Background(), db, filepath.Join(root, "missing"), ParquetPartitionOptions{})
+		testError(t, err, errReadParquet.Error(), "no such file")
+
+		file := filepath.Join(t.TempDir(), "file.parquet")
+		require.NoError(t, os.WriteFile(file, nil, 0o600))
+		_, err = ReadParquetPartitioned(context.Background(), db, file, ParquetPartitionOptions{})
+		testError(t, err, errReadParquet.Error(), "not a directory")
+
+		_, err = ReadParquetPartitioned(context.Background(), db, "s3://bucket/dataset",
+			ParquetPartitionOptions{ExcludePartitionColumns: true})
+		testError(t, err, errReadParquet.Error(), "remote path")
+	})
+}
+
+func TestExportParquet(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	t.Run("file", func(t *testing.T) {
+		path := filepath.Join(dir, "data.parquet")
+		rows, err := ExportParquet(ctx, db, `SELECT range AS id FROM range(?);`, path, ParquetOptions{
+			Compression:  ParquetZstd,
+			RowGroupSize: 2048,
+		}, 10000)
+		require.NoError(t, err)
+		require.Equal(t, int64(10000), rows)
+
+		var compression string
+		var rowGroups int
+		require.NoError(t, db.QueryRow(`SELECT any_value(compression), count(DISTINCT row_group_id)
+			FROM parquet_metadata(?)`, path).Scan(&compression, &rowGroups))
+		require.Equal(t, "ZSTD", compression)
+		require.Greater(t, rowGroups, 1)
+	})
+
+	t.Run("partitioned", func(t *testing.T) {
+		root := filepath.Join(dir, "dataset")
+		query := `SELECT range AS id, 2020 + range % 2 AS "year" FROM range(10)`
+		opts := ParquetOptions{PartitionBy: []string{"year"}}
+		_, err := ExportParquet(ctx, db, query, root, opts)
+		require.NoError(t, err)
+
+		_, err = ExportParquet(ctx, db, query, root, opts)
+		testError(t, err, errWriteParquet.Error(), "OVERWRITE_OR_IGNORE")
+		opts.Overwrite = true
+		_, err = ExportParquet(ctx, db, query, root, opts)
+		require.NoError(t, err)
+
+		rows, err := ReadParquetPartitioned(ctx, db, root, ParquetPartitionOptions{Filters: map[string]any{"year": 2021}})
+		require.NoError(t, err)
+		defer rows.Close()
+		n := 0
+		for rows.Next() {
+			n++
+		}
+		require.NoError(t, rows.Err())
+		require.Equal(t, 5, n)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		path := filepath.Join(dir, "error.parquet")
+		_, err := ExportParquet(ctx, db, " ; ", path, ParquetOptions{})
+		testError(t, err, errWriteParquet.Error(), "empty query")
+
+		_, err = ExportParquet(ctx, db, `SELECT 1`, "", ParquetOptions{})
+		testError(t, err, errWriteParquet.Error(), "empty path")
+
+		_, err = ExportParquet(ctx, db, `SELECT 1`, path, ParquetOptions{Compression: "brotli"})
+		testError(t, err, errWriteParquet.Error(), `unsupported compression "brotli"`)
+
+		_, err = ExportParquet(ctx, db, `SELECT 1`, path, ParquetOptions{RowGroupSize: -1})
+		testError(t, err, errWriteParquet.Error(), "invalid row group size: -1")
+
+		_, err = ExportParquet(ctx, db, `SELECT 1`, path, ParquetOptions{PartitionBy: []string{""}})
+		testError(t, err, errWriteParquet.Error(), "empty partition column")
+
+		_, err = ExportParquet(ctx, db, `SELECT 1`, path, ParquetOptions{Overwrite: true})
+		testError(t, err, errWriteParquet.Error(), "overwrite requires a partitioned export")
+
+		_, err = ExportParquet(ctx, db, `SELECT * FROM missing`, path, ParquetOptions{})
+		testError(t, err, errWriteParquet.Error(), "missing")
+	})
+}
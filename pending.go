@@ -0,0 +1,232 @@
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"runtime"
+	"time"
+)
+
+// pendingIdleInterval is the interval, at which Wait polls a pending query, while the threads of DuckDB
+// execute all of its remaining tasks.
+const pendingIdleInterval = 100 * time.Microsecond
+
+// PendingQuery is a query, whose execution the caller drives in tasks, see StartQuery.
+// Unlike QueryContext, which blocks a goroutine in cgo until the query completes, Poll executes a single task,
+// and returns to the caller, so that a single goroutine can fan out queries over multiple connections,
+// by polling their pending queries in turn. A pending query is not safe for concurrent use.
+// Its connection must not execute other queries, until its result is ready, or it is closed.
+type PendingQuery struct {
+	s       *stmt
+	ctx     context.Context
+	pending C.duckdb_pending_result
+	// The ID of the query while it is in flight, see ActiveQueries.
+	queryID uint64
+	// end ends the query hooks of the query.
+	end func(rows int64, chunks int64, err error)
+	// stopProgress stops reporting the progress of the query, see WithProgress.
+	stopProgress func()
+
+	// True, if the result of the query is ready.
+	ready bool
+	// True, if no task was available during the last poll, as the threads of DuckDB execute all remaining tasks.
+	idle bool
+	// True, if the pending result is destroyed, i.e., the query completed, failed, or was closed.
+	done bool
+	err  error
+}
+
+// StartQuery prepares the query, starts executing it with the arguments, and returns its pending query.
+// The statement of the query is closed with the rows of its result, or when closing the pending query.
+// The context applies to the entire execution of the query, i.e., cancelling it fails the next poll.
+func StartQuery(ctx context.Context, driverConn driver.Conn, query string, args ...any) (*PendingQuery, error) {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return nil, err
+	}
+	nargs, err := con.batchArgs(args)
+	if err != nil {
+		return nil, getError(errStartQuery, err)
+	}
+
+	s, err := con.prepareStmtContext(ctx, query)
+	if err != nil {
+		return nil, getError(errStartQuery, err)
+	}
+	p, err := s.Start(ctx, nargs)
+	if err != nil {
+		s.Close()
+		return nil, err
+	}
+	s.closeOnRowsClose = true
+	return p, nil
+}
+
+// Start starts executing the prepared statement with the arguments, and returns its pending query,
+// see StartQuery. The statements of a DuckDB driver connection implement it, e.g., with
+//
+//	p, err := driverStmt.(interface {
+//		Start(context.Context, []driver.NamedValue) (*duckdb.PendingQuery, error)
+//	}).Start(ctx, args)
+//
+// The statement must not execute again, until the result of the pending query is closed, or the pending
+// query is closed.
+func (s *stmt) Start(ctx context.Context, nargs []driver.NamedValue) (*PendingQuery, error) {
+	if s.closed {
+		return nil, getError(errClosedStmt, nil)
+	}
+	if s.rows {
+		panic("database/sql/driver: misuse of duckdb driver: Start with active Rows")
+	}
+
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	if err := s.checkReadOnlyTx(); err != nil {
+		return nil, err
+	}
+	if err := s.bind(nargs); err != nil {
+		return nil, err
+	}
+	s.trackSession()
+
+	stopProgress, err := s.c.reportProgress(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var pending C.duckdb_pending_result
+	if C.duckdb_pending_prepared(*s.stmt, &pending) == C.DuckDBError {
+		dbErr := C.GoString(C.duckdb_pending_error(pending))
+		C.duckdb_destroy_pending(&pending)
+		stopProgress()
+		return nil, s.c.queryError(dbErr)
+	}
+
+	// Like rows, the pending query uses the statement until it completes.
+	s.rows = true
+	return &PendingQuery{
+		s:            s,
+		ctx:          ctx,
+		pending:      pending,
+		queryID:      s.c.startQuery(s.query),
+		end:          s.c.startHooks(ctx, QueryKindQuery, s.query, nargs),
+		stopProgress: stopProgress,
+	}, nil
+}
+
+// Poll executes a single task of the query, and reports whether its result is ready, see Result.
+// It returns the error of the query, if it failed, or the error of the context, if it is done.
+// Once the result is ready, or the query failed, Poll no longer executes tasks.
+func (p *PendingQuery) Poll() (bool, error) {
+	if p.done || p.ready {
+		return p.ready, p.err
+	}
+	if err := p.ctx.Err(); err != nil {
+		return false, p.fail(err)
+	}
+
+	p.s.c.mu.Lock()
+	state := C.duckdb_pending_execute_task(p.pending)
+	p.s.c.mu.Unlock()
+
+	switch state {
+	case C.DUCKDB_PENDING_RESULT_READY:
+		p.ready = true
+	case C.DUCKDB_PENDING_ERROR:
+		return false, p.fail(p.s.c.queryError(C.GoString(C.duckdb_pending_error(p.pending))))
+	}
+	p.idle = state == C.DUCKDB_PENDING_NO_TASKS_AVAILABLE
+	return p.ready, nil
+}
+
+// Wait polls the query until its result is ready, and returns its rows, see Result. It yields to the Go
+// scheduler between tasks, and sleeps briefly while the threads of DuckDB execute all remaining tasks.
+func (p *PendingQuery) Wait() (driver.Rows, error) {
+	for {
+		ready, err := p.Poll()
+		if err != nil {
+			return nil, err
+		}
+		if ready {
+			return p.Result()
+		}
+		if p.idle {
+			time.Sleep(pendingIdleInterval)
+		} else {
+			runtime.Gosched()
+		}
+	}
+}
+
+// Result returns the rows of the ready result of the query. It fails, if Poll did not report the result as ready.
+// The rows must be closed like the rows of QueryContext. Closing the pending query afterwards does nothing.
+func (p *PendingQuery) Result() (driver.Rows, error) {
+	if p.err != nil {
+		return nil, p.err
+	}
+	if !p.ready || p.done {
+		return nil, getError(errStartQuery, errors.New("the result is not ready"))
+	}
+
+	p.s.c.mu.Lock()
+	var res C.duckdb_result
+	state := C.duckdb_execute_pending(p.pending, &res)
+	p.s.c.mu.Unlock()
+	if state == C.DuckDBError {
+		err := p.s.c.queryError(C.GoString(C.duckdb_result_error(&res)))
+		C.duckdb_destroy_result(&res)
+		return nil, p.fail(err)
+	}
+
+	p.release()
+	r := newRowsWithStmt(res, p.s)
+	numRows, numChunks := rowCount(r)
+	p.end(numRows, numChunks, nil)
+	return r, nil
+}
+
+// Close stops executing the query, unless its result was returned, and closes the statement of StartQuery.
+func (p *PendingQuery) Close() error {
+	if p.done {
+		return nil
+	}
+	p.err = getError(errStartQuery, errors.New("the pending query is closed"))
+	return p.finish(p.err)
+}
+
+// fail stops executing the query, and returns its error.
+func (p *PendingQuery) fail(err error) error {
+	p.err = err
+	_ = p.finish(err)
+	return err
+}
+
+// finish releases the pending query, and its statement.
+func (p *PendingQuery) finish(err error) error {
+	p.release()
+	p.end(-1, -1, err)
+	p.s.rows = false
+	if p.s.closeOnRowsClose {
+		return p.s.Close()
+	}
+	return nil
+}
+
+func (p *PendingQuery) release() {
+	p.done = true
+	p.s.c.mu.Lock()
+	C.duckdb_destroy_pending(&p.pending)
+	p.s.c.mu.Unlock()
+	p.stopProgress()
+	p.s.c.finishQuery(p.queryID)
+}
@@ -0,0 +1,134 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStartQuery(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE pending AS SELECT range AS id, range::VARCHAR AS name FROM range(100000)`)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	startQuery := func(ctx context.Context, query string, args ...any) (p *PendingQuery, err error) {
+		con, err := db.Conn(context.Background())
+		require.NoError(t, err)
+		t.Cleanup(func() { con.Close() })
+		require.NoError(t, con.Raw(func(driverConn any) error {
+			p, err = StartQuery(ctx, driverConn.(driver.Conn), query, args...)
+			return nil
+		}))
+		return p, err
+	}
+	scanInt64 := func(r driver.Rows) int64 {
+		defer r.Close()
+		values := make([]driver.Value, 1)
+		require.NoError(t, r.Next(values))
+		require.Equal(t, io.EOF, r.Next(values))
+		return values[0].(int64)
+	}
+
+	t.Run("wait", func(t *testing.T) {
+		p, err := startQuery(ctx, `SELECT sum(id)::BIGINT AS total FROM pending WHERE id < ?`, 10)
+		require.NoError(t, err)
+		r, err := p.Wait()
+		require.NoError(t, err)
+		require.Equal(t, []string{"total"}, r.Columns())
+		require.Equal(t, int64(45), scanInt64(r))
+		require.NoError(t, p.Close())
+	})
+
+	t.Run("fan out", func(t *testing.T) {
+		queries := make([]*PendingQuery, 4)
+		for i := range queries {
+			queries[i], err = startQuery(ctx, `SELECT count(*) FROM pending WHERE id % 4 = ?`, i)
+			require.NoError(t, err)
+		}
+
+		// A single goroutine polls all pending queries in turn.
+		var total int64
+		for remaining := len(queries); remaining > 0; {
+			for i, p := range queries {
+				if p == nil {
+					continue
+				}
+				ready, err := p.Poll()
+				require.NoError(t, err)
+				if !ready {
+					continue
+				}
+				r, err := p.Result()
+				require.NoError(t, err)
+				total += scanInt64(r)
+				queries[i] = nil
+				remaining--
+			}
+		}
+		require.Equal(t, int64(100000), total)
+	})
+
+	t.Run("failing query", func(t *testing.T) {
+		p, err := startQuery(ctx, `SELECT name::INTEGER + 1 FROM pending UNION ALL SELECT 'x'::VARCHAR::INTEGER`)
+		if err == nil {
+			_, err = p.Wait()
+		}
+		require.ErrorContains(t, err, "Conversion Error")
+
+		_, err = startQuery(ctx, `SELECT * FROM missing`)
+		require.ErrorIs(t, err, errStartQuery)
+		require.ErrorContains(t, err, "Catalog Error")
+	})
+
+	t.Run("canceled context", func(t *testing.T) {
+		cancelCtx, cancel := context.WithCancel(ctx)
+		p, err := startQuery(cancelCtx, `SELECT count(*) FROM pending a, pending b WHERE a.id < b.id`)
+		require.NoError(t, err)
+		cancel()
+		_, err = p.Wait()
+		require.ErrorIs(t, err, context.Canceled)
+		_, err = p.Result()
+		require.ErrorIs(t, err, context.Canceled)
+	})
+
+	t.Run("close", func(t *testing.T) {
+		p, err := startQuery(ctx, `SELECT count(*) FROM pending`)
+		require.NoError(t, err)
+		_, err = p.Result()
+		require.ErrorIs(t, err, errStartQuery)
+		require.NoError(t, p.Close())
+		_, err = p.Poll()
+		require.ErrorIs(t, err, errStartQuery)
+		require.NoError(t, p.Close())
+	})
+
+	t.Run("prepared statement", func(t *testing.T) {
+		con, err := db.Conn(ctx)
+		require.NoError(t, err)
+		defer con.Close()
+		require.NoError(t, con.Raw(func(driverConn any) error {
+			s, err := driverConn.(driver.ConnPrepareContext).PrepareContext(ctx, `SELECT ? * 2`)
+			require.NoError(t, err)
+			defer s.Close()
+
+			starter := s.(interface {
+				Start(context.Context, []driver.NamedValue) (*PendingQuery, error)
+			})
+			for i := int64(1); i <= 2; i++ {
+				p, err := starter.Start(ctx, []driver.NamedValue{{Ordinal: 1, Value: i}})
+				require.NoError(t, err)
+				r, err := p.Wait()
+				require.NoError(t, err)
+				require.Equal(t, 2*i, scanInt64(r))
+			}
+			return nil
+		}))
+	})
+}
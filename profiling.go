@@ -0,0 +1,169 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"os"
+	"strings"
+	"time"
+)
+
+// QueryProfile is the profiling output of a query, see ProfilingInfo.
+type QueryProfile struct {
+	// Query is the SQL of the query.
+	Query string
+	// Latency is the total execution time of the query.
+	Latency time.Duration
+	// Operators are the root operators of the physical plan of the query, usually a single RESULT_COLLECTOR.
+	Operators []ProfileOperator
+}
+
+// ProfileOperator is an operator of the physical plan of a profiled query.
+type ProfileOperator struct {
+	// Name is the name of the operator, e.g., HASH_JOIN or TABLE_SCAN.
+	Name string
+	// Timing is the execution time of the operator, excluding its children.
+	Timing time.Duration
+	// Cardinality is the number of rows the operator produced.
+	Cardinality uint64
+	// ExtraInfo describes the operator, e.g., the join condition of a HASH_JOIN, and estimated cardinalities.
+	ExtraInfo string
+	// Children are the operators producing the input of the operator.
+	Children []ProfileOperator
+}
+
+// EnableProfiling enables profiling the queries of the connection, see ProfilingInfo.
+// DuckDB writes the profiling output to a temporary file, which the connection removes when it closes,
+// or when DisableProfiling disables profiling.
+func EnableProfiling(driverConn driver.Conn) error {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return err
+	}
+	if con.profilingOutput != "" {
+		return nil
+	}
+
+	f, err := os.CreateTemp("", "duckdb-profile-*.json")
+	if err != nil {
+		return getError(errProfiling, err)
+	}
+	path := f.Name()
+	if err = f.Close(); err != nil {
+		_ = os.Remove(path)
+		return getError(errProfiling, err)
+	}
+
+	ctx := context.Background()
+	if _, err = con.ExecContext(ctx, `PRAGMA enable_profiling = 'json'`, nil); err == nil {
+		_, err = con.ExecContext(ctx, `PRAGMA profiling_output = `+quoteString(path), nil)
+	}
+	if err != nil {
+		_, _ = con.ExecContext(ctx, `PRAGMA disable_profiling`, nil)
+		_ = os.Remove(path)
+		return getError(errProfiling, err)
+	}
+	con.profilingOutput = path
+	return nil
+}
+
+// DisableProfiling disables profiling the queries of the connection, and removes its profiling output.
+func DisableProfiling(driverConn driver.Conn) error {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return err
+	}
+	if con.profilingOutput == "" {
+		return nil
+	}
+
+	if _, err = con.ExecContext(context.Background(), `PRAGMA disable_profiling`, nil); err != nil {
+		return getError(errProfiling, err)
+	}
+	return con.removeProfilingOutput()
+}
+
+// ProfilingInfo returns the profiling output of the last query of the connection, after EnableProfiling.
+// The profile of a query is complete once it finished, i.e., once the rows of a streaming result are closed.
+func ProfilingInfo(driverConn driver.Conn) (*QueryProfile, error) {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return nil, err
+	}
+	if con.profilingOutput == "" {
+		return nil, getError(errProfiling, errors.New("profiling is disabled"))
+	}
+
+	data, err := os.ReadFile(con.profilingOutput)
+	if err != nil {
+		return nil, getError(errProfiling, err)
+	}
+	if len(data) == 0 {
+		return nil, getError(errProfiling, errors.New("no profiled query"))
+	}
+
+	var root struct {
+		ExtraInfo string            `json:"extra-info"`
+		Timing    float64           `json:"timing"`
+		Children  []profileOperator `json:"children"`
+	}
+	if err = json.Unmarshal(data, &root); err != nil {
+		return nil, getError(errProfiling, err)
+	}
+	return &QueryProfile{
+		Query:     root.ExtraInfo,
+		Latency:   profileDuration(root.Timing),
+		Operators: profileOperators(root.Children),
+	}, nil
+}
+
+// profileOperator is an operator of DuckDB's JSON profiling output.
+type profileOperator struct {
+	Name        string            `json:"name"`
+	Timing      float64           `json:"timing"`
+	Cardinality uint64            `json:"cardinality"`
+	ExtraInfo   string            `json:"extra_info"`
+	Children    []profileOperator `json:"children"`
+}
+
+func profileOperators(ops []profileOperator) []ProfileOperator {
+	operators := make([]ProfileOperator, len(ops))
+	for i, op := range ops {
+		operators[i] = ProfileOperator{
+			Name:        strings.TrimSpace(op.Name),
+			Timing:      profileDuration(op.Timing),
+			Cardinality: op.Cardinality,
+			ExtraInfo:   strings.TrimSpace(op.ExtraInfo),
+			Children:    profileOperators(op.Children),
+		}
+	}
+	return operators
+}
+
+// profileDuration converts the seconds of a timing of the profiling output.
+func profileDuration(seconds float64) time.Duration {
+	return time.Duration(seconds * float64(time.Second))
+}
+
+// openConn returns the DuckDB connection of the driver connection, if it is open.
+func openConn(driverConn driver.Conn) (*conn, error) {
+	con, ok := driverConn.(*conn)
+	if !ok {
+		return nil, getError(errInvalidCon, nil)
+	}
+	if con.closed {
+		return nil, getError(errClosedCon, nil)
+	}
+	return con, nil
+}
+
+func (c *conn) removeProfilingOutput() error {
+	path := c.profilingOutput
+	c.profilingOutput = ""
+	if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return getError(errProfiling, err)
+	}
+	return nil
+}
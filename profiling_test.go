@@ -0,0 +1,80 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// findOperator returns the first operator with the name in depth-first order.
+func findOperator(ops []ProfileOperator, name string) *ProfileOperator {
+	for i := range ops {
+		if ops[i].Name == name {
+			return &ops[i]
+		}
+		if op := findOperator(ops[i].Children, name); op != nil {
+			return op
+		}
+	}
+	return nil
+}
+
+func TestProfiling(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	con, err := c.Connect(context.Background())
+	require.NoError(t, err)
+
+	_, err = ProfilingInfo(con)
+	testError(t, err, errProfiling.Error(), "profiling is disabled")
+
+	require.NoError(t, EnableProfiling(con))
+	require.NoError(t, EnableProfiling(con))
+	path := con.(*conn).profilingOutput
+	require.FileExists(t, path)
+
+	query := `SELECT count(*) FROM range(1000) a JOIN range(100) b ON a.range = b.range`
+	driverRows := queryDriverRows(t, con, query)
+	values := make([]driver.Value, 1)
+	require.NoError(t, driverRows.Next(values))
+	require.Equal(t, int64(100), values[0])
+	require.NoError(t, driverRows.Close())
+
+	profile, err := ProfilingInfo(con)
+	require.NoError(t, err)
+	require.Equal(t, query, profile.Query)
+	require.Positive(t, profile.Latency)
+	require.Len(t, profile.Operators, 1)
+	require.Equal(t, "RESULT_COLLECTOR", profile.Operators[0].Name)
+
+	join := findOperator(profile.Operators, "HASH_JOIN")
+	require.NotNil(t, join)
+	require.Equal(t, uint64(100), join.Cardinality)
+	require.Contains(t, join.ExtraInfo, "range = range")
+	require.Len(t, join.Children, 2)
+	require.Equal(t, "RANGE", join.Children[0].Name)
+
+	require.NoError(t, DisableProfiling(con))
+	require.NoFileExists(t, path)
+	_, err = ProfilingInfo(con)
+	testError(t, err, errProfiling.Error(), "profiling is disabled")
+
+	// Closing the connection removes the profiling output.
+	require.NoError(t, EnableProfiling(con))
+	path = con.(*conn).profilingOutput
+	require.NoError(t, con.Close())
+	_, err = os.Stat(path)
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	err = EnableProfiling(con)
+	testError(t, err, errClosedCon.Error())
+	_, err = ProfilingInfo(nil)
+	testError(t, err, errInvalidCon.Error())
+}
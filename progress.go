@@ -0,0 +1,142 @@
+package duckdb
+
+/*
+#include <stdlib.h>
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"time"
+	"unsafe"
+)
+
+// QueryProgress is the progress of the execution of a query, see ContextWithProgress.
+type QueryProgress struct {
+	// Percentage is the estimated percentage of the query, or -1, if DuckDB cannot estimate it.
+	// DuckDB estimates the progress of scans of tables and files, but not of most table functions, e.g., range.
+	Percentage float64
+	// RowsProcessed is the number of rows the query processed.
+	RowsProcessed uint64
+	// TotalRows is the estimated number of rows the query processes.
+	TotalRows uint64
+}
+
+type progressKey struct{}
+
+// progressReporter reports the progress of the queries of a context.
+type progressReporter struct {
+	interval time.Duration
+	fn       func(QueryProgress)
+}
+
+// defaultProgressInterval is the interval of ContextWithProgress, if it is not positive.
+const defaultProgressInterval = 100 * time.Millisecond
+
+// ContextWithProgress returns a copy of ctx, which reports the progress of the queries executed with it.
+// While a query executes, the driver calls fn with its progress every interval, which defaults to 100ms.
+// It does not call fn for queries finishing within the first interval. The connection enables DuckDB's
+// progress tracking for the query, without printing the progress bar.
+// The progress of a streaming result is only reported until its first chunk is ready.
+func ContextWithProgress(ctx context.Context, interval time.Duration, fn func(QueryProgress)) context.Context {
+	if interval <= 0 {
+		interval = defaultProgressInterval
+	}
+	return context.WithValue(ctx, progressKey{}, &progressReporter{interval: interval, fn: fn})
+}
+
+// reportProgress reports the progress of the execution of the connection to the progress reporter of the context,
+// if any, until calling stop. It must be called while holding the lock of the connection.
+func (c *conn) reportProgress(ctx context.Context) (stop func(), err error) {
+	reporter, _ := ctx.Value(progressKey{}).(*progressReporter)
+	if reporter == nil || reporter.fn == nil {
+		return func() {}, nil
+	}
+	if err = c.enableProgress(); err != nil {
+		return nil, err
+	}
+
+	mainDoneCh := make(chan struct{})
+	bgDoneCh := make(chan struct{})
+	go func() {
+		defer close(bgDoneCh)
+		ticker := time.NewTicker(reporter.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				// Like interrupting, getting the progress does not acquire the lock of the connection.
+				reporter.fn(c.queryProgress())
+			case <-mainDoneCh:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(mainDoneCh)
+		// Wait for the last report, so that fn is not called after the query finished.
+		<-bgDoneCh
+	}, nil
+}
+
+// ProgressTracker polls the progress of the queries executing on a connection, see TrackProgress.
+type ProgressTracker struct {
+	c *conn
+}
+
+// TrackProgress enables DuckDB's progress tracking of the connection, and returns a tracker of the progress
+// of its queries. Unlike ContextWithProgress, the caller polls the progress, e.g., from another goroutine
+// serving a dashboard, while the connection executes a query. The tracker must not be used after closing
+// the connection.
+func TrackProgress(driverConn driver.Conn) (*ProgressTracker, error) {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return nil, err
+	}
+
+	con.mu.Lock()
+	defer con.mu.Unlock()
+	if err = con.enableProgress(); err != nil {
+		return nil, err
+	}
+	return &ProgressTracker{c: con}, nil
+}
+
+// Progress returns the progress of the query executing on the connection. Like interrupting a query,
+// it does not wait for the query, so it is safe to call concurrently with it. Without an executing query,
+// the Percentage of the progress is -1.
+func (t *ProgressTracker) Progress() QueryProgress {
+	return t.c.queryProgress()
+}
+
+func (c *conn) queryProgress() QueryProgress {
+	progress := C.duckdb_query_progress(c.duckdbCon)
+	return QueryProgress{
+		Percentage:    float64(progress.percentage),
+		RowsProcessed: uint64(progress.rows_processed),
+		TotalRows:     uint64(progress.total_rows_to_process),
+	}
+}
+
+// enableProgress enables DuckDB's progress tracking of the connection, without printing the progress bar.
+// It must be called while holding the lock of the connection.
+func (c *conn) enableProgress() error {
+	if c.progressEnabled {
+		return nil
+	}
+
+	query := C.CString(`SET enable_progress_bar = true; SET enable_progress_bar_print = false; SET progress_bar_time = 0`)
+	defer C.free(unsafe.Pointer(query))
+
+	var res C.duckdb_result
+	state := C.duckdb_query(c.duckdbCon, query, &res)
+	defer C.duckdb_destroy_result(&res)
+	if state == C.DuckDBError {
+		return newError(C.GoString(C.duckdb_result_error(&res)))
+	}
+	c.progressEnabled = true
+	return nil
+}
@@ -0,0 +1,98 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestQueryProgress(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	var mu sync.Mutex
+	var reports []QueryProgress
+	ctx := ContextWithProgress(context.Background(), 5*time.Millisecond, func(progress QueryProgress) {
+		mu.Lock()
+		defer mu.Unlock()
+		reports = append(reports, progress)
+	})
+
+	_, err := db.Exec(`CREATE TABLE tbl AS SELECT range AS i FROM range(10000000)`)
+	require.NoError(t, err)
+
+	var count int64
+	err = db.QueryRowContext(ctx, `SELECT count(DISTINCT i::VARCHAR) FROM tbl`).Scan(&count)
+	require.NoError(t, err)
+	require.Equal(t, int64(10000000), count)
+
+	mu.Lock()
+	defer mu.Unlock()
+	require.NotEmpty(t, reports)
+	var progressed bool
+	for _, progress := range reports {
+		require.LessOrEqual(t, progress.Percentage, 100.0)
+		if progress.Percentage > 0 {
+			progressed = true
+			require.Positive(t, progress.RowsProcessed)
+			require.GreaterOrEqual(t, progress.TotalRows, uint64(10000000))
+		}
+	}
+	require.True(t, progressed)
+
+	// Queries without the context do not report their progress.
+	n := len(reports)
+	mu.Unlock()
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM range(100000000)`).Scan(&count))
+	mu.Lock()
+	require.Len(t, reports, n)
+}
+
+func TestTrackProgress(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE tbl AS SELECT range AS i FROM range(10000000)`)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	con, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer con.Close()
+
+	var tracker *ProgressTracker
+	require.NoError(t, con.Raw(func(driverConn any) error {
+		tracker, err = TrackProgress(driverConn.(driver.Conn))
+		return err
+	}))
+	require.Equal(t, -1.0, tracker.Progress().Percentage)
+
+	done := make(chan error)
+	go func() {
+		var count int64
+		done <- con.QueryRowContext(ctx, `SELECT count(DISTINCT i::VARCHAR) FROM tbl`).Scan(&count)
+	}()
+
+	var progressed bool
+	for finished := false; !finished; {
+		select {
+		case err = <-done:
+			require.NoError(t, err)
+			finished = true
+		case <-time.After(time.Millisecond):
+			progress := tracker.Progress()
+			require.LessOrEqual(t, progress.Percentage, 100.0)
+			if progress.Percentage > 0 {
+				progressed = true
+				require.Positive(t, progress.RowsProcessed)
+			}
+		}
+	}
+	require.True(t, progressed)
+}
@@ -0,0 +1,101 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+)
+
+// Conn is a DuckDB driver connection, see RawConn. Its methods provide the driver-specific capabilities
+// of the connection, without type-asserting the driver connection of sql.Conn.Raw.
+// Like the driver connection, it must not be used after the function of RawConn returns.
+type Conn struct {
+	c *conn
+}
+
+// RawConn calls fn with the DuckDB driver connection of the sql.Conn. It fails, if the sql.Conn is not
+// a connection of this driver, or if it is closed. The functions of this package accepting a driver.Conn, e.g.,
+// ExecBatch, require such a DuckDB driver connection, e.g., of Conn.Driver, or of the Raw function of a sql.Conn.
+func RawConn(sqlConn *sql.Conn, fn func(c *Conn) error) error {
+	return sqlConn.Raw(func(driverConn any) error {
+		dc, ok := driverConn.(driver.Conn)
+		if !ok {
+			return getError(errInvalidCon, nil)
+		}
+		con, err := openConn(dc)
+		if err != nil {
+			return err
+		}
+		return fn(&Conn{c: con})
+	})
+}
+
+// Driver returns the driver connection, e.g., for the functions of this package accepting a driver.Conn.
+func (c *Conn) Driver() driver.Conn {
+	return c.c
+}
+
+// NewAppender returns a new Appender of the table, see NewAppenderFromConn.
+func (c *Conn) NewAppender(schema, table string, opts ...AppenderOption) (*Appender, error) {
+	return NewAppenderFromConn(c.c, schema, table, opts...)
+}
+
+// NewConcurrentAppender returns a new ConcurrentAppender of the table, see NewConcurrentAppender.
+func (c *Conn) NewConcurrentAppender(schema, table string, opts ...AppenderOption) (*ConcurrentAppender, error) {
+	return NewConcurrentAppender(c.c, schema, table, opts...)
+}
+
+// Arrow returns the Arrow interface of the connection, see NewArrowFromConn.
+func (c *Conn) Arrow() (*Arrow, error) {
+	return NewArrowFromConn(c.c)
+}
+
+// RegisterTableFunction registers the table function, see RegisterTableFunction.
+func (c *Conn) RegisterTableFunction(name string, fn TableFunction) error {
+	return RegisterTableFunction(c.c, name, fn)
+}
+
+// EnableProfiling enables the profiling of the queries of the connection, see EnableProfiling.
+func (c *Conn) EnableProfiling() error {
+	return EnableProfiling(c.c)
+}
+
+// DisableProfiling disables the profiling of the queries of the connection, see DisableProfiling.
+func (c *Conn) DisableProfiling() error {
+	return DisableProfiling(c.c)
+}
+
+// ProfilingInfo returns the profile of the last query of the connection, see ProfilingInfo.
+func (c *Conn) ProfilingInfo() (*QueryProfile, error) {
+	return ProfilingInfo(c.c)
+}
+
+// ExecBatch executes the query for each set of arguments, see ExecBatch.
+func (c *Conn) ExecBatch(ctx context.Context, query string, args [][]any) (int64, error) {
+	return ExecBatch(ctx, c.c, query, args)
+}
+
+// QueryMaps returns the rows of the query as maps, see QueryMaps.
+func (c *Conn) QueryMaps(ctx context.Context, query string, args ...any) ([]map[string]any, error) {
+	return QueryMaps(ctx, c.c, query, args...)
+}
+
+// SetSetting sets the setting of the connection to the value, see SetSetting.
+func (c *Conn) SetSetting(ctx context.Context, name string, value any) error {
+	return SetSetting(ctx, c.c, name, value)
+}
+
+// GetSetting returns the setting of the connection, see GetSetting.
+func (c *Conn) GetSetting(ctx context.Context, name string) (Setting, error) {
+	return GetSetting(ctx, c.c, name)
+}
+
+// ListSettings returns all settings of the connection, see ListSettings.
+func (c *Conn) ListSettings(ctx context.Context) ([]Setting, error) {
+	return ListSettings(ctx, c.c)
+}
+
+// ExplainQuery returns the plans of the query, see ExplainQuery.
+func (c *Conn) ExplainQuery(ctx context.Context, query string, args ...any) (*QueryPlan, error) {
+	return ExplainQuery(ctx, c.c, query, args...)
+}
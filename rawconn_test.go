@@ -0,0 +1,56 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRawConn(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE raw (id BIGINT, name VARCHAR)`)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	con, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer con.Close()
+
+	require.NoError(t, RawConn(con, func(c *Conn) error {
+		appender, err := c.NewAppender("", "raw")
+		require.NoError(t, err)
+		require.NoError(t, appender.AppendRow(int64(1), "a"))
+		require.NoError(t, appender.AppendRow(int64(2), "b"))
+		require.NoError(t, appender.Close())
+
+		arrow, err := c.Arrow()
+		require.NoError(t, err)
+		reader, err := arrow.QueryContext(ctx, `SELECT * FROM raw`)
+		require.NoError(t, err)
+		defer reader.Release()
+		require.True(t, reader.Next())
+		require.Equal(t, int64(2), reader.Record().NumRows())
+
+		maps, err := c.QueryMaps(ctx, `SELECT name FROM raw ORDER BY id`)
+		require.NoError(t, err)
+		require.Equal(t, []map[string]any{{"name": "a"}, {"name": "b"}}, maps)
+
+		require.NoError(t, c.EnableProfiling())
+		_, err = c.QueryMaps(ctx, `SELECT count(*) FROM raw`)
+		require.NoError(t, err)
+		profile, err := c.ProfilingInfo()
+		require.NoError(t, err)
+		require.NotNil(t, profile)
+		require.NoError(t, c.DisableProfiling())
+
+		return ValidateQuery(ctx, c.Driver(), `SELECT * FROM raw`)
+	}))
+
+	require.NoError(t, con.Close())
+	require.ErrorIs(t, RawConn(con, func(*Conn) error { return nil }), sql.ErrConnDone)
+}
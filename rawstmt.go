@@ -0,0 +1,137 @@
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+)
+
+// Stmt is a prepared statement of a Conn, see Conn.Prepare. Unlike the statements of database/sql, which bind
+// all arguments on each execution, the parameters of a Stmt stay bound between its executions. A loop executing
+// the statement thousands of times binds only the parameters that change, and executes without arguments.
+// Like the Conn, it must not be used after the function of RawConn returns, and it is not safe for concurrent use.
+type Stmt struct {
+	s *stmt
+}
+
+// Prepare prepares the query, e.g., "INSERT INTO tbl VALUES (?, ?)", as a Stmt, which the caller must close.
+func (c *Conn) Prepare(ctx context.Context, query string) (*Stmt, error) {
+	ds, err := c.c.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	s := ds.(*stmt)
+	s.keepBindings = true
+	return &Stmt{s: s}, nil
+}
+
+// NumInput returns the number of parameters of the statement.
+func (s *Stmt) NumInput() int {
+	if s.s.closed {
+		return 0
+	}
+	return s.s.NumInput()
+}
+
+// Bind binds an argument to each parameter of the statement, like the arguments of ExecContext, i.e.,
+// by their position, or by the names of sql.Named arguments. It replaces all previous bindings.
+func (s *Stmt) Bind(args ...any) error {
+	nargs, err := s.args(args)
+	if err != nil {
+		return getError(errStmtBind, err)
+	}
+	s.s.c.mu.Lock()
+	defer s.s.c.mu.Unlock()
+	if err = s.s.bind(nargs); err != nil {
+		return getError(errStmtBind, err)
+	}
+	return nil
+}
+
+// BindParam binds the value to the parameter of the one-based index, and keeps the bindings of the other parameters.
+func (s *Stmt) BindParam(index int, value any) error {
+	nargs, err := s.args([]any{value})
+	if err != nil {
+		return getError(errStmtBind, err)
+	}
+	if index < 1 || index > s.s.NumInput() {
+		return getError(errStmtBind, fmt.Errorf("invalid parameter index: %d", index))
+	}
+	nargs[0].Ordinal = index
+	s.s.c.mu.Lock()
+	defer s.s.c.mu.Unlock()
+	if err = s.s.bindArgs(nargs); err != nil {
+		return getError(errStmtBind, err)
+	}
+	return nil
+}
+
+// ClearBindings clears the bindings of all parameters. Executing the statement fails, until all of its parameters
+// are bound again.
+func (s *Stmt) ClearBindings() error {
+	if s.s.closed {
+		return getError(errStmtBind, errClosedStmt)
+	}
+	s.s.c.mu.Lock()
+	defer s.s.c.mu.Unlock()
+	if state := C.duckdb_clear_bindings(*s.s.stmt); state == C.DuckDBError {
+		return getError(errStmtBind, errors.New("could not clear bindings"))
+	}
+	return nil
+}
+
+// Exec executes the statement with the bound parameters.
+func (s *Stmt) Exec(ctx context.Context) (driver.Result, error) {
+	if s.s.closed {
+		return nil, errClosedStmt
+	}
+	return s.s.ExecContext(ctx, nil)
+}
+
+// Query executes the statement with the bound parameters, and returns its rows. The rows must be closed
+// before executing the statement again, or closing it.
+func (s *Stmt) Query(ctx context.Context) (driver.Rows, error) {
+	if s.s.closed {
+		return nil, errClosedStmt
+	}
+	return s.s.QueryContext(ctx, nil)
+}
+
+// Close closes the statement.
+func (s *Stmt) Close() error {
+	if s.s.closed {
+		return nil
+	}
+	return s.s.Close()
+}
+
+// args converts the arguments of the statement like database/sql, see batchArgs.
+func (s *Stmt) args(args []any) ([]driver.NamedValue, error) {
+	if s.s.closed {
+		return nil, errClosedStmt
+	}
+	values := make([]any, len(args))
+	for i, arg := range args {
+		values[i] = arg
+		if named, ok := arg.(sql.NamedArg); ok {
+			values[i] = named.Value
+		}
+	}
+	nargs, err := s.s.c.batchArgs(values)
+	if err != nil {
+		return nil, err
+	}
+	for i, arg := range args {
+		if named, ok := arg.(sql.NamedArg); ok {
+			nargs[i].Name = named.Name
+		}
+	}
+	return nargs, nil
+}
@@ -0,0 +1,78 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStmt(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE events (id BIGINT, kind VARCHAR, value DOUBLE)`)
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	con, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer con.Close()
+
+	require.NoError(t, RawConn(con, func(c *Conn) error {
+		s, err := c.Prepare(ctx, `INSERT INTO events VALUES (?, ?, ?)`)
+		require.NoError(t, err)
+		require.Equal(t, 3, s.NumInput())
+
+		// The bindings of the kind and the value are kept between the executions.
+		require.NoError(t, s.Bind(int64(0), "click", 1.5))
+		for id := 1; id <= 1000; id++ {
+			require.NoError(t, s.BindParam(1, id))
+			res, err := s.Exec(ctx)
+			require.NoError(t, err)
+			affected, err := res.RowsAffected()
+			require.NoError(t, err)
+			require.Equal(t, int64(1), affected)
+		}
+
+		require.NoError(t, s.ClearBindings())
+		_, err = s.Exec(ctx)
+		require.Error(t, err)
+
+		err = s.Bind(int64(1))
+		testError(t, err, errStmtBind.Error(), "incorrect argument count")
+		err = s.BindParam(4, "x")
+		testError(t, err, errStmtBind.Error(), "invalid parameter index: 4")
+		err = s.BindParam(2, struct{}{})
+		testError(t, err, errStmtBind.Error())
+
+		require.NoError(t, s.Close())
+		require.NoError(t, s.Close())
+		require.Equal(t, 0, s.NumInput())
+		err = s.Bind(int64(1), "view", 2.0)
+		testError(t, err, errStmtBind.Error(), errClosedStmt.Error())
+		_, err = s.Exec(ctx)
+		require.ErrorIs(t, err, errClosedStmt)
+
+		s, err = c.Prepare(ctx, `SELECT count(*), sum(value) FROM events WHERE kind = $kind AND id > $id`)
+		require.NoError(t, err)
+		defer s.Close()
+		require.NoError(t, s.Bind(sql.Named("kind", "click"), sql.Named("id", 500)))
+		for _, id := range []int{500, 900} {
+			require.NoError(t, s.BindParam(2, id))
+			rows, err := s.Query(ctx)
+			require.NoError(t, err)
+			values := make([]driver.Value, 2)
+			require.NoError(t, rows.Next(values))
+			require.Equal(t, int64(1000-id), values[0])
+			require.Equal(t, 1.5*float64(1000-id), values[1])
+			require.ErrorIs(t, rows.Next(values), io.EOF)
+			require.NoError(t, rows.Close())
+		}
+		return nil
+	}))
+}
@@ -0,0 +1,99 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+)
+
+// Relation is a lazily evaluated query, which is composed of relational operators.
+// Each operator returns a new Relation, so a Relation can be reused as the base of several queries.
+// Nothing executes until calling Execute.
+//
+// The C API of DuckDB does not expose its relation API, so a Relation composes SQL subqueries
+// and executes them through a Queryer. DuckDB's optimizer flattens the subqueries,
+// e.g., it pushes filters down into table scans.
+type Relation struct {
+	db    Queryer
+	query string
+	args  []any
+	// order is the ORDER BY clause of the relation, if any. DuckDB does not guarantee that the order of a subquery
+	// survives its enclosing query, so SQL appends it to the outermost query instead of wrapping it.
+	order string
+}
+
+// TableRelation returns the Relation of all rows of a table.
+// The table name may be schema-qualified, e.g., "main.tbl", and each part is quoted.
+func TableRelation(db Queryer, table string) *Relation {
+	return &Relation{db: db, query: "SELECT * FROM " + quoteQualifiedIdentifier(table)}
+}
+
+// QueryRelation returns the Relation of the result of a query.
+// The query must use ? placeholders for its parameters.
+func QueryRelation(db Queryer, query string, args ...any) *Relation {
+	return &Relation{db: db, query: query, args: args}
+}
+
+// Filter returns the rows of r satisfying the condition expr, in the order of r.
+// The expression must use ? placeholders for its parameters.
+func (r *Relation) Filter(expr string, args ...any) *Relation {
+	return r.wrap("SELECT * FROM (", ") WHERE "+expr, args...)
+}
+
+// Project returns the expressions exprs evaluated for each row of r, e.g., "a", "b + 1 AS c", in the order of r.
+func (r *Relation) Project(exprs ...string) *Relation {
+	return r.wrap("SELECT "+strings.Join(exprs, ", ")+" FROM (", ")")
+}
+
+// Order returns the rows of r ordered by exprs, e.g., "a DESC", "b". The order applies to the outermost query of
+// the Relation, so each Project following Order must keep the columns, which exprs refer to, unless it is the last
+// operator. Join does not keep the order.
+func (r *Relation) Order(exprs ...string) *Relation {
+	rel := r.wrap("", "")
+	rel.order = strings.Join(exprs, ", ")
+	return rel
+}
+
+// Limit returns at most n rows of r, i.e., the first n rows in the order of r.
+func (r *Relation) Limit(n int) *Relation {
+	if r.order == "" {
+		return r.wrap("SELECT * FROM (", ") LIMIT ?", n)
+	}
+	// The limit applies to the ordered rows, and the enclosing query keeps their order.
+	return r.wrap("SELECT * FROM (SELECT * FROM (", ") ORDER BY "+r.order+" LIMIT ?)", n)
+}
+
+// Join returns the inner join of r and other on the condition cond.
+// The columns of r and other are qualified by the aliases lhs and rhs, e.g., "lhs.id = rhs.id".
+func (r *Relation) Join(other *Relation, cond string) *Relation {
+	args := append(append([]any{}, r.args...), other.args...)
+	query := fmt.Sprintf("SELECT * FROM (%s) AS lhs JOIN (%s) AS rhs ON %s", r.query, other.query, cond)
+	return &Relation{db: r.db, query: query, args: args}
+}
+
+// SQL returns the query of r, and its arguments.
+func (r *Relation) SQL() (string, []any) {
+	query := r.query
+	if r.order != "" {
+		query += " ORDER BY " + r.order
+	}
+	return query, append([]any{}, r.args...)
+}
+
+// Execute executes r and returns its rows.
+func (r *Relation) Execute(ctx context.Context) (*sql.Rows, error) {
+	query, args := r.SQL()
+	return r.db.QueryContext(ctx, query, args...)
+}
+
+// wrap returns a new Relation, whose query wraps the query of r as a subquery between prefix and suffix, and which
+// keeps the order of r. The args are appended to the arguments of r, so the suffix must contain all placeholders.
+func (r *Relation) wrap(prefix string, suffix string, args ...any) *Relation {
+	return &Relation{
+		db:    r.db,
+		query: prefix + r.query + suffix,
+		args:  append(append([]any{}, r.args...), args...),
+		order: r.order,
+	}
+}
@@ -0,0 +1,88 @@
+package duckdb
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestRelation(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE SCHEMA s;
+		CREATE TABLE s."user" AS SELECT i AS id, 'user ' || i AS name FROM range(10) t(i);
+		CREATE TABLE orders AS SELECT i AS id, i % 3 AS user_id, i * 10 AS amount FROM range(20) t(i)`)
+	require.NoError(t, err)
+
+	collect := func(t *testing.T, rel *Relation) [][]any {
+		rows, err := rel.Execute(context.Background())
+		require.NoError(t, err)
+		defer rows.Close()
+
+		cols, err := rows.Columns()
+		require.NoError(t, err)
+
+		var res [][]any
+		for rows.Next() {
+			row := make([]any, len(cols))
+			ptrs := make([]any, len(cols))
+			for i := range row {
+				ptrs[i] = &row[i]
+			}
+			require.NoError(t, rows.Scan(ptrs...))
+			res = append(res, row)
+		}
+		require.NoError(t, rows.Err())
+		return res
+	}
+
+	users := TableRelation(db, `s.user`)
+
+	t.Run("filter, project, order, and limit", func(t *testing.T) {
+		rel := users.Filter("id >= ? AND id < ?", 2, 8).Project("id", "upper(name) AS name").Order("id DESC").Limit(3)
+		require.Equal(t, [][]any{{int64(7), "USER 7"}, {int64(6), "USER 6"}, {int64(5), "USER 5"}}, collect(t, rel))
+
+		// Operators do not modify their base relation.
+		require.Len(t, collect(t, users), 10)
+	})
+
+	t.Run("order of the outermost query", func(t *testing.T) {
+		orders := TableRelation(db, "orders")
+		rel := orders.Order("amount DESC").Filter("user_id = ?", 1).Project("id")
+		require.Equal(t, [][]any{{int64(19)}, {int64(16)}, {int64(13)}, {int64(10)}, {int64(7)}, {int64(4)}, {int64(1)}},
+			collect(t, rel))
+
+		// The ORDER BY is not part of a subquery.
+		query, _ := rel.SQL()
+		require.Equal(t, 1, strings.Count(query, "ORDER BY"))
+		require.True(t, strings.HasSuffix(query, ") WHERE user_id = ?) ORDER BY amount DESC"), query)
+
+		// The limit applies to the ordered rows, and its enclosing queries keep their order.
+		rel = orders.Order("amount DESC").Limit(5).Filter("user_id <> ?", 0).Project("id", "amount")
+		require.Equal(t, [][]any{{int64(19), int64(190)}, {int64(17), int64(170)}, {int64(16), int64(160)}},
+			collect(t, rel))
+	})
+
+	t.Run("join", func(t *testing.T) {
+		orders := QueryRelation(db, `SELECT user_id, sum(amount) AS total FROM orders WHERE amount > ? GROUP BY user_id`, 50)
+		rel := users.Filter("id < ?", 2).
+			Join(orders, "lhs.id = rhs.user_id").
+			Project("name", "total::BIGINT").
+			Order("name")
+		require.Equal(t, [][]any{{"user 0", int64(60 + 90 + 120 + 150 + 180)}, {"user 1", int64(70 + 100 + 130 + 160 + 190)}},
+			collect(t, rel))
+
+		query, args := rel.SQL()
+		require.Contains(t, query, `FROM "s"."user"`)
+		require.Equal(t, []any{2, 50}, args)
+	})
+
+	t.Run("error", func(t *testing.T) {
+		_, err := users.Project("missing").Execute(context.Background())
+		require.ErrorContains(t, err, "missing")
+	})
+}
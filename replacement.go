@@ -0,0 +1,125 @@
+package duckdb
+
+/*
+#include <stdlib.h>
+#include <duckdb.h>
+
+void replacementScan(duckdb_replacement_scan_info info, char *table_name, void *data);
+void deleteHandle(void *data);
+*/
+import "C"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"unsafe"
+)
+
+// ReplacementScan replaces a reference to an unknown table, e.g., my_source of SELECT * FROM my_source,
+// by a call of the table function with the arguments, e.g., read_parquet with the path of a file,
+// or a table function of RegisterTableFunction.
+// It returns an empty function name to keep the reference, which then fails, unless another replacement scan
+// replaces it. The arguments can be strings, integers, and string slices, which bind as VARCHAR[].
+type ReplacementScan func(tableName string) (function string, args []any, err error)
+
+// AddReplacementScan adds the replacement scan to the database of the connector.
+// DuckDB calls the replacement scans in the order of their addition when binding a query,
+// so the replacement scan must be safe for concurrent use by the connections of the connector.
+func (c *Connector) AddReplacementScan(scan ReplacementScan) error {
+	if c.db == nil {
+		return getError(errReplacementScan, errors.New("closed connector"))
+	}
+	if scan == nil {
+		return getError(errReplacementScan, errors.New("missing replacement scan"))
+	}
+	C.duckdb_add_replacement_scan(c.db, C.duckdb_replacement_callback_t(C.replacementScan), newHandle(scan),
+		C.duckdb_delete_callback_t(C.deleteHandle))
+	return nil
+}
+
+//export replacementScan
+func replacementScan(info C.duckdb_replacement_scan_info, tableName *C.char, data unsafe.Pointer) {
+	scan := handleValue(data).(ReplacementScan)
+
+	function, args, err := func() (function string, args []any, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic in replacement scan: %v", r)
+			}
+		}()
+		return scan(C.GoString(tableName))
+	}()
+	if err == nil && function == "" {
+		return
+	}
+
+	var values []C.duckdb_value
+	defer func() {
+		for i := range values {
+			C.duckdb_destroy_value(&values[i])
+		}
+	}()
+	for i := 0; err == nil && i < len(args); i++ {
+		var value C.duckdb_value
+		if value, err = replacementScanArg(args[i]); err == nil {
+			values = append(values, value)
+		}
+	}
+	if err != nil {
+		msg := C.CString(err.Error())
+		defer C.free(unsafe.Pointer(msg))
+		C.duckdb_replacement_scan_set_error(info, msg)
+		return
+	}
+
+	cFunction := C.CString(function)
+	defer C.free(unsafe.Pointer(cFunction))
+	C.duckdb_replacement_scan_set_function_name(info, cFunction)
+	for _, value := range values {
+		C.duckdb_replacement_scan_add_parameter(info, value)
+	}
+}
+
+// replacementScanArg returns the value of the argument of a ReplacementScan. The caller must destroy it.
+func replacementScanArg(arg any) (C.duckdb_value, error) {
+	v := reflect.ValueOf(arg)
+	switch v.Kind() {
+	case reflect.String:
+		cText := C.CString(v.String())
+		defer C.free(unsafe.Pointer(cText))
+		return C.duckdb_create_varchar(cText), nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return C.duckdb_create_int64(C.int64_t(v.Int())), nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return nil, fmt.Errorf("replacement scan argument %d out of range", v.Uint())
+		}
+		return C.duckdb_create_int64(C.int64_t(v.Uint())), nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() != reflect.String {
+			break
+		}
+
+		elems := make([]C.duckdb_value, v.Len())
+		for i := range elems {
+			cText := C.CString(v.Index(i).String())
+			elems[i] = C.duckdb_create_varchar(cText)
+			C.free(unsafe.Pointer(cText))
+		}
+		defer func() {
+			for i := range elems {
+				C.duckdb_destroy_value(&elems[i])
+			}
+		}()
+
+		lt := C.duckdb_create_logical_type(C.DUCKDB_TYPE_VARCHAR)
+		defer C.duckdb_destroy_logical_type(&lt)
+		if len(elems) == 0 {
+			return C.duckdb_create_list_value(lt, nil, 0), nil
+		}
+		return C.duckdb_create_list_value(lt, &elems[0], C.idx_t(len(elems))), nil
+	}
+	return nil, castError(fmt.Sprintf("%T", arg), "replacement scan argument")
+}
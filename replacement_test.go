@@ -0,0 +1,126 @@
+package duckdb
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestReplacementScan(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	dir := t.TempDir()
+	require.NoError(t, c.AddReplacementScan(func(tableName string) (string, []any, error) {
+		switch {
+		case strings.HasPrefix(tableName, "numbers_"):
+			var n int
+			if _, err := fmt.Sscanf(tableName, "numbers_%d", &n); err != nil {
+				return "", nil, err
+			}
+			return "range", []any{n}, nil
+		case tableName == "csv_files":
+			return "read_csv", []any{[]string{filepath.Join(dir, "a.csv"), filepath.Join(dir, "b.csv")}}, nil
+		case tableName == "failing":
+			return "", nil, errors.New("no such source")
+		case tableName == "bad_arg":
+			return "range", []any{1.5}, nil
+		}
+		return "", nil, nil
+	}))
+	require.NoError(t, c.AddReplacementScan(func(tableName string) (string, []any, error) {
+		if tableName == "greeting" {
+			return "repeat", []any{"hello", uint8(2)}, nil
+		}
+		return "", nil, nil
+	}))
+
+	db := sql.OpenDB(c)
+	defer db.Close()
+
+	t.Run("table function", func(t *testing.T) {
+		var count, sum int64
+		require.NoError(t, db.QueryRow(`SELECT count(*), sum(range) FROM numbers_10`).Scan(&count, &sum))
+		require.Equal(t, int64(10), count)
+		require.Equal(t, int64(45), sum)
+	})
+
+	t.Run("string list argument", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "a.csv"), []byte("i\n1\n2\n"), 0o600))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "b.csv"), []byte("i\n3\n"), 0o600))
+
+		var sum int64
+		require.NoError(t, db.QueryRow(`SELECT sum(i) FROM csv_files`).Scan(&sum))
+		require.Equal(t, int64(6), sum)
+	})
+
+	t.Run("later replacement scan", func(t *testing.T) {
+		rows, err := db.Query(`SELECT * FROM greeting`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var values []string
+		for rows.Next() {
+			var s string
+			require.NoError(t, rows.Scan(&s))
+			values = append(values, s)
+		}
+		require.NoError(t, rows.Err())
+		require.Equal(t, []string{"hello", "hello"}, values)
+	})
+
+	t.Run("existing table", func(t *testing.T) {
+		_, err := db.Exec(`CREATE TABLE numbers_3 (i INTEGER); INSERT INTO numbers_3 VALUES (42)`)
+		require.NoError(t, err)
+
+		var i int32
+		require.NoError(t, db.QueryRow(`SELECT * FROM numbers_3`).Scan(&i))
+		require.Equal(t, int32(42), i)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		err := db.QueryRow(`SELECT * FROM failing`).Scan()
+		require.ErrorContains(t, err, "no such source")
+
+		err = db.QueryRow(`SELECT * FROM bad_arg`).Scan()
+		require.ErrorContains(t, err, castErrMsg)
+
+		err = db.QueryRow(`SELECT * FROM unknown_table`).Scan()
+		require.ErrorContains(t, err, "unknown_table")
+
+		err = c.AddReplacementScan(nil)
+		testError(t, err, errReplacementScan.Error(), "missing replacement scan")
+	})
+}
+
+func TestReplacementScanTableFunction(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	require.NoError(t, c.AddReplacementScan(func(tableName string) (string, []any, error) {
+		if tableName == "go_source" {
+			return "series", []any{int64(20)}, nil
+		}
+		return "", nil, nil
+	}))
+
+	db := sql.OpenDB(c)
+	defer db.Close()
+	registerTableFunction(t, db, "series", seriesFunction())
+
+	var count int64
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM go_source`).Scan(&count))
+	require.Equal(t, int64(20), count)
+}
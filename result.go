@@ -4,10 +4,15 @@ type result struct {
 	rowsAffected int64
 }
 
+// LastInsertId always returns 0, as DuckDB has no row IDs of inserted rows. Use a RETURNING clause instead.
 func (r result) LastInsertId() (int64, error) {
 	return 0, nil
 }
 
+// RowsAffected returns the number of rows inserted, updated, or deleted by the statement, including the
+// rows of an INSERT ... ON CONFLICT DO UPDATE, and the number of rows created by CREATE TABLE ... AS,
+// or copied by COPY. It returns 0 without an error for other statements, e.g., SELECT, CREATE TABLE, or SET.
+// Executing multiple statements returns the number of rows of the last statement.
 func (r result) RowsAffected() (int64, error) {
 	return r.rowsAffected, nil
 }
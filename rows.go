@@ -6,10 +6,13 @@ package duckdb
 import "C"
 
 import (
+	"bytes"
+	"context"
 	"database/sql/driver"
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"math/big"
 	"reflect"
 	"strings"
@@ -20,29 +23,74 @@ import (
 type rows struct {
 	res           C.duckdb_result
 	stmt          *stmt
+	config        *connectorConfig
 	chunk         C.duckdb_data_chunk
 	columns       []string
 	chunkCount    C.idx_t
 	chunkRowCount C.idx_t
 	chunkIdx      C.idx_t
 	chunkRowIdx   C.idx_t
+	// True, if the chunks of the result are computed when fetching them, see WithStreamingResults.
+	streaming bool
+	// True, if all chunks of the result have been fetched.
+	exhausted bool
+	// The ID of the query of a streaming result, while it is in flight, see ActiveQueries.
+	queryID uint64
+	// The context of the query of a streaming result. Cancelling it interrupts fetching the next chunk.
+	ctx context.Context
+	// The results of the following result sets of a query with multiple statements, see NextResultSet.
+	resultSets []C.duckdb_result
+	// The scanner of each column, which resolves the conversion of the column once per result set,
+	// and the data of its vector once per chunk.
+	scanners []*vectorScanner
+	// strings holds the VARCHAR values of the rows, so that scanning a string does not allocate.
+	strings stringArena
+	// True, if the rows are closed, so that reading them returns an error matching ErrClosed.
+	closed bool
+	// The leak check of the rows, see newLeakCheck.
+	leak *leakCheck
+	// restoreMemoryLimit restores the memory limit of a streaming result, if any, when closing the rows,
+	// see ContextWithMemoryLimit.
+	restoreMemoryLimit func()
 }
 
 func newRowsWithStmt(res C.duckdb_result, stmt *stmt) *rows {
+	r := &rows{
+		stmt:   stmt,
+		config: stmt.c.config,
+	}
+	r.setResult(res)
+	r.leak = newLeakCheck(stmt.c, "duckdb rows garbage collected without Close", stmt.query)
+	return r
+}
+
+// setResult sets the result of the current result set of the rows.
+func (r *rows) setResult(res C.duckdb_result) {
 	n := C.duckdb_column_count(&res)
 	columns := make([]string, 0, n)
+	scanners := make([]*vectorScanner, n)
 	for i := C.idx_t(0); i < n; i++ {
 		columns = append(columns, C.GoString(C.duckdb_column_name(&res, i)))
+		ty := C.duckdb_column_logical_type(&res, i)
+		scanners[i] = newVectorScanner(r.config, ty, &r.strings)
+		C.duckdb_destroy_logical_type(&ty)
+		// database/sql copies bytes before the next call of Next, except for a sql.RawBytes destination,
+		// which must not be used after it. So the bytes of a column need not be copied from the chunk.
+		scanners[i].borrowBytes = true
 	}
 
-	return &rows{
-		res:           res,
-		stmt:          stmt,
-		columns:       columns,
-		chunkCount:    C.duckdb_result_chunk_count(res),
-		chunkRowCount: 0,
-		chunkIdx:      0,
-		chunkRowIdx:   0,
+	r.res = res
+	r.columns = columns
+	r.scanners = scanners
+	r.chunkCount = 0
+	r.chunkRowCount = 0
+	r.chunkIdx = 0
+	r.chunkRowIdx = 0
+	r.exhausted = false
+	r.streaming = bool(C.duckdb_result_is_streaming(res))
+	// The materialized result functions cannot be mixed with the streaming result functions.
+	if !r.streaming {
+		r.chunkCount = C.duckdb_result_chunk_count(res)
 	}
 }
 
@@ -51,22 +99,33 @@ func (r *rows) Columns() []string {
 }
 
 func (r *rows) Next(dst []driver.Value) error {
+	if r.closed {
+		return getError(errClosedRows, nil)
+	}
+	// A result without columns, e.g., of a statement without output, has no rows to scan.
+	if len(r.columns) == 0 {
+		return io.EOF
+	}
+
 	for r.chunkRowIdx == r.chunkRowCount {
 		C.duckdb_destroy_data_chunk(&r.chunk)
-		if r.chunkIdx == r.chunkCount {
+		chunk, err := r.nextChunk()
+		if err != nil {
+			return err
+		}
+		if chunk == nil {
 			return io.EOF
 		}
-		r.chunk = C.duckdb_result_get_chunk(r.res, r.chunkIdx)
-		r.chunkIdx++
+		r.chunk = chunk
 		r.chunkRowCount = C.duckdb_data_chunk_get_size(r.chunk)
 		r.chunkRowIdx = 0
+		for colIdx, scanner := range r.scanners {
+			scanner.setVector(C.duckdb_data_chunk_get_vector(r.chunk, C.idx_t(colIdx)))
+		}
 	}
 
-	colCount := len(r.columns)
-
-	for colIdx := C.idx_t(0); colIdx < C.idx_t(colCount); colIdx++ {
-		vector := C.duckdb_data_chunk_get_vector(r.chunk, colIdx)
-		value, err := scanValue(vector, r.chunkRowIdx)
+	for colIdx, scanner := range r.scanners {
+		value, err := scanner.value(r.chunkRowIdx)
 		if err != nil {
 			return err
 		}
@@ -78,29 +137,60 @@ func (r *rows) Next(dst []driver.Value) error {
 	return nil
 }
 
-func scanValue(vector C.duckdb_vector, rowIdx C.idx_t) (any, error) {
-	v, err := scan(vector, rowIdx)
-	if err != nil {
-		return nil, err
+// nextChunk fetches the next chunk of the result, which the caller must destroy.
+// It returns nil, if the result is exhausted.
+func (r *rows) nextChunk() (C.duckdb_data_chunk, error) {
+	if r.closed {
+		return nil, getError(errClosedRows, nil)
+	}
+	if r.exhausted {
+		return nil, nil
 	}
 
-	switch value := v.(type) {
-	case map[string]any, []any, Map, driver.Value:
-		return value, nil
-	case nil:
+	if !r.streaming {
+		if r.chunkIdx == r.chunkCount {
+			r.exhausted = true
+			return nil, nil
+		}
+		chunk := C.duckdb_result_get_chunk(r.res, r.chunkIdx)
+		r.chunkIdx++
+		r.countChunk()
+		return chunk, nil
+	}
+
+	// Fetching the next chunk of a streaming result executes the query on the connection.
+	ctx := r.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	r.stmt.c.mu.Lock()
+	stop := r.stmt.c.interruptOnDone(ctx)
+	chunk := C.duckdb_stream_fetch_chunk(r.res)
+	stop()
+	r.stmt.c.mu.Unlock()
+	if chunk == nil {
+		r.exhausted = true
+		r.finishQuery()
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		if err := C.duckdb_result_error(&r.res); err != nil {
+			return nil, r.stmt.c.queryError(C.GoString(err))
+		}
 		return nil, nil
-	default:
-		panic(fmt.Sprintf("BUG: found unexpected type when scanning: %T", value))
 	}
+	r.countChunk()
+	return chunk, nil
 }
 
-func scan(vector C.duckdb_vector, rowIdx C.idx_t) (any, error) {
-	// FIXME: implement support for these types:
-	// DUCKDB_TYPE_UHUGEINT
-	// DUCKDB_TYPE_UNION
-	// DUCKDB_TYPE_BIT
-	// DUCKDB_TYPE_TIME_TZ
+// countChunk counts a fetched chunk in the statistics of the Connector, see Connector.Stats.
+func (r *rows) countChunk() {
+	if r.stmt != nil && r.stmt.c.stats != nil {
+		r.stmt.c.stats.fetchedChunks.Add(1)
+	}
+}
 
+func scan(config *connectorConfig, vector C.duckdb_vector, rowIdx C.idx_t) (any, error) {
 	validity := C.duckdb_vector_get_validity(vector)
 	if !C.duckdb_validity_row_is_valid(validity, rowIdx) {
 		return nil, nil
@@ -132,46 +222,65 @@ func scan(vector C.duckdb_vector, rowIdx C.idx_t) (any, error) {
 	case C.DUCKDB_TYPE_UBIGINT:
 		return get[uint64](vector, rowIdx), nil
 	case C.DUCKDB_TYPE_FLOAT:
-		return get[float32](vector, rowIdx), nil
+		v := get[float32](vector, rowIdx)
+		return config.nonFiniteFloat(v, float64(v))
 	case C.DUCKDB_TYPE_DOUBLE:
-		return get[float64](vector, rowIdx), nil
+		v := get[float64](vector, rowIdx)
+		return config.nonFiniteFloat(v, v)
 	case C.DUCKDB_TYPE_TIMESTAMP:
-		return time.UnixMicro(int64(get[C.duckdb_timestamp](vector, rowIdx).micros)).UTC(), nil
+		return scanTimestamp(config, vector, rowIdx, time.UnixMicro)
 	case C.DUCKDB_TYPE_DATE:
-		date := C.duckdb_from_date(get[C.duckdb_date](vector, rowIdx))
-		return time.Date(int(date.year), time.Month(date.month), int(date.day), 0, 0, 0, 0, time.UTC), nil
+		return scanDate(config, vector, rowIdx)
 	case C.DUCKDB_TYPE_TIME:
 		return time.UnixMicro(int64(get[C.duckdb_time](vector, rowIdx).micros)).UTC(), nil
+	case C.DUCKDB_TYPE_TIME_TZ:
+		return scanTimeTZ(vector, rowIdx), nil
 	case C.DUCKDB_TYPE_INTERVAL:
 		return scanInterval(vector, rowIdx)
 	case C.DUCKDB_TYPE_HUGEINT:
 		hugeInt := get[C.duckdb_hugeint](vector, rowIdx)
 		return hugeIntToNative(hugeInt), nil
+	case C.DUCKDB_TYPE_UHUGEINT:
+		return uhugeIntToNative(get[C.duckdb_uhugeint](vector, rowIdx)), nil
 	case C.DUCKDB_TYPE_VARCHAR:
+		if isJSONType(columnType) {
+			// JSON values scan as bytes, so that they scan into a json.RawMessage.
+			return scanBlob(vector, rowIdx), nil
+		}
 		return scanString(vector, rowIdx), nil
 	case C.DUCKDB_TYPE_BLOB:
 		return scanBlob(vector, rowIdx), nil
 	case C.DUCKDB_TYPE_DECIMAL:
 		return scanDecimal(columnType, vector, rowIdx)
 	case C.DUCKDB_TYPE_TIMESTAMP_S:
-		return time.Unix(int64(get[C.duckdb_timestamp](vector, rowIdx).micros), 0).UTC(), nil
+		return scanTimestamp(config, vector, rowIdx, func(s int64) time.Time { return time.Unix(s, 0) })
 	case C.DUCKDB_TYPE_TIMESTAMP_MS:
-		return time.UnixMilli(int64(get[C.duckdb_timestamp](vector, rowIdx).micros)).UTC(), nil
+		return scanTimestamp(config, vector, rowIdx, time.UnixMilli)
 	case C.DUCKDB_TYPE_TIMESTAMP_NS:
-		return time.Unix(0, int64(get[C.duckdb_timestamp](vector, rowIdx).micros)).UTC(), nil
+		return scanTimestamp(config, vector, rowIdx, func(ns int64) time.Time { return time.Unix(0, ns) })
 	case C.DUCKDB_TYPE_ENUM:
 		return scanENUM(columnType, vector, rowIdx)
 	case C.DUCKDB_TYPE_LIST:
-		return scanList(vector, rowIdx)
+		return scanList(config, vector, rowIdx)
+	case C.DUCKDB_TYPE_ARRAY:
+		return scanArray(config, columnType, vector, rowIdx)
 	case C.DUCKDB_TYPE_STRUCT:
-		return scanStruct(columnType, vector, rowIdx)
+		return scanStruct(config, columnType, vector, rowIdx)
 	case C.DUCKDB_TYPE_MAP:
-		return scanMap(columnType, vector, rowIdx)
+		return scanMap(config, columnType, vector, rowIdx)
+	case C.DUCKDB_TYPE_UNION:
+		return scanUnion(config, columnType, vector, rowIdx)
+	case C.DUCKDB_TYPE_BIT:
+		return bitStringFromStorage(stringAt(unsafe.Pointer(C.duckdb_vector_get_data(vector)), rowIdx))
 	case C.DUCKDB_TYPE_UUID:
 		hugeInt := get[C.duckdb_hugeint](vector, rowIdx)
 		return hugeIntToUUID(hugeInt), nil
 	case C.DUCKDB_TYPE_TIMESTAMP_TZ:
-		return time.UnixMicro(int64(get[C.duckdb_timestamp](vector, rowIdx).micros)).UTC(), nil
+		micros := int64(get[C.duckdb_timestamp](vector, rowIdx).micros)
+		if isInfiniteTimestamp(micros) {
+			return config.infiniteTime(micros > 0, false)
+		}
+		return time.UnixMicro(micros).In(config.timestampTZLocation()), nil
 	default:
 		return nil, fmt.Errorf("unsupported type %d", typeId)
 	}
@@ -208,14 +317,22 @@ func (r *rows) ColumnTypeScanType(index int) reflect.Type {
 	case C.DUCKDB_TYPE_TIMESTAMP:
 		return reflect.TypeOf(time.Time{})
 	case C.DUCKDB_TYPE_DATE:
+		if r.config != nil && r.config.civilDates {
+			return reflect.TypeOf(Date{})
+		}
 		return reflect.TypeOf(time.Time{})
-	case C.DUCKDB_TYPE_TIME:
+	case C.DUCKDB_TYPE_TIME, C.DUCKDB_TYPE_TIME_TZ:
 		return reflect.TypeOf(time.Time{})
 	case C.DUCKDB_TYPE_INTERVAL:
 		return reflect.TypeOf(Interval{})
-	case C.DUCKDB_TYPE_HUGEINT:
+	case C.DUCKDB_TYPE_HUGEINT, C.DUCKDB_TYPE_UHUGEINT:
 		return reflect.TypeOf(big.NewInt(0))
 	case C.DUCKDB_TYPE_VARCHAR:
+		lt := C.duckdb_column_logical_type(&r.res, C.idx_t(index))
+		defer C.duckdb_destroy_logical_type(&lt)
+		if isJSONType(lt) {
+			return reflect.TypeOf([]byte{})
+		}
 		return reflect.TypeOf("")
 	case C.DUCKDB_TYPE_ENUM:
 		return reflect.TypeOf("")
@@ -231,10 +348,16 @@ func (r *rows) ColumnTypeScanType(index int) reflect.Type {
 		return reflect.TypeOf(time.Time{})
 	case C.DUCKDB_TYPE_LIST:
 		return reflect.TypeOf([]any{})
+	case C.DUCKDB_TYPE_ARRAY:
+		return reflect.TypeOf([]any{})
 	case C.DUCKDB_TYPE_STRUCT:
 		return reflect.TypeOf(map[string]any{})
 	case C.DUCKDB_TYPE_MAP:
 		return reflect.TypeOf(Map{})
+	case C.DUCKDB_TYPE_UNION:
+		return reflect.TypeOf(Union{})
+	case C.DUCKDB_TYPE_BIT:
+		return reflect.TypeOf(BitString{})
 	case C.DUCKDB_TYPE_UUID:
 		return reflect.TypeOf([]byte{})
 	case C.DUCKDB_TYPE_TIMESTAMP_TZ:
@@ -255,8 +378,16 @@ func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
 		fallthrough
 	case C.DUCKDB_TYPE_LIST:
 		fallthrough
+	case C.DUCKDB_TYPE_ARRAY:
+		fallthrough
 	case C.DUCKDB_TYPE_STRUCT:
 		fallthrough
+	case C.DUCKDB_TYPE_VARCHAR:
+		fallthrough
+	case C.DUCKDB_TYPE_BLOB:
+		fallthrough
+	case C.DUCKDB_TYPE_UNION:
+		fallthrough
 	case C.DUCKDB_TYPE_MAP:
 		logColType := C.duckdb_column_logical_type(&r.res, C.idx_t(index))
 		defer C.duckdb_destroy_logical_type(&logColType)
@@ -267,12 +398,82 @@ func (r *rows) ColumnTypeDatabaseTypeName(index int) string {
 	}
 }
 
+// Implements driver.RowsColumnTypeNullable.
+// DuckDB does not report whether a result column contains NULL values, so ok is false.
+func (r *rows) ColumnTypeNullable(index int) (nullable, ok bool) {
+	return true, false
+}
+
+// Implements driver.RowsColumnTypeLength.
+// VARCHAR and BLOB columns have a variable length without limit.
+func (r *rows) ColumnTypeLength(index int) (length int64, ok bool) {
+	switch C.duckdb_column_type(&r.res, C.idx_t(index)) {
+	case C.DUCKDB_TYPE_VARCHAR, C.DUCKDB_TYPE_BLOB:
+		return math.MaxInt64, true
+	default:
+		return 0, false
+	}
+}
+
+// Implements driver.RowsColumnTypePrecisionScale.
+// The precision and scale of a DECIMAL column are its width and scale.
+func (r *rows) ColumnTypePrecisionScale(index int) (precision, scale int64, ok bool) {
+	if C.duckdb_column_type(&r.res, C.idx_t(index)) != C.DUCKDB_TYPE_DECIMAL {
+		return 0, 0, false
+	}
+	logColType := C.duckdb_column_logical_type(&r.res, C.idx_t(index))
+	defer C.duckdb_destroy_logical_type(&logColType)
+	return int64(C.duckdb_decimal_width(logColType)), int64(C.duckdb_decimal_scale(logColType)), true
+}
+
+// HasNextResultSet returns true, if the query has another result set, see NextResultSet.
+func (r *rows) HasNextResultSet() bool {
+	return len(r.resultSets) > 0
+}
+
+// NextResultSet advances to the next result set of a query with multiple statements. The result sets are the
+// results of the statements returning rows, followed by the result of the last statement.
+func (r *rows) NextResultSet() error {
+	if r.closed {
+		return getError(errClosedRows, nil)
+	}
+	if len(r.resultSets) == 0 {
+		return io.EOF
+	}
+	C.duckdb_destroy_data_chunk(&r.chunk)
+	C.duckdb_destroy_result(&r.res)
+	r.setResult(r.resultSets[0])
+	r.resultSets = r.resultSets[1:]
+	return nil
+}
+
+// Close closes the rows. Closing closed rows does nothing, like closing the rows of database/sql.
 func (r *rows) Close() error {
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+	r.leak.close()
+	// Interrupt the pending execution of a streaming result, instead of computing the rest of it.
+	if r.streaming && !r.exhausted && r.stmt != nil {
+		C.duckdb_interrupt(r.stmt.c.duckdbCon)
+	}
 	C.duckdb_destroy_data_chunk(&r.chunk)
 	C.duckdb_destroy_result(&r.res)
+	for i := range r.resultSets {
+		C.duckdb_destroy_result(&r.resultSets[i])
+	}
+	r.resultSets = nil
+	if r.restoreMemoryLimit != nil && r.stmt != nil {
+		r.stmt.c.mu.Lock()
+		r.restoreMemoryLimit()
+		r.stmt.c.mu.Unlock()
+		r.restoreMemoryLimit = nil
+	}
 
 	var err error
 	if r.stmt != nil {
+		r.finishQuery()
 		r.stmt.rows = false
 		if r.stmt.closeOnRowsClose {
 			err = r.stmt.Close()
@@ -289,8 +490,26 @@ func get[T any](vector C.duckdb_vector, rowIdx C.idx_t) T {
 	return xs[rowIdx]
 }
 
-func scanMap(ty C.duckdb_logical_type, vector C.duckdb_vector, rowIdx C.idx_t) (Map, error) {
-	list, err := scanList(vector, rowIdx)
+// scanUnion returns the active member of the UNION value at rowIdx of the vector.
+// The vector of a UNION is a STRUCT vector, whose first child contains the tags, i.e., the indexes of the active
+// members, and whose other children contain the values of the members.
+func scanUnion(config *connectorConfig, ty C.duckdb_logical_type, vector C.duckdb_vector, rowIdx C.idx_t) (Union, error) {
+	tag := C.idx_t(get[uint8](C.duckdb_struct_vector_get_child(vector, 0), rowIdx))
+	if tag >= C.duckdb_union_type_member_count(ty) {
+		return Union{}, errInvalidType
+	}
+
+	name := C.duckdb_union_type_member_name(ty, tag)
+	defer C.duckdb_free(unsafe.Pointer(name))
+	value, err := scan(config, C.duckdb_struct_vector_get_child(vector, tag+1), rowIdx)
+	if err != nil {
+		return Union{}, err
+	}
+	return Union{Tag: C.GoString(name), Value: value}, nil
+}
+
+func scanMap(config *connectorConfig, ty C.duckdb_logical_type, vector C.duckdb_vector, rowIdx C.idx_t) (Map, error) {
+	list, err := scanList(config, vector, rowIdx)
 	if err != nil {
 		return nil, err
 	}
@@ -326,32 +545,87 @@ func scanMap(ty C.duckdb_logical_type, vector C.duckdb_vector, rowIdx C.idx_t) (
 	return out, nil
 }
 
+// scanString copies the string once from the vector into Go memory.
 func scanString(vector C.duckdb_vector, rowIdx C.idx_t) string {
-	return string(scanBlob(vector, rowIdx))
+	return string(stringData(vector, rowIdx))
 }
 
-// duckdb/tools/juliapkg/src/ctypes.jl
-// `json`, `varchar`, and `blob` are C-style char arrays
 func scanBlob(vector C.duckdb_vector, rowIdx C.idx_t) []byte {
-	// we don't have to free s.ptr, as it is part of the data in the vector
-	s := get[duckdb_string_t](vector, rowIdx)
+	return bytes.Clone(stringData(vector, rowIdx))
+}
 
+// duckdb/tools/juliapkg/src/ctypes.jl
+// `json`, `varchar`, and `blob` are C-style char arrays.
+// stringData returns the bytes of the string without copying them.
+// The slice points into the memory of the vector, so it must be copied before the chunk is destroyed.
+func stringData(vector C.duckdb_vector, rowIdx C.idx_t) []byte {
+	return stringAt(C.duckdb_vector_get_data(vector), rowIdx)
+}
+
+// stringAt is like stringData, but it takes the data of the vector.
+func stringAt(data unsafe.Pointer, rowIdx C.idx_t) []byte {
+	// we don't have to free s.ptr, as it is part of the data in the vector
+	s := &(*[1 << 31]duckdb_string_t)(data)[rowIdx]
 	if s.length <= stringInlineLength {
 		// inlined data is stored from byte 4..16 (up to 12 bytes)
-		return C.GoBytes(unsafe.Pointer(&s.prefix), C.int(s.length))
+		return unsafe.Slice(&s.prefix[0], s.length)
 	}
 
 	// any longer strings are stored as a pointer in `ptr`
-	return C.GoBytes(unsafe.Pointer(s.ptr), C.int(s.length))
+	return unsafe.Slice((*byte)(unsafe.Pointer(s.ptr)), s.length)
 }
 
-func scanList(vector C.duckdb_vector, rowIdx C.idx_t) ([]any, error) {
+// stringArenaBlockSize is the size of the blocks of a stringArena.
+const stringArenaBlockSize = 16 << 10
+
+// stringArena copies strings out of vectors into blocks of Go memory, so that converting a string does not allocate,
+// except for every new block. The strings point into their blocks, so a block is never reused, and a string keeps
+// its block alive. Strings longer than a quarter of a block get their own allocation.
+type stringArena struct {
+	block []byte
+}
+
+// string returns a copy of the bytes as a string. A nil arena allocates each string.
+func (a *stringArena) string(b []byte) string {
+	if len(b) == 0 {
+		return ""
+	}
+	if a == nil || len(b) > stringArenaBlockSize/4 {
+		return string(b)
+	}
+	if len(a.block)+len(b) > cap(a.block) {
+		a.block = make([]byte, 0, stringArenaBlockSize)
+	}
+	start := len(a.block)
+	a.block = append(a.block, b...)
+	return unsafe.String(&a.block[start], len(b))
+}
+
+func scanList(config *connectorConfig, vector C.duckdb_vector, rowIdx C.idx_t) ([]any, error) {
 	data := C.duckdb_list_vector_get_child(vector)
 	entry := get[duckdb_list_entry_t](vector, rowIdx)
 	converted := make([]any, 0, entry.length)
 
 	for i := entry.offset; i < entry.offset+entry.length; i++ {
-		value, err := scan(data, i)
+		value, err := scan(config, data, i)
+		if err != nil {
+			return nil, err
+		}
+		converted = append(converted, value)
+	}
+
+	return converted, nil
+}
+
+// scanArray scans a fixed-size ARRAY. Like a LIST, it returns the elements as []any.
+func scanArray(config *connectorConfig, ty C.duckdb_logical_type, vector C.duckdb_vector, rowIdx C.idx_t) ([]any, error) {
+	data := C.duckdb_array_vector_get_child(vector)
+	size := C.duckdb_array_type_array_size(ty)
+	converted := make([]any, 0, size)
+
+	// The elements of all arrays are stored consecutively in the child vector.
+	for i := rowIdx * size; i < (rowIdx+1)*size; i++ {
+		value, err := scan(config, data, i)
 		if err != nil {
 			return nil, err
 		}
@@ -361,7 +635,7 @@ func scanList(vector C.duckdb_vector, rowIdx C.idx_t) ([]any, error) {
 	return converted, nil
 }
 
-func scanStruct(ty C.duckdb_logical_type, vector C.duckdb_vector, rowIdx C.idx_t) (map[string]any, error) {
+func scanStruct(config *connectorConfig, ty C.duckdb_logical_type, vector C.duckdb_vector, rowIdx C.idx_t) (map[string]any, error) {
 	data := map[string]any{}
 
 	for j := C.idx_t(0); j < C.duckdb_struct_type_child_count(ty); j++ {
@@ -370,7 +644,7 @@ func scanStruct(ty C.duckdb_logical_type, vector C.duckdb_vector, rowIdx C.idx_t
 		C.duckdb_free(unsafe.Pointer(ptrToChildName))
 
 		child := C.duckdb_struct_vector_get_child(vector, j)
-		value, err := scan(child, rowIdx)
+		value, err := scan(config, child, rowIdx)
 		if err != nil {
 			return nil, err
 		}
@@ -379,6 +653,48 @@ func scanStruct(ty C.duckdb_logical_type, vector C.duckdb_vector, rowIdx C.idx_t
 	return data, nil
 }
 
+// scanTimestamp interprets the wall clock time of a TIMESTAMP as being in the configured location.
+// The TIMESTAMP stores the ticks of its precision, which toTime converts, e.g., the microseconds of a TIMESTAMP.
+func scanTimestamp(config *connectorConfig, vector C.duckdb_vector, rowIdx C.idx_t,
+	toTime func(ticks int64) time.Time,
+) (any, error) {
+	ticks := int64(get[C.duckdb_timestamp](vector, rowIdx).micros)
+	if isInfiniteTimestamp(ticks) {
+		return config.infiniteTime(ticks > 0, false)
+	}
+	return wallClockIn(toTime(ticks), config.timestampLocation()), nil
+}
+
+// scanDate returns a DATE as a Date with WithCivilDates, and otherwise as the time.Time of its midnight
+// in the configured location.
+func scanDate(config *connectorConfig, vector C.duckdb_vector, rowIdx C.idx_t) (any, error) {
+	civil := config != nil && config.civilDates
+	days := get[C.duckdb_date](vector, rowIdx)
+	if isInfiniteDate(int32(days.days)) {
+		return config.infiniteTime(days.days > 0, civil)
+	}
+	date := C.duckdb_from_date(days)
+	d := Date{Year: int(date.year), Month: time.Month(date.month), Day: int(date.day)}
+	if civil {
+		return d, nil
+	}
+	return d.In(config.dateLocation()), nil
+}
+
+// microsToTimestamp returns the wall clock time of the TIMESTAMP micros in loc.
+func microsToTimestamp(micros int64, loc *time.Location) time.Time {
+	return wallClockIn(time.UnixMicro(micros), loc)
+}
+
+// wallClockIn returns the wall clock time of ts in UTC, attached to loc without shifting it.
+func wallClockIn(ts time.Time, loc *time.Location) time.Time {
+	ts = ts.UTC()
+	if loc == time.UTC {
+		return ts
+	}
+	return time.Date(ts.Year(), ts.Month(), ts.Day(), ts.Hour(), ts.Minute(), ts.Second(), ts.Nanosecond(), loc)
+}
+
 func scanDecimal(ty C.duckdb_logical_type, vector C.duckdb_vector, rowIdx C.idx_t) (Decimal, error) {
 	scale := C.duckdb_decimal_scale(ty)
 	width := C.duckdb_decimal_width(ty)
@@ -407,6 +723,14 @@ func scanDecimal(ty C.duckdb_logical_type, vector C.duckdb_vector, rowIdx C.idx_
 	return Decimal{Width: uint8(width), Scale: uint8(scale), Value: nativeValue}, nil
 }
 
+// scanTimeTZ returns the TIME WITH TIME ZONE value at rowIdx of the vector as a time.Time on January 1, 1970,
+// in a fixed zone with the offset of the value.
+func scanTimeTZ(vector C.duckdb_vector, rowIdx C.idx_t) time.Time {
+	tz := C.duckdb_from_time_tz(get[C.duckdb_time_tz](vector, rowIdx))
+	loc := time.FixedZone("", int(tz.offset))
+	return time.Date(1970, 1, 1, int(tz.time.hour), int(tz.time.min), int(tz.time.sec), int(tz.time.micros)*1000, loc)
+}
+
 func scanInterval(vector C.duckdb_vector, rowIdx C.idx_t) (Interval, error) {
 	i := get[C.duckdb_interval](vector, rowIdx)
 	data := Interval{
@@ -418,24 +742,29 @@ func scanInterval(vector C.duckdb_vector, rowIdx C.idx_t) (Interval, error) {
 }
 
 func scanENUM(ty C.duckdb_logical_type, vector C.duckdb_vector, rowIdx C.idx_t) (string, error) {
-	var idx uint64
-	internalType := C.duckdb_enum_internal_type(ty)
+	idx, err := enumIndex(C.duckdb_enum_internal_type(ty), vector, rowIdx)
+	if err != nil {
+		return "", err
+	}
+
+	val := C.duckdb_enum_dictionary_value(ty, (C.idx_t)(idx))
+	defer C.duckdb_free(unsafe.Pointer(val))
+	return C.GoString(val), nil
+}
+
+// enumIndex returns the index into the dictionary of the ENUM value at rowIdx of the vector.
+func enumIndex(internalType C.duckdb_type, vector C.duckdb_vector, rowIdx C.idx_t) (uint64, error) {
 	switch internalType {
 	case C.DUCKDB_TYPE_UTINYINT:
-		idx = uint64(get[uint8](vector, rowIdx))
+		return uint64(get[uint8](vector, rowIdx)), nil
 	case C.DUCKDB_TYPE_USMALLINT:
-		idx = uint64(get[uint16](vector, rowIdx))
+		return uint64(get[uint16](vector, rowIdx)), nil
 	case C.DUCKDB_TYPE_UINTEGER:
-		idx = uint64(get[uint32](vector, rowIdx))
+		return uint64(get[uint32](vector, rowIdx)), nil
 	case C.DUCKDB_TYPE_UBIGINT:
-		idx = get[uint64](vector, rowIdx)
-	default:
-		return "", errInvalidType
+		return get[uint64](vector, rowIdx), nil
 	}
-
-	val := C.duckdb_enum_dictionary_value(ty, (C.idx_t)(idx))
-	defer C.duckdb_free(unsafe.Pointer(val))
-	return C.GoString(val), nil
+	return 0, errInvalidType
 }
 
 var (
@@ -477,10 +806,14 @@ func typeName(t C.duckdb_type) string {
 		return "DATE"
 	case C.DUCKDB_TYPE_TIME:
 		return "TIME"
+	case C.DUCKDB_TYPE_TIME_TZ:
+		return "TIMETZ"
 	case C.DUCKDB_TYPE_INTERVAL:
 		return "INTERVAL"
 	case C.DUCKDB_TYPE_HUGEINT:
 		return "HUGEINT"
+	case C.DUCKDB_TYPE_UHUGEINT:
+		return "UHUGEINT"
 	case C.DUCKDB_TYPE_VARCHAR:
 		return "VARCHAR"
 	case C.DUCKDB_TYPE_BLOB:
@@ -500,6 +833,9 @@ func typeName(t C.duckdb_type) string {
 	case C.DUCKDB_TYPE_LIST:
 		// NOTE: should be handled as logical type
 		return "LIST"
+	case C.DUCKDB_TYPE_ARRAY:
+		// NOTE: should be handled as logical type
+		return "ARRAY"
 	case C.DUCKDB_TYPE_STRUCT:
 		// NOTE: should be handled as logical type
 		return "STRUCT"
@@ -510,6 +846,11 @@ func typeName(t C.duckdb_type) string {
 		return "UUID"
 	case C.DUCKDB_TYPE_TIMESTAMP_TZ:
 		return "TIMESTAMPTZ"
+	case C.DUCKDB_TYPE_UNION:
+		// NOTE: should be handled as logical type
+		return "UNION"
+	case C.DUCKDB_TYPE_BIT:
+		return "BIT"
 	default:
 		// Should never happen
 		return ""
@@ -530,15 +871,54 @@ func logicalTypeName(lt C.duckdb_logical_type) string {
 		clt := C.duckdb_list_type_child_type(lt)
 		defer C.duckdb_destroy_logical_type(&clt)
 		return logicalTypeName(clt) + "[]"
+	case C.DUCKDB_TYPE_ARRAY:
+		clt := C.duckdb_array_type_child_type(lt)
+		defer C.duckdb_destroy_logical_type(&clt)
+		return fmt.Sprintf("%s[%d]", logicalTypeName(clt), C.duckdb_array_type_array_size(lt))
 	case C.DUCKDB_TYPE_STRUCT:
 		return logicalTypeNameStruct(lt)
 	case C.DUCKDB_TYPE_MAP:
 		return logicalTypeNameMap(lt)
+	case C.DUCKDB_TYPE_UNION:
+		return logicalTypeNameUnion(lt)
+	case C.DUCKDB_TYPE_VARCHAR:
+		if isJSONType(lt) {
+			return "JSON"
+		}
+		return typeName(t)
+	case C.DUCKDB_TYPE_BLOB:
+		if isGeometryType(lt) {
+			return "GEOMETRY"
+		}
+		return typeName(t)
 	default:
 		return typeName(t)
 	}
 }
 
+// isJSONType returns true, if the logical type is the JSON type of the json extension,
+// which is a VARCHAR with the alias JSON.
+func isJSONType(lt C.duckdb_logical_type) bool {
+	return logicalTypeAlias(lt) == "JSON"
+}
+
+// isGeometryType returns true, if the logical type is the GEOMETRY type of the spatial extension,
+// which is a BLOB with the alias GEOMETRY. Its values are in the internal format of the spatial extension,
+// not in WKB, see the README.
+func isGeometryType(lt C.duckdb_logical_type) bool {
+	return logicalTypeAlias(lt) == "GEOMETRY"
+}
+
+// logicalTypeAlias returns the alias of the logical type, or an empty string, if it has none.
+func logicalTypeAlias(lt C.duckdb_logical_type) string {
+	alias := C.duckdb_logical_type_get_alias(lt)
+	if alias == nil {
+		return ""
+	}
+	defer C.duckdb_free(unsafe.Pointer(alias))
+	return C.GoString(alias)
+}
+
 func logicalTypeNameStruct(lt C.duckdb_logical_type) string {
 	count := int(C.duckdb_struct_type_child_count(lt))
 	name := "STRUCT("
@@ -559,6 +939,19 @@ func logicalTypeNameStruct(lt C.duckdb_logical_type) string {
 	return name + ")"
 }
 
+func logicalTypeNameUnion(lt C.duckdb_logical_type) string {
+	count := int(C.duckdb_union_type_member_count(lt))
+	members := make([]string, count)
+	for i := 0; i < count; i++ {
+		name := C.duckdb_union_type_member_name(lt, C.idx_t(i))
+		mlt := C.duckdb_union_type_member_type(lt, C.idx_t(i))
+		members[i] = escapeStructFieldName(C.GoString(name)) + " " + logicalTypeName(mlt)
+		C.duckdb_free(unsafe.Pointer(name))
+		C.duckdb_destroy_logical_type(&mlt)
+	}
+	return "UNION(" + strings.Join(members, ", ") + ")"
+}
+
 func logicalTypeNameMap(lt C.duckdb_logical_type) string {
 	// Key logical type
 	klt := C.duckdb_map_type_key_type(lt)
@@ -573,5 +966,44 @@ func logicalTypeNameMap(lt C.duckdb_logical_type) string {
 
 // DuckDB escapes struct field names by doubling double quotes, then wrapping in double quotes.
 func escapeStructFieldName(s string) string {
+	return quoteIdentifier(s)
+}
+
+// quoteIdentifier quotes an identifier, e.g., a column name, in the same way as DuckDB.
+func quoteIdentifier(s string) string {
 	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
 }
+
+// quoteQualifiedIdentifier quotes each part of a qualified identifier, e.g., schema.table.
+func quoteQualifiedIdentifier(s string) string {
+	parts := splitQualifiedIdentifier(s)
+	for i, part := range parts {
+		parts[i] = quoteIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// splitQualifiedIdentifier splits a qualified identifier at its dots, e.g., "My Schema".tbl into My Schema and tbl.
+// A part in double quotes may contain dots. Within it, two double quotes are an escaped double quote.
+func splitQualifiedIdentifier(s string) []string {
+	var parts []string
+	var part strings.Builder
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quoted && s[i] == '"' && i+1 < len(s) && s[i+1] == '"':
+			part.WriteByte('"')
+			i++
+		case quoted && s[i] == '"':
+			quoted = false
+		case !quoted && s[i] == '"' && part.Len() == 0:
+			quoted = true
+		case !quoted && s[i] == '.':
+			parts = append(parts, part.String())
+			part.Reset()
+		default:
+			part.WriteByte(s[i])
+		}
+	}
+	return append(parts, part.String())
+}
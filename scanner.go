@@ -0,0 +1,258 @@
+package duckdb
+
+import (
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+)
+
+// StructScanner scans rows into structs by matching the column names to the struct fields.
+//
+// A field with a `db` tag matches the column named by the tag, and its field name is ignored.
+// A field with the tag `db:"-"` is skipped. Any other exported field matches the column of its name.
+// The fields of embedded structs are promoted, i.e., they match as if they were fields of the outer struct.
+//
+// DuckDB folds unquoted identifiers to lowercase, so names match case-insensitively by default.
+// If several fields match a column, then a `db` tag takes precedence over a field name,
+// and an exact match takes precedence over a case-insensitive match.
+type StructScanner struct {
+	// CaseSensitive only matches names with the exact same case.
+	CaseSensitive bool
+	// SnakeCase additionally matches names after converting both of them to snake_case.
+	// For example, the column user_id matches the field UserID.
+	// Snake case matches have the lowest precedence.
+	SnakeCase bool
+}
+
+// ScanStruct scans the current row of rows into the struct pointed to by dest,
+// using the default StructScanner.
+func ScanStruct(rows *sql.Rows, dest any) error {
+	return StructScanner{}.Scan(rows, dest)
+}
+
+// Scan scans the current row of rows into the struct pointed to by dest.
+// It returns an error, if a column does not match any field.
+// Fields of nested types, which database/sql cannot scan into, e.g., a []int64 for a LIST, a map for a MAP,
+// or a struct for a STRUCT, convert recursively like the values of List and Struct.
+func (s StructScanner) Scan(rows *sql.Rows, dest any) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Pointer || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return getError(errScanStruct, castError(fmt.Sprintf("%T", dest), "pointer to struct"))
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	indexes, err := s.fieldIndexes(v.Elem().Type(), columns)
+	if err != nil {
+		return getError(errScanStruct, err)
+	}
+
+	ptrs := make([]any, len(indexes))
+	for i, index := range indexes {
+		ptrs[i] = scanDest(v.Elem().FieldByIndex(index))
+	}
+	return rows.Scan(ptrs...)
+}
+
+// ScanRows scans all rows into structs of type T, using the default StructScanner, and closes the rows.
+// Unlike scanning with an external mapper, the fields can have nested types, e.g., a []string for a LIST
+// or a struct for a STRUCT, see StructScanner.Scan.
+func ScanRows[T any](rows *sql.Rows) ([]T, error) {
+	defer rows.Close()
+
+	var dummy T
+	if t := reflect.TypeOf(dummy); t == nil || t.Kind() != reflect.Struct {
+		return nil, getError(errScanStruct, castError(fmt.Sprintf("%T", dummy), reflect.Struct.String()))
+	}
+
+	result := []T{}
+	for rows.Next() {
+		var row T
+		if err := ScanStruct(rows, &row); err != nil {
+			return nil, err
+		}
+		result = append(result, row)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// scanDest returns the destination of scanning into the field. It converts the values of nested types,
+// which database/sql cannot scan into the field, see nestedDest.
+func scanDest(field reflect.Value) any {
+	ptr := field.Addr().Interface()
+	if _, ok := ptr.(sql.Scanner); ok || !isNestedType(field.Type()) {
+		return ptr
+	}
+	return nestedDest{dst: field}
+}
+
+// isNestedType returns true, if database/sql cannot scan the values of nested types into the type.
+// Byte slices are not nested types, as database/sql copies the bytes of BLOB values.
+// The types registered with RegisterType are nested types.
+func isNestedType(t reflect.Type) bool {
+	for t.Kind() == reflect.Pointer {
+		if reflect.PointerTo(t.Elem()).Implements(scannerType) {
+			return false
+		}
+		t = t.Elem()
+	}
+	if _, ok := typeDecoder(t); ok {
+		return true
+	}
+	switch t.Kind() {
+	case reflect.Slice, reflect.Array:
+		return t.Elem().Kind() != reflect.Uint8
+	case reflect.Map:
+		return true
+	case reflect.Struct:
+		return t != reflect.TypeOf(time.Time{})
+	}
+	return false
+}
+
+var scannerType = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+// nestedDest scans a value of a nested type into its field, like the values of List and Struct.
+type nestedDest struct {
+	dst reflect.Value
+}
+
+func (d nestedDest) Scan(v any) error {
+	if err := convertElement(v, d.dst); err != nil {
+		return getError(errScanStruct, err)
+	}
+	return nil
+}
+
+// fieldIndexes returns the index of the matching field for each column.
+func (s StructScanner) fieldIndexes(t reflect.Type, columns []string) ([][]int, error) {
+	fields := structFields(t)
+	indexes := make([][]int, len(columns))
+
+	for i, column := range columns {
+		bestRank := matchNone
+		for j := range fields {
+			if rank := s.match(&fields[j], column); rank > bestRank {
+				bestRank = rank
+				indexes[i] = fields[j].index
+			}
+		}
+		if bestRank == matchNone {
+			return nil, fmt.Errorf("%s: %s", missingFieldErrMsg, column)
+		}
+	}
+	return indexes, nil
+}
+
+// matchRank orders the ways a name can match. Higher ranks take precedence.
+type matchRank int
+
+const (
+	matchNone matchRank = iota
+	matchSnakeCase
+	matchFold
+	matchExact
+)
+
+func (s StructScanner) match(field *structField, column string) matchRank {
+	rank := matchNone
+	switch {
+	case field.name == column:
+		rank = matchExact
+	case !s.CaseSensitive && strings.EqualFold(field.name, column):
+		rank = matchFold
+	case s.SnakeCase && s.matchSnakeCase(field.name, column):
+		rank = matchSnakeCase
+	default:
+		return matchNone
+	}
+
+	// Tagged fields take precedence over untagged fields.
+	if field.tagged {
+		rank += matchExact
+	}
+	return rank
+}
+
+func (s StructScanner) matchSnakeCase(name string, column string) bool {
+	if s.CaseSensitive {
+		return toSnakeCase(name) == column || toSnakeCase(column) == name
+	}
+	return strings.EqualFold(toSnakeCase(name), toSnakeCase(column))
+}
+
+// toSnakeCase converts a CamelCase name to snake_case, e.g., UserID to user_id, and HTTPServer to http_server.
+func toSnakeCase(name string) string {
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if unicode.IsUpper(r) && i > 0 {
+			prev := runes[i-1]
+			nextIsLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if prev != '_' && (unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextIsLower)) {
+				b.WriteRune('_')
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// structField is an exported field of a struct, including promoted fields of embedded structs.
+type structField struct {
+	// The `db` tag, if present, or the field name.
+	name string
+	// True, if the name is a `db` tag.
+	tagged bool
+	// The index sequence for reflect.Value.FieldByIndex.
+	index []int
+}
+
+// structFieldCache caches the []structField of each struct type.
+var structFieldCache sync.Map
+
+func structFields(t reflect.Type) []structField {
+	if fields, ok := structFieldCache.Load(t); ok {
+		return fields.([]structField)
+	}
+
+	var fields []structField
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		tag, tagged := f.Tag.Lookup("db")
+		if tag == "-" {
+			continue
+		}
+
+		if f.Anonymous && !tagged && f.Type.Kind() == reflect.Struct {
+			for _, embedded := range structFields(f.Type) {
+				embedded.index = append([]int{i}, embedded.index...)
+				fields = append(fields, embedded)
+			}
+			continue
+		}
+		if !f.IsExported() {
+			continue
+		}
+
+		field := structField{name: f.Name, index: []int{i}}
+		if tagged && tag != "" {
+			field.name = tag
+			field.tagged = true
+		}
+		fields = append(fields, field)
+	}
+
+	structFieldCache.Store(t, fields)
+	return fields
+}
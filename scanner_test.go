@@ -0,0 +1,184 @@
+package duckdb
+
+import (
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type scanUser struct {
+	UserID   int64
+	UserName string `db:"name"`
+	Email    string
+	Ignored  string `db:"-"`
+}
+
+func TestScanStruct(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE users (userid BIGINT, name VARCHAR, "EMAIL" VARCHAR, user_id BIGINT)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO users VALUES (1, 'marc', 'marc@example.com', 2)`)
+	require.NoError(t, err)
+
+	t.Run("case-insensitive by default", func(t *testing.T) {
+		rows, err := db.Query(`SELECT userid, name, "EMAIL" FROM users`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		require.True(t, rows.Next())
+		var user scanUser
+		require.NoError(t, ScanStruct(rows, &user))
+		require.Equal(t, scanUser{UserID: 1, UserName: "marc", Email: "marc@example.com"}, user)
+	})
+
+	t.Run("case-sensitive", func(t *testing.T) {
+		rows, err := db.Query(`SELECT userid FROM users`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		require.True(t, rows.Next())
+		var user scanUser
+		err = StructScanner{CaseSensitive: true}.Scan(rows, &user)
+		testError(t, err, errScanStruct.Error(), missingFieldErrMsg, "userid")
+	})
+
+	t.Run("snake case", func(t *testing.T) {
+		for _, column := range []string{"user_id", `user_id AS "USER_ID"`} {
+			rows, err := db.Query(`SELECT ` + column + ` FROM users`)
+			require.NoError(t, err)
+
+			require.True(t, rows.Next())
+			var user scanUser
+			err = ScanStruct(rows, &user)
+			testError(t, err, errScanStruct.Error(), missingFieldErrMsg)
+			require.NoError(t, StructScanner{SnakeCase: true}.Scan(rows, &user))
+			require.NotZero(t, user.UserID)
+			require.NoError(t, rows.Close())
+		}
+	})
+
+	t.Run("precedence", func(t *testing.T) {
+		type user struct {
+			Name     string
+			NAME     string
+			UserName string `db:"NAME"`
+		}
+
+		rows, err := db.Query(`SELECT name FROM users`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		// The tag takes precedence over the field names, even if its case does not match.
+		require.True(t, rows.Next())
+		var u user
+		require.NoError(t, ScanStruct(rows, &u))
+		require.Equal(t, user{UserName: "marc"}, u)
+	})
+
+	t.Run("embedded struct", func(t *testing.T) {
+		type audit struct {
+			CreatedBy string
+		}
+		type user struct {
+			audit
+			ID int32 `db:"id"`
+		}
+
+		rows, err := db.Query(`SELECT 42 AS ID, 'admin' AS created_by`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		require.True(t, rows.Next())
+		var u user
+		require.NoError(t, StructScanner{SnakeCase: true}.Scan(rows, &u))
+		require.Equal(t, user{audit: audit{CreatedBy: "admin"}, ID: 42}, u)
+	})
+
+	t.Run("invalid destination", func(t *testing.T) {
+		rows, err := db.Query(`SELECT 1`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		require.True(t, rows.Next())
+		var i int
+		testError(t, ScanStruct(rows, &i), errScanStruct.Error(), castErrMsg)
+	})
+}
+
+func TestScanRows(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	type address struct {
+		City string
+		Zip  *int32
+	}
+	type order struct {
+		ID       int64
+		Tags     []string
+		Scores   [2]float64
+		Address  address
+		Previous *address
+		Counts   map[string]int64
+		Created  time.Time
+		Payload  []byte
+		Note     sql.NullString
+	}
+
+	rows, err := db.Query(`SELECT range AS id, ['a', range::VARCHAR] AS tags, [range, 0.5]::DOUBLE[2] AS scores,
+		{'city': 'Berlin', 'zip': NULL::INTEGER} AS address,
+		CASE WHEN range = 1 THEN {'city': 'Paris', 'zip': 75001} END AS previous,
+		MAP {'x': range} AS counts, TIMESTAMP '2024-01-02 03:04:05' AS created, 'ab'::BLOB AS payload,
+		NULL AS note
+		FROM range(2)`)
+	require.NoError(t, err)
+	orders, err := ScanRows[order](rows)
+	require.NoError(t, err)
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	zip := int32(75001)
+	require.Equal(t, []order{
+		{ID: 0, Tags: []string{"a", "0"}, Scores: [2]float64{0, 0.5}, Address: address{City: "Berlin"},
+			Counts: map[string]int64{"x": 0}, Created: created, Payload: []byte("ab")},
+		{ID: 1, Tags: []string{"a", "1"}, Scores: [2]float64{1, 0.5}, Address: address{City: "Berlin"},
+			Previous: &address{City: "Paris", Zip: &zip}, Counts: map[string]int64{"x": 1}, Created: created,
+			Payload: []byte("ab")},
+	}, orders)
+
+	rows, err = db.Query(`SELECT 1 AS id WHERE false`)
+	require.NoError(t, err)
+	orders, err = ScanRows[order](rows)
+	require.NoError(t, err)
+	require.Empty(t, orders)
+
+	rows, err = db.Query(`SELECT 'x' AS tags`)
+	require.NoError(t, err)
+	_, err = ScanRows[order](rows)
+	require.ErrorIs(t, err, errScanStruct)
+
+	rows, err = db.Query(`SELECT 1`)
+	require.NoError(t, err)
+	_, err = ScanRows[int](rows)
+	testError(t, err, errScanStruct.Error())
+}
+
+func TestToSnakeCase(t *testing.T) {
+	t.Parallel()
+
+	for name, expected := range map[string]string{
+		"UserID":     "user_id",
+		"userId":     "user_id",
+		"HTTPServer": "http_server",
+		"ID":         "id",
+		"user_id":    "user_id",
+		"Address2":   "address2",
+	} {
+		require.Equal(t, expected, toSnakeCase(name), name)
+	}
+}
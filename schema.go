@@ -0,0 +1,273 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// ShowCreate returns the CREATE TABLE statement that recreates the table, including its column types,
+// defaults, and constraints. DuckDB reconstructs the statement from its catalog, so it is normalized,
+// e.g., all identifiers that require quotes are quoted, and CHECK constraints are table constraints.
+//
+// The table name may be qualified by its schema, or by its database and schema, e.g., "main.tbl".
+// Parts in double quotes may contain dots, e.g., "my.schema".tbl. Otherwise, the current schema
+// and database are used. Like DuckDB, ShowCreate matches names case-insensitively, preferring an exact match.
+func ShowCreate(ctx context.Context, db Queryer, table string) (string, error) {
+	parts := splitQualifiedIdentifier(table)
+	if len(parts) > 3 {
+		return "", getError(errShowCreate, fmt.Errorf("invalid table name: %s", table))
+	}
+
+	// Prepend the current database and schema to the name, if missing.
+	names := []string{"", "", ""}
+	copy(names[3-len(parts):], parts)
+
+	const query = `SELECT sql FROM duckdb_tables()
+		WHERE lower(database_name) = lower(coalesce(nullif(?, ''), current_database()))
+		AND lower(schema_name) = lower(coalesce(nullif(?, ''), current_schema()))
+		AND lower(table_name) = lower(?)
+		ORDER BY (database_name = ?)::INTEGER + (schema_name = ?)::INTEGER + (table_name = ?)::INTEGER DESC
+		LIMIT 1`
+
+	rows, err := db.QueryContext(ctx, query, names[0], names[1], names[2], names[0], names[1], names[2])
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err = rows.Err(); err != nil {
+			return "", err
+		}
+		return "", getError(errShowCreate, fmt.Errorf("table not found: %s", table))
+	}
+
+	var ddl sql.NullString
+	if err = rows.Scan(&ddl); err != nil {
+		return "", err
+	}
+	if !ddl.Valid {
+		return "", getError(errShowCreate, fmt.Errorf("no CREATE statement for table: %s", table))
+	}
+	return ddl.String, rows.Close()
+}
+
+// TableColumnDescriptor describes a column of a table, see DescribeTable.
+type TableColumnDescriptor struct {
+	Name string
+	// Type is the full type of the column, including the types nested in it.
+	Type     *TypeDescriptor
+	Nullable bool
+	// Default is the expression of the default value of the column, or nil, if the column has no default.
+	Default *string
+}
+
+// DescribeTable returns the columns of a table in their order, with their names, types, nullability,
+// and defaults. If the catalog, i.e., the database, or the schema is empty, then the current one is used.
+// Like DuckDB, DescribeTable matches names case-insensitively, preferring an exact match.
+func DescribeTable(ctx context.Context, driverConn driver.Conn, catalog, schema, table string) ([]TableColumnDescriptor, error) {
+	const tableQuery = `SELECT database_name, schema_name, table_name FROM duckdb_tables()
+		WHERE lower(database_name) = lower(coalesce(nullif(?, ''), current_database()))
+		AND lower(schema_name) = lower(coalesce(nullif(?, ''), current_schema()))
+		AND lower(table_name) = lower(?)
+		ORDER BY (database_name = ?)::INTEGER + (schema_name = ?)::INTEGER + (table_name = ?)::INTEGER DESC
+		LIMIT 1`
+	tables, err := QueryMaps(ctx, driverConn, tableQuery, catalog, schema, table, catalog, schema, table)
+	if err != nil {
+		return nil, getError(errDescribeTable, err)
+	}
+	if len(tables) == 0 {
+		return nil, getError(errDescribeTable, fmt.Errorf("table not found: %s", table))
+	}
+	catalog = tables[0]["database_name"].(string)
+	schema = tables[0]["schema_name"].(string)
+	table = tables[0]["table_name"].(string)
+
+	columns, err := QueryMaps(ctx, driverConn, `SELECT column_name, is_nullable, column_default FROM duckdb_columns()
+		WHERE database_name = ? AND schema_name = ? AND table_name = ? ORDER BY column_index`, catalog, schema, table)
+	if err != nil {
+		return nil, getError(errDescribeTable, err)
+	}
+
+	// The catalog only contains the names of the types, so describe the columns of a query of the table.
+	desc, err := Describe(ctx, driverConn,
+		"SELECT * FROM "+quoteIdentifier(catalog)+"."+quoteIdentifier(schema)+"."+quoteIdentifier(table))
+	if err != nil {
+		return nil, getError(errDescribeTable, err)
+	}
+	if len(desc.Columns) != len(columns) {
+		return nil, getError(errDescribeTable, fmt.Errorf("table %s changed while describing it", table))
+	}
+
+	result := make([]TableColumnDescriptor, len(columns))
+	for i, column := range columns {
+		result[i] = TableColumnDescriptor{
+			Name:     column["column_name"].(string),
+			Type:     desc.Columns[i].Type,
+			Nullable: column["is_nullable"].(bool),
+		}
+		if def, ok := column["column_default"].(string); ok {
+			result[i].Default = &def
+		}
+	}
+	return result, nil
+}
+
+// SchemaJSONVersion is the version of the document returned by SchemaJSON.
+// It increases with every change of the format.
+const SchemaJSONVersion = 1
+
+type schemaDocument struct {
+	Format  string        `json:"format"`
+	Version int           `json:"version"`
+	Schema  string        `json:"schema"`
+	Tables  []schemaTable `json:"tables"`
+}
+
+type schemaTable struct {
+	Name        string             `json:"name"`
+	Columns     []schemaColumn     `json:"columns"`
+	Constraints []schemaConstraint `json:"constraints"`
+	Indexes     []schemaIndex      `json:"indexes"`
+}
+
+type schemaColumn struct {
+	Name     string          `json:"name"`
+	Type     *TypeDescriptor `json:"type"`
+	Nullable bool            `json:"nullable"`
+	Default  *string         `json:"default,omitempty"`
+}
+
+type schemaConstraint struct {
+	Type    string   `json:"type"`
+	Text    string   `json:"text"`
+	Columns []string `json:"columns"`
+}
+
+type schemaIndex struct {
+	Name    string `json:"name"`
+	Unique  bool   `json:"unique"`
+	Primary bool   `json:"primary"`
+	SQL     string `json:"sql,omitempty"`
+}
+
+// SchemaJSON returns a JSON document describing the tables of a schema of the current database,
+// including their columns with their TypeDescriptor, their constraints, and their indexes.
+// If the schema is empty, then it describes the current schema.
+// The document is deterministic, so that the documents of two databases can be diffed: tables
+// and indexes are sorted by name, and constraints by their type and text. Columns keep their order in
+// the table. NOT NULL constraints are part of their column. The document contains its format name
+// and SchemaJSONVersion. It does not contain the names of the database and of the internal objects,
+// e.g., oids, as they differ between databases with the same schema.
+func SchemaJSON(ctx context.Context, driverConn driver.Conn, schema string) ([]byte, error) {
+	if schema == "" {
+		current, err := QueryMaps(ctx, driverConn, `SELECT current_schema() AS name`)
+		if err != nil {
+			return nil, err
+		}
+		schema = current[0]["name"].(string)
+	}
+	const filter = `WHERE database_name = current_database() AND schema_name = ?`
+
+	tables, err := QueryMaps(ctx, driverConn, `SELECT table_name FROM duckdb_tables() `+filter+`
+		ORDER BY table_name`, schema)
+	if err != nil {
+		return nil, err
+	}
+	columns, err := QueryMaps(ctx, driverConn, `SELECT table_name, column_name, is_nullable, column_default
+		FROM duckdb_columns() `+filter+` ORDER BY table_name, column_index`, schema)
+	if err != nil {
+		return nil, err
+	}
+	constraints, err := QueryMaps(ctx, driverConn, `SELECT table_name, constraint_type, constraint_text,
+		constraint_column_names FROM duckdb_constraints() `+filter+` AND constraint_type != 'NOT NULL'`, schema)
+	if err != nil {
+		return nil, err
+	}
+	indexes, err := QueryMaps(ctx, driverConn, `SELECT table_name, index_name, is_unique, is_primary, sql
+		FROM duckdb_indexes() `+filter+` ORDER BY index_name`, schema)
+	if err != nil {
+		return nil, err
+	}
+
+	doc := schemaDocument{Format: "go-duckdb/schema", Version: SchemaJSONVersion, Schema: schema}
+	byName := make(map[string]*schemaTable, len(tables))
+	doc.Tables = make([]schemaTable, len(tables))
+	for i, table := range tables {
+		name := table["table_name"].(string)
+		doc.Tables[i] = schemaTable{
+			Name:        name,
+			Columns:     []schemaColumn{},
+			Constraints: []schemaConstraint{},
+			Indexes:     []schemaIndex{},
+		}
+		byName[name] = &doc.Tables[i]
+	}
+
+	for _, column := range columns {
+		// duckdb_columns() also contains the columns of views.
+		table, ok := byName[column["table_name"].(string)]
+		if !ok {
+			continue
+		}
+		c := schemaColumn{Name: column["column_name"].(string), Nullable: column["is_nullable"].(bool)}
+		if def, ok := column["column_default"].(string); ok {
+			c.Default = &def
+		}
+		table.Columns = append(table.Columns, c)
+	}
+	for _, constraint := range constraints {
+		table := byName[constraint["table_name"].(string)]
+		c := schemaConstraint{
+			Type:    constraint["constraint_type"].(string),
+			Text:    constraint["constraint_text"].(string),
+			Columns: []string{},
+		}
+		for _, name := range constraint["constraint_column_names"].([]any) {
+			c.Columns = append(c.Columns, name.(string))
+		}
+		table.Constraints = append(table.Constraints, c)
+	}
+	for _, index := range indexes {
+		table := byName[index["table_name"].(string)]
+		idx := schemaIndex{
+			Name:    index["index_name"].(string),
+			Unique:  index["is_unique"].(bool),
+			Primary: index["is_primary"].(bool),
+		}
+		idx.SQL, _ = index["sql"].(string)
+		table.Indexes = append(table.Indexes, idx)
+	}
+
+	for i := range doc.Tables {
+		table := &doc.Tables[i]
+		sort.Slice(table.Constraints, func(a, b int) bool {
+			if table.Constraints[a].Type != table.Constraints[b].Type {
+				return table.Constraints[a].Type < table.Constraints[b].Type
+			}
+			return table.Constraints[a].Text < table.Constraints[b].Text
+		})
+
+		// The catalog only contains the names of the types, so describe the columns of a query of the table.
+		desc, err := Describe(ctx, driverConn, "SELECT * FROM "+quoteIdentifier(doc.Schema)+"."+quoteIdentifier(table.Name))
+		if err != nil {
+			return nil, getError(errSchemaJSON, err)
+		}
+		if len(desc.Columns) != len(table.Columns) {
+			return nil, getError(errSchemaJSON, fmt.Errorf("table %s changed during the export", table.Name))
+		}
+		for j := range table.Columns {
+			table.Columns[j].Type = desc.Columns[j].Type
+		}
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return nil, getError(errSchemaJSON, err)
+	}
+	return out, nil
+}
@@ -0,0 +1,195 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShowCreate(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE SCHEMA "my.schema";
+		CREATE TABLE "my.schema"."Odd ""T""" (
+			id INTEGER PRIMARY KEY,
+			name VARCHAR NOT NULL DEFAULT 'x',
+			c INTEGER CHECK (c > 0),
+			u INTEGER UNIQUE,
+			"Mixed Col" DOUBLE
+		);
+		CREATE TABLE "Users" (id INTEGER PRIMARY KEY);
+		CREATE TABLE orders (id INTEGER, user_id INTEGER REFERENCES "Users"(id), amount DECIMAL(10, 2))`)
+	require.NoError(t, err)
+
+	t.Run("schema-qualified", func(t *testing.T) {
+		ddl, err := ShowCreate(context.Background(), db, `"my.schema"."Odd ""T"""`)
+		require.NoError(t, err)
+		require.Equal(t, `CREATE TABLE "my.schema"."Odd ""T"""(id INTEGER PRIMARY KEY, "name" VARCHAR NOT NULL DEFAULT('x'), `+
+			`c INTEGER, u INTEGER UNIQUE, "Mixed Col" DOUBLE, CHECK((c > 0)));`, ddl)
+	})
+
+	t.Run("current schema", func(t *testing.T) {
+		for _, name := range []string{"orders", "ORDERS", "main.orders", "memory.main.orders"} {
+			ddl, err := ShowCreate(context.Background(), db, name)
+			require.NoError(t, err, name)
+			require.Equal(t, `CREATE TABLE orders(id INTEGER, user_id INTEGER, amount DECIMAL(10,2), `+
+				`FOREIGN KEY (user_id) REFERENCES Users(id));`, ddl)
+		}
+	})
+
+	t.Run("recreate", func(t *testing.T) {
+		ddl, err := ShowCreate(context.Background(), db, `users`)
+		require.NoError(t, err)
+
+		// Recreate the table in another schema.
+		_, err = db.Exec(`CREATE SCHEMA copy; USE copy; ` + ddl + ` USE main`)
+		require.NoError(t, err)
+
+		copied, err := ShowCreate(context.Background(), db, `copy.Users`)
+		require.NoError(t, err)
+		require.Equal(t, `CREATE TABLE "copy".Users(id INTEGER PRIMARY KEY);`, copied)
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := ShowCreate(context.Background(), db, `missing`)
+		testError(t, err, errShowCreate.Error(), "table not found: missing")
+
+		_, err = ShowCreate(context.Background(), db, `a.b.c.d`)
+		testError(t, err, errShowCreate.Error(), "invalid table name")
+	})
+}
+
+func TestSchemaJSON(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE SCHEMA other;
+		CREATE TYPE mood AS ENUM ('sad', 'happy');
+		CREATE TABLE users (id INTEGER PRIMARY KEY, name VARCHAR NOT NULL DEFAULT 'x', m mood);
+		CREATE TABLE orders (
+			id INTEGER,
+			user_id INTEGER REFERENCES users(id),
+			amount DECIMAL(10, 2) CHECK (amount > 0),
+			tags STRUCT(k VARCHAR, v INTEGER[]),
+			UNIQUE (id, user_id)
+		);
+		CREATE INDEX orders_amount ON orders(amount);
+		CREATE TABLE other.ignored (i INTEGER)`)
+	require.NoError(t, err)
+
+	con, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	var out []byte
+	require.NoError(t, con.Raw(func(driverConn any) error {
+		out, err = SchemaJSON(context.Background(), driverConn.(driver.Conn), "")
+		return err
+	}))
+
+	var doc map[string]any
+	require.NoError(t, json.Unmarshal(out, &doc))
+	require.Equal(t, "go-duckdb/schema", doc["format"])
+	require.Equal(t, float64(SchemaJSONVersion), doc["version"])
+	require.Equal(t, "main", doc["schema"])
+
+	tables := doc["tables"].([]any)
+	require.Len(t, tables, 2)
+	orders := tables[0].(map[string]any)
+	require.Equal(t, "orders", orders["name"])
+	require.Equal(t, "users", tables[1].(map[string]any)["name"])
+
+	columns := orders["columns"].([]any)
+	require.Len(t, columns, 4)
+	amount := columns[2].(map[string]any)
+	require.Equal(t, "amount", amount["name"])
+	require.Equal(t, map[string]any{"kind": "DECIMAL", "name": "DECIMAL(10,2)", "width": float64(10), "scale": float64(2)},
+		amount["type"])
+	tags := columns[3].(map[string]any)["type"].(map[string]any)
+	require.Equal(t, "STRUCT", tags["kind"])
+	require.Len(t, tags["fields"], 2)
+
+	constraints := orders["constraints"].([]any)
+	require.Len(t, constraints, 3)
+	require.Equal(t, "CHECK", constraints[0].(map[string]any)["type"])
+	require.Equal(t, "FOREIGN KEY", constraints[1].(map[string]any)["type"])
+	require.Equal(t, []any{"id", "user_id"}, constraints[2].(map[string]any)["columns"])
+
+	indexes := orders["indexes"].([]any)
+	require.Len(t, indexes, 1)
+	require.Equal(t, "orders_amount", indexes[0].(map[string]any)["name"])
+
+	users := tables[1].(map[string]any)["columns"].([]any)
+	require.Equal(t, false, users[1].(map[string]any)["nullable"])
+	require.Equal(t, "'x'", users[1].(map[string]any)["default"])
+	require.Equal(t, []any{"sad", "happy"}, users[2].(map[string]any)["type"].(map[string]any)["enumValues"])
+
+	// The document is deterministic.
+	var again []byte
+	require.NoError(t, con.Raw(func(driverConn any) error {
+		again, err = SchemaJSON(context.Background(), driverConn.(driver.Conn), "main")
+		return err
+	}))
+	require.Equal(t, string(out), string(again))
+}
+
+func TestDescribeTable(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE SCHEMA other;
+		CREATE TABLE other."Events" (
+			id BIGINT PRIMARY KEY,
+			name VARCHAR NOT NULL DEFAULT 'x',
+			tags MAP(VARCHAR, INTEGER[])
+		);
+		CREATE TABLE events (i INTEGER)`)
+	require.NoError(t, err)
+
+	con, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	describe := func(catalog, schema, table string) (columns []TableColumnDescriptor, err error) {
+		err = con.Raw(func(driverConn any) error {
+			columns, err = DescribeTable(context.Background(), driverConn.(driver.Conn), catalog, schema, table)
+			return err
+		})
+		return columns, err
+	}
+
+	columns, err := describe("memory", "other", "events")
+	require.NoError(t, err)
+	require.Len(t, columns, 3)
+
+	require.Equal(t, "id", columns[0].Name)
+	require.Equal(t, "BIGINT", columns[0].Type.Name)
+	require.False(t, columns[0].Nullable)
+	require.Nil(t, columns[0].Default)
+
+	require.Equal(t, "name", columns[1].Name)
+	require.False(t, columns[1].Nullable)
+	require.Equal(t, "'x'", *columns[1].Default)
+
+	require.True(t, columns[2].Nullable)
+	require.Equal(t, "MAP", columns[2].Type.Kind)
+	require.Equal(t, "VARCHAR", columns[2].Type.KeyType.Name)
+	require.Equal(t, "LIST", columns[2].Type.ValueType.Kind)
+	require.Equal(t, "INTEGER", columns[2].Type.ValueType.Child.Name)
+
+	// The current database and schema are used by default.
+	columns, err = describe("", "", "EVENTS")
+	require.NoError(t, err)
+	require.Len(t, columns, 1)
+	require.Equal(t, "i", columns[0].Name)
+
+	_, err = describe("", "", "missing")
+	testError(t, err, errDescribeTable.Error(), "table not found: missing")
+}
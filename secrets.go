@@ -0,0 +1,229 @@
+package duckdb
+
+/*
+#include <stdlib.h>
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"strings"
+	"unsafe"
+)
+
+// Secret is a secret of DuckDB's secrets manager, e.g., an S3Secret, see CreateSecret.
+type Secret interface {
+	// secretType returns the TYPE of the secret.
+	secretType() string
+	// secretOptions returns the options of the secret. Empty options are omitted.
+	secretOptions() []secretOption
+}
+
+type secretOption struct {
+	name  string
+	value string
+}
+
+// S3Secret holds the credentials of S3 and S3-compatible object storage. DuckDB's httpfs extension provides it.
+type S3Secret struct {
+	// KeyID is the access key ID.
+	KeyID string
+	// Secret is the secret access key.
+	Secret string
+	// SessionToken is the session token of temporary credentials, if any.
+	SessionToken string
+	// Region is the region of the bucket, e.g., us-east-1.
+	Region string
+	// Endpoint is the endpoint of an S3-compatible storage, e.g., storage.example.com:9000.
+	Endpoint string
+	// URLStyle is either "vhost", the default, or "path".
+	URLStyle string
+	// Scope is the path prefix the secret applies to, e.g., s3://my-bucket. It defaults to all S3 paths.
+	Scope string
+}
+
+func (s S3Secret) secretType() string {
+	return "S3"
+}
+
+func (s S3Secret) secretOptions() []secretOption {
+	return []secretOption{
+		{"KEY_ID", s.KeyID},
+		{"SECRET", s.Secret},
+		{"SESSION_TOKEN", s.SessionToken},
+		{"REGION", s.Region},
+		{"ENDPOINT", s.Endpoint},
+		{"URL_STYLE", s.URLStyle},
+		{"SCOPE", s.Scope},
+	}
+}
+
+// GCSSecret holds the HMAC keys of Google Cloud Storage. DuckDB's httpfs extension provides it.
+type GCSSecret struct {
+	// KeyID is the HMAC key ID.
+	KeyID string
+	// Secret is the HMAC secret.
+	Secret string
+	// Scope is the path prefix the secret applies to, e.g., gcs://my-bucket. It defaults to all GCS paths.
+	Scope string
+}
+
+func (s GCSSecret) secretType() string {
+	return "GCS"
+}
+
+func (s GCSSecret) secretOptions() []secretOption {
+	return []secretOption{
+		{"KEY_ID", s.KeyID},
+		{"SECRET", s.Secret},
+		{"SCOPE", s.Scope},
+	}
+}
+
+// AzureSecret holds the credentials of Azure Blob Storage. DuckDB's azure extension provides it.
+// It authenticates with either the connection string, or, for public containers, the account name.
+type AzureSecret struct {
+	// ConnectionString is the connection string of the storage account.
+	ConnectionString string
+	// AccountName is the name of the storage account.
+	AccountName string
+	// Scope is the path prefix the secret applies to, e.g., azure://my-container. It defaults to all Azure paths.
+	Scope string
+}
+
+func (s AzureSecret) secretType() string {
+	return "AZURE"
+}
+
+func (s AzureSecret) secretOptions() []secretOption {
+	return []secretOption{
+		{"CONNECTION_STRING", s.ConnectionString},
+		{"ACCOUNT_NAME", s.AccountName},
+		{"SCOPE", s.Scope},
+	}
+}
+
+// SecretInfo describes a secret of DuckDB's secrets manager, without its credentials, see ListSecrets.
+type SecretInfo struct {
+	// Name is the name of the secret.
+	Name string
+	// Type is the type of the secret, e.g., s3.
+	Type string
+	// Provider is the provider of the secret, e.g., config.
+	Provider string
+	// Persistent is true, if DuckDB stores the secret in the secret directory, and false, if it is temporary.
+	Persistent bool
+	// Storage is the storage of the secret, e.g., memory or local_file.
+	Storage string
+	// Scope contains the path prefixes the secret applies to.
+	Scope []string
+}
+
+// CreateSecret creates or replaces the temporary secret, which the connections of the database use to access
+// cloud storage. Unlike a CREATE SECRET statement, the credentials are neither part of a query of the application,
+// nor of ActiveQueries or error details, see WithExplainOnError. The extension providing the secret type must be
+// loaded, or autoloaded, see WithAutoloadExtensions.
+func CreateSecret(ctx context.Context, driverConn driver.Conn, name string, secret Secret) error {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		return getError(errSecret, errors.New("empty secret name"))
+	}
+	if secret == nil {
+		return getError(errSecret, errors.New("missing secret"))
+	}
+
+	var query strings.Builder
+	query.WriteString("CREATE OR REPLACE TEMPORARY SECRET " + quoteIdentifier(name) + " (TYPE " + secret.secretType())
+	for _, option := range secret.secretOptions() {
+		if option.value != "" {
+			query.WriteString(", " + option.name + " " + quoteString(option.value))
+		}
+	}
+	query.WriteString(")")
+
+	if err = con.execSensitive(ctx, query.String()); err != nil {
+		return getError(errSecret, err)
+	}
+	return nil
+}
+
+// ListSecrets returns the secrets of the database of the connection, ordered by their name.
+func ListSecrets(ctx context.Context, driverConn driver.Conn) ([]SecretInfo, error) {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return nil, err
+	}
+
+	driverRows, err := con.QueryContext(ctx, `SELECT name, type, provider, persistent, storage, scope
+		FROM duckdb_secrets() ORDER BY name`, nil)
+	if err != nil {
+		return nil, getError(errSecret, err)
+	}
+	defer driverRows.Close()
+
+	var secrets []SecretInfo
+	values := make([]driver.Value, 6)
+	for {
+		if err = driverRows.Next(values); err == io.EOF {
+			return secrets, nil
+		} else if err != nil {
+			return nil, getError(errSecret, err)
+		}
+
+		info := SecretInfo{}
+		info.Name, _ = values[0].(string)
+		info.Type, _ = values[1].(string)
+		info.Provider, _ = values[2].(string)
+		info.Persistent, _ = values[3].(bool)
+		info.Storage, _ = values[4].(string)
+		scope, _ := values[5].([]any)
+		for _, prefix := range scope {
+			if s, ok := prefix.(string); ok {
+				info.Scope = append(info.Scope, s)
+			}
+		}
+		secrets = append(secrets, info)
+	}
+}
+
+// DropSecret drops the secret of the database of the connection. It fails, if the secret does not exist.
+func DropSecret(ctx context.Context, driverConn driver.Conn, name string) error {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return err
+	}
+	if _, err = con.ExecContext(ctx, "DROP SECRET "+quoteIdentifier(name), nil); err != nil {
+		return getError(errSecret, err)
+	}
+	return nil
+}
+
+// execSensitive executes a query containing credentials, without tracking it as an active query
+// or attaching it to the details of an error.
+func (c *conn) execSensitive(ctx context.Context, query string) error {
+	cQuery := C.CString(query)
+	defer C.free(unsafe.Pointer(cQuery))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stop := c.interruptOnDone(ctx)
+	defer stop()
+
+	var res C.duckdb_result
+	state := C.duckdb_query(c.duckdbCon, cQuery, &res)
+	defer C.duckdb_destroy_result(&res)
+	if state == C.DuckDBError {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return newError(C.GoString(C.duckdb_result_error(&res)))
+	}
+	return nil
+}
@@ -0,0 +1,67 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSecrets(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	con, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	ctx := context.Background()
+	err = con.Raw(func(driverConn any) error {
+		return CreateSecret(ctx, driverConn.(driver.Conn), "s3", S3Secret{
+			KeyID:  "my-key-id",
+			Secret: "my-secret",
+			Region: "us-east-1",
+			Scope:  "s3://bucket",
+		})
+	})
+	// The httpfs extension providing the S3 secret type is not loaded.
+	testError(t, err, errSecret.Error(), "httpfs")
+	require.NotContains(t, err.Error(), "my-secret")
+
+	for _, secret := range []Secret{GCSSecret{KeyID: "id", Secret: "secret'"}, AzureSecret{AccountName: "account"}} {
+		err = con.Raw(func(driverConn any) error {
+			return CreateSecret(ctx, driverConn.(driver.Conn), "other", secret)
+		})
+		testError(t, err, errSecret.Error(), "does not exist")
+	}
+
+	err = con.Raw(func(driverConn any) error {
+		return CreateSecret(ctx, driverConn.(driver.Conn), "", S3Secret{})
+	})
+	testError(t, err, errSecret.Error(), "empty secret name")
+	err = con.Raw(func(driverConn any) error {
+		return CreateSecret(ctx, driverConn.(driver.Conn), "s3", nil)
+	})
+	testError(t, err, errSecret.Error(), "missing secret")
+
+	var secrets []SecretInfo
+	require.NoError(t, con.Raw(func(driverConn any) error {
+		secrets, err = ListSecrets(ctx, driverConn.(driver.Conn))
+		return err
+	}))
+	require.Empty(t, secrets)
+
+	err = con.Raw(func(driverConn any) error {
+		return DropSecret(ctx, driverConn.(driver.Conn), "s3")
+	})
+	testError(t, err, errSecret.Error(), "non-existent secret")
+
+	err = CreateSecret(ctx, nil, "s3", S3Secret{})
+	testError(t, err, errInvalidCon.Error())
+	_, err = ListSecrets(ctx, nil)
+	testError(t, err, errInvalidCon.Error())
+	err = DropSecret(ctx, nil, "s3")
+	testError(t, err, errInvalidCon.Error())
+}
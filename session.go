@@ -0,0 +1,147 @@
+package duckdb
+
+/*
+#include <stdlib.h>
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"strings"
+	"unsafe"
+)
+
+// sessionStateQuery returns a fingerprint of the session of a connection, i.e., its settings, current schema,
+// and temporary tables, views, and sequences. It excludes the settings the driver changes, see ContextWithProgress
+// and EnableProfiling. Listing temporary macros is too slow, as DuckDB lists all functions to find them.
+const sessionStateQuery = `SELECT md5(concat_ws(',',
+	(SELECT string_agg(name || '=' || coalesce(value, ''), ',' ORDER BY name) FROM duckdb_settings()
+		WHERE name NOT IN ('enable_progress_bar', 'enable_progress_bar_print', 'progress_bar_time',
+			'enable_profiling', 'profiling_mode', 'profiling_output')),
+	current_database(), current_schema(),
+	(SELECT count(*) FROM duckdb_tables() WHERE temporary),
+	(SELECT count(*) FROM duckdb_views() WHERE temporary),
+	(SELECT count(*) FROM duckdb_sequences() WHERE temporary)))`
+
+// invalidatedErrMsgs are the parts of the error messages of DuckDB, after which the database rejects all queries.
+// A fatal error invalidates the database, and subsequent queries fail because of the invalidated database.
+var invalidatedErrMsgs = []string{"FATAL Error", "database has been invalidated"}
+
+// IsValid implements driver.Validator. database/sql calls it before returning a connection to its pool.
+// A closed connection, a connection of a closed Connector, and a connection whose database DuckDB invalidated
+// after a fatal error are not valid, so that database/sql discards them instead of handing them out again.
+func (c *conn) IsValid() bool {
+	return !c.closed && !c.invalidated && !c.connectorClosed.Load()
+}
+
+// Ping implements driver.Pinger. It executes a trivial query on the connection.
+// It returns driver.ErrBadConn, if the connection is not valid, see IsValid, so that database/sql discards it.
+func (c *conn) Ping(ctx context.Context) error {
+	if !c.IsValid() {
+		return driver.ErrBadConn
+	}
+
+	query := C.CString(`SELECT 1`)
+	defer C.free(unsafe.Pointer(query))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stop := c.interruptOnDone(ctx)
+	defer stop()
+
+	var res C.duckdb_result
+	state := C.duckdb_query(c.duckdbCon, query, &res)
+	defer C.duckdb_destroy_result(&res)
+	if state == C.DuckDBError {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		err := c.queryError(C.GoString(C.duckdb_result_error(&res)))
+		if !c.IsValid() {
+			return driver.ErrBadConn
+		}
+		return getError(errPing, err)
+	}
+	return nil
+}
+
+// queryError returns the *Error of a failed query with the error message of DuckDB.
+// It invalidates the connection, if the error message reports a fatal error, see IsValid.
+func (c *conn) queryError(msg string) *Error {
+	for _, invalidatedMsg := range invalidatedErrMsgs {
+		if strings.Contains(msg, invalidatedMsg) {
+			c.invalidated = true
+			break
+		}
+	}
+	return newError(msg)
+}
+
+// ResetSession implements driver.SessionResetter. database/sql calls it before reusing a connection of its pool.
+// A connection whose session changed since it was created, e.g., because it has temporary tables or a different
+// search_path, is discarded, so that the next user gets a new connection. Profiling connections are discarded, too.
+// Attached databases belong to the database instead of a connection, so they do not discard a connection.
+// Temporary macros and statements prepared with the PREPARE statement are not detected.
+// Only a connection, which executed a statement that may change its session, see trackSession, compares the
+// fingerprint of its session, so that reusing other connections does not execute a query.
+func (c *conn) ResetSession(ctx context.Context) error {
+	if !c.IsValid() || c.tx || c.profilingOutput != "" {
+		return driver.ErrBadConn
+	}
+	if !c.sessionDirty {
+		return nil
+	}
+	state, err := c.sessionState(ctx)
+	if err != nil || state != c.initialSessionState {
+		return driver.ErrBadConn
+	}
+	c.sessionDirty = false
+	return nil
+}
+
+// trackSession marks the session of the connection as possibly changed, if the statement may change it, e.g., a SET,
+// CREATE, or ATTACH statement. Before the first such statement, it takes the fingerprint of the unchanged session,
+// which ResetSession compares. It must be called while holding the lock of the connection.
+func (s *stmt) trackSession() {
+	if s.c.sessionDirty {
+		return
+	}
+	switch C.duckdb_prepared_statement_type(*s.stmt) {
+	case C.DUCKDB_STATEMENT_TYPE_VARIABLE_SET, C.DUCKDB_STATEMENT_TYPE_SET, C.DUCKDB_STATEMENT_TYPE_PRAGMA,
+		C.DUCKDB_STATEMENT_TYPE_CREATE, C.DUCKDB_STATEMENT_TYPE_DROP, C.DUCKDB_STATEMENT_TYPE_ALTER,
+		C.DUCKDB_STATEMENT_TYPE_ATTACH, C.DUCKDB_STATEMENT_TYPE_DETACH, C.DUCKDB_STATEMENT_TYPE_LOAD,
+		C.DUCKDB_STATEMENT_TYPE_EXECUTE, C.DUCKDB_STATEMENT_TYPE_CALL, C.DUCKDB_STATEMENT_TYPE_INVALID:
+	default:
+		return
+	}
+	if s.c.initialSessionState == "" {
+		// A failed fingerprint does not match any session, so that ResetSession discards the connection.
+		s.c.initialSessionState, _ = s.c.rawQueryString(sessionStateQuery)
+	}
+	s.c.sessionDirty = true
+}
+
+// sessionState returns the fingerprint of the session of the connection, see sessionStateQuery.
+// Unlike the queries of the application, it is not tracked by ActiveQueries.
+func (c *conn) sessionState(ctx context.Context) (string, error) {
+	query := C.CString(sessionStateQuery)
+	defer C.free(unsafe.Pointer(query))
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stop := c.interruptOnDone(ctx)
+	defer stop()
+
+	var res C.duckdb_result
+	state := C.duckdb_query(c.duckdbCon, query, &res)
+	defer C.duckdb_destroy_result(&res)
+	if state == C.DuckDBError {
+		return "", newError(C.GoString(C.duckdb_result_error(&res)))
+	}
+
+	fingerprint := C.duckdb_value_varchar(&res, 0, 0)
+	defer C.duckdb_free(unsafe.Pointer(fingerprint))
+	return C.GoString(fingerprint), nil
+}
@@ -0,0 +1,147 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestResetSession(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	db.SetMaxIdleConns(1)
+
+	ctx := context.Background()
+	connID := func(t *testing.T, con *sql.Conn) uint64 {
+		var id uint64
+		require.NoError(t, con.Raw(func(driverConn any) error {
+			id = driverConn.(*conn).id
+			return nil
+		}))
+		return id
+	}
+	// withConn returns the ID of the connection, after calling fn with it.
+	withConn := func(t *testing.T, fn func(con *sql.Conn)) uint64 {
+		con, err := db.Conn(ctx)
+		require.NoError(t, err)
+		defer con.Close()
+		fn(con)
+		return connID(t, con)
+	}
+
+	t.Run("no session statements", func(t *testing.T) {
+		// A connection, which only executed queries, does not take the fingerprint of its session.
+		con, err := db.Conn(ctx)
+		require.NoError(t, err)
+		defer con.Close()
+		require.NoError(t, con.QueryRowContext(ctx, `SELECT 1`).Scan(new(int)))
+		require.NoError(t, con.Raw(func(driverConn any) error {
+			require.False(t, driverConn.(*conn).sessionDirty)
+			require.Empty(t, driverConn.(*conn).initialSessionState)
+			return nil
+		}))
+	})
+
+	t.Run("clean session", func(t *testing.T) {
+		first := withConn(t, func(con *sql.Conn) {
+			_, err := con.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS t (i INTEGER); INSERT INTO t VALUES (1)`)
+			require.NoError(t, err)
+		})
+		second := withConn(t, func(*sql.Conn) {})
+		require.Equal(t, first, second)
+	})
+
+	for name, query := range map[string]string{
+		"temporary table": `CREATE TEMP TABLE tmp (i INTEGER)`,
+		"temporary view":  `CREATE TEMP VIEW v AS SELECT 1`,
+		"setting":         `SET search_path = 'temp'`,
+		"schema":          `CREATE SCHEMA IF NOT EXISTS s; USE s`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			first := withConn(t, func(con *sql.Conn) {
+				_, err := con.ExecContext(ctx, query)
+				require.NoError(t, err)
+			})
+			second := withConn(t, func(con *sql.Conn) {
+				var count int
+				require.NoError(t, con.QueryRowContext(ctx, `SELECT count(*) FROM duckdb_tables() WHERE temporary`).
+					Scan(&count))
+				require.Zero(t, count)
+				var schema string
+				require.NoError(t, con.QueryRowContext(ctx, `SELECT current_schema()`).Scan(&schema))
+				require.Equal(t, "main", schema)
+			})
+			require.NotEqual(t, first, second)
+		})
+	}
+
+	t.Run("driver settings", func(t *testing.T) {
+		first := withConn(t, func(con *sql.Conn) {
+			progressCtx := ContextWithProgress(ctx, 0, func(QueryProgress) {})
+			_, err := con.ExecContext(progressCtx, `SELECT 1`)
+			require.NoError(t, err)
+		})
+		second := withConn(t, func(*sql.Conn) {})
+		require.Equal(t, first, second)
+	})
+}
+
+func TestIsValid(t *testing.T) {
+	t.Parallel()
+
+	t.Run("fatal error", func(t *testing.T) {
+		c, err := NewConnector("", nil)
+		require.NoError(t, err)
+		defer c.Close()
+		driverConn, err := c.Connect(context.Background())
+		require.NoError(t, err)
+		defer driverConn.Close()
+		con := driverConn.(*conn)
+		require.True(t, con.IsValid())
+
+		// A regular error does not invalidate the connection.
+		_, err = con.ExecContext(context.Background(), `SELECT * FROM missing`, nil)
+		require.Error(t, err)
+		require.True(t, con.IsValid())
+
+		con.queryError("FATAL Error: Failed: database has been invalidated because of a previous fatal error")
+		require.False(t, con.IsValid())
+		require.ErrorIs(t, con.ResetSession(context.Background()), driver.ErrBadConn)
+	})
+
+	t.Run("closed connector", func(t *testing.T) {
+		c, err := NewConnector("", nil)
+		require.NoError(t, err)
+		driverConn, err := c.Connect(context.Background())
+		require.NoError(t, err)
+		defer driverConn.Close()
+		require.True(t, driverConn.(*conn).IsValid())
+
+		require.NoError(t, c.Close())
+		require.False(t, driverConn.(*conn).IsValid())
+	})
+}
+
+func TestPing(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+	require.NoError(t, db.PingContext(context.Background()))
+
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	defer c.Close()
+	driverConn, err := c.Connect(context.Background())
+	require.NoError(t, err)
+	defer driverConn.Close()
+	con := driverConn.(*conn)
+	require.NoError(t, con.Ping(context.Background()))
+
+	con.queryError("FATAL Error: Failed: database has been invalidated because of a previous fatal error")
+	require.ErrorIs(t, con.Ping(context.Background()), driver.ErrBadConn)
+}
@@ -0,0 +1,128 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// Setting is a configuration option of DuckDB, see ListSettings.
+type Setting struct {
+	// Name is the name of the setting, e.g., threads or memory_limit.
+	Name string
+	// Value is the current value of the setting, converted according to its InputType: a bool for BOOLEAN,
+	// an int64 for BIGINT, a uint64 for UBIGINT, a float64 for DOUBLE, and a string otherwise, e.g., for
+	// the byte size of memory_limit. It is nil, if the setting has no value.
+	Value any
+	// Description describes the setting.
+	Description string
+	// InputType is the DuckDB type of the values of the setting, e.g., BOOLEAN or VARCHAR.
+	InputType string
+	// Scope is GLOBAL, if the setting applies to all connections of the database, or LOCAL, if it applies
+	// to a single connection.
+	Scope string
+}
+
+// SetSetting sets the setting of the connection to the value, e.g., SetSetting(ctx, conn, "threads", 4).
+// The value can be a string, a bool, an integer, or a float, which SetSetting quotes as a SQL literal,
+// instead of formatting a SET statement. A nil value resets the setting to its default.
+// Setting a GLOBAL setting changes it for all connections of the database. database/sql discards connections
+// whose settings changed when returning them to its pool, see ResetSession.
+func SetSetting(ctx context.Context, driverConn driver.Conn, name string, value any) error {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return err
+	}
+
+	var query string
+	switch v := value.(type) {
+	case nil:
+		query = "RESET " + quoteIdentifier(name)
+	case string:
+		query = "SET " + quoteIdentifier(name) + " = " + quoteString(v)
+	case bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		query = "SET " + quoteIdentifier(name) + " = " + fmt.Sprint(v)
+	default:
+		return getError(errSetting, fmt.Errorf("unsupported value %T of setting %s", v, name))
+	}
+	if _, err = con.ExecContext(ctx, query, nil); err != nil {
+		return getError(errSetting, err)
+	}
+	return nil
+}
+
+// GetSetting returns the setting of the connection. It fails for an unknown setting.
+func GetSetting(ctx context.Context, driverConn driver.Conn, name string) (Setting, error) {
+	settings, err := querySettings(ctx, driverConn, `WHERE name = ?`, name)
+	if err != nil {
+		return Setting{}, err
+	}
+	if len(settings) == 0 {
+		return Setting{}, getError(errSetting, fmt.Errorf("unknown setting %s", name))
+	}
+	return settings[0], nil
+}
+
+// ListSettings returns all settings of the connection, ordered by their name.
+func ListSettings(ctx context.Context, driverConn driver.Conn) ([]Setting, error) {
+	return querySettings(ctx, driverConn, "")
+}
+
+func querySettings(ctx context.Context, driverConn driver.Conn, where string, args ...any) ([]Setting, error) {
+	con, err := openConn(driverConn)
+	if err != nil {
+		return nil, err
+	}
+
+	driverRows, err := con.QueryContext(ctx, `SELECT name, value, description, input_type, scope
+		FROM duckdb_settings() `+where+` ORDER BY name`, anyArgsToNamedArgs(args))
+	if err != nil {
+		return nil, getError(errSetting, err)
+	}
+	defer driverRows.Close()
+
+	var settings []Setting
+	values := make([]driver.Value, 5)
+	for {
+		if err = driverRows.Next(values); err == io.EOF {
+			return settings, nil
+		} else if err != nil {
+			return nil, getError(errSetting, err)
+		}
+
+		setting := Setting{}
+		setting.Name, _ = values[0].(string)
+		setting.Description, _ = values[2].(string)
+		setting.InputType, _ = values[3].(string)
+		setting.Scope, _ = values[4].(string)
+		if value, ok := values[1].(string); ok {
+			setting.Value = settingValue(value, setting.InputType)
+		}
+		settings = append(settings, setting)
+	}
+}
+
+// settingValue converts the text of the value of a setting according to its input type.
+// A value, which does not parse, e.g., of a BIGINT setting, whose value has a unit, stays a string.
+func settingValue(value string, inputType string) any {
+	var v any
+	var err error
+	switch inputType {
+	case "BOOLEAN":
+		v, err = strconv.ParseBool(value)
+	case "BIGINT":
+		v, err = strconv.ParseInt(value, 10, 64)
+	case "UBIGINT":
+		v, err = strconv.ParseUint(value, 10, 64)
+	case "DOUBLE":
+		v, err = strconv.ParseFloat(value, 64)
+	default:
+		return value
+	}
+	if err != nil {
+		return value
+	}
+	return v
+}
@@ -0,0 +1,70 @@
+package duckdb
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSettings(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	con, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	ctx := context.Background()
+	require.NoError(t, RawConn(con, func(c *Conn) error {
+		require.NoError(t, c.SetSetting(ctx, "threads", 3))
+		setting, err := c.GetSetting(ctx, "threads")
+		require.NoError(t, err)
+		require.Equal(t, int64(3), setting.Value)
+		require.Equal(t, "BIGINT", setting.InputType)
+		require.Equal(t, "GLOBAL", setting.Scope)
+		require.NotEmpty(t, setting.Description)
+
+		require.NoError(t, c.SetSetting(ctx, "enable_progress_bar", true))
+		setting, err = c.GetSetting(ctx, "enable_progress_bar")
+		require.NoError(t, err)
+		require.Equal(t, true, setting.Value)
+		require.Equal(t, "LOCAL", setting.Scope)
+		require.NoError(t, c.SetSetting(ctx, "enable_progress_bar", nil))
+		setting, err = c.GetSetting(ctx, "enable_progress_bar")
+		require.NoError(t, err)
+		require.Equal(t, false, setting.Value)
+
+		// Strings are quoted.
+		require.NoError(t, c.SetSetting(ctx, "memory_limit", "1GiB"))
+		setting, err = c.GetSetting(ctx, "memory_limit")
+		require.NoError(t, err)
+		require.Equal(t, "VARCHAR", setting.InputType)
+		require.Equal(t, "1.0 GiB", setting.Value)
+		dir := filepath.Join(t.TempDir(), "it's")
+		require.NoError(t, c.SetSetting(ctx, "temp_directory", dir))
+		setting, err = c.GetSetting(ctx, "temp_directory")
+		require.NoError(t, err)
+		require.Equal(t, dir, setting.Value)
+
+		settings, err := c.ListSettings(ctx)
+		require.NoError(t, err)
+		require.Greater(t, len(settings), 10)
+		for i := 1; i < len(settings); i++ {
+			require.Less(t, settings[i-1].Name, settings[i].Name)
+		}
+
+		err = c.SetSetting(ctx, "missing", 1)
+		testError(t, err, errSetting.Error(), "unrecognized configuration parameter")
+		err = c.SetSetting(ctx, "threads", []int{1})
+		testError(t, err, errSetting.Error(), "unsupported value []int of setting threads")
+		_, err = c.GetSetting(ctx, "missing")
+		testError(t, err, errSetting.Error(), "unknown setting missing")
+		return nil
+	}))
+
+	_, err = ListSettings(ctx, nil)
+	testError(t, err, errInvalidCon.Error())
+}
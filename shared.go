@@ -0,0 +1,87 @@
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// sharedMemoryPrefix is the prefix of the DSN of a named in-memory database.
+const sharedMemoryPrefix = ":memory:"
+
+// sharedMemoryName returns the name of the named in-memory database of the path, or an empty string,
+// if it is not one. It removes the cache parameter from the DSN, as it is not a DuckDB configuration option.
+func sharedMemoryName(path string, parsedDSN *url.URL) (string, error) {
+	name, ok := strings.CutPrefix(path, sharedMemoryPrefix)
+	if !ok || name == "" {
+		return "", nil
+	}
+
+	query := parsedDSN.Query()
+	if cache := query.Get("cache"); cache != "" && cache != "shared" {
+		return "", fmt.Errorf("invalid cache mode of in-memory database %s: %s", name, cache)
+	}
+	query.Del("cache")
+	parsedDSN.RawQuery = query.Encode()
+	return name, nil
+}
+
+// sharedDatabase is a named in-memory database shared by the Connectors of the process.
+type sharedDatabase struct {
+	db   C.duckdb_database
+	refs int
+}
+
+// sharedDatabaseRegistry keeps the named in-memory databases of the process, while they have Connectors.
+type sharedDatabaseRegistry struct {
+	mu        sync.Mutex
+	databases map[string]*sharedDatabase
+}
+
+var sharedDatabases sharedDatabaseRegistry
+
+// acquire returns the database of the name, and opens it, if no Connector shares it yet.
+// It returns true, if it opened the database.
+func (r *sharedDatabaseRegistry) acquire(name string, open func() (C.duckdb_database, error)) (
+	C.duckdb_database, bool, error,
+) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if shared, ok := r.databases[name]; ok {
+		shared.refs++
+		return shared.db, false, nil
+	}
+
+	db, err := open()
+	if err != nil {
+		return nil, false, err
+	}
+	if r.databases == nil {
+		r.databases = make(map[string]*sharedDatabase)
+	}
+	r.databases[name] = &sharedDatabase{db: db, refs: 1}
+	return db, true, nil
+}
+
+// release closes the database of the name, if its last Connector releases it.
+func (r *sharedDatabaseRegistry) release(name string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	shared, ok := r.databases[name]
+	if !ok {
+		return
+	}
+	shared.refs--
+	if shared.refs == 0 {
+		C.duckdb_close(&shared.db)
+		delete(r.databases, name)
+	}
+}
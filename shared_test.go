@@ -0,0 +1,61 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSharedMemoryDatabase(t *testing.T) {
+	t.Parallel()
+
+	first, err := NewConnector(":memory:shared_test?cache=shared&threads=2", nil,
+		WithBootQueries(`CREATE TABLE tbl (i INTEGER)`))
+	require.NoError(t, err)
+	db := sql.OpenDB(first)
+	_, err = db.Exec(`INSERT INTO tbl VALUES (1)`)
+	require.NoError(t, err)
+
+	// The second connector shares the database, and does not execute its boot queries again.
+	second, err := NewConnector(":memory:shared_test", nil, WithBootQueries(`CREATE TABLE tbl (i INTEGER)`))
+	require.NoError(t, err)
+	con, err := second.Connect(context.Background())
+	require.NoError(t, err)
+	a, err := NewAppenderFromConn(con, "", "tbl")
+	require.NoError(t, err)
+	require.NoError(t, a.AppendRow(int32(2)))
+	require.NoError(t, a.Close())
+	require.NoError(t, con.Close())
+
+	var sum int64
+	require.NoError(t, db.QueryRow(`SELECT sum(i) FROM tbl`).Scan(&sum))
+	require.Equal(t, int64(3), sum)
+
+	// Other names and unnamed in-memory databases are not shared.
+	for _, dsn := range []string{":memory:other_shared_test", ":memory:", ""} {
+		otherDB, err := sql.Open("duckdb", dsn)
+		require.NoError(t, err)
+		err = otherDB.QueryRow(`SELECT sum(i) FROM tbl`).Scan(&sum)
+		require.ErrorContains(t, err, "Table with name tbl does not exist", dsn)
+		require.NoError(t, otherDB.Close())
+	}
+
+	// The database stays open until its last connector closes.
+	require.NoError(t, db.Close())
+	db = sql.OpenDB(second)
+	require.NoError(t, db.QueryRow(`SELECT sum(i) FROM tbl`).Scan(&sum))
+	require.Equal(t, int64(3), sum)
+	require.NoError(t, db.Close())
+	require.NoError(t, second.Close())
+
+	db, err = sql.Open("duckdb", ":memory:shared_test")
+	require.NoError(t, err)
+	defer db.Close()
+	err = db.QueryRow(`SELECT sum(i) FROM tbl`).Scan(&sum)
+	require.ErrorContains(t, err, "Table with name tbl does not exist")
+
+	_, err = NewConnector(":memory:shared_test?cache=private", nil)
+	testError(t, err, errParseDSN.Error(), "invalid cache mode")
+}
@@ -9,9 +9,13 @@ import "C"
 import (
 	"context"
 	"database/sql/driver"
+	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"math/big"
+	"reflect"
+	"strings"
 	"time"
 	"unsafe"
 )
@@ -22,24 +26,52 @@ type stmt struct {
 	closeOnRowsClose bool
 	closed           bool
 	rows             bool
+	// True, if the statement is part of a multi-statement query, so it must not be retried on its own.
+	multiStmt bool
+	// The query containing the statement, see ActiveQueries.
+	query string
+	// True, if the statement is owned by the statement cache of the connection, see WithStatementCache.
+	cached bool
+	// True, if the statement was prepared by Prepare, so its executions call the query hooks, see WithQueryHook.
+	prepared bool
+	// closing is closed by Close, so that an execution of the statement on another goroutine is interrupted,
+	// see interruptOnClose.
+	closing chan struct{}
+	// True, if the statement is a Stmt, whose executions keep the parameters bound by Stmt.Bind,
+	// instead of binding their arguments.
+	keepBindings bool
+	// The leak check of a statement prepared by Prepare, see newLeakCheck.
+	leak *leakCheck
+	// restoreMemoryLimit restores the memory limit of a streaming execution, which its rows take over, so that
+	// the limit applies until the rows are closed, see ContextWithMemoryLimit.
+	restoreMemoryLimit func()
 }
 
+// Close closes the statement. If another goroutine executes the statement, e.g., of a driver connection,
+// then Close interrupts the execution, which fails with an INTERRUPT error, instead of waiting for it to complete.
+// Closing a closed statement returns an error matching ErrClosed.
 func (s *stmt) Close() error {
+	if s.closed {
+		return getError(errClosedStmt, nil)
+	}
 	if s.rows {
 		panic("database/sql/driver: misuse of duckdb driver: Close with active Rows")
 	}
-	if s.closed {
-		panic("database/sql/driver: misuse of duckdb driver: double Close of Stmt")
-	}
 
+	close(s.closing)
 	s.closed = true
+	s.leak.close()
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
 	C.duckdb_destroy_prepare(s.stmt)
 	return nil
 }
 
+// NumInput returns the number of parameters of the statement, or -1 after closing it, so that executing it
+// returns the error of the closed statement.
 func (s *stmt) NumInput() int {
 	if s.closed {
-		panic("database/sql/driver: misuse of duckdb driver: NumInput after Close")
+		return -1
 	}
 	paramCount := C.duckdb_nparams(*s.stmt)
 	return int(paramCount)
@@ -49,11 +81,26 @@ func (s *stmt) bind(args []driver.NamedValue) error {
 	if s.NumInput() != len(args) {
 		return fmt.Errorf("incorrect argument count for command: have %d want %d", len(args), s.NumInput())
 	}
+	return s.bindArgs(args)
+}
 
-	// FIXME (feature): we can't pass nested types as parameters (bind_value) yet
+// bindArgs binds the arguments to their parameters, and keeps the bindings of the other parameters.
+func (s *stmt) bindArgs(args []driver.NamedValue) error {
+	// FIXME (feature): we can't pass nested types as parameters (bind_value) yet, except for maps, see mapParamText.
 
-	for i, v := range args {
-		switch v := v.Value.(type) {
+	for _, arg := range args {
+		value, err := valuerValue(arg.Value)
+		if err != nil {
+			return err
+		}
+
+		// The parameter index is zero-based here, and one-based in the DuckDB API.
+		i, err := s.paramIndex(arg)
+		if err != nil {
+			return err
+		}
+
+		switch v := value.(type) {
 		case bool:
 			if rv := C.duckdb_bind_boolean(*s.stmt, C.idx_t(i+1), C.bool(v)); rv == C.DuckDBError {
 				return errCouldNotBind
@@ -78,10 +125,22 @@ func (s *stmt) bind(args []driver.NamedValue) error {
 			if rv := C.duckdb_bind_int64(*s.stmt, C.idx_t(i+1), C.int64_t(v)); rv == C.DuckDBError {
 				return errCouldNotBind
 			}
+		case uint:
+			if rv := C.duckdb_bind_uint64(*s.stmt, C.idx_t(i+1), C.uint64_t(v)); rv == C.DuckDBError {
+				return errCouldNotBind
+			}
 		case *big.Int:
 			val, err := hugeIntFromNative(v)
 			if err != nil {
-				return err
+				// The C API cannot bind a UHUGEINT, or a value exceeding the range of a HUGEINT, so bind its text,
+				// which casts to the type of the parameter, e.g., a UHUGEINT or a DOUBLE.
+				val := C.CString(v.String())
+				rv := C.duckdb_bind_varchar(*s.stmt, C.idx_t(i+1), val)
+				C.free(unsafe.Pointer(val))
+				if rv == C.DuckDBError {
+					return errCouldNotBind
+				}
+				continue
 			}
 			if rv := C.duckdb_bind_hugeint(*s.stmt, C.idx_t(i+1), val); rv == C.DuckDBError {
 				return errCouldNotBind
@@ -118,18 +177,32 @@ func (s *stmt) bind(args []driver.NamedValue) error {
 			}
 			C.free(unsafe.Pointer(val))
 		case []byte:
-			val := C.CBytes(v)
-			l := len(v)
-			if rv := C.duckdb_bind_blob(*s.stmt, C.idx_t(i+1), val, C.uint64_t(l)); rv == C.DuckDBError {
-				C.free(unsafe.Pointer(val))
+			// DuckDB copies the bytes into the parameter, so they need not be copied into C memory first.
+			var val unsafe.Pointer
+			if len(v) > 0 {
+				val = unsafe.Pointer(&v[0])
+			}
+			if rv := C.duckdb_bind_blob(*s.stmt, C.idx_t(i+1), val, C.uint64_t(len(v))); rv == C.DuckDBError {
 				return errCouldNotBind
 			}
-			C.free(unsafe.Pointer(val))
+		case io.Reader:
+			if err := s.bindBlobReader(i, v); err != nil {
+				return err
+			}
 		case time.Time:
-			val := C.duckdb_timestamp{
-				micros: C.int64_t(v.UTC().UnixMicro()),
+			if err := s.bindTime(i+1, v); err != nil {
+				return err
+			}
+		case Date:
+			if rv := C.duckdb_bind_date(*s.stmt, C.idx_t(i+1), v.toNative()); rv == C.DuckDBError {
+				return errCouldNotBind
+			}
+		case Decimal:
+			val, err := decimalToNative(v)
+			if err != nil {
+				return fmt.Errorf("%w: %s", errCouldNotBind, err.Error())
 			}
-			if rv := C.duckdb_bind_timestamp(*s.stmt, C.idx_t(i+1), val); rv == C.DuckDBError {
+			if rv := C.duckdb_bind_decimal(*s.stmt, C.idx_t(i+1), val); rv == C.DuckDBError {
 				return errCouldNotBind
 			}
 		case Interval:
@@ -141,32 +214,229 @@ func (s *stmt) bind(args []driver.NamedValue) error {
 			if rv := C.duckdb_bind_interval(*s.stmt, C.idx_t(i+1), val); rv == C.DuckDBError {
 				return errCouldNotBind
 			}
+		case TypedParam:
+			return fmt.Errorf("%w: a TypedParam requires the ExecContext or QueryContext of a connection", errCouldNotBind)
 		case nil:
 			if rv := C.duckdb_bind_null(*s.stmt, C.idx_t(i+1)); rv == C.DuckDBError {
 				return errCouldNotBind
 			}
 		default:
-			return driver.ErrSkip
+			if reflect.ValueOf(v).Kind() != reflect.Map {
+				return &ParamTypeError{Index: i + 1, Type: reflect.TypeOf(v)}
+			}
+			// The C API cannot bind nested values, so bind the text of the map, which casts to a MAP parameter.
+			text, err := mapParamText(reflect.ValueOf(v))
+			if err != nil {
+				return fmt.Errorf("%w: %s", errCouldNotBind, err.Error())
+			}
+			val := C.CString(text)
+			rv := C.duckdb_bind_varchar(*s.stmt, C.idx_t(i+1), val)
+			C.free(unsafe.Pointer(val))
+			if rv == C.DuckDBError {
+				return errCouldNotBind
+			}
 		}
 	}
 
 	return nil
 }
 
+// paramIndex returns the zero-based index of the parameter of an argument.
+// A named argument binds to the parameter of its name, e.g., $id, which fills all occurrences of the name.
+// Other arguments bind by their ordinal position.
+func (s *stmt) paramIndex(arg driver.NamedValue) (int, error) {
+	if arg.Name == "" {
+		return arg.Ordinal - 1, nil
+	}
+
+	name := C.CString(arg.Name)
+	defer C.free(unsafe.Pointer(name))
+
+	var index C.idx_t
+	if state := C.duckdb_bind_parameter_index(*s.stmt, &index, name); state == C.DuckDBError {
+		return 0, fmt.Errorf("%w: unknown named parameter: %s", errCouldNotBind, arg.Name)
+	}
+	return int(index) - 1, nil
+}
+
+// paramNames returns the name of each parameter. Positional parameters are named by their position.
+func (s *stmt) paramNames() []string {
+	names := make([]string, s.NumInput())
+	for i := range names {
+		name := C.duckdb_parameter_name(*s.stmt, C.idx_t(i+1))
+		names[i] = C.GoString(name)
+		C.duckdb_free(unsafe.Pointer(name))
+	}
+	return names
+}
+
+// valuerValue returns the value of a driver.Valuer, the JSON text of a JSON parameter, see isJSONParam,
+// the Decimal of a DecimalValuer, and any other value unchanged.
+// For example, the value of an invalid sql.NullString is nil, so it binds NULL.
+// database/sql already converts Valuers, but the helpers of this package pass arguments directly to the driver.
+func valuerValue(v any) (any, error) {
+	if isJSONParam(v) {
+		return jsonParamText(v)
+	}
+	if d, ok := v.(DecimalValuer); ok {
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+			return nil, nil
+		}
+		dec, err := decimalFromValuer(d)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", errCouldNotBind, err.Error())
+		}
+		return dec, nil
+	}
+	valuer, ok := v.(driver.Valuer)
+	if !ok {
+		return v, nil
+	}
+	if rv := reflect.ValueOf(v); rv.Kind() == reflect.Pointer && rv.IsNil() {
+		return nil, nil
+	}
+	return valuer.Value()
+}
+
+// isJSONParam returns true, if v binds as the text of a JSON value, i.e., if it is a json.RawMessage,
+// or a json.Marshaler without a native parameter type, e.g., not a driver.Valuer or a time.Time.
+func isJSONParam(v any) bool {
+	switch v.(type) {
+	case json.RawMessage:
+		return true
+	case driver.Valuer, DecimalValuer, time.Time, *big.Int:
+		return false
+	case json.Marshaler:
+		return true
+	}
+	return false
+}
+
+// jsonParamText returns the JSON text of a JSON parameter, or nil for a nil json.RawMessage or pointer.
+func jsonParamText(v any) (any, error) {
+	if rv := reflect.ValueOf(v); (rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Slice) && rv.IsNil() {
+		return nil, nil
+	}
+	if msg, ok := v.(json.RawMessage); ok {
+		return string(msg), nil
+	}
+	text, err := json.Marshal(v)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", errCouldNotBind, err.Error())
+	}
+	return string(text), nil
+}
+
+// bindTime binds a time.Time to the parameter at index. A TIME parameter binds the wall clock time of v
+// in its location, and a TIME WITH TIME ZONE parameter additionally binds the offset of the location.
+// Other parameters bind a TIMESTAMP, see timestampMicros, which DuckDB casts to TIMESTAMP_S, TIMESTAMP_MS,
+// and TIMESTAMP_NS parameters. DuckDB v0.10 cannot bind nanoseconds, so a TIMESTAMP_NS parameter stores the
+// microseconds of v, and a TIMESTAMP_S or TIMESTAMP_MS parameter truncates them.
+func (s *stmt) bindTime(index int, v time.Time) error {
+	var rv C.duckdb_state
+	switch C.duckdb_param_type(*s.stmt, C.idx_t(index)) {
+	case C.DUCKDB_TYPE_TIME:
+		rv = C.duckdb_bind_time(*s.stmt, C.idx_t(index), C.duckdb_time{micros: C.int64_t(timeOfDayMicros(v))})
+	case C.DUCKDB_TYPE_TIME_TZ:
+		// The C API cannot bind a TIME WITH TIME ZONE, so bind its text, which casts to the parameter type.
+		val := C.CString(v.Format("15:04:05.999999-07:00"))
+		rv = C.duckdb_bind_varchar(*s.stmt, C.idx_t(index), val)
+		C.free(unsafe.Pointer(val))
+	default:
+		val := C.duckdb_timestamp{micros: C.int64_t(s.timestampMicros(index, v))}
+		rv = C.duckdb_bind_timestamp(*s.stmt, C.idx_t(index), val)
+	}
+	if rv == C.DuckDBError {
+		return errCouldNotBind
+	}
+	return nil
+}
+
+// timeOfDayMicros returns the microseconds since midnight of the wall clock time of v.
+func timeOfDayMicros(v time.Time) int64 {
+	return int64(v.Hour())*int64(time.Hour/time.Microsecond) + int64(v.Minute())*int64(time.Minute/time.Microsecond) +
+		int64(v.Second())*int64(time.Second/time.Microsecond) + int64(v.Nanosecond()/1000)
+}
+
+// timestampMicros returns the microseconds of a time.Time bound to the parameter at index.
+// TIMESTAMP parameters, and TIMESTAMP parameters of other precisions, store the wall clock time in the configured
+// location. The sentinels of InfinityAsSentinel bind infinite values.
+func (s *stmt) timestampMicros(index int, v time.Time) int64 {
+	if micros, ok := infiniteTimeMicros(v); ok {
+		return micros
+	}
+	paramType := C.duckdb_param_type(*s.stmt, C.idx_t(index))
+	if paramType == C.DUCKDB_TYPE_DATE {
+		// A DATE parameter stores the date in the configured location.
+		return DateOf(v.In(s.c.config.dateLocation())).In(time.UTC).UnixMicro()
+	}
+
+	loc := s.c.config.timestampLocation()
+	if loc == time.UTC {
+		return v.UTC().UnixMicro()
+	}
+	switch paramType {
+	case C.DUCKDB_TYPE_TIMESTAMP, C.DUCKDB_TYPE_TIMESTAMP_S, C.DUCKDB_TYPE_TIMESTAMP_MS, C.DUCKDB_TYPE_TIMESTAMP_NS,
+		C.DUCKDB_TYPE_INVALID:
+		w := v.In(loc)
+		return time.Date(w.Year(), w.Month(), w.Day(), w.Hour(), w.Minute(), w.Second(), w.Nanosecond(), time.UTC).UnixMicro()
+	default:
+		return v.UTC().UnixMicro()
+	}
+}
+
 // Deprecated: Use ExecContext instead.
 func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
 	return s.ExecContext(context.Background(), argsToNamedArgs(args))
 }
 
 func (s *stmt) ExecContext(ctx context.Context, nargs []driver.NamedValue) (driver.Result, error) {
-	res, err := s.execute(ctx, nargs)
+	if s.prepared {
+		end := s.c.startHooks(ctx, QueryKindExec, s.query, nargs)
+		res, err := s.execContext(ctx, nargs)
+		end(resultRows(res), 0, err)
+		return res, err
+	}
+	return s.execContext(ctx, nargs)
+}
+
+func (s *stmt) execContext(ctx context.Context, nargs []driver.NamedValue) (driver.Result, error) {
+	res, err := s.executeMaterialized(ctx, nargs)
 	if err != nil {
 		return nil, err
 	}
 	defer C.duckdb_destroy_result(res)
 
-	ra := int64(C.duckdb_value_int64(res, 0, 0))
-	return &result{ra}, nil
+	return &result{rowsAffected(res)}, nil
+}
+
+// executeMaterialized executes the statement and returns its materialized result, which the caller must destroy.
+func (s *stmt) executeMaterialized(ctx context.Context, nargs []driver.NamedValue) (*C.duckdb_result, error) {
+	id := s.c.startQuery(s.query)
+	defer s.c.finishQuery(id)
+
+	var res *C.duckdb_result
+	err := s.retryConflicts(ctx, nargs, func() (err error) {
+		res, err = s.execute(ctx, nargs, false)
+		return err
+	})
+	return res, err
+}
+
+// rowsAffected returns the number of rows changed by a statement, see result.RowsAffected. DuckDB returns the number
+// of changed rows as the value of the Count column of the result, except for a statement with a RETURNING clause,
+// which returns the changed rows. Other statements returning rows, e.g., SELECT, do not change rows, and the results
+// of statements, which return nothing, e.g., CREATE TABLE, have no value, i.e., 0.
+func rowsAffected(res *C.duckdb_result) int64 {
+	if C.duckdb_result_return_type(*res) == C.DUCKDB_RESULT_TYPE_QUERY_RESULT {
+		switch C.duckdb_result_statement_type(*res) {
+		case C.DUCKDB_STATEMENT_TYPE_INSERT, C.DUCKDB_STATEMENT_TYPE_UPDATE, C.DUCKDB_STATEMENT_TYPE_DELETE:
+			return int64(C.duckdb_row_count(res))
+		}
+		return 0
+	}
+	// CREATE TABLE ... AS returns the number of created rows, although its return type is nothing.
+	return int64(C.duckdb_value_int64(res, 0, 0))
 }
 
 // Deprecated: Use QueryContext instead.
@@ -175,42 +445,147 @@ func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
 }
 
 func (s *stmt) QueryContext(ctx context.Context, nargs []driver.NamedValue) (driver.Rows, error) {
-	res, err := s.execute(ctx, nargs)
+	if s.prepared {
+		end := s.c.startHooks(ctx, QueryKindQuery, s.query, nargs)
+		rows, err := s.queryContext(ctx, nargs)
+		if err != nil {
+			end(-1, -1, err)
+			return nil, err
+		}
+		numRows, numChunks := rowCount(rows)
+		end(numRows, numChunks, nil)
+		return rows, nil
+	}
+	return s.queryContext(ctx, nargs)
+}
+
+func (s *stmt) queryContext(ctx context.Context, nargs []driver.NamedValue) (driver.Rows, error) {
+	id := s.c.startQuery(s.query)
+
+	var res *C.duckdb_result
+	err := s.retryConflicts(ctx, nargs, func() (err error) {
+		res, err = s.execute(ctx, nargs, s.c.config.streamingContext(ctx))
+		return err
+	})
 	if err != nil {
+		s.c.finishQuery(id)
 		return nil, err
 	}
 	s.rows = true
-	return newRowsWithStmt(*res, s), nil
+	r := newRowsWithStmt(*res, s)
+	r.restoreMemoryLimit, s.restoreMemoryLimit = s.restoreMemoryLimit, nil
+	if r.streaming {
+		// The query is in flight until all chunks are fetched, which observes the context.
+		r.queryID = id
+		r.ctx = ctx
+	} else {
+		s.c.finishQuery(id)
+	}
+	return r, nil
 }
 
 // This method executes the query in steps and checks if context is cancelled before executing each step.
 // It uses Pending Result Interface C APIs to achieve this. Reference - https://duckdb.org/docs/api/c/api#pending-result-interface
-func (s *stmt) execute(ctx context.Context, args []driver.NamedValue) (*C.duckdb_result, error) {
+// If streaming is set, then the result is a streaming result, whose chunks are computed when fetching them.
+func (s *stmt) execute(ctx context.Context, args []driver.NamedValue, streaming bool) (*C.duckdb_result, error) {
 	if s.closed {
-		panic("database/sql/driver: misuse of duckdb driver: ExecContext or QueryContext after Close")
+		return nil, getError(errClosedStmt, nil)
 	}
 	if s.rows {
 		panic("database/sql/driver: misuse of duckdb driver: ExecContext or QueryContext with active Rows")
 	}
 
-	if err := s.bind(args); err != nil {
+	ctx, cancel := withQueryTimeout(ctx)
+	defer cancel()
+
+	s.c.mu.Lock()
+	defer s.c.mu.Unlock()
+
+	// The context may be done while waiting for the connection.
+	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	if err := s.checkReadOnlyTx(); err != nil {
+		return nil, err
+	}
+	if !s.keepBindings {
+		if err := s.bind(args); err != nil {
+			return nil, err
+		}
+	}
+	s.trackSession()
+	restoreMemoryLimit, err := s.c.limitMemory(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keepMemoryLimit := false
+	defer func() {
+		if !keepMemoryLimit {
+			restoreMemoryLimit()
+		}
+	}()
+
+	// DuckDB tracks the progress of a query, if it is enabled before creating its pending result.
+	stopProgress, err := s.c.reportProgress(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer stopProgress()
+	stopMemoryUsage := s.c.reportMemoryUsage(ctx)
+	defer stopMemoryUsage()
 
 	var pendingRes C.duckdb_pending_result
-	if state := C.duckdb_pending_prepared(*s.stmt, &pendingRes); state == C.DuckDBError {
+	var state C.duckdb_state
+	if streaming {
+		state = C.duckdb_pending_prepared_streaming(*s.stmt, &pendingRes)
+	} else {
+		state = C.duckdb_pending_prepared(*s.stmt, &pendingRes)
+	}
+	if state == C.DuckDBError {
 		dbErr := C.GoString(C.duckdb_pending_error(pendingRes))
 		C.duckdb_destroy_pending(&pendingRes)
-		return nil, errors.New(dbErr)
+		return nil, s.c.queryError(dbErr)
 	}
 	defer C.duckdb_destroy_pending(&pendingRes)
 
+	stop := s.c.interruptOnDone(ctx)
+	stopOnClose := s.interruptOnClose()
+	var res C.duckdb_result
+	state = C.duckdb_execute_pending(pendingRes, &res)
+	stopOnClose()
+	stop()
+	if state == C.DuckDBError {
+		if ctx.Err() != nil {
+			C.duckdb_destroy_result(&res)
+			return nil, ctx.Err()
+		}
+
+		err := C.GoString(C.duckdb_result_error(&res))
+		C.duckdb_destroy_result(&res)
+		return nil, s.c.queryError(err)
+	}
+
+	// The chunks of a streaming result are computed when fetching them, so the memory limit applies until the rows
+	// are closed.
+	if streaming {
+		s.restoreMemoryLimit = restoreMemoryLimit
+		keepMemoryLimit = true
+	}
+	return &res, nil
+}
+
+// interruptOnDone interrupts the execution of the connection, if the context is done before calling stop.
+func (c *conn) interruptOnDone(ctx context.Context) (stop func()) {
+	if ctx.Done() == nil {
+		return func() {}
+	}
+
 	mainDoneCh := make(chan struct{})
 	bgDoneCh := make(chan struct{})
 	go func() {
 		select {
 		case <-ctx.Done():
-			C.duckdb_interrupt(s.c.duckdbCon)
+			C.duckdb_interrupt(c.duckdbCon)
 			close(bgDoneCh)
 			return
 		case <-mainDoneCh:
@@ -219,25 +594,83 @@ func (s *stmt) execute(ctx context.Context, args []driver.NamedValue) (*C.duckdb
 		}
 	}()
 
-	var res C.duckdb_result
-	state := C.duckdb_execute_pending(pendingRes, &res)
-	close(mainDoneCh)
-	// also wait for background goroutine to finish
-	// sometimes the bg goroutine is not scheduled immediately and by that time if another query is running on this connection
-	// it can cancel that query so need to wait for it to finish as well
-	<-bgDoneCh
-	if state == C.DuckDBError {
-		if ctx.Err() != nil {
-			C.duckdb_destroy_result(&res)
-			return nil, ctx.Err()
+	return func() {
+		close(mainDoneCh)
+		// also wait for background goroutine to finish
+		// sometimes the bg goroutine is not scheduled immediately and by that time if another query is running on this connection
+		// it can cancel that query so need to wait for it to finish as well
+		<-bgDoneCh
+	}
+}
+
+// interruptOnClose interrupts the execution of the statement, if another goroutine closes the statement before
+// calling stop. Like interruptOnDone, stop waits for the interrupting goroutine, so that closing the statement
+// does not interrupt a later query of the connection.
+func (s *stmt) interruptOnClose() (stop func()) {
+	doneCh := make(chan struct{})
+	bgDoneCh := make(chan struct{})
+	go func() {
+		defer close(bgDoneCh)
+		select {
+		case <-s.closing:
+			C.duckdb_interrupt(s.c.duckdbCon)
+		case <-doneCh:
 		}
+	}()
 
-		err := C.GoString(C.duckdb_result_error(&res))
-		C.duckdb_destroy_result(&res)
-		return nil, errors.New(err)
+	return func() {
+		close(doneCh)
+		<-bgDoneCh
 	}
+}
 
-	return &res, nil
+// retryConflicts calls execute, and retries it after a backoff on a transaction conflict, see WithConflictRetry.
+// Only single statements outside of a transaction are retried, as DuckDB rolls back their
+// auto-commit transaction as a whole.
+func (s *stmt) retryConflicts(ctx context.Context, args []driver.NamedValue, execute func() error) error {
+	config := s.c.config
+	retries := 0
+	if config != nil && !s.multiStmt && !s.c.tx && !hasReaderArg(args) {
+		retries = config.conflictRetries
+	}
+
+	backoff := config.conflictBackoffDuration()
+	for attempt := 0; ; attempt++ {
+		err := execute()
+		if err == nil || attempt == retries || !isTransactionConflict(err) {
+			return err
+		}
+
+		timer := time.NewTimer(backoff << attempt)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// hasReaderArg returns true, if an argument is an io.Reader, see bindBlobReader. A retry would bind the bytes
+// left in the reader, i.e., none, instead of its value, so such statements do not retry.
+func hasReaderArg(args []driver.NamedValue) bool {
+	for _, arg := range args {
+		if _, ok := arg.Value.(io.Reader); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// isTransactionConflict returns true, if the error is a conflict of concurrent transactions, e.g.,
+// updating the same row.
+func isTransactionConflict(err error) bool {
+	var duckdbErr *Error
+	if !errors.As(err, &duckdbErr) {
+		return false
+	}
+	return strings.HasPrefix(duckdbErr.Msg, "TransactionContext Error") &&
+		strings.Contains(strings.ToLower(duckdbErr.Msg), "conflict")
 }
 
 func argsToNamedArgs(values []driver.Value) []driver.NamedValue {
@@ -249,4 +682,34 @@ func argsToNamedArgs(values []driver.Value) []driver.NamedValue {
 	return args
 }
 
+func anyArgsToNamedArgs(args []any) []driver.NamedValue {
+	if len(args) == 0 {
+		return nil
+	}
+
+	values := make([]driver.Value, len(args))
+	for i, arg := range args {
+		values[i] = arg
+	}
+
+	return argsToNamedArgs(values)
+}
+
 var errCouldNotBind = errors.New("could not bind parameter")
+
+// ParamTypeError is the error of binding an argument, whose Go type does not map to a DuckDB type.
+// It matches the error of binding a parameter with errors.Is.
+type ParamTypeError struct {
+	// Index is the one-based index of the parameter.
+	Index int
+	// Type is the Go type of the argument.
+	Type reflect.Type
+}
+
+func (e *ParamTypeError) Error() string {
+	return fmt.Sprintf("%s: parameter %d: unsupported Go type %s", errCouldNotBind.Error(), e.Index, e.Type)
+}
+
+func (e *ParamTypeError) Unwrap() error {
+	return errCouldNotBind
+}
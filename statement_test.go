@@ -1,8 +1,19 @@
 package duckdb
 
 import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -51,3 +62,497 @@ func TestPrepareWithError(t *testing.T) {
 		defer stmt.Close()
 	}
 }
+
+func TestReturning(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE SEQUENCE returning_seq START 100;
+		CREATE TABLE returning_tbl (id INTEGER DEFAULT nextval('returning_seq'), v VARCHAR)`)
+	require.NoError(t, err)
+
+	t.Run("query", func(t *testing.T) {
+		rows, err := db.Query(`INSERT INTO returning_tbl (v) VALUES ('a'), ('b') RETURNING id, v`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var ids []int
+		var values []string
+		for rows.Next() {
+			var id int
+			var v string
+			require.NoError(t, rows.Scan(&id, &v))
+			ids = append(ids, id)
+			values = append(values, v)
+		}
+		require.NoError(t, rows.Err())
+		require.Equal(t, []int{100, 101}, ids)
+		require.Equal(t, []string{"a", "b"}, values)
+	})
+
+	// The statements depend on each other, so they run in order.
+	for _, tc := range []struct {
+		name  string
+		query string
+	}{
+		{"insert", `INSERT INTO returning_tbl (v) VALUES ('c'), ('d'), ('e') RETURNING id`},
+		{"update", `UPDATE returning_tbl SET v = 'x' WHERE id >= 102 RETURNING id`},
+		{"delete", `DELETE FROM returning_tbl WHERE id >= 102 RETURNING id`},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := db.Exec(tc.query)
+			require.NoError(t, err)
+			affected, err := res.RowsAffected()
+			require.NoError(t, err)
+			require.Equal(t, int64(3), affected)
+		})
+	}
+}
+
+func TestBindNullTypes(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		sqlType string
+		valid   any
+		invalid any
+		value   any
+	}{
+		{"VARCHAR", sql.NullString{String: "duck", Valid: true}, sql.NullString{}, "duck"},
+		{"BIGINT", sql.NullInt64{Int64: 42, Valid: true}, sql.NullInt64{}, int64(42)},
+		{"INTEGER", sql.NullInt32{Int32: 42, Valid: true}, sql.NullInt32{}, int32(42)},
+		{"SMALLINT", sql.NullInt16{Int16: 42, Valid: true}, sql.NullInt16{}, int16(42)},
+		{"UTINYINT", sql.NullByte{Byte: 42, Valid: true}, sql.NullByte{}, uint8(42)},
+		{"DOUBLE", sql.NullFloat64{Float64: 1.5, Valid: true}, sql.NullFloat64{}, 1.5},
+		{"BOOLEAN", sql.NullBool{Bool: true, Valid: true}, sql.NullBool{}, true},
+		{"TIMESTAMP", sql.NullTime{Time: ts, Valid: true}, sql.NullTime{}, ts},
+		{"VARCHAR", &sql.NullString{String: "ptr", Valid: true}, (*sql.NullString)(nil), "ptr"},
+	}
+
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	con, err := c.Connect(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%T", test.valid), func(t *testing.T) {
+			query := fmt.Sprintf("SELECT ?::%s", test.sqlType)
+
+			// Through database/sql.
+			var res any
+			require.NoError(t, db.QueryRow(query, test.valid).Scan(&res))
+			require.Equal(t, test.value, res)
+			require.NoError(t, db.QueryRow(query, test.invalid).Scan(&res))
+			require.Nil(t, res)
+
+			// Directly through the driver connection.
+			for _, arg := range []any{test.valid, test.invalid} {
+				driverRows, err := con.(driver.QueryerContext).QueryContext(context.Background(), query,
+					[]driver.NamedValue{{Ordinal: 1, Value: arg}})
+				require.NoError(t, err)
+
+				values := make([]driver.Value, 1)
+				require.NoError(t, driverRows.Next(values))
+				require.NoError(t, driverRows.Close())
+				if arg == test.valid {
+					require.Equal(t, test.value, values[0])
+				} else {
+					require.Nil(t, values[0])
+				}
+			}
+		})
+	}
+}
+
+func TestBindPrimitiveTypes(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	// An untyped parameter has the DuckDB type of its argument.
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 123456000, time.UTC)
+	tests := []struct {
+		arg      any
+		typeName string
+		value    any
+	}{
+		{true, "BOOLEAN", true},
+		{int8(-8), "TINYINT", int8(-8)},
+		{int16(-16), "SMALLINT", int16(-16)},
+		{int32(-32), "INTEGER", int32(-32)},
+		{int64(-64), "BIGINT", int64(-64)},
+		{-1, "BIGINT", int64(-1)},
+		{uint8(8), "UTINYINT", uint8(8)},
+		{uint16(16), "USMALLINT", uint16(16)},
+		{uint32(32), "UINTEGER", uint32(32)},
+		{uint64(math.MaxUint64), "UBIGINT", uint64(math.MaxUint64)},
+		{uint(1), "UBIGINT", uint64(1)},
+		{float32(0.1), "FLOAT", float32(0.1)},
+		{0.1, "DOUBLE", 0.1},
+		{"duck", "VARCHAR", "duck"},
+		{[]byte{0, 1, 2}, "BLOB", []byte{0, 1, 2}},
+		{ts, "TIMESTAMP", ts},
+	}
+
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%T", test.arg), func(t *testing.T) {
+			var typeName string
+			var value any
+			require.NoError(t, db.QueryRow(`SELECT typeof(?), ?`, test.arg, test.arg).Scan(&typeName, &value))
+			require.Equal(t, test.typeName, typeName)
+			require.Equal(t, test.value, value)
+		})
+	}
+
+	t.Run("unsupported type", func(t *testing.T) {
+		c, err := NewConnector("", nil)
+		require.NoError(t, err)
+		defer c.Close()
+		con, err := c.Connect(context.Background())
+		require.NoError(t, err)
+		defer con.Close()
+
+		_, err = con.(driver.QueryerContext).QueryContext(context.Background(), `SELECT ?, ?`,
+			[]driver.NamedValue{{Ordinal: 1, Value: 1}, {Ordinal: 2, Value: struct{}{}}})
+		var paramErr *ParamTypeError
+		require.ErrorAs(t, err, &paramErr)
+		require.Equal(t, 2, paramErr.Index)
+		require.Equal(t, reflect.TypeOf(struct{}{}), paramErr.Type)
+		require.ErrorIs(t, err, errCouldNotBind)
+	})
+}
+
+func TestBindUint64(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	type id uint64
+	_, err := db.Exec(`CREATE TABLE ids (id UBIGINT PRIMARY KEY, name VARCHAR)`)
+	require.NoError(t, err)
+
+	// Keys above math.MaxInt64 round-trip, including those of named types and typed parameters.
+	keys := []any{uint64(math.MaxUint64), id(math.MaxInt64 + 1), TypedParam{Value: uint64(math.MaxUint64 - 1), Type: "UBIGINT"}}
+	for i, key := range keys {
+		_, err = db.Exec(`INSERT INTO ids VALUES (?, ?)`, key, fmt.Sprint(i))
+		require.NoError(t, err)
+	}
+
+	var name string
+	require.NoError(t, db.QueryRow(`SELECT name FROM ids WHERE id = ?`, id(math.MaxUint64)).Scan(&name))
+	require.Equal(t, "0", name)
+
+	var key uint64
+	require.NoError(t, db.QueryRow(`SELECT id FROM ids WHERE name = ?`, "1").Scan(&key))
+	require.Equal(t, uint64(math.MaxInt64+1), key)
+	require.NoError(t, db.QueryRow(`SELECT id FROM ids WHERE name = ?`, "2").Scan(&key))
+	require.Equal(t, uint64(math.MaxUint64-1), key)
+}
+
+// testDecimal is a decimal of another package, like github.com/shopspring/decimal.Decimal.
+type testDecimal struct {
+	coefficient int64
+	exponent    int32
+}
+
+func (d testDecimal) Coefficient() *big.Int { return big.NewInt(d.coefficient) }
+func (d testDecimal) Exponent() int32       { return d.exponent }
+
+func (d testDecimal) Value() (driver.Value, error) {
+	return nil, errors.New("the DecimalValuer binds instead of the driver.Valuer")
+}
+
+func TestBindDecimal(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	bigNumber, ok := new(big.Int).SetString("12345678901234567890123456789", 10)
+	require.True(t, ok)
+
+	tests := []struct {
+		arg      any
+		typeName string
+		value    any
+	}{
+		{bigNumber, "HUGEINT", bigNumber},
+		{Decimal{Width: 5, Scale: 2, Value: big.NewInt(-12345)}, "DECIMAL(5,2)",
+			Decimal{Width: 5, Scale: 2, Value: big.NewInt(-12345)}},
+		{Decimal{Scale: 3, Value: big.NewInt(42)}, "DECIMAL(3,3)", Decimal{Width: 3, Scale: 3, Value: big.NewInt(42)}},
+		{Decimal{Width: 38, Scale: 10, Value: bigNumber}, "DECIMAL(38,10)",
+			Decimal{Width: 38, Scale: 10, Value: bigNumber}},
+		{testDecimal{coefficient: 314, exponent: -2}, "DECIMAL(3,2)", Decimal{Width: 3, Scale: 2, Value: big.NewInt(314)}},
+		{testDecimal{coefficient: 7, exponent: 2}, "DECIMAL(3,0)", Decimal{Width: 3, Value: big.NewInt(700)}},
+	}
+	for _, test := range tests {
+		t.Run(test.typeName, func(t *testing.T) {
+			var typeName string
+			var value any
+			require.NoError(t, db.QueryRow(`SELECT typeof(?), ?`, test.arg, test.arg).Scan(&typeName, &value))
+			require.Equal(t, test.typeName, typeName)
+			require.Equal(t, test.value, value)
+		})
+	}
+
+	// The value casts to the type of a typed parameter.
+	var d Decimal
+	require.NoError(t, db.QueryRow(`SELECT ?::DECIMAL(10, 4)`, testDecimal{coefficient: 15, exponent: -1}).Scan(&d))
+	require.Equal(t, "1.5000", d.String())
+
+	_, err := db.Exec(`SELECT ?`, Decimal{Width: 2, Value: big.NewInt(123)})
+	require.ErrorIs(t, err, errCouldNotBind)
+	_, err = db.Exec(`SELECT ?`, Decimal{Scale: 39, Value: big.NewInt(1)})
+	require.ErrorIs(t, err, errCouldNotBind)
+}
+
+func TestNamedParameters(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	// A single named argument binds all occurrences of its parameter, regardless of the argument order.
+	var sum int
+	var other string
+	require.NoError(t, db.QueryRow(`SELECT $id + $id * 10, $other || $id`, sql.Named("other", "x"), sql.Named("id", 2)).
+		Scan(&sum, &other))
+	require.Equal(t, 22, sum)
+	require.Equal(t, "x2", other)
+
+	_, err := db.Exec(`CREATE TABLE tbl (id INTEGER, parent INTEGER)`)
+	require.NoError(t, err)
+	_, err = db.Exec(`INSERT INTO tbl VALUES ($id, $id), ($id + 1, $id)`, sql.Named("id", 7))
+	require.NoError(t, err)
+
+	var count int
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM tbl WHERE parent = $id`, sql.Named("id", 7)).Scan(&count))
+	require.Equal(t, 2, count)
+
+	err = db.QueryRow(`SELECT $id`, sql.Named("missing", 1)).Scan(&count)
+	require.ErrorContains(t, err, "unknown named parameter: missing")
+}
+
+func TestConflictRetry(t *testing.T) {
+	t.Parallel()
+
+	openConflictDB := func(t *testing.T, opts ...ConnectorOption) *sql.DB {
+		c, err := NewConnector("", nil, opts...)
+		require.NoError(t, err)
+		db := sql.OpenDB(c)
+		_, err = db.Exec(`CREATE TABLE tbl (id INTEGER, v INTEGER); INSERT INTO tbl VALUES (1, 0), (2, 0)`)
+		require.NoError(t, err)
+		return db
+	}
+
+	// lock updates the row in an open transaction, so concurrent updates of the row conflict until commit.
+	lock := func(t *testing.T, db *sql.DB) *sql.Tx {
+		tx, err := db.Begin()
+		require.NoError(t, err)
+		_, err = tx.Exec(`UPDATE tbl SET v = v + 1 WHERE id = 1`)
+		require.NoError(t, err)
+		return tx
+	}
+
+	value := func(t *testing.T, db *sql.DB, id int) int {
+		var v int
+		require.NoError(t, db.QueryRow(`SELECT v FROM tbl WHERE id = ?`, id).Scan(&v))
+		return v
+	}
+
+	t.Run("retry until commit", func(t *testing.T) {
+		db := openConflictDB(t, WithConflictRetry(10, 5*time.Millisecond))
+		defer db.Close()
+
+		tx := lock(t, db)
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			assert.NoError(t, tx.Commit())
+		}()
+
+		_, err := db.Exec(`UPDATE tbl SET v = v + 10 WHERE id = 1`)
+		require.NoError(t, err)
+		require.Equal(t, 11, value(t, db, 1))
+
+		// Queries retry as well.
+		tx = lock(t, db)
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			assert.NoError(t, tx.Commit())
+		}()
+
+		var v int
+		require.NoError(t, db.QueryRow(`UPDATE tbl SET v = v + 10 WHERE id = 1 RETURNING v`).Scan(&v))
+		require.Equal(t, 22, v)
+	})
+
+	t.Run("no retries", func(t *testing.T) {
+		db := openConflictDB(t)
+		defer db.Close()
+
+		tx := lock(t, db)
+		defer tx.Rollback()
+
+		_, err := db.Exec(`UPDATE tbl SET v = v + 10 WHERE id = 1`)
+		require.ErrorContains(t, err, "Conflict on update")
+		require.True(t, isTransactionConflict(err))
+	})
+
+	t.Run("retries exhausted", func(t *testing.T) {
+		db := openConflictDB(t, WithConflictRetry(2, time.Millisecond))
+		defer db.Close()
+
+		tx := lock(t, db)
+		defer tx.Rollback()
+
+		_, err := db.Exec(`UPDATE tbl SET v = v + 10 WHERE id = 1`)
+		require.ErrorContains(t, err, "Conflict on update")
+	})
+
+	t.Run("cancel during backoff", func(t *testing.T) {
+		db := openConflictDB(t, WithConflictRetry(10, time.Hour))
+		defer db.Close()
+
+		tx := lock(t, db)
+		defer tx.Rollback()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+		defer cancel()
+		_, err := db.ExecContext(ctx, `UPDATE tbl SET v = v + 10 WHERE id = 1`)
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("no retry of reader arguments", func(t *testing.T) {
+		db := openConflictDB(t, WithConflictRetry(10, 5*time.Millisecond))
+		defer db.Close()
+
+		tx := lock(t, db)
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			assert.NoError(t, tx.Commit())
+		}()
+
+		// A retry would bind the empty rest of the reader.
+		_, err := db.Exec(`UPDATE tbl SET v = octet_length(?) WHERE id = 1`, strings.NewReader("abc"))
+		require.ErrorContains(t, err, "Conflict on update")
+	})
+
+	t.Run("no retry of multiple statements", func(t *testing.T) {
+		db := openConflictDB(t, WithConflictRetry(10, 5*time.Millisecond))
+		defer db.Close()
+
+		tx := lock(t, db)
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			assert.NoError(t, tx.Commit())
+		}()
+
+		_, err := db.Exec(`UPDATE tbl SET v = v + 100 WHERE id = 2; UPDATE tbl SET v = v + 10 WHERE id = 1`)
+		require.ErrorContains(t, err, "Conflict on update")
+		require.Equal(t, 100, value(t, db, 2))
+	})
+
+	t.Run("no retry within transactions", func(t *testing.T) {
+		db := openConflictDB(t, WithConflictRetry(10, 5*time.Millisecond))
+		defer db.Close()
+
+		tx := lock(t, db)
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			assert.NoError(t, tx.Commit())
+		}()
+
+		other, err := db.Begin()
+		require.NoError(t, err)
+		defer other.Rollback()
+		_, err = other.Exec(`UPDATE tbl SET v = v + 10 WHERE id = 1`)
+		require.ErrorContains(t, err, "Conflict on update")
+	})
+
+	t.Run("invalid option", func(t *testing.T) {
+		_, err := NewConnector("", nil, WithConflictRetry(-1, 0))
+		testError(t, err, errInvalidOption.Error(), "invalid number of conflict retries")
+	})
+}
+
+func TestCanceledContext(t *testing.T) {
+	t.Parallel()
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	defer c.Close()
+	driverConn, err := c.Connect(context.Background())
+	require.NoError(t, err)
+	defer driverConn.Close()
+	con := driverConn.(*conn)
+
+	// database/sql checks the context before calling the driver, so call the driver directly.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	_, err = con.PrepareContext(ctx, `SELECT 1`)
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = con.ExecContext(ctx, `CREATE TABLE a (i INTEGER); CREATE TABLE b (i INTEGER)`, nil)
+	require.ErrorIs(t, err, context.Canceled)
+	_, err = con.QueryContext(ctx, `CREATE TABLE a (i INTEGER); SELECT 1`, nil)
+	require.ErrorIs(t, err, context.Canceled)
+
+	// A prepared statement does not execute with a done context.
+	s, err := con.PrepareContext(context.Background(), `CREATE TABLE a (i INTEGER)`)
+	require.NoError(t, err)
+	_, err = s.(driver.StmtExecContext).ExecContext(ctx, nil)
+	require.ErrorIs(t, err, context.Canceled)
+	require.NoError(t, s.Close())
+
+	// None of the statements executed.
+	rows, err := con.QueryContext(context.Background(), `SELECT count(*) FROM duckdb_tables()`, nil)
+	require.NoError(t, err)
+	values := make([]driver.Value, 1)
+	require.NoError(t, rows.Next(values))
+	require.Equal(t, int64(0), values[0])
+	require.NoError(t, rows.Close())
+}
+
+func TestCloseInterruptsExecution(t *testing.T) {
+	t.Parallel()
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	defer c.Close()
+	driverConn, err := c.Connect(context.Background())
+	require.NoError(t, err)
+	defer driverConn.Close()
+	con := driverConn.(*conn)
+
+	s, err := con.PrepareContext(context.Background(), `SELECT count(*) FROM range(10000000) t1, range(1000000) t2`)
+	require.NoError(t, err)
+	tracker, err := TrackProgress(con)
+	require.NoError(t, err)
+
+	done := make(chan error)
+	go func() {
+		_, err := s.(driver.StmtExecContext).ExecContext(context.Background(), nil)
+		done <- err
+	}()
+	require.Eventually(t, func() bool { return tracker.Progress().Percentage >= 0 }, 10*time.Second, time.Millisecond)
+
+	// Executing the query would take much longer than 10 seconds.
+	now := time.Now()
+	require.NoError(t, s.Close())
+	err = <-done
+	var duckdbErr *Error
+	require.ErrorAs(t, err, &duckdbErr)
+	require.Equal(t, ErrorTypeInterrupt, duckdbErr.Type)
+	require.Less(t, time.Since(now), 10*time.Second)
+
+	// The connection is usable afterward.
+	rows, err := con.QueryContext(context.Background(), `SELECT 42`, nil)
+	require.NoError(t, err)
+	values := make([]driver.Value, 1)
+	require.NoError(t, rows.Next(values))
+	require.Equal(t, int32(42), values[0])
+	require.NoError(t, rows.Close())
+}
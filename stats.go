@@ -0,0 +1,129 @@
+package duckdb
+
+/*
+#include <stdlib.h>
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"context"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"unsafe"
+)
+
+// ConnectorStats are the statistics of a Connector, e.g., to export them as metrics.
+// The counters are cumulative since opening the Connector.
+type ConnectorStats struct {
+	// MemoryUsageBytes is the memory usage of the buffer manager of the database.
+	// The database may be shared with other Connectors, see NewConnector.
+	MemoryUsageBytes int64
+	// PeakMemoryUsageBytes is the peak of the memory usage of the database sampled by Stats, and while executing
+	// the queries of a context of ContextWithMemoryUsage.
+	PeakMemoryUsageBytes int64
+	// MemoryLimitBytes is the memory_limit of the database, rounded to one decimal of its unit, e.g., 9.5 MiB.
+	// It is zero, if the memory is unlimited.
+	MemoryLimitBytes int64
+	// OpenConnections is the number of open connections of the Connector, including idle connections of the sql.DB pool.
+	OpenConnections int64
+	// Queries is the number of statements executed on the connections. A multi-statement query counts each
+	// of its statements, and a statement retried after a transaction conflict counts once, see WithConflictRetry.
+	Queries uint64
+	// AppendedRows is the number of rows appended by the Appenders of the connections. It includes the rows
+	// of a transaction of an Appender, which was rolled back, see WithTransaction.
+	AppendedRows uint64
+	// FetchedChunks is the number of chunks of the results of queries read by the driver.
+	FetchedChunks uint64
+	// CgoCalls is the number of cgo calls of the process, see runtime.NumCgoCall.
+	// The driver calls DuckDB through cgo, so it includes the calls of the driver.
+	CgoCalls int64
+}
+
+// connectorStats collects the counters of ConnectorStats. The connections of the Connector update them concurrently.
+type connectorStats struct {
+	openConnections atomic.Int64
+	queries         atomic.Uint64
+	appendedRows    atomic.Uint64
+	fetchedChunks   atomic.Uint64
+	// peakMemoryUsage is the peak of the sampled memory usage, see sampleMemory.
+	peakMemoryUsage atomic.Int64
+}
+
+// Stats returns the statistics of the Connector. It queries the memory usage on a separate connection,
+// so it does not wait for the connections of the Connector.
+func (c *Connector) Stats(ctx context.Context) (ConnectorStats, error) {
+	if c.db == nil {
+		return ConnectorStats{}, getError(errConnectorStats, errClosedCon)
+	}
+
+	usage, limit, err := c.sampleMemory(ctx)
+	if err != nil {
+		return ConnectorStats{}, getError(errConnectorStats, err)
+	}
+	return ConnectorStats{
+		MemoryUsageBytes:     usage,
+		PeakMemoryUsageBytes: c.stats.peakMemoryUsage.Load(),
+		MemoryLimitBytes:     limit,
+		OpenConnections:      c.stats.openConnections.Load(),
+		Queries:              c.stats.queries.Load(),
+		AppendedRows:         c.stats.appendedRows.Load(),
+		FetchedChunks:        c.stats.fetchedChunks.Load(),
+		CgoCalls:             runtime.NumCgoCall(),
+	}, nil
+}
+
+// sampleMemory returns the exact sum of the memory usage of all memory tags of the database, and its memory limit,
+// and updates the peak memory usage of the statistics.
+func (c *Connector) sampleMemory(ctx context.Context) (usage int64, limit int64, err error) {
+	if c.db == nil || c.closed.Load() {
+		return 0, 0, errClosedCon
+	}
+
+	var duckdbCon C.duckdb_connection
+	if state := C.duckdb_connect(c.db, &duckdbCon); state == C.DuckDBError {
+		return 0, 0, getError(errConnect, nil)
+	}
+	con := &conn{duckdbCon: duckdbCon, config: c.config}
+	defer con.Close()
+
+	query := C.CString(`SELECT coalesce((SELECT sum(memory_usage_bytes) FROM duckdb_memory()), 0)::BIGINT,
+		(SELECT memory_limit FROM pragma_database_size() LIMIT 1)`)
+	defer C.free(unsafe.Pointer(query))
+
+	stop := con.interruptOnDone(ctx)
+	defer stop()
+
+	var res C.duckdb_result
+	state := C.duckdb_query(con.duckdbCon, query, &res)
+	defer C.duckdb_destroy_result(&res)
+	if state == C.DuckDBError {
+		if ctx.Err() != nil {
+			return 0, 0, ctx.Err()
+		}
+		return 0, 0, newError(C.GoString(C.duckdb_result_error(&res)))
+	}
+
+	usageStr := C.duckdb_value_varchar(&res, 0, 0)
+	defer C.duckdb_free(unsafe.Pointer(usageStr))
+	if usage, err = strconv.ParseInt(C.GoString(usageStr), 10, 64); err != nil {
+		return 0, 0, err
+	}
+	limitStr := C.duckdb_value_varchar(&res, 1, 0)
+	defer C.duckdb_free(unsafe.Pointer(limitStr))
+	if s := C.GoString(limitStr); s != "" && s != "Unlimited" {
+		limitBytes, err := parseByteSize(s)
+		if err != nil {
+			return 0, 0, err
+		}
+		limit = int64(limitBytes)
+	}
+
+	for peak := c.stats.peakMemoryUsage.Load(); usage > peak; peak = c.stats.peakMemoryUsage.Load() {
+		if c.stats.peakMemoryUsage.CompareAndSwap(peak, usage) {
+			break
+		}
+	}
+	return usage, limit, nil
+}
@@ -0,0 +1,65 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestConnectorStats(t *testing.T) {
+	t.Parallel()
+
+	connector, err := NewConnector("", nil)
+	require.NoError(t, err)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	ctx := context.Background()
+	initial, err := connector.Stats(ctx)
+	require.NoError(t, err)
+	require.Zero(t, initial.OpenConnections)
+	require.Zero(t, initial.Queries)
+
+	con, err := db.Conn(ctx)
+	require.NoError(t, err)
+	_, err = con.ExecContext(ctx, `CREATE TABLE stats_tbl (i INTEGER); INSERT INTO stats_tbl SELECT * FROM range(5000)`)
+	require.NoError(t, err)
+
+	require.NoError(t, con.Raw(func(driverConn any) error {
+		a, err := NewAppenderFromConn(driverConn.(driver.Conn), "", "stats_tbl")
+		require.NoError(t, err)
+		for i := 0; i < 10; i++ {
+			require.NoError(t, a.AppendRow(int32(i)))
+		}
+		return a.Close()
+	}))
+
+	var count int
+	rows, err := con.QueryContext(ctx, `SELECT * FROM stats_tbl`)
+	require.NoError(t, err)
+	for rows.Next() {
+		count++
+	}
+	require.NoError(t, rows.Err())
+	require.NoError(t, rows.Close())
+	require.Equal(t, 5010, count)
+
+	stats, err := connector.Stats(ctx)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), stats.OpenConnections)
+	require.Equal(t, initial.Queries+3, stats.Queries)
+	require.Equal(t, uint64(10), stats.AppendedRows)
+	require.Equal(t, uint64(3), stats.FetchedChunks)
+	require.Positive(t, stats.MemoryUsageBytes)
+	require.GreaterOrEqual(t, stats.PeakMemoryUsageBytes, stats.MemoryUsageBytes)
+	require.Positive(t, stats.MemoryLimitBytes)
+	require.Greater(t, stats.CgoCalls, initial.CgoCalls)
+
+	require.NoError(t, con.Close())
+	require.NoError(t, db.Close())
+	_, err = connector.Stats(ctx)
+	require.ErrorContains(t, err, errConnectorStats.Error())
+}
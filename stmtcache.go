@@ -0,0 +1,78 @@
+package duckdb
+
+import "container/list"
+
+// stmtCache is an LRU cache of the prepared statements of a connection, keyed by their query, see WithStatementCache.
+// Like the connection, it is not safe for concurrent use.
+type stmtCache struct {
+	size int
+	// order contains the cached statements, starting with the most recently used one.
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+func newStmtCache(size int) *stmtCache {
+	if size <= 0 {
+		return nil
+	}
+	return &stmtCache{size: size, order: list.New(), entries: make(map[string]*list.Element)}
+}
+
+// get returns the cached statement of the query, or nil, if it is not cached, or if its rows are still open.
+func (c *stmtCache) get(query string) *stmt {
+	if c == nil {
+		return nil
+	}
+	e, ok := c.entries[query]
+	if !ok {
+		return nil
+	}
+	s := e.Value.(*stmt)
+	if s.rows {
+		return nil
+	}
+	c.order.MoveToFront(e)
+	return s
+}
+
+// put caches the statement of a single-statement query, and evicts the least recently used statement,
+// if the cache is full. The cache owns the statement, so its users must not close it.
+func (c *stmtCache) put(query string, s *stmt) {
+	if c == nil {
+		return
+	}
+	if _, ok := c.entries[query]; ok {
+		// The cached statement is in use by open rows, so the new statement is not cached.
+		return
+	}
+	s.cached = true
+	c.entries[query] = c.order.PushFront(s)
+	if c.order.Len() > c.size {
+		evicted := c.order.Back()
+		c.order.Remove(evicted)
+		delete(c.entries, evicted.Value.(*stmt).query)
+		c.release(evicted.Value.(*stmt))
+	}
+}
+
+// close closes all cached statements.
+func (c *stmtCache) close() {
+	if c == nil {
+		return
+	}
+	for e := c.order.Front(); e != nil; e = e.Next() {
+		c.release(e.Value.(*stmt))
+	}
+	c.order.Init()
+	clear(c.entries)
+}
+
+// release closes a statement removed from the cache. A statement with open rows closes with its rows.
+func (*stmtCache) release(s *stmt) {
+	s.cached = false
+	if s.rows {
+		s.closeOnRowsClose = true
+		return
+	}
+	s.Close()
+}
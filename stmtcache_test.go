@@ -0,0 +1,114 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestStatementCache(t *testing.T) {
+	t.Parallel()
+
+	connector, err := NewConnector("", nil, WithStatementCache(2))
+	require.NoError(t, err)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	ctx := context.Background()
+	con, err := db.Conn(ctx)
+	require.NoError(t, err)
+	defer con.Close()
+
+	// cached returns the queries of the cached statements, starting with the most recently used one.
+	cached := func(t *testing.T) []string {
+		var queries []string
+		require.NoError(t, con.Raw(func(driverConn any) error {
+			for e := driverConn.(*conn).stmts.order.Front(); e != nil; e = e.Next() {
+				queries = append(queries, e.Value.(*stmt).query)
+			}
+			return nil
+		}))
+		return queries
+	}
+
+	_, err = con.ExecContext(ctx, `CREATE TABLE cache_tbl (i INTEGER); INSERT INTO cache_tbl VALUES (1)`)
+	require.NoError(t, err)
+	require.Empty(t, cached(t))
+
+	const insert = `INSERT INTO cache_tbl VALUES (?)`
+	const selectSum = `SELECT sum(i) FROM cache_tbl WHERE i > ?`
+	for i := 2; i <= 4; i++ {
+		_, err = con.ExecContext(ctx, insert, i)
+		require.NoError(t, err)
+		var sum int
+		require.NoError(t, con.QueryRowContext(ctx, selectSum, 0).Scan(&sum))
+		require.Equal(t, i*(i+1)/2, sum)
+	}
+	require.Equal(t, []string{selectSum, insert}, cached(t))
+
+	t.Run("eviction", func(t *testing.T) {
+		var count int
+		require.NoError(t, con.QueryRowContext(ctx, `SELECT count(*) FROM cache_tbl`).Scan(&count))
+		require.Equal(t, 4, count)
+		require.Equal(t, []string{`SELECT count(*) FROM cache_tbl`, selectSum}, cached(t))
+	})
+
+	t.Run("open rows", func(t *testing.T) {
+		rows, err := con.QueryContext(ctx, selectSum, 0)
+		require.NoError(t, err)
+
+		// The cached statement is in use, so the query is prepared again.
+		var sum int
+		require.NoError(t, con.QueryRowContext(ctx, selectSum, 1).Scan(&sum))
+		require.Equal(t, 9, sum)
+
+		// Evicting the statement closes it with its rows.
+		_, err = con.ExecContext(ctx, `SELECT 1`)
+		require.NoError(t, err)
+		_, err = con.ExecContext(ctx, `SELECT 2`)
+		require.NoError(t, err)
+		require.NotContains(t, cached(t), selectSum)
+
+		require.True(t, rows.Next())
+		require.NoError(t, rows.Scan(&sum))
+		require.Equal(t, 10, sum)
+		require.NoError(t, rows.Close())
+	})
+
+	t.Run("schema change", func(t *testing.T) {
+		const selectAll = `SELECT * FROM cache_tbl LIMIT 1`
+		var i int
+		require.NoError(t, con.QueryRowContext(ctx, selectAll).Scan(&i))
+
+		_, err = con.ExecContext(ctx, `DROP TABLE cache_tbl; CREATE TABLE cache_tbl (s VARCHAR); INSERT INTO cache_tbl VALUES ('a')`)
+		require.NoError(t, err)
+		var s string
+		require.NoError(t, con.QueryRowContext(ctx, selectAll).Scan(&s))
+		require.Equal(t, "a", s)
+	})
+
+	t.Run("arrow", func(t *testing.T) {
+		const selectCount = `SELECT count(*) FROM cache_tbl WHERE s <> ?`
+		var stmts []*stmt
+		for i := 0; i < 2; i++ {
+			require.NoError(t, con.Raw(func(driverConn any) error {
+				rows, err := WriteArrowIPC(ctx, driverConn.(driver.Conn), selectCount, io.Discard, "b")
+				require.Equal(t, int64(1), rows)
+				stmts = append(stmts, driverConn.(*conn).stmts.get(selectCount))
+				return err
+			}))
+		}
+		require.Equal(t, selectCount, cached(t)[0])
+		require.Same(t, stmts[0], stmts[1])
+	})
+
+	t.Run("invalid size", func(t *testing.T) {
+		_, err := NewConnector("", nil, WithStatementCache(-1))
+		require.ErrorContains(t, err, "invalid statement cache size")
+	})
+}
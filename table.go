@@ -0,0 +1,278 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// registeredTableFunction is the name of the table function scanning the rows of RegisterTable.
+const registeredTableFunction = "go_registered_table"
+
+var (
+	// registerTableMu serializes registering the table function of RegisterTable on a database,
+	// as DuckDB aborts on registering a function twice.
+	registerTableMu sync.Mutex
+	// registeredTables contains the *registeredTable of each RegisterTable call by its id.
+	registeredTables    sync.Map
+	nextRegisteredTable atomic.Int64
+)
+
+// registeredTable contains the rows of a RegisterTable call.
+type registeredTable struct {
+	columns []TableColumn
+	// fields are the index sequences of the fields of the columns.
+	fields [][]int
+	rows   reflect.Value
+}
+
+// RegisterTable registers the rows, a slice of structs or of pointers to structs, as a temporary view
+// with the name on the connection, so that its queries can scan and join them with DuckDB tables
+// without inserting them into a table first, e.g., SELECT * FROM name JOIN tbl USING (id).
+// The fields of the structs are the columns of the view. They match their names like the fields of a StructScanner,
+// i.e., a field with a `db` tag is the column named by the tag, and a field with the tag `db:"-"` is skipped.
+//
+// A column has the DuckDB type of its Go type, e.g., BIGINT for an int, VARCHAR for a string, BLOB for a []byte,
+// TIMESTAMP for a time.Time, DATE for a Date, UUID for a UUID, and a LIST or an ARRAY for other slices and arrays.
+// Pointer fields are NULL, if they are nil, and so are all columns of a nil row. Other types, e.g., nested structs
+// or maps, are not supported.
+//
+// Queries read the rows when they scan the view, so they see changes of the elements of the slice.
+// The returned release function drops the view, and must be called once the view is no longer needed.
+func RegisterTable[T any](driverConn driver.Conn, name string, rows []T) (release func() error, err error) {
+	con, ok := driverConn.(*conn)
+	if !ok {
+		return nil, getError(errInvalidCon, nil)
+	}
+	if con.closed {
+		return nil, getError(errClosedCon, nil)
+	}
+	if name == "" {
+		return nil, getError(errRegisterTable, errors.New("empty table name"))
+	}
+
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, getError(errRegisterTable, castError(t.String(), "struct or pointer to struct"))
+	}
+
+	table := &registeredTable{rows: reflect.ValueOf(rows)}
+	for _, field := range structFields(t) {
+		typeName, err := goColumnType(t.FieldByIndex(field.index).Type)
+		if err != nil {
+			return nil, getError(errRegisterTable, fmt.Errorf("field %s: %w", field.name, err))
+		}
+		table.columns = append(table.columns, TableColumn{Name: field.name, Type: typeName})
+		table.fields = append(table.fields, field.index)
+	}
+	if len(table.columns) == 0 {
+		return nil, getError(errRegisterTable, fmt.Errorf("%s has no fields", t))
+	}
+
+	if err = registerGoTableFunction(con); err != nil {
+		return nil, getError(errRegisterTable, err)
+	}
+
+	id := nextRegisteredTable.Add(1)
+	registeredTables.Store(id, table)
+	view := "temp.main." + quoteIdentifier(name)
+	_, err = con.ExecContext(context.Background(), fmt.Sprintf(`CREATE OR REPLACE TEMP VIEW %s AS SELECT * FROM %s(%d)`,
+		quoteIdentifier(name), registeredTableFunction, id), nil)
+	if err != nil {
+		registeredTables.Delete(id)
+		return nil, getError(errRegisterTable, err)
+	}
+
+	return func() error {
+		defer registeredTables.Delete(id)
+		_, err := con.ExecContext(context.Background(), `DROP VIEW IF EXISTS `+view, nil)
+		return err
+	}, nil
+}
+
+// registerGoTableFunction registers the table function of RegisterTable on the database of the connection,
+// unless it is registered already.
+func registerGoTableFunction(con *conn) error {
+	registerTableMu.Lock()
+	defer registerTableMu.Unlock()
+
+	exists, err := functionExists(con, registeredTableFunction)
+	if err != nil || exists {
+		return err
+	}
+	return RegisterTableFunction(con, registeredTableFunction, TableFunction{
+		Parameters:         []string{"BIGINT"},
+		Bind:               bindRegisteredTable,
+		ProjectionPushdown: true,
+	})
+}
+
+func bindRegisteredTable(args TableFunctionArgs) (*TableBinding, error) {
+	value, ok := registeredTables.Load(args.Positional[0])
+	if !ok {
+		return nil, errors.New("the table was released")
+	}
+	table := value.(*registeredTable)
+
+	var next int
+	return &TableBinding{
+		Columns:          table.columns,
+		Cardinality:      table.rows.Len(),
+		ExactCardinality: true,
+		Init: func([]int) error {
+			next = 0
+			return nil
+		},
+		Fill: func(chunk *TableChunk) (int, error) {
+			count := 0
+			for ; count < chunk.Capacity() && next < table.rows.Len(); count, next = count+1, next+1 {
+				row := table.rows.Index(next)
+				for row.Kind() == reflect.Pointer && !row.IsNil() {
+					row = row.Elem()
+				}
+				for col, index := range table.fields {
+					if !chunk.Projected(col) {
+						continue
+					}
+					var value any
+					if row.Kind() == reflect.Struct {
+						if field, err := row.FieldByIndexErr(index); err == nil {
+							value = goColumnValue(field)
+						}
+					}
+					if err := chunk.SetValue(col, count, value); err != nil {
+						return 0, err
+					}
+				}
+			}
+			return count, nil
+		},
+	}, nil
+}
+
+var (
+	timeType = reflect.TypeOf(time.Time{})
+	dateType = reflect.TypeOf(Date{})
+	uuidType = reflect.TypeOf(UUID{})
+)
+
+// goColumnType returns the name of the DuckDB type of the values of the Go type, see RegisterTable.
+func goColumnType(t reflect.Type) (string, error) {
+	switch t {
+	case timeType:
+		return "TIMESTAMP", nil
+	case dateType:
+		return "DATE", nil
+	case uuidType:
+		return "UUID", nil
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return goColumnType(t.Elem())
+	case reflect.Bool:
+		return "BOOLEAN", nil
+	case reflect.Int8:
+		return "TINYINT", nil
+	case reflect.Int16:
+		return "SMALLINT", nil
+	case reflect.Int32:
+		return "INTEGER", nil
+	case reflect.Int, reflect.Int64:
+		return "BIGINT", nil
+	case reflect.Uint8:
+		return "UTINYINT", nil
+	case reflect.Uint16:
+		return "USMALLINT", nil
+	case reflect.Uint32:
+		return "UINTEGER", nil
+	case reflect.Uint, reflect.Uint64:
+		return "UBIGINT", nil
+	case reflect.Float32:
+		return "FLOAT", nil
+	case reflect.Float64:
+		return "DOUBLE", nil
+	case reflect.String:
+		return "VARCHAR", nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return "BLOB", nil
+		}
+		child, err := goColumnType(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return child + "[]", nil
+	case reflect.Array:
+		child, err := goColumnType(t.Elem())
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s[%d]", child, t.Len()), nil
+	}
+	return "", unsupportedTypeError(t.String())
+}
+
+// goColumnValue returns the value of a field of a row of RegisterTable, converted to the Go type
+// the Appender expects for its DuckDB type, see goColumnType.
+func goColumnValue(v reflect.Value) any {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+	switch v.Type() {
+	case timeType, dateType, uuidType:
+		return v.Interface()
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.Int8:
+		return int8(v.Int())
+	case reflect.Int16:
+		return int16(v.Int())
+	case reflect.Int32:
+		return int32(v.Int())
+	case reflect.Int, reflect.Int64:
+		return v.Int()
+	case reflect.Uint8:
+		return uint8(v.Uint())
+	case reflect.Uint16:
+		return uint16(v.Uint())
+	case reflect.Uint32:
+		return uint32(v.Uint())
+	case reflect.Uint, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32:
+		return float32(v.Float())
+	case reflect.Float64:
+		return v.Float()
+	case reflect.String:
+		return v.String()
+	case reflect.Slice:
+		if v.IsNil() {
+			return nil
+		}
+		if v.Type().Elem().Kind() == reflect.Uint8 {
+			return v.Bytes()
+		}
+	}
+
+	// Slices and arrays.
+	list := make([]any, v.Len())
+	for i := range list {
+		list[i] = goColumnValue(v.Index(i))
+	}
+	return list
+}
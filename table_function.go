@@ -0,0 +1,516 @@
+package duckdb
+
+/*
+#include <stdlib.h>
+#include <duckdb.h>
+
+void tableFunctionBind(duckdb_bind_info info);
+void tableFunctionInit(duckdb_init_info info);
+void tableFunctionExecute(duckdb_function_info info, duckdb_data_chunk output);
+void deleteHandle(void *data);
+*/
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"runtime/cgo"
+	"sort"
+	"strconv"
+	"strings"
+	"unsafe"
+)
+
+// TableFunction is a table function implemented in Go, see RegisterTableFunction.
+type TableFunction struct {
+	// Parameters are the types of the positional parameters, e.g., VARCHAR or INTEGER[].
+	Parameters []string
+	// NamedParameters are the types of the named parameters by their names, e.g., a call of
+	// fn(1, step = 2) passes step as a named parameter.
+	NamedParameters map[string]string
+	// Bind binds a call of the table function to its arguments. It returns the columns of the rows of the call,
+	// and the functions producing them.
+	Bind func(args TableFunctionArgs) (*TableBinding, error)
+	// ProjectionPushdown enables DuckDB to scan only the columns a query reads, see TableBinding.Init.
+	// DuckDB's C API does not push filters down to table functions.
+	ProjectionPushdown bool
+}
+
+// TableFunctionArgs are the arguments of a call of a TableFunction.
+// Integer arguments are int64 or uint64 values, FLOAT and DOUBLE arguments are float64 values,
+// and BOOLEAN arguments are bool values. All other arguments are their text, e.g., 2024-01-02 of a DATE.
+// A NULL argument is nil.
+type TableFunctionArgs struct {
+	// Positional are the positional arguments, in the order of the Parameters.
+	Positional []any
+	// Named are the named arguments of the call by their names. It lacks named parameters, which the call omits.
+	Named map[string]any
+}
+
+// TableColumn is a column of the rows of a table function.
+type TableColumn struct {
+	Name string
+	// Type is the type of the column, as returned by DatabaseTypeName, e.g., BIGINT, VARCHAR[], or DOUBLE[3].
+	// The values of a column convert like the values an Appender appends to a column of the type.
+	Type string
+}
+
+// TableBinding is a call of a TableFunction bound to its arguments.
+// A binding can be scanned several times, e.g., by executing a prepared statement several times.
+type TableBinding struct {
+	// Columns are the columns of the rows.
+	Columns []TableColumn
+	// Cardinality is the estimated number of rows, or zero, if the number is unknown.
+	Cardinality int
+	// ExactCardinality is set, if the Cardinality is the exact number of rows.
+	ExactCardinality bool
+	// Init is called before each scan of the binding with the indexes of the columns of the scan.
+	// These are all columns, unless the table function enables ProjectionPushdown. It is optional.
+	Init func(projection []int) error
+	// Fill sets the values of the next rows of the scan, and returns their number.
+	// It returns zero after the last row.
+	Fill func(chunk *TableChunk) (int, error)
+}
+
+// TableChunk is a chunk of rows produced by a table function.
+// A TableChunk is only valid within the Fill call it is passed to.
+type TableChunk struct {
+	vectors   []vector
+	projected []bool
+	capacity  int
+}
+
+// Capacity returns the maximum number of rows of the chunk.
+func (c *TableChunk) Capacity() int {
+	return c.capacity
+}
+
+// Projected returns true, if the scan produces the column. SetValue ignores the values of other columns.
+func (c *TableChunk) Projected(column int) bool {
+	return column >= 0 && column < len(c.projected) && c.projected[column]
+}
+
+// SetValue sets the value of the column of the row. A nil value sets NULL.
+func (c *TableChunk) SetValue(column, row int, value any) error {
+	if column < 0 || column >= len(c.vectors) {
+		return getError(errSetTableValue, fmt.Errorf("%s %d out of range", columnErrMsg, column))
+	}
+	if row < 0 || row >= c.capacity {
+		return getError(errSetTableValue, fmt.Errorf("row %d out of range", row))
+	}
+	if !c.projected[column] {
+		return nil
+	}
+
+	vec := &c.vectors[column]
+	v, err := vec.tryCast(value)
+	if err != nil {
+		return getError(errSetTableValue, columnError(err, column))
+	}
+	vec.fn(vec, C.idx_t(row), v)
+	return nil
+}
+
+// tableFunction is the extra info of a registered table function.
+type tableFunction struct {
+	fn          TableFunction
+	paramTypes  []C.duckdb_type
+	namedParams []string
+	namedTypes  []C.duckdb_type
+}
+
+// tableScan is the bind data of a call of a table function.
+type tableScan struct {
+	binding *TableBinding
+	vectors []vector
+}
+
+// tableScanInit is the init data of a scan of a tableScan.
+type tableScanInit struct {
+	// columns are the column indexes of the vectors of the output chunks.
+	// The index of a virtual column, e.g., of the row ID, which the scan does not produce, is -1.
+	columns   []int
+	projected []bool
+}
+
+// RegisterTableFunction registers the table function under the name on the database of the connection.
+// All connections to the database can call it, e.g., SELECT * FROM name(1, 2).
+// DuckDB calls the functions of a table function sequentially, but possibly not on the goroutine of the query.
+func RegisterTableFunction(driverConn driver.Conn, name string, fn TableFunction) error {
+	con, ok := driverConn.(*conn)
+	if !ok {
+		return getError(errInvalidCon, nil)
+	}
+	if con.closed {
+		return getError(errClosedCon, nil)
+	}
+	if name == "" {
+		return getError(errRegisterTableFunction, errors.New("empty function name"))
+	}
+	if fn.Bind == nil {
+		return getError(errRegisterTableFunction, errors.New("missing Bind function"))
+	}
+
+	// DuckDB aborts on registering a function under the name of an existing function.
+	exists, err := functionExists(con, name)
+	if err != nil {
+		return getError(errRegisterTableFunction, err)
+	}
+	if exists {
+		return getError(errRegisterTableFunction, fmt.Errorf("function %s already exists", name))
+	}
+
+	f := &tableFunction{fn: fn}
+	lts := make([]C.duckdb_logical_type, 0, len(fn.Parameters)+len(fn.NamedParameters))
+	defer func() {
+		for i := range lts {
+			C.duckdb_destroy_logical_type(&lts[i])
+		}
+	}()
+
+	for _, typeName := range fn.Parameters {
+		lt, err := newLogicalType(typeName)
+		if err != nil {
+			return getError(errRegisterTableFunction, err)
+		}
+		lts = append(lts, lt)
+		f.paramTypes = append(f.paramTypes, C.duckdb_get_type_id(lt))
+	}
+	for paramName := range fn.NamedParameters {
+		f.namedParams = append(f.namedParams, paramName)
+	}
+	sort.Strings(f.namedParams)
+	for _, paramName := range f.namedParams {
+		lt, err := newLogicalType(fn.NamedParameters[paramName])
+		if err != nil {
+			return getError(errRegisterTableFunction, err)
+		}
+		lts = append(lts, lt)
+		f.namedTypes = append(f.namedTypes, C.duckdb_get_type_id(lt))
+	}
+
+	tf := C.duckdb_create_table_function()
+	defer C.duckdb_destroy_table_function(&tf)
+
+	cName := C.CString(name)
+	defer C.free(unsafe.Pointer(cName))
+	C.duckdb_table_function_set_name(tf, cName)
+
+	for i := range fn.Parameters {
+		C.duckdb_table_function_add_parameter(tf, lts[i])
+	}
+	for i, paramName := range f.namedParams {
+		cParamName := C.CString(paramName)
+		C.duckdb_table_function_add_named_parameter(tf, cParamName, lts[len(fn.Parameters)+i])
+		C.free(unsafe.Pointer(cParamName))
+	}
+
+	C.duckdb_table_function_set_extra_info(tf, newHandle(f), C.duckdb_delete_callback_t(C.deleteHandle))
+	C.duckdb_table_function_set_bind(tf, C.duckdb_table_function_bind_t(C.tableFunctionBind))
+	C.duckdb_table_function_set_init(tf, C.duckdb_table_function_init_t(C.tableFunctionInit))
+	C.duckdb_table_function_set_function(tf, C.duckdb_table_function_t(C.tableFunctionExecute))
+	C.duckdb_table_function_supports_projection_pushdown(tf, C.bool(fn.ProjectionPushdown))
+
+	con.mu.Lock()
+	state := C.duckdb_register_table_function(con.duckdbCon, tf)
+	con.mu.Unlock()
+
+	if state == C.DuckDBError {
+		return getError(errRegisterTableFunction, errors.New(name))
+	}
+	return nil
+}
+
+// functionExists returns true, if a function of any kind has the name, case-insensitively.
+func functionExists(con *conn, name string) (bool, error) {
+	r, err := con.QueryContext(context.Background(),
+		`SELECT count(*) FROM duckdb_functions() WHERE lower(function_name) = lower(?)`,
+		[]driver.NamedValue{{Ordinal: 1, Value: name}})
+	if err != nil {
+		return false, err
+	}
+	defer r.Close()
+
+	values := make([]driver.Value, 1)
+	if err = r.Next(values); err != nil {
+		return false, err
+	}
+	return values[0].(int64) > 0, nil
+}
+
+//export tableFunctionBind
+func tableFunctionBind(info C.duckdb_bind_info) {
+	f := handleValue(C.duckdb_bind_get_extra_info(info)).(*tableFunction)
+
+	args := TableFunctionArgs{Named: make(map[string]any)}
+	count := int(C.duckdb_bind_get_parameter_count(info))
+	for i := 0; i < count; i++ {
+		value := C.duckdb_bind_get_parameter(info, C.idx_t(i))
+		args.Positional = append(args.Positional, tableFunctionArg(value, f.paramTypes[i]))
+		C.duckdb_destroy_value(&value)
+	}
+	for i, name := range f.namedParams {
+		cName := C.CString(name)
+		value := C.duckdb_bind_get_named_parameter(info, cName)
+		C.free(unsafe.Pointer(cName))
+		if value == nil {
+			continue
+		}
+		args.Named[name] = tableFunctionArg(value, f.namedTypes[i])
+		C.duckdb_destroy_value(&value)
+	}
+
+	scan, err := bindTableScan(f.fn.Bind, args)
+	if err != nil {
+		setTableFunctionError(err, func(msg *C.char) { C.duckdb_bind_set_error(info, msg) })
+		return
+	}
+
+	for _, column := range scan.binding.Columns {
+		lt, _ := newLogicalType(column.Type)
+		cName := C.CString(column.Name)
+		C.duckdb_bind_add_result_column(info, cName, lt)
+		C.free(unsafe.Pointer(cName))
+		C.duckdb_destroy_logical_type(&lt)
+	}
+	if scan.binding.Cardinality > 0 {
+		C.duckdb_bind_set_cardinality(info, C.idx_t(scan.binding.Cardinality), C.bool(scan.binding.ExactCardinality))
+	}
+	C.duckdb_bind_set_bind_data(info, newHandle(scan), C.duckdb_delete_callback_t(C.deleteHandle))
+}
+
+// bindTableScan calls the bind function, and validates the binding it returns.
+func bindTableScan(bind func(args TableFunctionArgs) (*TableBinding, error), args TableFunctionArgs) (
+	scan *tableScan, err error,
+) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic in Bind: %v", r)
+		}
+	}()
+
+	binding, err := bind(args)
+	if err != nil {
+		return nil, err
+	}
+	if binding == nil || binding.Fill == nil {
+		return nil, errors.New("missing Fill function")
+	}
+	if len(binding.Columns) == 0 {
+		return nil, errors.New("missing columns")
+	}
+
+	scan = &tableScan{binding: binding, vectors: make([]vector, len(binding.Columns))}
+	for i, column := range binding.Columns {
+		if column.Name == "" {
+			return nil, columnError(errors.New("empty column name"), i)
+		}
+		lt, err := newLogicalType(column.Type)
+		if err != nil {
+			return nil, columnError(err, i)
+		}
+		err = scan.vectors[i].init(lt, i)
+		C.duckdb_destroy_logical_type(&lt)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return scan, nil
+}
+
+//export tableFunctionInit
+func tableFunctionInit(info C.duckdb_init_info) {
+	scan := handleValue(C.duckdb_init_get_bind_data(info)).(*tableScan)
+	C.duckdb_init_set_max_threads(info, 1)
+
+	scanInit := &tableScanInit{projected: make([]bool, len(scan.vectors))}
+	var projection []int
+	count := int(C.duckdb_init_get_column_count(info))
+	for i := 0; i < count; i++ {
+		column := -1
+		if index := C.duckdb_init_get_column_index(info, C.idx_t(i)); index < C.idx_t(len(scan.vectors)) {
+			column = int(index)
+			scanInit.projected[column] = true
+			projection = append(projection, column)
+		}
+		scanInit.columns = append(scanInit.columns, column)
+	}
+	C.duckdb_init_set_init_data(info, newHandle(scanInit), C.duckdb_delete_callback_t(C.deleteHandle))
+	if scan.binding.Init == nil {
+		return
+	}
+
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic in Init: %v", r)
+			}
+		}()
+		return scan.binding.Init(projection)
+	}()
+	if err != nil {
+		setTableFunctionError(err, func(msg *C.char) { C.duckdb_init_set_error(info, msg) })
+	}
+}
+
+//export tableFunctionExecute
+func tableFunctionExecute(info C.duckdb_function_info, output C.duckdb_data_chunk) {
+	scan := handleValue(C.duckdb_function_get_bind_data(info)).(*tableScan)
+	scanInit := handleValue(C.duckdb_function_get_init_data(info)).(*tableScanInit)
+
+	for i, column := range scanInit.columns {
+		if column < 0 {
+			continue
+		}
+		vec := C.duckdb_data_chunk_get_vector(output, C.idx_t(i))
+		scan.vectors[column].duckdbVector = vec
+		scan.vectors[column].getChildVectors(vec)
+	}
+	chunk := &TableChunk{vectors: scan.vectors, projected: scanInit.projected, capacity: int(C.duckdb_vector_size())}
+
+	n, err := func() (n int, err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("panic in Fill: %v", r)
+			}
+		}()
+		return scan.binding.Fill(chunk)
+	}()
+	if err == nil && (n < 0 || n > chunk.capacity) {
+		err = fmt.Errorf("invalid row count %d of chunk with capacity %d", n, chunk.capacity)
+	}
+	if err != nil {
+		setTableFunctionError(err, func(msg *C.char) { C.duckdb_function_set_error(info, msg) })
+		n = 0
+	}
+	C.duckdb_data_chunk_set_size(output, C.idx_t(n))
+}
+
+func setTableFunctionError(err error, set func(msg *C.char)) {
+	msg := C.CString(err.Error())
+	defer C.free(unsafe.Pointer(msg))
+	set(msg)
+}
+
+// tableFunctionArg returns the Go value of the argument of a parameter of the type, see TableFunctionArgs.
+func tableFunctionArg(value C.duckdb_value, t C.duckdb_type) any {
+	if valueIsNull(value) {
+		return nil
+	}
+
+	cText := C.duckdb_get_varchar(value)
+	text := C.GoString(cText)
+	C.duckdb_free(unsafe.Pointer(cText))
+
+	switch t {
+	case C.DUCKDB_TYPE_TINYINT, C.DUCKDB_TYPE_SMALLINT, C.DUCKDB_TYPE_INTEGER, C.DUCKDB_TYPE_BIGINT:
+		if v, err := strconv.ParseInt(text, 10, 64); err == nil {
+			return v
+		}
+	case C.DUCKDB_TYPE_UTINYINT, C.DUCKDB_TYPE_USMALLINT, C.DUCKDB_TYPE_UINTEGER, C.DUCKDB_TYPE_UBIGINT:
+		if v, err := strconv.ParseUint(text, 10, 64); err == nil {
+			return v
+		}
+	case C.DUCKDB_TYPE_FLOAT, C.DUCKDB_TYPE_DOUBLE:
+		if v, err := strconv.ParseFloat(text, 64); err == nil {
+			return v
+		}
+	case C.DUCKDB_TYPE_BOOLEAN:
+		if v, err := strconv.ParseBool(text); err == nil {
+			return v
+		}
+	}
+	return text
+}
+
+// valueIsNull returns true, if the value is NULL. The C API lacks a function for it, and its getters abort on NULL
+// values. Instead, it wraps the value in a STRUCT(v INTEGER), which casts the value to an INTEGER. Casting a NULL value
+// of any type succeeds, whereas other values either fail to cast, or render as a number instead of NULL.
+func valueIsNull(value C.duckdb_value) bool {
+	child := C.duckdb_create_logical_type(C.DUCKDB_TYPE_INTEGER)
+	defer C.duckdb_destroy_logical_type(&child)
+	name := C.CString("v")
+	defer C.free(unsafe.Pointer(name))
+	structType := C.duckdb_create_struct_type(&child, &name, 1)
+	defer C.duckdb_destroy_logical_type(&structType)
+
+	structValue := C.duckdb_create_struct_value(structType, &value)
+	if structValue == nil {
+		return false
+	}
+	defer C.duckdb_destroy_value(&structValue)
+	cText := C.duckdb_get_varchar(structValue)
+	defer C.duckdb_free(unsafe.Pointer(cText))
+	return C.GoString(cText) == "{'v': NULL}"
+}
+
+// logicalTypes are the types of the columns of table functions by their names.
+var logicalTypes = func() map[string]C.duckdb_type {
+	m := make(map[string]C.duckdb_type)
+	for _, t := range []C.duckdb_type{
+		C.DUCKDB_TYPE_BOOLEAN, C.DUCKDB_TYPE_TINYINT, C.DUCKDB_TYPE_SMALLINT, C.DUCKDB_TYPE_INTEGER,
+		C.DUCKDB_TYPE_BIGINT, C.DUCKDB_TYPE_UTINYINT, C.DUCKDB_TYPE_USMALLINT, C.DUCKDB_TYPE_UINTEGER,
+		C.DUCKDB_TYPE_UBIGINT, C.DUCKDB_TYPE_FLOAT, C.DUCKDB_TYPE_DOUBLE, C.DUCKDB_TYPE_VARCHAR,
+		C.DUCKDB_TYPE_BLOB, C.DUCKDB_TYPE_DATE, C.DUCKDB_TYPE_TIME, C.DUCKDB_TYPE_TIME_TZ,
+		C.DUCKDB_TYPE_TIMESTAMP, C.DUCKDB_TYPE_TIMESTAMP_S, C.DUCKDB_TYPE_TIMESTAMP_MS,
+		C.DUCKDB_TYPE_TIMESTAMP_NS, C.DUCKDB_TYPE_TIMESTAMP_TZ, C.DUCKDB_TYPE_UUID,
+	} {
+		m[typeName(t)] = t
+	}
+	return m
+}()
+
+// newLogicalType returns the logical type of the name of a primitive type, or of a LIST or an ARRAY of it,
+// e.g., INTEGER, INTEGER[], or INTEGER[3]. The caller must destroy the logical type.
+func newLogicalType(name string) (C.duckdb_logical_type, error) {
+	name = strings.ToUpper(strings.TrimSpace(name))
+	if strings.HasSuffix(name, "]") {
+		open := strings.LastIndexByte(name, '[')
+		if open <= 0 {
+			return nil, unsupportedTypeError(name)
+		}
+
+		var size int
+		if sizeText := name[open+1 : len(name)-1]; sizeText != "" {
+			var err error
+			if size, err = strconv.Atoi(sizeText); err != nil || size <= 0 {
+				return nil, unsupportedTypeError(name)
+			}
+		}
+
+		child, err := newLogicalType(name[:open])
+		if err != nil {
+			return nil, err
+		}
+		defer C.duckdb_destroy_logical_type(&child)
+		if size == 0 {
+			return C.duckdb_create_list_type(child), nil
+		}
+		return C.duckdb_create_array_type(child, C.idx_t(size)), nil
+	}
+
+	t, ok := logicalTypes[name]
+	if !ok {
+		return nil, unsupportedTypeError(name)
+	}
+	return C.duckdb_create_logical_type(t), nil
+}
+
+// newHandle returns C memory containing a cgo.Handle of the value, which deleteHandle deletes.
+func newHandle(value any) unsafe.Pointer {
+	p := C.malloc(C.size_t(unsafe.Sizeof(cgo.Handle(0))))
+	*(*cgo.Handle)(p) = cgo.NewHandle(value)
+	return p
+}
+
+func handleValue(p unsafe.Pointer) any {
+	return (*(*cgo.Handle)(p)).Value()
+}
+
+//export deleteHandle
+func deleteHandle(p unsafe.Pointer) {
+	(*(*cgo.Handle)(p)).Delete()
+	C.free(p)
+}
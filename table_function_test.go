@@ -0,0 +1,310 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// seriesFunction returns the rows i, i * step as VARCHAR, and [i, i + 1] for i in [0, n).
+func seriesFunction() TableFunction {
+	return TableFunction{
+		Parameters:      []string{"BIGINT"},
+		NamedParameters: map[string]string{"step": "INTEGER", "prefix": "VARCHAR"},
+		Bind: func(args TableFunctionArgs) (*TableBinding, error) {
+			n, ok := args.Positional[0].(int64)
+			if !ok || n < 0 {
+				return nil, fmt.Errorf("invalid row count %v", args.Positional[0])
+			}
+			step := int64(1)
+			if v, ok := args.Named["step"]; ok {
+				step = v.(int64)
+			}
+			prefix, _ := args.Named["prefix"].(string)
+
+			var next int64
+			return &TableBinding{
+				Columns: []TableColumn{
+					{Name: "i", Type: "BIGINT"},
+					{Name: "s", Type: "VARCHAR"},
+					{Name: "l", Type: "INTEGER[]"},
+				},
+				Cardinality:      int(n),
+				ExactCardinality: true,
+				Init: func([]int) error {
+					next = 0
+					return nil
+				},
+				Fill: func(chunk *TableChunk) (int, error) {
+					rows := 0
+					for ; rows < chunk.Capacity() && next < n; rows++ {
+						if err := chunk.SetValue(0, rows, next); err != nil {
+							return 0, err
+						}
+						var s any
+						if next%10 != 9 {
+							s = fmt.Sprintf("%s%d", prefix, next*step)
+						}
+						if err := chunk.SetValue(1, rows, s); err != nil {
+							return 0, err
+						}
+						if err := chunk.SetValue(2, rows, []int32{int32(next), int32(next + 1)}); err != nil {
+							return 0, err
+						}
+						next++
+					}
+					return rows, nil
+				},
+			}, nil
+		},
+	}
+}
+
+func registerTableFunction(t *testing.T, db *sql.DB, name string, fn TableFunction) {
+	con, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	require.NoError(t, con.Raw(func(driverConn any) error {
+		return RegisterTableFunction(driverConn.(*conn), name, fn)
+	}))
+}
+
+func TestTableFunction(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+	registerTableFunction(t, db, "series", seriesFunction())
+
+	t.Run("rows", func(t *testing.T) {
+		rows, err := db.Query(`SELECT i, s, l FROM series(5000)`)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var count int64
+		for rows.Next() {
+			var i int64
+			var s sql.NullString
+			var l []any
+			require.NoError(t, rows.Scan(&i, &s, &l))
+			require.Equal(t, count, i)
+			require.Equal(t, i%10 != 9, s.Valid)
+			if s.Valid {
+				require.Equal(t, fmt.Sprint(i), s.String)
+			}
+			require.Equal(t, []any{int32(i), int32(i + 1)}, l)
+			count++
+		}
+		require.NoError(t, rows.Err())
+		require.Equal(t, int64(5000), count)
+	})
+
+	t.Run("named parameters", func(t *testing.T) {
+		var s string
+		require.NoError(t, db.QueryRow(`SELECT s FROM series(4, step = 3, prefix = 'x') WHERE i = 3`).Scan(&s))
+		require.Equal(t, "x9", s)
+	})
+
+	t.Run("join and aggregate", func(t *testing.T) {
+		var sum, count int64
+		require.NoError(t, db.QueryRow(`SELECT sum(a.i), count(*) FROM series(100) a JOIN series(10) b ON a.i = b.i`).
+			Scan(&sum, &count))
+		require.Equal(t, int64(45), sum)
+		require.Equal(t, int64(10), count)
+	})
+
+	t.Run("prepared statement", func(t *testing.T) {
+		stmt, err := db.Prepare(`SELECT count(*) FROM series(?)`)
+		require.NoError(t, err)
+		defer stmt.Close()
+
+		for _, n := range []int64{3, 3000, 0} {
+			var count int64
+			require.NoError(t, stmt.QueryRow(n).Scan(&count))
+			require.Equal(t, n, count)
+		}
+	})
+
+	t.Run("bind error", func(t *testing.T) {
+		err := db.QueryRow(`SELECT * FROM series(-1)`).Scan()
+		require.ErrorContains(t, err, "invalid row count -1")
+	})
+}
+
+func TestTableFunctionErrors(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	con, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	register := func(name string, fn TableFunction) error {
+		return con.Raw(func(driverConn any) error {
+			return RegisterTableFunction(driverConn.(*conn), name, fn)
+		})
+	}
+	binding := func(columns []TableColumn, fill func(chunk *TableChunk) (int, error)) TableFunction {
+		return TableFunction{Bind: func(TableFunctionArgs) (*TableBinding, error) {
+			return &TableBinding{Columns: columns, Fill: fill}, nil
+		}}
+	}
+	oneRow := func(value any) func(chunk *TableChunk) (int, error) {
+		done := false
+		return func(chunk *TableChunk) (int, error) {
+			if done {
+				return 0, nil
+			}
+			done = true
+			return 1, chunk.SetValue(0, 0, value)
+		}
+	}
+
+	t.Run("registration", func(t *testing.T) {
+		err := RegisterTableFunction(nil, "fn", seriesFunction())
+		testError(t, err, errInvalidCon.Error())
+
+		err = register("", seriesFunction())
+		testError(t, err, errRegisterTableFunction.Error(), "empty function name")
+
+		err = register("fn", TableFunction{})
+		testError(t, err, errRegisterTableFunction.Error(), "missing Bind function")
+
+		err = register("fn", TableFunction{Parameters: []string{"HUGEINT"}, Bind: seriesFunction().Bind})
+		testError(t, err, errRegisterTableFunction.Error(), unsupportedTypeErrMsg, "HUGEINT")
+
+		require.NoError(t, register("dup", seriesFunction()))
+		err = register("dup", seriesFunction())
+		testError(t, err, errRegisterTableFunction.Error(), "function dup already exists")
+	})
+
+	t.Run("bind", func(t *testing.T) {
+		require.NoError(t, register("no_columns", binding(nil, oneRow(1))))
+		err := db.QueryRow(`SELECT * FROM no_columns()`).Scan()
+		require.ErrorContains(t, err, "missing columns")
+
+		require.NoError(t, register("bad_type", binding([]TableColumn{{Name: "d", Type: "DECIMAL(4,1)"}}, oneRow(1))))
+		err = db.QueryRow(`SELECT * FROM bad_type()`).Scan()
+		require.ErrorContains(t, err, unsupportedTypeErrMsg)
+
+		require.NoError(t, register("bind_panic", TableFunction{Bind: func(TableFunctionArgs) (*TableBinding, error) {
+			panic("oops")
+		}}))
+		err = db.QueryRow(`SELECT * FROM bind_panic()`).Scan()
+		require.ErrorContains(t, err, "panic in Bind: oops")
+	})
+
+	t.Run("init and fill", func(t *testing.T) {
+		require.NoError(t, register("init_error", TableFunction{Bind: func(TableFunctionArgs) (*TableBinding, error) {
+			return &TableBinding{
+				Columns: []TableColumn{{Name: "i", Type: "INTEGER"}},
+				Init:    func([]int) error { return errors.New("init failed") },
+				Fill:    oneRow(int32(1)),
+			}, nil
+		}}))
+		err := db.QueryRow(`SELECT * FROM init_error()`).Scan()
+		require.ErrorContains(t, err, "init failed")
+
+		require.NoError(t, register("wrong_type", binding([]TableColumn{{Name: "i", Type: "INTEGER"}}, oneRow("one"))))
+		var i int32
+		err = db.QueryRow(`SELECT * FROM wrong_type()`).Scan(&i)
+		require.ErrorContains(t, err, castErrMsg)
+
+		require.NoError(t, register("too_many", binding([]TableColumn{{Name: "i", Type: "INTEGER"}},
+			func(chunk *TableChunk) (int, error) { return chunk.Capacity() + 1, nil })))
+		err = db.QueryRow(`SELECT * FROM too_many()`).Scan(&i)
+		require.ErrorContains(t, err, "invalid row count")
+	})
+}
+
+func TestTableFunctionNullArgs(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	var args TableFunctionArgs
+	registerTableFunction(t, db, "args", TableFunction{
+		Parameters:      []string{"BIGINT", "VARCHAR", "DOUBLE", "BOOLEAN", "DATE", "BLOB", "INTEGER[]"},
+		NamedParameters: map[string]string{"u": "UBIGINT", "s": "VARCHAR"},
+		Bind: func(a TableFunctionArgs) (*TableBinding, error) {
+			args = a
+			return &TableBinding{
+				Columns: []TableColumn{{Name: "i", Type: "INTEGER"}},
+				Fill:    func(*TableChunk) (int, error) { return 0, nil },
+			}, nil
+		},
+	})
+
+	_, err := db.Exec(`SELECT * FROM args(-1, 'NULL', 1.5, true, DATE '2024-01-02', 'NULL'::BLOB, [NULL], u = 18446744073709551615, s = '')`)
+	require.NoError(t, err)
+	require.Equal(t, []any{int64(-1), "NULL", 1.5, true, "2024-01-02", "NULL", "[NULL]"}, args.Positional)
+	require.Equal(t, map[string]any{"u": uint64(18446744073709551615), "s": ""}, args.Named)
+
+	_, err = db.Exec(`SELECT * FROM args(NULL, NULL, NULL, NULL, NULL, NULL, NULL, s = NULL)`)
+	require.NoError(t, err)
+	require.Equal(t, []any{nil, nil, nil, nil, nil, nil, nil}, args.Positional)
+	require.Equal(t, map[string]any{"s": nil}, args.Named)
+}
+
+func TestTableFunctionProjectionPushdown(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	var projections [][]int
+	var filled []int
+	registerTableFunction(t, db, "wide", TableFunction{
+		ProjectionPushdown: true,
+		Bind: func(TableFunctionArgs) (*TableBinding, error) {
+			done := false
+			return &TableBinding{
+				Columns: []TableColumn{{Name: "a", Type: "INTEGER"}, {Name: "b", Type: "VARCHAR"}, {Name: "c", Type: "DOUBLE"}},
+				Init: func(projection []int) error {
+					projections = append(projections, projection)
+					done = false
+					return nil
+				},
+				Fill: func(chunk *TableChunk) (int, error) {
+					if done {
+						return 0, nil
+					}
+					done = true
+					filled = filled[:0]
+					for column, value := range []any{int32(1), "two", 3.0} {
+						if chunk.Projected(column) {
+							filled = append(filled, column)
+						}
+						if err := chunk.SetValue(column, 0, value); err != nil {
+							return 0, err
+						}
+					}
+					return 1, nil
+				},
+			}, nil
+		},
+	})
+
+	var c float64
+	var a int32
+	require.NoError(t, db.QueryRow(`SELECT c, a FROM wide()`).Scan(&c, &a))
+	require.Equal(t, 3.0, c)
+	require.Equal(t, int32(1), a)
+	require.ElementsMatch(t, []int{0, 2}, projections[0])
+	require.Equal(t, []int{0, 2}, filled)
+
+	var b string
+	require.NoError(t, db.QueryRow(`SELECT b FROM wide() WHERE a = 1`).Scan(&b))
+	require.Equal(t, "two", b)
+	require.ElementsMatch(t, []int{0, 1}, projections[1])
+
+	var count int64
+	require.NoError(t, db.QueryRow(`SELECT count(*) FROM wide()`).Scan(&count))
+	require.Equal(t, int64(1), count)
+	require.Empty(t, projections[2])
+	require.Empty(t, filled)
+}
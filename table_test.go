@@ -0,0 +1,100 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type registeredUser struct {
+	ID      int
+	Name    string `db:"user_name"`
+	Score   *float64
+	Tags    []string
+	Created time.Time
+	Secret  string `db:"-"`
+}
+
+func TestRegisterTable(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	_, err := db.Exec(`CREATE TABLE orders (user_id BIGINT, amount INTEGER);
+		INSERT INTO orders VALUES (1, 10), (1, 5), (2, 7)`)
+	require.NoError(t, err)
+
+	conn, err := db.Conn(context.Background())
+	require.NoError(t, err)
+	defer conn.Close()
+
+	score := 1.5
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	users := []*registeredUser{
+		{ID: 1, Name: "ada", Score: &score, Tags: []string{"a", "b"}, Created: created},
+		{ID: 2, Name: "bob", Created: created},
+		nil,
+	}
+
+	var release func() error
+	require.NoError(t, conn.Raw(func(driverConn any) error {
+		release, err = RegisterTable(driverConn.(driver.Conn), "users", users)
+		return err
+	}))
+
+	// The rows join with DuckDB tables.
+	rows, err := conn.QueryContext(context.Background(), `SELECT user_name, sum(amount)::BIGINT FROM users
+		JOIN orders ON users.id = orders.user_id GROUP BY user_name ORDER BY user_name`)
+	require.NoError(t, err)
+	var names []string
+	var sums []int64
+	for rows.Next() {
+		var name string
+		var sum int64
+		require.NoError(t, rows.Scan(&name, &sum))
+		names = append(names, name)
+		sums = append(sums, sum)
+	}
+	require.NoError(t, rows.Err())
+	require.Equal(t, []string{"ada", "bob"}, names)
+	require.Equal(t, []int64{15, 7}, sums)
+
+	var s sql.NullFloat64
+	var tags []any
+	var ts time.Time
+	require.NoError(t, conn.QueryRowContext(context.Background(),
+		`SELECT score, tags, created FROM users WHERE id = 1`).Scan(&s, &tags, &ts))
+	require.Equal(t, 1.5, s.Float64)
+	require.Equal(t, []any{"a", "b"}, tags)
+	require.Equal(t, created, ts)
+
+	// Nil pointers and nil rows are NULL, and the view sees changes of the elements.
+	users[1].Name = "bobby"
+	var nulls int
+	var name string
+	require.NoError(t, conn.QueryRowContext(context.Background(),
+		`SELECT count(*) FILTER (score IS NULL), max(user_name) FILTER (id = 2) FROM users`).Scan(&nulls, &name))
+	require.Equal(t, 2, nulls)
+	require.Equal(t, "bobby", name)
+
+	var columns int
+	require.NoError(t, conn.QueryRowContext(context.Background(),
+		`SELECT count(*) FROM (DESCRIBE users)`).Scan(&columns))
+	require.Equal(t, 5, columns)
+
+	require.NoError(t, release())
+	_, err = conn.ExecContext(context.Background(), `SELECT * FROM users`)
+	require.Error(t, err)
+
+	require.NoError(t, conn.Raw(func(driverConn any) error {
+		_, err := RegisterTable(driverConn.(driver.Conn), "bad", []struct{ M map[string]int }{})
+		testError(t, err, errRegisterTable.Error(), "field M")
+		_, err = RegisterTable(driverConn.(driver.Conn), "bad", []int{1})
+		testError(t, err, errRegisterTable.Error())
+		return nil
+	}))
+}
@@ -0,0 +1,125 @@
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// taskIdleInterval is the interval, at which Run polls for tasks, while the scheduler of DuckDB has none.
+const taskIdleInterval = time.Millisecond
+
+// taskBatchSize is the maximum number of tasks, which Run executes, before returning to the Go scheduler.
+const taskBatchSize = 16
+
+// TaskExecutor executes the tasks of the scheduler of a database on goroutines of the application, see
+// NewTaskExecutor. Together with WithExternalThreads, which reserves threads for the executors, it replaces
+// DuckDB's own thread pool, so that the application controls the CPU usage of its queries, e.g., by the number of
+// goroutines running an executor. The caller of a query always executes its tasks, too.
+// A task executor is safe for concurrent use.
+type TaskExecutor struct {
+	state C.duckdb_task_state
+
+	mu      sync.Mutex
+	closed  bool
+	running sync.WaitGroup
+
+	executed atomic.Uint64
+}
+
+// NewTaskExecutor returns a task executor for the database of the connector.
+// It must be closed before closing the connector.
+func NewTaskExecutor(connector *Connector) (*TaskExecutor, error) {
+	if connector.db == nil || connector.closed.Load() {
+		return nil, getError(errTaskExecutor, errors.New("the connector is closed"))
+	}
+	return &TaskExecutor{state: C.duckdb_create_task_state(connector.db)}, nil
+}
+
+// Run executes the tasks of the database on the calling goroutine, until the context is done, or the executor is
+// closed. It executes a small batch of tasks at a time, returning to the Go scheduler between batches, and polls
+// for tasks every millisecond, while there are none. Multiple goroutines can run an executor concurrently.
+// It returns the error of the context, or nil, once the executor is closed.
+func (e *TaskExecutor) Run(ctx context.Context) error {
+	if e.start() != nil {
+		return nil
+	}
+	defer e.running.Done()
+
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if C.duckdb_task_state_is_finished(e.state) {
+			return nil
+		}
+		if e.executeTasks(taskBatchSize) > 0 {
+			continue
+		}
+
+		timer := time.NewTimer(taskIdleInterval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// ExecuteTasks executes at most maxTasks tasks of the database on the calling goroutine, and returns the number of
+// executed tasks, which is less, if the scheduler of DuckDB has no more tasks.
+func (e *TaskExecutor) ExecuteTasks(maxTasks int) (int, error) {
+	if maxTasks < 1 {
+		return 0, getError(errTaskExecutor, errors.New("the maximum number of tasks must be positive"))
+	}
+	if err := e.start(); err != nil {
+		return 0, err
+	}
+	defer e.running.Done()
+	return e.executeTasks(maxTasks), nil
+}
+
+// TasksExecuted returns the number of tasks, which the executor executed.
+func (e *TaskExecutor) TasksExecuted() uint64 {
+	return e.executed.Load()
+}
+
+// Close stops all goroutines running the executor, waits for their current tasks, and releases the executor.
+func (e *TaskExecutor) Close() error {
+	e.mu.Lock()
+	if e.closed {
+		e.mu.Unlock()
+		return nil
+	}
+	e.closed = true
+	C.duckdb_finish_execution(e.state)
+	e.mu.Unlock()
+
+	e.running.Wait()
+	C.duckdb_destroy_task_state(e.state)
+	return nil
+}
+
+func (e *TaskExecutor) start() error {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if e.closed {
+		return getError(errTaskExecutor, errors.New("the task executor is closed"))
+	}
+	e.running.Add(1)
+	return nil
+}
+
+func (e *TaskExecutor) executeTasks(maxTasks int) int {
+	n := int(C.duckdb_execute_n_tasks_state(e.state, C.idx_t(maxTasks)))
+	e.executed.Add(uint64(n))
+	return n
+}
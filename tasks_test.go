@@ -0,0 +1,74 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTaskExecutor(t *testing.T) {
+	t.Parallel()
+
+	// DuckDB starts no threads, so that only the caller and the task executors execute queries.
+	connector, err := NewConnector("", nil, WithThreads(3), WithExternalThreads(3))
+	require.NoError(t, err)
+	db := sql.OpenDB(connector)
+	defer db.Close()
+
+	stats, err := ThreadInfo(context.Background(), db)
+	require.NoError(t, err)
+	require.Equal(t, 3, stats.Threads)
+	require.Equal(t, 3, stats.ExternalThreads)
+
+	executor, err := NewTaskExecutor(connector)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			errs <- executor.Run(ctx)
+		}()
+	}
+
+	var count int64
+	require.NoError(t, db.QueryRow(`SELECT count(DISTINCT range % 1000003) FROM range(20000000)`).Scan(&count))
+	require.Equal(t, int64(1000003), count)
+	require.Positive(t, executor.TasksExecuted())
+
+	// Cancelling the context stops the goroutines running the executor.
+	cancel()
+	wg.Wait()
+	for i := 0; i < 2; i++ {
+		require.ErrorIs(t, <-errs, context.Canceled)
+	}
+
+	n, err := executor.ExecuteTasks(10)
+	require.NoError(t, err)
+	require.Zero(t, n)
+	_, err = executor.ExecuteTasks(0)
+	require.ErrorIs(t, err, errTaskExecutor)
+
+	// Closing the executor stops the goroutines running it, too.
+	done := make(chan error)
+	go func() { done <- executor.Run(context.Background()) }()
+	require.NoError(t, executor.Close())
+	require.NoError(t, <-done)
+	require.NoError(t, executor.Close())
+	require.NoError(t, executor.Run(context.Background()))
+	_, err = executor.ExecuteTasks(10)
+	require.ErrorIs(t, err, errTaskExecutor)
+
+	_, err = NewConnector("", nil, WithExternalThreads(0))
+	testError(t, err, errInvalidOption.Error(), "invalid number of external threads")
+
+	require.NoError(t, db.Close())
+	_, err = NewTaskExecutor(connector)
+	require.ErrorIs(t, err, errTaskExecutor)
+}
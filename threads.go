@@ -0,0 +1,60 @@
+package duckdb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ThreadStats is the parallelism of a database.
+type ThreadStats struct {
+	// Threads is the total number of threads used by DuckDB, see WithThreads.
+	Threads int
+	// ExternalThreads is the number of Threads that are not part of DuckDB's thread pool, i.e., the threads
+	// of the callers executing queries. It is only valid, if HasExternalThreads is set.
+	ExternalThreads int
+	// HasExternalThreads is set, if the DuckDB version has the external_threads setting.
+	HasExternalThreads bool
+}
+
+// ThreadInfo returns the effective parallelism of the database. This allows verifying the number of threads,
+// which DuckDB detects from the number of CPU cores, if not set. The C API of DuckDB does not expose the state
+// of its task scheduler, e.g., its number of active tasks.
+func ThreadInfo(ctx context.Context, db Queryer) (ThreadStats, error) {
+	var stats ThreadStats
+	rows, err := db.QueryContext(ctx,
+		`SELECT name, value FROM duckdb_settings() WHERE name IN ('threads', 'external_threads')`)
+	if err != nil {
+		return stats, err
+	}
+	defer rows.Close()
+
+	hasThreads := false
+	for rows.Next() {
+		var name, value string
+		if err = rows.Scan(&name, &value); err != nil {
+			return stats, err
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return stats, getError(errThreadInfo, fmt.Errorf("invalid value of setting %s: %s", name, value))
+		}
+
+		switch name {
+		case "threads":
+			stats.Threads = n
+			hasThreads = true
+		case "external_threads":
+			stats.ExternalThreads = n
+			stats.HasExternalThreads = true
+		}
+	}
+	if err = rows.Err(); err != nil {
+		return stats, err
+	}
+	if !hasThreads {
+		return stats, getError(errThreadInfo, errors.New("missing setting: threads"))
+	}
+	return stats, rows.Close()
+}
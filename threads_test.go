@@ -0,0 +1,33 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestThreadInfo(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewConnector("", nil, WithThreads(3))
+	require.NoError(t, err)
+	db := sql.OpenDB(c)
+	defer db.Close()
+
+	stats, err := ThreadInfo(context.Background(), db)
+	require.NoError(t, err)
+	require.Equal(t, 3, stats.Threads)
+	require.True(t, stats.HasExternalThreads)
+	require.LessOrEqual(t, stats.ExternalThreads, stats.Threads)
+
+	_, err = db.Exec(`SET threads = 2`)
+	require.NoError(t, err)
+	stats, err = ThreadInfo(context.Background(), db)
+	require.NoError(t, err)
+	require.Equal(t, 2, stats.Threads)
+
+	_, err = NewConnector("", nil, WithThreads(0))
+	testError(t, err, errInvalidOption.Error(), "invalid number of threads")
+}
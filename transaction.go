@@ -1,6 +1,14 @@
 package duckdb
 
-import "context"
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"context"
+	"fmt"
+)
 
 type tx struct {
 	c *conn
@@ -12,6 +20,7 @@ func (t *tx) Commit() error {
 	}
 
 	t.c.tx = false
+	t.c.readOnlyTx = false
 	_, err := t.c.ExecContext(context.Background(), "COMMIT TRANSACTION", nil)
 	t.c = nil
 
@@ -24,8 +33,23 @@ func (t *tx) Rollback() error {
 	}
 
 	t.c.tx = false
+	t.c.readOnlyTx = false
 	_, err := t.c.ExecContext(context.Background(), "ROLLBACK", nil)
 	t.c = nil
 
 	return err
 }
+
+// checkReadOnlyTx returns an error matching ErrReadOnly, if the statement may write to the database during
+// a read-only transaction. DuckDB does not support read-only transactions, so the driver only executes SELECT
+// and EXPLAIN statements in them. It must be called while holding the lock of the connection.
+func (s *stmt) checkReadOnlyTx() error {
+	if !s.c.readOnlyTx {
+		return nil
+	}
+	switch C.duckdb_prepared_statement_type(*s.stmt) {
+	case C.DUCKDB_STATEMENT_TYPE_SELECT, C.DUCKDB_STATEMENT_TYPE_EXPLAIN:
+		return nil
+	}
+	return fmt.Errorf("%s: %w: %s", driverErrMsg, ErrReadOnly, readOnlyTxErrMsg)
+}
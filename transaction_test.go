@@ -0,0 +1,55 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBeginTxOptions(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	ctx := context.Background()
+	_, err := db.Exec(`CREATE TABLE t (i INTEGER); INSERT INTO t VALUES (1)`)
+	require.NoError(t, err)
+
+	t.Run("read-only", func(t *testing.T) {
+		tx, err := db.BeginTx(ctx, &sql.TxOptions{ReadOnly: true})
+		require.NoError(t, err)
+
+		var i int
+		require.NoError(t, tx.QueryRow(`SELECT i FROM t`).Scan(&i))
+		require.Equal(t, 1, i)
+		_, err = tx.Exec(`EXPLAIN SELECT i FROM t`)
+		require.NoError(t, err)
+
+		for _, query := range []string{
+			`INSERT INTO t VALUES (2)`,
+			`CREATE TABLE u (i INTEGER)`,
+			`SELECT 1; DELETE FROM t`,
+		} {
+			_, err = tx.Exec(query)
+			require.ErrorIs(t, err, ErrReadOnly, query)
+			testError(t, err, ErrReadOnly.Error(), readOnlyTxErrMsg)
+		}
+		require.NoError(t, tx.Commit())
+
+		_, err = db.Exec(`INSERT INTO t VALUES (2)`)
+		require.NoError(t, err)
+	})
+
+	t.Run("isolation levels", func(t *testing.T) {
+		tx, err := db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSnapshot})
+		require.NoError(t, err)
+		_, err = tx.Exec(`INSERT INTO t VALUES (3)`)
+		require.NoError(t, err)
+		require.NoError(t, tx.Rollback())
+
+		_, err = db.BeginTx(ctx, &sql.TxOptions{Isolation: sql.LevelSerializable})
+		require.ErrorContains(t, err, "isolation level Serializable is not supported")
+	})
+}
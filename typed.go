@@ -0,0 +1,70 @@
+package duckdb
+
+import (
+	"database/sql/driver"
+	"strconv"
+	"strings"
+)
+
+// TypedParam is a parameter with an explicit DuckDB type, e.g., TypedParam{Value: "2024-01-01", Type: "DATE"}.
+// DuckDB infers the type of a parameter from its value and its context. If the value does not
+// determine the type, e.g., a string passed to a function expecting a DATE, then a TypedParam
+// resolves the ambiguity. The ExecContext and QueryContext of a connection replace each placeholder
+// of a TypedParam by CAST(placeholder AS Type). The Type is inserted verbatim, and must be a
+// DuckDB type name, e.g., DOUBLE, DECIMAL(18,3), or INTEGER[].
+// Statements prepared before binding their arguments cannot cast their placeholders, and return an error.
+type TypedParam struct {
+	Value any
+	Type  string
+}
+
+// castTypedParams casts the placeholders of the TypedParam arguments of the query to their types,
+// and replaces the TypedParam arguments by their values.
+func castTypedParams(query string, args []driver.NamedValue) (string, []driver.NamedValue) {
+	types := make(map[string]string)
+	for _, arg := range args {
+		if p, ok := arg.Value.(TypedParam); ok {
+			types[paramKey(arg)] = p.Type
+		}
+	}
+	if len(types) == 0 {
+		return query, args
+	}
+
+	runes := []rune(query)
+	var b strings.Builder
+	last, position := 0, 0
+	for _, p := range queryPlaceholders(runes) {
+		name := p.name
+		if name == "" {
+			// ? parameters are numbered by their position.
+			position++
+			name = strconv.Itoa(position)
+		}
+		typ, ok := types[name]
+		if !ok {
+			continue
+		}
+		b.WriteString(string(runes[last:p.start]))
+		b.WriteString("CAST(" + string(runes[p.start:p.end]) + " AS " + typ + ")")
+		last = p.end
+	}
+	b.WriteString(string(runes[last:]))
+
+	values := make([]driver.NamedValue, len(args))
+	copy(values, args)
+	for i := range values {
+		if p, ok := values[i].Value.(TypedParam); ok {
+			values[i].Value = p.Value
+		}
+	}
+	return b.String(), values
+}
+
+// paramKey returns the name of the parameter of an argument, or its position, if the argument is unnamed.
+func paramKey(arg driver.NamedValue) string {
+	if arg.Name != "" {
+		return arg.Name
+	}
+	return strconv.Itoa(arg.Ordinal)
+}
@@ -0,0 +1,70 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTypedParam(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	t.Run("ambiguous function call", func(t *testing.T) {
+		var year int64
+		err := db.QueryRow(`SELECT date_part('year', ?)`, "2024-05-01").Scan(&year)
+		require.Error(t, err)
+
+		require.NoError(t, db.QueryRow(`SELECT date_part('year', ?)`,
+			TypedParam{Value: "2024-05-01", Type: "DATE"}).Scan(&year))
+		require.Equal(t, int64(2024), year)
+	})
+
+	t.Run("result type", func(t *testing.T) {
+		var typ string
+		var v float64
+		require.NoError(t, db.QueryRow(`SELECT typeof(?), ? / 4`,
+			TypedParam{Value: 3, Type: "DECIMAL(4,1)"}, TypedParam{Value: 10, Type: "DOUBLE"}).Scan(&typ, &v))
+		require.Equal(t, "DECIMAL(4,1)", typ)
+		require.Equal(t, 2.5, v)
+	})
+
+	t.Run("positional and named parameters", func(t *testing.T) {
+		var a, b string
+		require.NoError(t, db.QueryRow(`SELECT typeof($2), typeof($1) -- $1 '$2'`,
+			1, TypedParam{Value: 2, Type: "SMALLINT"}).Scan(&a, &b))
+		require.Equal(t, "SMALLINT", a)
+		require.Equal(t, "BIGINT", b)
+
+		require.NoError(t, db.QueryRow(`SELECT typeof($x), typeof($y)`,
+			sql.Named("x", TypedParam{Value: 1, Type: "TINYINT"}), sql.Named("y", 2)).Scan(&a, &b))
+		require.Equal(t, "TINYINT", a)
+		require.Equal(t, "BIGINT", b)
+	})
+
+	t.Run("exec", func(t *testing.T) {
+		_, err := db.Exec(`CREATE TABLE events (d DATE)`)
+		require.NoError(t, err)
+		_, err = db.Exec(`INSERT INTO events SELECT ? + INTERVAL 1 DAY`, TypedParam{Value: "2024-05-01", Type: "DATE"})
+		require.NoError(t, err)
+
+		var d time.Time
+		require.NoError(t, db.QueryRow(`SELECT d FROM events`).Scan(&d))
+		require.Equal(t, time.Date(2024, 5, 2, 0, 0, 0, 0, time.UTC), d)
+	})
+
+	t.Run("prepared statement", func(t *testing.T) {
+		stmt, err := db.PrepareContext(context.Background(), `SELECT ?`)
+		require.NoError(t, err)
+		defer stmt.Close()
+
+		var v any
+		err = stmt.QueryRow(TypedParam{Value: 1, Type: "INTEGER"}).Scan(&v)
+		require.ErrorIs(t, err, errCouldNotBind)
+		require.Contains(t, err.Error(), "TypedParam")
+	})
+}
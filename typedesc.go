@@ -0,0 +1,108 @@
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"unsafe"
+)
+
+// TypeDescriptor describes the full logical type of a column, including the types nested in it.
+type TypeDescriptor struct {
+	// Kind is the name of the type without its parameters, e.g., INTEGER, DECIMAL, LIST, or STRUCT.
+	Kind string `json:"kind"`
+	// Name is the full name of the type, as returned by DatabaseTypeName, e.g., DECIMAL(18,3) or INTEGER[].
+	Name string `json:"name"`
+	// Child is the type of the elements of a LIST or an ARRAY.
+	Child *TypeDescriptor `json:"child,omitempty"`
+	// ArraySize is the number of elements of an ARRAY.
+	ArraySize int `json:"arraySize,omitempty"`
+	// Fields are the fields of a STRUCT, or the members of a UNION, in their order.
+	Fields []FieldDescriptor `json:"fields,omitempty"`
+	// KeyType is the type of the keys of a MAP.
+	KeyType *TypeDescriptor `json:"keyType,omitempty"`
+	// ValueType is the type of the values of a MAP.
+	ValueType *TypeDescriptor `json:"valueType,omitempty"`
+	// Width is the width of a DECIMAL.
+	Width uint8 `json:"width,omitempty"`
+	// Scale is the scale of a DECIMAL.
+	Scale uint8 `json:"scale,omitempty"`
+	// EnumValues are the values of an ENUM, in their order.
+	EnumValues []string `json:"enumValues,omitempty"`
+}
+
+// FieldDescriptor describes a field of a STRUCT, or a member of a UNION.
+type FieldDescriptor struct {
+	Name string          `json:"name"`
+	Type *TypeDescriptor `json:"type"`
+}
+
+// ColumnType returns the TypeDescriptor of the column at index of the rows.
+// The driver rows must be the result of a QueryContext of a DuckDB driver connection.
+func ColumnType(driverRows driver.Rows, index int) (*TypeDescriptor, error) {
+	r, ok := driverRows.(*rows)
+	if !ok {
+		return nil, getError(errColumnType, castError(fmt.Sprintf("%T", driverRows), fmt.Sprintf("%T", r)))
+	}
+	if index < 0 || index >= int(C.duckdb_column_count(&r.res)) {
+		return nil, getError(errColumnType, fmt.Errorf("%s %d out of range", columnErrMsg, index))
+	}
+
+	lt := C.duckdb_column_logical_type(&r.res, C.idx_t(index))
+	defer C.duckdb_destroy_logical_type(&lt)
+	return newTypeDescriptor(lt), nil
+}
+
+func newTypeDescriptor(lt C.duckdb_logical_type) *TypeDescriptor {
+	t := C.duckdb_get_type_id(lt)
+	desc := &TypeDescriptor{Kind: typeName(t), Name: logicalTypeName(lt)}
+
+	switch t {
+	case C.DUCKDB_TYPE_DECIMAL:
+		desc.Width = uint8(C.duckdb_decimal_width(lt))
+		desc.Scale = uint8(C.duckdb_decimal_scale(lt))
+	case C.DUCKDB_TYPE_ENUM:
+		desc.EnumValues = enumLabels(lt)
+	case C.DUCKDB_TYPE_LIST:
+		clt := C.duckdb_list_type_child_type(lt)
+		defer C.duckdb_destroy_logical_type(&clt)
+		desc.Child = newTypeDescriptor(clt)
+	case C.DUCKDB_TYPE_ARRAY:
+		clt := C.duckdb_array_type_child_type(lt)
+		defer C.duckdb_destroy_logical_type(&clt)
+		desc.Child = newTypeDescriptor(clt)
+		desc.ArraySize = int(C.duckdb_array_type_array_size(lt))
+	case C.DUCKDB_TYPE_MAP:
+		klt := C.duckdb_map_type_key_type(lt)
+		defer C.duckdb_destroy_logical_type(&klt)
+		vlt := C.duckdb_map_type_value_type(lt)
+		defer C.duckdb_destroy_logical_type(&vlt)
+		desc.KeyType = newTypeDescriptor(klt)
+		desc.ValueType = newTypeDescriptor(vlt)
+	case C.DUCKDB_TYPE_STRUCT:
+		count := int(C.duckdb_struct_type_child_count(lt))
+		desc.Fields = make([]FieldDescriptor, count)
+		for i := 0; i < count; i++ {
+			name := C.duckdb_struct_type_child_name(lt, C.idx_t(i))
+			clt := C.duckdb_struct_type_child_type(lt, C.idx_t(i))
+			desc.Fields[i] = FieldDescriptor{Name: C.GoString(name), Type: newTypeDescriptor(clt)}
+			C.duckdb_free(unsafe.Pointer(name))
+			C.duckdb_destroy_logical_type(&clt)
+		}
+	case C.DUCKDB_TYPE_UNION:
+		count := int(C.duckdb_union_type_member_count(lt))
+		desc.Fields = make([]FieldDescriptor, count)
+		for i := 0; i < count; i++ {
+			name := C.duckdb_union_type_member_name(lt, C.idx_t(i))
+			clt := C.duckdb_union_type_member_type(lt, C.idx_t(i))
+			desc.Fields[i] = FieldDescriptor{Name: C.GoString(name), Type: newTypeDescriptor(clt)}
+			C.duckdb_free(unsafe.Pointer(name))
+			C.duckdb_destroy_logical_type(&clt)
+		}
+	}
+	return desc
+}
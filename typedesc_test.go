@@ -0,0 +1,82 @@
+package duckdb
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestColumnType(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	con, err := c.Connect(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	_, err = con.(*conn).ExecContext(context.Background(), `CREATE TYPE mood AS ENUM ('sad', 'ok', 'happy')`, nil)
+	require.NoError(t, err)
+
+	driverRows := queryDriverRows(t, con, `SELECT
+		1::INTEGER AS i,
+		1.5::DECIMAL(18,3) AS d,
+		'ok'::mood AS e,
+		[[1, 2]] AS l,
+		[1, 2, 3]::INTEGER[3] AS a,
+		MAP {'k': [1.5::DOUBLE]} AS m,
+		{'name': 'x', 'tags': ['a'], 'inner': {'n': 1::BIGINT}} AS s,
+		union_value(num := 1::INTEGER)::UNION(num INTEGER, str VARCHAR) AS u`)
+	defer driverRows.Close()
+
+	integer := &TypeDescriptor{Kind: "INTEGER", Name: "INTEGER"}
+	varchar := &TypeDescriptor{Kind: "VARCHAR", Name: "VARCHAR"}
+
+	tests := []*TypeDescriptor{
+		integer,
+		{Kind: "DECIMAL", Name: "DECIMAL(18,3)", Width: 18, Scale: 3},
+		{Kind: "ENUM", Name: "ENUM", EnumValues: []string{"sad", "ok", "happy"}},
+		{
+			Kind: "LIST", Name: "INTEGER[][]",
+			Child: &TypeDescriptor{Kind: "LIST", Name: "INTEGER[]", Child: integer},
+		},
+		{Kind: "ARRAY", Name: "INTEGER[3]", Child: integer, ArraySize: 3},
+		{
+			Kind: "MAP", Name: "MAP(VARCHAR, DOUBLE[])",
+			KeyType:   varchar,
+			ValueType: &TypeDescriptor{Kind: "LIST", Name: "DOUBLE[]", Child: &TypeDescriptor{Kind: "DOUBLE", Name: "DOUBLE"}},
+		},
+		{
+			Kind: "STRUCT", Name: `STRUCT("name" VARCHAR, "tags" VARCHAR[], "inner" STRUCT("n" BIGINT))`,
+			Fields: []FieldDescriptor{
+				{Name: "name", Type: varchar},
+				{Name: "tags", Type: &TypeDescriptor{Kind: "LIST", Name: "VARCHAR[]", Child: varchar}},
+				{Name: "inner", Type: &TypeDescriptor{
+					Kind: "STRUCT", Name: `STRUCT("n" BIGINT)`,
+					Fields: []FieldDescriptor{{Name: "n", Type: &TypeDescriptor{Kind: "BIGINT", Name: "BIGINT"}}},
+				}},
+			},
+		},
+		{
+			Kind: "UNION", Name: `UNION("num" INTEGER, "str" VARCHAR)`,
+			Fields: []FieldDescriptor{{Name: "num", Type: integer}, {Name: "str", Type: varchar}},
+		},
+	}
+
+	require.Len(t, driverRows.Columns(), len(tests))
+	for i, expected := range tests {
+		desc, err := ColumnType(driverRows, i)
+		require.NoError(t, err)
+		require.Equal(t, expected, desc, driverRows.Columns()[i])
+	}
+
+	_, err = ColumnType(driverRows, len(tests))
+	testError(t, err, errColumnType.Error(), columnErrMsg)
+	_, err = ColumnType(driverRows, -1)
+	testError(t, err, errColumnType.Error(), columnErrMsg)
+	_, err = ColumnType(nil, 0)
+	testError(t, err, errColumnType.Error(), castErrMsg)
+}
@@ -6,25 +6,34 @@ package duckdb
 import "C"
 
 import (
+	"bytes"
+	"database/sql"
+	"database/sql/driver"
 	"encoding/binary"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/mitchellh/mapstructure"
 )
 
 var unsupportedAppenderTypeMap = map[C.duckdb_type]string{
 	C.DUCKDB_TYPE_INVALID:  "INVALID",
-	C.DUCKDB_TYPE_TIME:     "TIME",
 	C.DUCKDB_TYPE_INTERVAL: "INTERVAL",
 	C.DUCKDB_TYPE_HUGEINT:  "HUGEINT",
 	C.DUCKDB_TYPE_UHUGEINT: "UHUGEINT",
 	C.DUCKDB_TYPE_DECIMAL:  "DECIMAL",
 	C.DUCKDB_TYPE_ENUM:     "ENUM",
-	C.DUCKDB_TYPE_MAP:      "MAP",
 	C.DUCKDB_TYPE_UNION:    "UNION",
 	C.DUCKDB_TYPE_BIT:      "BIT",
-	C.DUCKDB_TYPE_TIME_TZ:  "TIME_TZ",
 }
 
 type numericType interface {
@@ -35,12 +44,70 @@ func convertNumericType[srcT numericType, destT numericType](val srcT) destT {
 	return destT(val)
 }
 
+// UUID is the value of a UUID column. It scans UUID columns and the text of UUIDs, binds as a UUID parameter,
+// and implements encoding.TextMarshaler and encoding.TextUnmarshaler like github.com/google/uuid.
 type UUID [16]byte
 
 func (u *UUID) Scan(v any) error {
-	if n := copy(u[:], v.([]byte)); n != 16 {
-		return fmt.Errorf("invalid UUID length: %d", n)
+	switch value := v.(type) {
+	case []byte:
+		if n := copy(u[:], value); n != 16 || len(value) != 16 {
+			return fmt.Errorf("invalid UUID length: %d", len(value))
+		}
+		return nil
+	case string:
+		return u.UnmarshalText([]byte(value))
+	case nil:
+		return errUnexpectedNull
 	}
+	return castError(fmt.Sprintf("%T", v), "UUID")
+}
+
+// Value returns the text of the UUID, which DuckDB casts to a UUID parameter.
+func (u UUID) Value() (driver.Value, error) {
+	return u.String(), nil
+}
+
+// String returns the hyphenated text of the UUID, e.g., 6ba7b810-9dad-11d1-80b4-00c04fd430c8.
+func (u UUID) String() string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], u[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], u[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], u[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], u[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], u[10:])
+	return string(buf[:])
+}
+
+func (u UUID) MarshalText() ([]byte, error) {
+	return []byte(u.String()), nil
+}
+
+// UnmarshalText parses the hyphenated text of a UUID, or its 32 hexadecimal digits without hyphens.
+func (u *UUID) UnmarshalText(text []byte) error {
+	digits := text
+	if len(text) == 36 {
+		if text[8] != '-' || text[13] != '-' || text[18] != '-' || text[23] != '-' {
+			return fmt.Errorf("invalid UUID format: %s", text)
+		}
+		digits = make([]byte, 0, 32)
+		for _, part := range [][]byte{text[0:8], text[9:13], text[14:18], text[19:23], text[24:]} {
+			digits = append(digits, part...)
+		}
+	}
+	if len(digits) != 32 {
+		return fmt.Errorf("invalid UUID length: %d", len(text))
+	}
+
+	var parsed UUID
+	if _, err := hex.Decode(parsed[:], digits); err != nil {
+		return fmt.Errorf("invalid UUID format: %s", text)
+	}
+	*u = parsed
 	return nil
 }
 
@@ -72,6 +139,13 @@ func hugeIntToNative(hi C.duckdb_hugeint) *big.Int {
 	return i
 }
 
+func uhugeIntToNative(hi C.duckdb_uhugeint) *big.Int {
+	i := new(big.Int).SetUint64(uint64(hi.upper))
+	i.Lsh(i, 64)
+	i.Add(i, new(big.Int).SetUint64(uint64(hi.lower)))
+	return i
+}
+
 func hugeIntFromNative(i *big.Int) (C.duckdb_hugeint, error) {
 	d := big.NewInt(1)
 	d.Lsh(d, 64)
@@ -93,21 +167,206 @@ func hugeIntFromNative(i *big.Int) (C.duckdb_hugeint, error) {
 type Map map[any]any
 
 func (m *Map) Scan(v any) error {
+	if v == nil {
+		// Like a NULL List, a NULL MAP scans into a nil Map.
+		*m = nil
+		return nil
+	}
 	data, ok := v.(Map)
 	if !ok {
-		return fmt.Errorf("invalid type `%T` for scanning `Map`, expected `Map`", data)
+		return fmt.Errorf("invalid type `%T` for scanning `Map`, expected `Map`", v)
 	}
 
 	*m = data
 	return nil
 }
 
+// mapParamText returns the text of a Go map parameter, which DuckDB casts to a MAP parameter, e.g., {a=1, b=2}.
+// The keys and values can be strings, booleans, numbers, time.Time values, nil, slices, and nested maps.
+// The cast does not support escaping, so strings containing both single and double quotes, strings ending with
+// an unpaired backslash, and the string NULL, which casts to a NULL value, return an error.
+func mapParamText(v reflect.Value) (string, error) {
+	keys := make([]string, 0, v.Len())
+	values := make(map[string]string, v.Len())
+	iter := v.MapRange()
+	for iter.Next() {
+		key, err := nestedParamText(iter.Key())
+		if err != nil {
+			return "", err
+		}
+		value, err := nestedParamText(iter.Value())
+		if err != nil {
+			return "", err
+		}
+		keys = append(keys, key)
+		values[key] = value
+	}
+
+	// Go maps are unordered, so sort the entries to bind the same text for equal maps.
+	sort.Strings(keys)
+	entries := make([]string, len(keys))
+	for i, key := range keys {
+		entries[i] = key + "=" + values[key]
+	}
+	return "{" + strings.Join(entries, ", ") + "}", nil
+}
+
+// nestedParamText returns the text of a key, value, or element of a map parameter.
+func nestedParamText(v reflect.Value) (string, error) {
+	if v.Kind() == reflect.Interface || v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "NULL", nil
+		}
+		if _, ok := v.Interface().(*big.Int); !ok {
+			return nestedParamText(v.Elem())
+		}
+	}
+
+	switch value := v.Interface().(type) {
+	case *big.Int:
+		return value.String(), nil
+	case time.Time:
+		return quoteParamText(value.Format("2006-01-02 15:04:05.999999-07:00"))
+	case []byte:
+		break
+	case string:
+		return quoteParamText(value)
+	default:
+		switch v.Kind() {
+		case reflect.Bool:
+			return strconv.FormatBool(v.Bool()), nil
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return strconv.FormatInt(v.Int(), 10), nil
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return strconv.FormatUint(v.Uint(), 10), nil
+		case reflect.Float32, reflect.Float64:
+			return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), nil
+		case reflect.String:
+			return quoteParamText(v.String())
+		case reflect.Map:
+			if v.IsNil() {
+				return "NULL", nil
+			}
+			return mapParamText(v)
+		case reflect.Slice, reflect.Array:
+			if v.Kind() == reflect.Slice && v.IsNil() {
+				return "NULL", nil
+			}
+			elements := make([]string, v.Len())
+			for i := range elements {
+				element, err := nestedParamText(v.Index(i))
+				if err != nil {
+					return "", err
+				}
+				elements[i] = element
+			}
+			return "[" + strings.Join(elements, ", ") + "]", nil
+		}
+	}
+	return "", castError(v.Type().String(), "MAP parameter")
+}
+
+// quoteParamText quotes a string of a map parameter. DuckDB keeps the backslashes of the quoted string, but
+// a backslash escapes the next character, so an unpaired backslash at the end would escape the closing quote.
+func quoteParamText(s string) (string, error) {
+	trimmed := strings.TrimRight(s, `\`)
+	switch {
+	case s == "NULL":
+		return "", errors.New("cannot bind the string NULL in a MAP parameter")
+	case (len(s)-len(trimmed))%2 != 0:
+		return "", fmt.Errorf("cannot bind a string ending with an unpaired backslash in a MAP parameter: %s", s)
+	case !strings.Contains(s, `"`):
+		return `"` + s + `"`, nil
+	case !strings.Contains(s, "'"):
+		return "'" + s + "'", nil
+	}
+	return "", fmt.Errorf("cannot bind a string with single and double quotes in a MAP parameter: %s", s)
+}
+
+// Date is a civil date without a time zone. DATE values scan as a Date with WithCivilDates,
+// and scan into a *Date regardless of the option. A Date binds as a DATE parameter.
+type Date struct {
+	Year  int
+	Month time.Month
+	Day   int
+}
+
+// DateOf returns the Date of the wall clock time of t in its location.
+func DateOf(t time.Time) Date {
+	year, month, day := t.Date()
+	return Date{Year: year, Month: month, Day: day}
+}
+
+// In returns the time.Time of midnight at the start of the date in loc.
+func (d Date) In(loc *time.Location) time.Time {
+	return time.Date(d.Year, d.Month, d.Day, 0, 0, 0, 0, loc)
+}
+
+// String returns the date in the format YYYY-MM-DD.
+func (d Date) String() string {
+	return d.In(time.UTC).Format(time.DateOnly)
+}
+
+// Scan implements sql.Scanner. It scans a time.Time, whose wall clock time determines the date,
+// a Date, and the text of a date in the format YYYY-MM-DD.
+func (d *Date) Scan(src any) error {
+	switch v := src.(type) {
+	case Date:
+		*d = v
+	case time.Time:
+		*d = DateOf(v)
+	case string:
+		t, err := time.Parse(time.DateOnly, v)
+		if err != nil {
+			return err
+		}
+		*d = DateOf(t)
+	case nil:
+		return errUnexpectedNull
+	default:
+		return fmt.Errorf("cannot scan %T into a Date", src)
+	}
+	return nil
+}
+
+func (d Date) toNative() C.duckdb_date {
+	return C.duckdb_to_date(C.duckdb_date_struct{
+		year:  C.int32_t(d.Year),
+		month: C.int8_t(d.Month),
+		day:   C.int8_t(d.Day),
+	})
+}
+
 type Interval struct {
 	Days   int32 `json:"days"`
 	Months int32 `json:"months"`
 	Micros int64 `json:"micros"`
 }
 
+// IntervalFromDuration returns the Interval of a time.Duration, which consists only of microseconds.
+// It truncates the duration to microseconds, the resolution of INTERVAL values.
+func IntervalFromDuration(d time.Duration) Interval {
+	return Interval{Micros: d.Microseconds()}
+}
+
+// Duration returns the time.Duration of the interval, counting a day as 24 hours, like DuckDB
+// does when extracting the epoch of an INTERVAL. It returns false, if the interval contains months,
+// whose length varies, or if the duration overflows a time.Duration.
+func (i Interval) Duration() (time.Duration, bool) {
+	const microsPerDay = int64(24 * time.Hour / time.Microsecond)
+	const maxMicros = int64(math.MaxInt64 / time.Microsecond)
+	const maxDays = maxMicros / microsPerDay
+	if i.Months != 0 || int64(i.Days) > maxDays || int64(i.Days) < -maxDays {
+		return 0, false
+	}
+
+	days := int64(i.Days) * microsPerDay
+	if i.Micros > maxMicros-days || i.Micros < -maxMicros-days {
+		return 0, false
+	}
+	return time.Duration(days+i.Micros) * time.Microsecond, true
+}
+
 // Use as the `Scanner` type for any composite types (maps, lists, structs)
 type Composite[T any] struct {
 	t T
@@ -121,12 +380,380 @@ func (s *Composite[T]) Scan(v any) error {
 	return mapstructure.Decode(v, &s.t)
 }
 
+// List scans a LIST or ARRAY value into a typed slice, e.g., List[int64] or List[[]string] for nested lists.
+// Nested values can also scan into Go arrays of their length, e.g., List[[3]float32] for a FLOAT[3][].
+// Unlike Composite, it only converts numbers without loss, and a NULL element requires an element type
+// that has a nil value, e.g., List[*int64]. A NULL list scans into a nil List.
+type List[T any] []T
+
+func (l *List[T]) Scan(v any) error {
+	if v == nil {
+		*l = nil
+		return nil
+	}
+	var list []T
+	if err := convertElement(v, reflect.ValueOf(&list).Elem()); err != nil {
+		return getError(errScanList, err)
+	}
+	*l = list
+	return nil
+}
+
+// Struct scans a STRUCT value into the Go struct T. The fields of T match the fields of the STRUCT
+// like a StructScanner with its default settings matches the columns, i.e., by their `db` tag,
+// or by their field name, case-insensitively. Nested STRUCT, LIST, and MAP values convert recursively,
+// like the elements of a List, e.g., a MAP(VARCHAR, INTEGER) into a map[string]int32. Valid is false, if the value is NULL.
+type Struct[T any] struct {
+	Value T
+	Valid bool
+}
+
+func (s *Struct[T]) Scan(v any) error {
+	var zero T
+	s.Value, s.Valid = zero, v != nil
+	if v == nil {
+		return nil
+	}
+	if err := convertElement(v, reflect.ValueOf(&s.Value).Elem()); err != nil {
+		return getError(errScanStructVal, err)
+	}
+	return nil
+}
+
+// Nested returns an sql.Scanner, which scans a STRUCT, LIST, or MAP value into the value pointed to by dest,
+// e.g., rows.Scan(duckdb.Nested(&point)) for a user-defined struct, or duckdb.Nested(&m) for a map[string]int64,
+// without converting from the map[string]any or []any of the driver. The value converts like the value of a
+// List or Struct, and decodes into the types registered with RegisterType.
+func Nested(dest any) sql.Scanner {
+	return nestedScanner{dest: dest}
+}
+
+type nestedScanner struct {
+	dest any
+}
+
+func (s nestedScanner) Scan(v any) error {
+	dst := reflect.ValueOf(s.dest)
+	if dst.Kind() != reflect.Pointer || dst.IsNil() {
+		return getError(errScanNested, castError(fmt.Sprintf("%T", s.dest), "non-nil pointer"))
+	}
+	if err := convertElement(v, dst.Elem()); err != nil {
+		return getError(errScanNested, err)
+	}
+	return nil
+}
+
+// typeDecoders contains the decoding function of each type registered with RegisterType.
+var typeDecoders sync.Map
+
+// RegisterType registers the function decoding the non-NULL values of nested types into T, e.g., a STRUCT into a
+// type with unexported fields, or a LIST into a type, which is not a slice. The scanned values are of the types
+// of the type mapping, e.g., a map[string]any for a STRUCT. The function applies wherever the driver converts
+// nested values, i.e., to Nested, List, Struct, and the fields of a StructScanner, and to the elements of nested
+// values, e.g., a List[T] decodes each element with it. Without a registered function, the values convert by
+// reflection. RegisterType is meant to be called during initialization, and replaces previous registrations of T.
+func RegisterType[T any](decode func(v any) (T, error)) {
+	typeDecoders.Store(reflect.TypeOf((*T)(nil)).Elem(), func(v any, dst reflect.Value) error {
+		t, err := decode(v)
+		if err != nil {
+			return err
+		}
+		dst.Set(reflect.ValueOf(&t).Elem())
+		return nil
+	})
+}
+
+// typeDecoder returns the function registered with RegisterType for the type.
+func typeDecoder(t reflect.Type) (func(v any, dst reflect.Value) error, bool) {
+	decode, ok := typeDecoders.Load(t)
+	if !ok {
+		return nil, false
+	}
+	return decode.(func(v any, dst reflect.Value) error), true
+}
+
+// convertElement sets dst to the scanned value src, converting the elements of lists,
+// the entries of maps, and the fields of structs recursively.
+func convertElement(src any, dst reflect.Value) error {
+	if decode, ok := typeDecoder(dst.Type()); ok && src != nil {
+		return decode(src, dst)
+	}
+	if scanner, ok := elementScanner(src, dst); ok {
+		return scanner.Scan(src)
+	}
+	if src == nil {
+		switch dst.Kind() {
+		case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+			dst.SetZero()
+			return nil
+		}
+		return errUnexpectedNull
+	}
+
+	v := reflect.ValueOf(src)
+	switch {
+	case v.Type().AssignableTo(dst.Type()):
+		dst.Set(v)
+		return nil
+	case dst.Kind() == reflect.Pointer:
+		elem := reflect.New(dst.Type().Elem())
+		if err := convertElement(src, elem.Elem()); err != nil {
+			return err
+		}
+		dst.Set(elem)
+		return nil
+	case dst.Kind() == reflect.Slice && v.Kind() == reflect.Slice:
+		slice := reflect.MakeSlice(dst.Type(), v.Len(), v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if err := convertElement(v.Index(i).Interface(), slice.Index(i)); err != nil {
+				return err
+			}
+		}
+		dst.Set(slice)
+		return nil
+	case dst.Kind() == reflect.Array && v.Kind() == reflect.Slice:
+		if v.Len() != dst.Len() {
+			return castError(fmt.Sprintf("%s of length %d", v.Type(), v.Len()), dst.Type().String())
+		}
+		for i := 0; i < v.Len(); i++ {
+			if err := convertElement(v.Index(i).Interface(), dst.Index(i)); err != nil {
+				return err
+			}
+		}
+		return nil
+	case dst.Kind() == reflect.Map && v.Kind() == reflect.Map:
+		m := reflect.MakeMapWithSize(dst.Type(), v.Len())
+		iter := v.MapRange()
+		for iter.Next() {
+			key := reflect.New(dst.Type().Key()).Elem()
+			if err := convertElement(iter.Key().Interface(), key); err != nil {
+				return err
+			}
+			value := reflect.New(dst.Type().Elem()).Elem()
+			if err := convertElement(iter.Value().Interface(), value); err != nil {
+				return err
+			}
+			m.SetMapIndex(key, value)
+		}
+		dst.Set(m)
+		return nil
+	case dst.Kind() == reflect.Struct && v.Type() == reflect.TypeOf(map[string]any{}):
+		return convertStructFields(src.(map[string]any), dst)
+	}
+
+	// Convert numbers, if the destination type holds the value.
+	switch {
+	case v.CanInt() && dst.CanInt() && !dst.OverflowInt(v.Int()):
+		dst.SetInt(v.Int())
+	case v.CanInt() && dst.CanUint() && v.Int() >= 0 && !dst.OverflowUint(uint64(v.Int())):
+		dst.SetUint(uint64(v.Int()))
+	case v.CanUint() && dst.CanUint() && !dst.OverflowUint(v.Uint()):
+		dst.SetUint(v.Uint())
+	case v.CanUint() && dst.CanInt() && v.Uint() <= math.MaxInt64 && !dst.OverflowInt(int64(v.Uint())):
+		dst.SetInt(int64(v.Uint()))
+	case v.CanFloat() && dst.CanFloat() && (dst.Kind() == reflect.Float64 || v.Kind() == reflect.Float32):
+		dst.SetFloat(v.Float())
+	default:
+		return castError(v.Type().String(), dst.Type().String())
+	}
+	return nil
+}
+
+// elementScanner returns the sql.Scanner of dst, e.g., of a sql.NullString, if dst cannot hold src as is.
+// Like database/sql, it leaves a nil pointer for a NULL value, instead of scanning NULL into its element.
+func elementScanner(src any, dst reflect.Value) (sql.Scanner, bool) {
+	if !dst.CanAddr() || dst.Kind() == reflect.Pointer || !dst.Addr().Type().Implements(scannerType) {
+		return nil, false
+	}
+	if src != nil && reflect.TypeOf(src).AssignableTo(dst.Type()) {
+		return nil, false
+	}
+	return dst.Addr().Interface().(sql.Scanner), true
+}
+
+// convertStructFields sets the fields of dst to the fields of the STRUCT value src.
+// Fields of dst without a matching field in src keep their zero value.
+func convertStructFields(src map[string]any, dst reflect.Value) error {
+	fields := structFields(dst.Type())
+	dst.SetZero()
+	for name, value := range src {
+		bestRank := matchNone
+		var index []int
+		for i := range fields {
+			if rank := (StructScanner{}).match(&fields[i], name); rank > bestRank {
+				bestRank = rank
+				index = fields[i].index
+			}
+		}
+		if bestRank == matchNone {
+			return fmt.Errorf("%s: %s", missingKeyErrMsg, name)
+		}
+		if err := convertElement(value, dst.FieldByIndex(index)); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// BitString is the value of a BIT column, i.e., a string of bits of any length.
+// It binds as a BIT parameter, and implements encoding.TextMarshaler and encoding.TextUnmarshaler
+// with the text of its bits, e.g., 0101.
+type BitString struct {
+	// The bits, most significant bit first. The first byte has leading zeros, if len is not a multiple of 8.
+	data []byte
+	len  int
+}
+
+// ParseBitString parses the text of a bit string, e.g., 0101.
+func ParseBitString(s string) (BitString, error) {
+	var b BitString
+	return b, b.UnmarshalText([]byte(s))
+}
+
+// bitStringFromStorage returns the BitString of the storage of a BIT value. Its first byte is the number
+// of padding bits, which precede the bits in the next byte, and which are all set.
+func bitStringFromStorage(storage []byte) (BitString, error) {
+	if len(storage) < 2 || storage[0] > 7 {
+		return BitString{}, errInvalidType
+	}
+	data := bytes.Clone(storage[1:])
+	data[0] &= 0xff >> storage[0]
+	return BitString{data: data, len: 8*len(data) - int(storage[0])}, nil
+}
+
+// Len returns the number of bits.
+func (b BitString) Len() int {
+	return b.len
+}
+
+// Bytes returns the bits packed into bytes, most significant bit first. If the number of bits is not a multiple of 8,
+// then the first byte has leading zeros, e.g., 101 returns []byte{0b101}.
+func (b BitString) Bytes() []byte {
+	return bytes.Clone(b.data)
+}
+
+// String returns the text of the bits, e.g., 0101.
+func (b BitString) String() string {
+	var sb strings.Builder
+	sb.Grow(b.len)
+	padding := 8*len(b.data) - b.len
+	for i := padding; i < 8*len(b.data); i++ {
+		if b.data[i/8]&(0x80>>(i%8)) != 0 {
+			sb.WriteByte('1')
+		} else {
+			sb.WriteByte('0')
+		}
+	}
+	return sb.String()
+}
+
+func (b BitString) MarshalText() ([]byte, error) {
+	return []byte(b.String()), nil
+}
+
+// UnmarshalText parses the text of a bit string, which only contains the digits 0 and 1.
+func (b *BitString) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		return errors.New("empty bit string")
+	}
+	padding := (8 - len(text)%8) % 8
+	data := make([]byte, (len(text)+7)/8)
+	for i, digit := range text {
+		pos := padding + i
+		switch digit {
+		case '1':
+			data[pos/8] |= 0x80 >> (pos % 8)
+		case '0':
+		default:
+			return fmt.Errorf("invalid bit string digit: %q", digit)
+		}
+	}
+	*b = BitString{data: data, len: len(text)}
+	return nil
+}
+
+func (b *BitString) Scan(v any) error {
+	switch value := v.(type) {
+	case BitString:
+		*b = value
+		return nil
+	case string:
+		return b.UnmarshalText([]byte(value))
+	case []byte:
+		return b.UnmarshalText(value)
+	case nil:
+		return errUnexpectedNull
+	}
+	return castError(fmt.Sprintf("%T", v), "BitString")
+}
+
+// Value returns the text of the bits, which DuckDB casts to a BIT parameter.
+func (b BitString) Value() (driver.Value, error) {
+	return b.String(), nil
+}
+
+// Union is the value of a UNION. Tag is the name of its active member, and Value is the value of the member,
+// which has the same Go type as when scanning a value of the member type into an any.
+type Union struct {
+	Tag   string
+	Value any
+}
+
+// Decimal is the value of a DECIMAL(Width, Scale), which is Value * 10^-Scale.
+// As a parameter, a Decimal with a zero Width binds a DECIMAL of the smallest width holding Value.
 type Decimal struct {
 	Width uint8
 	Scale uint8
 	Value *big.Int
 }
 
+// DecimalValuer is implemented by the decimal types of other packages, e.g., github.com/shopspring/decimal.Decimal,
+// whose values are their coefficient times ten to the power of their exponent.
+// A DecimalValuer binds as a DECIMAL parameter, see Decimal, even if it is a driver.Valuer.
+type DecimalValuer interface {
+	Coefficient() *big.Int
+	Exponent() int32
+}
+
+// maxDecimalWidth is the maximum width of a DECIMAL.
+const maxDecimalWidth = 38
+
+// decimalFromValuer returns the Decimal of a DecimalValuer, with the smallest width holding its value.
+func decimalFromValuer(v DecimalValuer) (Decimal, error) {
+	value := new(big.Int).Set(v.Coefficient())
+	exp := int(v.Exponent())
+	if exp > 0 {
+		value.Mul(value, new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(exp)), nil))
+		exp = 0
+	}
+	if -exp > maxDecimalWidth {
+		return Decimal{}, fmt.Errorf("the scale %d of the decimal exceeds the maximum DECIMAL width %d", -exp,
+			maxDecimalWidth)
+	}
+	return Decimal{Scale: uint8(-exp), Value: value}, nil
+}
+
+// decimalToNative returns the duckdb_decimal of a Decimal. A zero Width is the smallest width holding the value.
+func decimalToNative(d Decimal) (C.duckdb_decimal, error) {
+	if d.Value == nil {
+		return C.duckdb_decimal{}, errors.New("the decimal has no value")
+	}
+	digits := len(new(big.Int).Abs(d.Value).String())
+	width := int(d.Width)
+	if width == 0 {
+		width = max(digits, int(d.Scale), 1)
+	}
+	if width > maxDecimalWidth || int(d.Scale) > width || digits > width && d.Value.Sign() != 0 {
+		return C.duckdb_decimal{}, fmt.Errorf("%s does not fit a DECIMAL(%d, %d)", d.String(), width, d.Scale)
+	}
+
+	value, err := hugeIntFromNative(d.Value)
+	if err != nil {
+		return C.duckdb_decimal{}, err
+	}
+	return C.duckdb_decimal{width: C.uint8_t(width), scale: C.uint8_t(d.Scale), value: value}, nil
+}
+
 func (d *Decimal) Float64() float64 {
 	scale := big.NewInt(int64(d.Scale))
 	factor := new(big.Float).SetInt(new(big.Int).Exp(big.NewInt(10), scale, nil))
@@ -135,3 +762,20 @@ func (d *Decimal) Float64() float64 {
 	f, _ := value.Float64()
 	return f
 }
+
+// String returns the exact decimal representation of the value, e.g., -1.23 for Value -123 and Scale 2.
+func (d *Decimal) String() string {
+	digits := new(big.Int).Abs(d.Value).String()
+	sign := ""
+	if d.Value.Sign() < 0 {
+		sign = "-"
+	}
+	if d.Scale == 0 {
+		return sign + digits
+	}
+	if pad := int(d.Scale) + 1 - len(digits); pad > 0 {
+		digits = strings.Repeat("0", pad) + digits
+	}
+	point := len(digits) - int(d.Scale)
+	return sign + digits[:point] + "." + digits[point:]
+}
@@ -0,0 +1,178 @@
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"strings"
+)
+
+// ValidateQuery checks whether DuckDB can prepare the query, without executing it.
+// It returns nil for a valid query, and the *Error of DuckDB for a syntax or binder error.
+// Preparing a statement does not have side effects, and parameters do not require values.
+// This works for queries, DML, and most DDL statements.
+// A statement that depends on the effects of a previous statement, e.g., an INSERT into a table that
+// does not exist yet, is not valid until the previous statement has been executed.
+// Thus, ValidateQuery rejects queries containing multiple statements.
+func ValidateQuery(ctx context.Context, driverConn driver.Conn, query string) error {
+	s, err := prepareSingleStmt(ctx, driverConn, query, errValidateQuery)
+	if err != nil {
+		return err
+	}
+	return s.Close()
+}
+
+// ParameterNames returns the name of each distinct parameter of a single-statement query, in the order
+// of their indexes. A named parameter that occurs several times, e.g., $id in "SELECT $id, $id + 1",
+// is listed once, and a single sql.Named argument binds all of its occurrences.
+// Positional parameters are named by their position, e.g., "1" for the first ? or $1 parameter.
+func ParameterNames(ctx context.Context, driverConn driver.Conn, query string) ([]string, error) {
+	s, err := prepareSingleStmt(ctx, driverConn, query, errParameterNames)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	return s.paramNames(), nil
+}
+
+// ParameterDescriptor describes a parameter of a prepared statement.
+type ParameterDescriptor struct {
+	// Name is the name of the parameter, as returned by ParameterNames.
+	Name string
+	// Type is the name of the DuckDB type of the parameter without its parameters, e.g., INTEGER or DECIMAL,
+	// which DuckDB infers from the context of the parameter, e.g., the type of the column it is compared to.
+	// It is empty, if DuckDB cannot infer it, e.g., for SELECT ?, in which case the parameter accepts any value.
+	// The C API of DuckDB does not report the types of named parameters, e.g., $id, so that their Type is empty.
+	Type string
+}
+
+// DescribeParameters returns the name and DuckDB type of each distinct parameter of a single-statement query,
+// in the order of their indexes, so that arguments can be validated before executing the query.
+// The statements of a DuckDB driver connection describe their parameters, too, e.g., with
+//
+//	params := driverStmt.(interface{ Parameters() []duckdb.ParameterDescriptor }).Parameters()
+func DescribeParameters(ctx context.Context, driverConn driver.Conn, query string) ([]ParameterDescriptor, error) {
+	s, err := prepareSingleStmt(ctx, driverConn, query, errDescribeParameters)
+	if err != nil {
+		return nil, err
+	}
+	defer s.Close()
+	return s.Parameters(), nil
+}
+
+// Parameters returns the name and DuckDB type of each parameter of the prepared statement, see DescribeParameters.
+func (s *stmt) Parameters() []ParameterDescriptor {
+	names := s.paramNames()
+	params := make([]ParameterDescriptor, len(names))
+	for i, name := range names {
+		params[i] = ParameterDescriptor{Name: name}
+		if t := C.duckdb_param_type(*s.stmt, C.idx_t(i+1)); t != C.DUCKDB_TYPE_INVALID {
+			params[i].Type = typeName(t)
+		}
+	}
+	return params
+}
+
+// prepareSingleStmt prepares a query, which must contain exactly one statement.
+// errSingle is the driver error of multi-statement queries.
+func prepareSingleStmt(ctx context.Context, driverConn driver.Conn, query string, errSingle error) (*stmt, error) {
+	con, ok := driverConn.(*conn)
+	if !ok {
+		return nil, getError(errInvalidCon, nil)
+	}
+	if con.closed {
+		return nil, getError(errClosedCon, nil)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	stmts, size, err := con.extractStmts(query)
+	if err != nil {
+		return nil, err
+	}
+	defer C.duckdb_destroy_extracted(&stmts)
+
+	if size != 1 {
+		return nil, getError(errSingle, fmt.Errorf("expected a single statement, got %d", size))
+	}
+	return con.prepareExtractedStmt(stmts, 0)
+}
+
+// QueryDescription describes a single-statement query, see Describe.
+type QueryDescription struct {
+	// Parameters are the names of the parameters, as returned by ParameterNames.
+	Parameters []string
+	// ParameterTypes are the DuckDB types of the parameters in the order of Parameters, or empty strings
+	// for parameters of unknown types, see ParameterDescriptor.
+	ParameterTypes []string
+	// Columns are the result columns of a SELECT query, and nil for other statements.
+	Columns []ColumnDescriptor
+}
+
+// ColumnDescriptor describes a result column of a query.
+type ColumnDescriptor struct {
+	Name string
+	Type *TypeDescriptor
+}
+
+// Describe returns the parameters of a single-statement query and, for SELECT queries, the names
+// and TypeDescriptor of its result columns, before fetching any rows.
+// The C API of DuckDB does not expose the result types of a prepared statement. Thus, Describe
+// executes the query wrapped in SELECT * FROM (query) LIMIT 0, which DuckDB plans as an empty result,
+// i.e., it does not scan any data. Queries that cannot be wrapped, e.g., DESCRIBE or SUMMARIZE,
+// are executed in full. Thus, Describe may execute the query, including the side effects of its functions,
+// e.g., of nextval, and the cost of scanning its tables.
+// All parameters are bound to NULL. A result column, whose type depends only on the type of a
+// parameter without a cast, e.g., SELECT $1, has the type of NULL.
+func Describe(ctx context.Context, driverConn driver.Conn, query string) (QueryDescription, error) {
+	s, err := prepareSingleStmt(ctx, driverConn, query, errDescribe)
+	if err != nil {
+		return QueryDescription{}, err
+	}
+	defer s.Close()
+
+	params := s.Parameters()
+	desc := QueryDescription{Parameters: make([]string, len(params)), ParameterTypes: make([]string, len(params))}
+	for i, param := range params {
+		desc.Parameters[i] = param.Name
+		desc.ParameterTypes[i] = param.Type
+	}
+	if C.duckdb_prepared_statement_type(*s.stmt) != C.DUCKDB_STATEMENT_TYPE_SELECT {
+		return desc, nil
+	}
+
+	// The newline ends a trailing line comment of the query.
+	wrapped := "SELECT * FROM (" + strings.TrimRight(strings.TrimSpace(query), ";") + "\n) LIMIT 0"
+	if ws, err := s.c.prepareStmt(wrapped); err == nil && ws.NumInput() == s.NumInput() {
+		defer ws.Close()
+		s = ws
+	}
+
+	args := make([]driver.NamedValue, s.NumInput())
+	for i := range args {
+		args[i] = driver.NamedValue{Ordinal: i + 1}
+	}
+	res, err := s.execute(ctx, args, false)
+	if err != nil {
+		return QueryDescription{}, err
+	}
+	defer C.duckdb_destroy_result(res)
+
+	count := int(C.duckdb_column_count(res))
+	desc.Columns = make([]ColumnDescriptor, count)
+	for i := 0; i < count; i++ {
+		lt := C.duckdb_column_logical_type(res, C.idx_t(i))
+		desc.Columns[i] = ColumnDescriptor{
+			Name: C.GoString(C.duckdb_column_name(res, C.idx_t(i))),
+			Type: newTypeDescriptor(lt),
+		}
+		C.duckdb_destroy_logical_type(&lt)
+	}
+	return desc, nil
+}
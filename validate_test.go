@@ -0,0 +1,216 @@
+package duckdb
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidateQuery(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	con, err := c.Connect(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	_, err = con.(driver.ExecerContext).ExecContext(context.Background(), `CREATE TABLE tbl (i INTEGER)`, nil)
+	require.NoError(t, err)
+
+	countRows := func() int64 {
+		rows, err := con.(driver.QueryerContext).QueryContext(context.Background(), `SELECT count(*) FROM tbl`, nil)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		values := make([]driver.Value, 1)
+		require.NoError(t, rows.Next(values))
+		return values[0].(int64)
+	}
+
+	t.Run("valid", func(t *testing.T) {
+		for _, query := range []string{
+			`SELECT i FROM tbl WHERE i > ?`,
+			`INSERT INTO tbl VALUES (?)`,
+			`INSERT INTO tbl VALUES (42)`,
+			`UPDATE tbl SET i = $val`,
+			`DELETE FROM tbl`,
+			`CREATE TABLE other (j VARCHAR)`,
+			`DROP TABLE tbl`,
+		} {
+			require.NoError(t, ValidateQuery(context.Background(), con, query), query)
+		}
+
+		// Validating does not execute the statements.
+		require.Equal(t, int64(0), countRows())
+		require.NoError(t, ValidateQuery(context.Background(), con, `SELECT * FROM tbl`))
+		require.Error(t, ValidateQuery(context.Background(), con, `SELECT * FROM other`))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		var duckdbErr *Error
+
+		err := ValidateQuery(context.Background(), con, `SELEC 1`)
+		require.True(t, errors.As(err, &duckdbErr))
+		require.Contains(t, duckdbErr.Msg, "syntax error")
+
+		err = ValidateQuery(context.Background(), con, `SELECT missing FROM tbl`)
+		require.True(t, errors.As(err, &duckdbErr))
+		require.Contains(t, duckdbErr.Msg, "Binder Error")
+	})
+
+	t.Run("multiple statements", func(t *testing.T) {
+		err := ValidateQuery(context.Background(), con, `SELECT 1; SELECT 2`)
+		testError(t, err, errValidateQuery.Error(), "got 2")
+	})
+
+	t.Run("closed connection", func(t *testing.T) {
+		closed, err := c.Connect(context.Background())
+		require.NoError(t, err)
+		require.NoError(t, closed.Close())
+		testError(t, ValidateQuery(context.Background(), closed, `SELECT 1`), errClosedCon.Error())
+	})
+}
+
+func TestParameterNames(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	con, err := c.Connect(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	names, err := ParameterNames(context.Background(), con, `SELECT $id, $name, $id + 1, $name || $other`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"id", "name", "other"}, names)
+
+	names, err = ParameterNames(context.Background(), con, `SELECT ?, ?`)
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2"}, names)
+
+	names, err = ParameterNames(context.Background(), con, `SELECT 42`)
+	require.NoError(t, err)
+	require.Empty(t, names)
+
+	_, err = ParameterNames(context.Background(), con, `SELECT $a; SELECT $b`)
+	testError(t, err, errParameterNames.Error(), "got 2")
+}
+
+func TestDescribeParameters(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	con, err := c.Connect(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	_, err = con.(driver.ExecerContext).ExecContext(context.Background(),
+		`CREATE TABLE tbl (id BIGINT, name VARCHAR, price DECIMAL(18, 3))`, nil)
+	require.NoError(t, err)
+
+	params, err := DescribeParameters(context.Background(), con,
+		`UPDATE tbl SET name = ? WHERE id = ? AND price < $3`)
+	require.NoError(t, err)
+	require.Equal(t, []ParameterDescriptor{
+		{Name: "1", Type: "VARCHAR"},
+		{Name: "2", Type: "BIGINT"},
+		{Name: "3", Type: "DECIMAL"},
+	}, params)
+
+	// DuckDB cannot infer the type of an operand of +, which is overloaded for many types.
+	params, err = DescribeParameters(context.Background(), con, `SELECT ? + 1`)
+	require.NoError(t, err)
+	require.Equal(t, []ParameterDescriptor{{Name: "1"}}, params)
+
+	// The types of named parameters are unknown.
+	params, err = DescribeParameters(context.Background(), con, `SELECT * FROM tbl WHERE id = $id`)
+	require.NoError(t, err)
+	require.Equal(t, []ParameterDescriptor{{Name: "id"}}, params)
+
+	// The prepared statements of the connection describe their parameters.
+	s, err := con.(driver.ConnPrepareContext).PrepareContext(context.Background(), `INSERT INTO tbl VALUES (?, ?, ?)`)
+	require.NoError(t, err)
+	defer s.Close()
+	params = s.(interface{ Parameters() []ParameterDescriptor }).Parameters()
+	require.Len(t, params, s.NumInput())
+	require.Equal(t, "DECIMAL", params[2].Type)
+
+	_, err = DescribeParameters(context.Background(), con, `SELECT 1; SELECT 2`)
+	testError(t, err, errDescribeParameters.Error(), "got 2")
+}
+
+func TestDescribe(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewConnector("", nil)
+	require.NoError(t, err)
+	defer c.Close()
+
+	con, err := c.Connect(context.Background())
+	require.NoError(t, err)
+	defer con.Close()
+
+	_, err = con.(driver.ExecerContext).ExecContext(context.Background(),
+		`CREATE TABLE tbl AS SELECT range::INTEGER AS id, {'name': 'x', 'tags': ['a']} AS s FROM range(1000)`, nil)
+	require.NoError(t, err)
+
+	t.Run("select", func(t *testing.T) {
+		desc, err := Describe(context.Background(), con, `SELECT id, s, id::DECIMAL(18, 3) AS d FROM tbl WHERE id > $min; -- comment`)
+		require.NoError(t, err)
+		require.Equal(t, []string{"min"}, desc.Parameters)
+		require.Equal(t, []string{""}, desc.ParameterTypes)
+		require.Len(t, desc.Columns, 3)
+
+		require.Equal(t, "id", desc.Columns[0].Name)
+		require.Equal(t, "INTEGER", desc.Columns[0].Type.Name)
+		require.Equal(t, "s", desc.Columns[1].Name)
+		require.Equal(t, "STRUCT", desc.Columns[1].Type.Kind)
+		require.Equal(t, "tags", desc.Columns[1].Type.Fields[1].Name)
+		require.Equal(t, "VARCHAR", desc.Columns[1].Type.Fields[1].Type.Child.Kind)
+		require.Equal(t, "d", desc.Columns[2].Name)
+		require.Equal(t, uint8(18), desc.Columns[2].Type.Width)
+		require.Equal(t, uint8(3), desc.Columns[2].Type.Scale)
+	})
+
+	t.Run("unwrappable select", func(t *testing.T) {
+		desc, err := Describe(context.Background(), con, `DESCRIBE tbl`)
+		require.NoError(t, err)
+		require.NotEmpty(t, desc.Columns)
+		require.Equal(t, "column_name", desc.Columns[0].Name)
+	})
+
+	t.Run("other statements", func(t *testing.T) {
+		desc, err := Describe(context.Background(), con, `INSERT INTO tbl (id) VALUES (?)`)
+		require.NoError(t, err)
+		require.Equal(t, []string{"1"}, desc.Parameters)
+		require.Nil(t, desc.Columns)
+
+		// Describing does not execute the statement.
+		rows, err := con.(driver.QueryerContext).QueryContext(context.Background(), `SELECT count(*) FROM tbl`, nil)
+		require.NoError(t, err)
+		defer rows.Close()
+		values := make([]driver.Value, 1)
+		require.NoError(t, rows.Next(values))
+		require.Equal(t, int64(1000), values[0])
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		_, err := Describe(context.Background(), con, `SELECT missing FROM tbl`)
+		var duckdbErr *Error
+		require.True(t, errors.As(err, &duckdbErr))
+
+		_, err = Describe(context.Background(), con, `SELECT 1; SELECT 2`)
+		testError(t, err, errDescribe.Error(), "got 2")
+	})
+}
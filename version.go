@@ -0,0 +1,135 @@
+package duckdb
+
+/*
+#include <duckdb.h>
+*/
+import "C"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// LibraryVersion returns the version of the linked DuckDB library, e.g., v0.10.2.
+func LibraryVersion() string {
+	return C.GoString(C.duckdb_library_version())
+}
+
+// LibraryVersionAtLeast returns true, if the version of the linked DuckDB library is at least the version,
+// e.g., v0.10.0. The versions compare by their major, minor, and patch numbers, so a development build
+// of a version, e.g., v0.10.3-dev42, compares like the version itself.
+func LibraryVersionAtLeast(version string) bool {
+	return compareVersions(LibraryVersion(), version) >= 0
+}
+
+// Feature is a feature of DuckDB, which depends on the version of the linked library, see SupportsFeature.
+type Feature int
+
+const (
+	// FeatureArrow is the Arrow interface, see NewArrowFromConn. The driver only links libraries providing it.
+	FeatureArrow Feature = iota
+	// FeatureSecrets is the secrets manager, see CreateSecret. DuckDB supports it as of v0.10.0.
+	FeatureSecrets
+	// FeatureExtensionAutoload is the autoloading of known extensions, e.g., of json, when a query uses them,
+	// see WithAutoloadExtensions. DuckDB supports it as of v0.9.0.
+	FeatureExtensionAutoload
+	// FeatureMaxTempDirectorySize is the max_temp_directory_size setting, see WithMaxTempDirectorySize.
+	// DuckDB supports it as of v1.0.0.
+	FeatureMaxTempDirectorySize
+)
+
+// featureVersions are the minimum library versions of the features.
+var featureVersions = map[Feature]string{
+	FeatureArrow:                "v0.0.0",
+	FeatureSecrets:              "v0.10.0",
+	FeatureExtensionAutoload:    "v0.9.0",
+	FeatureMaxTempDirectorySize: "v1.0.0",
+}
+
+// SupportsFeature returns true, if the linked DuckDB library supports the feature, so that an application
+// can adapt to the library at runtime, instead of failing inside a query.
+func SupportsFeature(feature Feature) bool {
+	version, ok := featureVersions[feature]
+	return ok && LibraryVersionAtLeast(version)
+}
+
+// compareVersions compares two versions like v1.2.3 by their numbers, ignoring any suffix after the patch number.
+func compareVersions(a, b string) int {
+	x, y := parseVersion(a), parseVersion(b)
+	for i := range x {
+		if x[i] != y[i] {
+			if x[i] < y[i] {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+func parseVersion(version string) [3]int {
+	var numbers [3]int
+	parts := strings.SplitN(strings.TrimPrefix(version, "v"), ".", 3)
+	for i, part := range parts {
+		if end := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' }); end >= 0 {
+			part = part[:end]
+		}
+		numbers[i], _ = strconv.Atoi(part)
+	}
+	return numbers
+}
+
+// StorageInfo describes the storage format of a database file, see ReadStorageInfo.
+type StorageInfo struct {
+	// Version is the version number of the storage format, e.g., 64 for files written by v0.9.0 to v0.10.x.
+	Version uint64
+	// LibraryVersion is the version of the library which wrote the file, e.g., v0.10.2.
+	// It is empty for files written before v0.10.0, which did not record it.
+	LibraryVersion string
+}
+
+// The layout of the main header of a database file, which follows the checksum of its first block.
+const (
+	storageMagicOffset   = 8
+	storageMagic         = "DUCK"
+	storageVersionOffset = storageMagicOffset + len(storageMagic)
+	// The library version follows the version number and four flags.
+	storageLibraryOffset = storageVersionOffset + 8 + 4*8
+	storageLibrarySize   = 32
+)
+
+// ReadStorageInfo reads the storage format of the database file at path from its header, without opening it.
+// DuckDB only opens files of the storage version it writes, so an application can check a file before opening it.
+func ReadStorageInfo(path string) (StorageInfo, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return StorageInfo{}, getError(errStorageInfo, err)
+	}
+	defer f.Close()
+
+	header := make([]byte, storageLibraryOffset+storageLibrarySize)
+	n, err := io.ReadFull(f, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+		return StorageInfo{}, getError(errStorageInfo, err)
+	}
+	header = header[:n]
+	if len(header) < storageVersionOffset+8 || string(header[storageMagicOffset:storageVersionOffset]) != storageMagic {
+		return StorageInfo{}, getError(errStorageInfo, fmt.Errorf("%s is not a DuckDB database file", path))
+	}
+
+	info := StorageInfo{Version: binary.LittleEndian.Uint64(header[storageVersionOffset:])}
+	if len(header) == storageLibraryOffset+storageLibrarySize {
+		library := header[storageLibraryOffset:]
+		if end := bytes.IndexByte(library, 0); end >= 0 {
+			library = library[:end]
+		}
+		info.LibraryVersion = string(library)
+	}
+	return info, nil
+}
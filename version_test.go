@@ -0,0 +1,67 @@
+package duckdb
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestLibraryVersion(t *testing.T) {
+	t.Parallel()
+	db := openDB(t)
+	defer db.Close()
+
+	var version string
+	require.NoError(t, db.QueryRow(`SELECT library_version FROM pragma_version()`).Scan(&version))
+	require.Equal(t, version, LibraryVersion())
+
+	require.True(t, LibraryVersionAtLeast("v0.9.0"))
+	require.True(t, LibraryVersionAtLeast(LibraryVersion()))
+	require.False(t, LibraryVersionAtLeast("v99.0.0"))
+
+	require.True(t, SupportsFeature(FeatureArrow))
+	require.True(t, SupportsFeature(FeatureSecrets))
+	require.True(t, SupportsFeature(FeatureExtensionAutoload))
+	require.False(t, SupportsFeature(Feature(-1)))
+
+	require.Equal(t, 0, compareVersions("v0.10.3-dev42", "v0.10.3"))
+	require.Equal(t, 1, compareVersions("v0.10.0", "v0.9.2"))
+	require.Equal(t, -1, compareVersions("v1", "v1.0.1"))
+}
+
+func TestReadStorageInfo(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "test.db")
+
+	db, err := sql.Open("duckdb", path)
+	require.NoError(t, err)
+	_, err = db.Exec(`CREATE TABLE t (i INTEGER)`)
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+
+	info, err := ReadStorageInfo(path)
+	require.NoError(t, err)
+	require.NotZero(t, info.Version)
+	require.Equal(t, LibraryVersion(), info.LibraryVersion)
+
+	other := filepath.Join(dir, "other")
+	require.NoError(t, os.WriteFile(other, []byte("not a database"), 0o600))
+	_, err = ReadStorageInfo(other)
+	testError(t, err, errStorageInfo.Error(), "not a DuckDB database file")
+
+	_, err = ReadStorageInfo(filepath.Join(dir, "missing.db"))
+	testError(t, err, errStorageInfo.Error())
+}
+
+func TestLoadLibrary(t *testing.T) {
+	t.Parallel()
+	// The library is linked at build time, or already loaded by the other tests.
+	db := openDB(t)
+	require.NoError(t, db.Close())
+	err := LoadLibrary(filepath.Join(t.TempDir(), "libduckdb.so"))
+	require.ErrorIs(t, err, errLoadLibrary)
+}